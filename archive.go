@@ -0,0 +1,175 @@
+// archive.go - ZIP archive export.
+//
+// This file implements ExportArchive, which writes several export jobs (e.g. a CSV extract next to
+// an XLSX report) as the entries of a single .zip file or sink. Each job runs the regular exporter
+// with the archive entry as its sink (see FileWriteParams.Writer), so entries are streamed into the
+// archive rather than staged on disk.
+
+package spit
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+)
+
+// ArchiveJob describes a single file of an archive export.
+type ArchiveJob struct {
+	Filename     string        // Entry name without extension (sanitized like FileWriteParams.Filename)
+	Format       Format        // Export format: FormatCSV, FormatXSLX, FormatHTML, FormatParquet or FormatMarkdown
+	Table        *Table        // Table to export
+	Sheets       []Spreadsheet // XLSX only: sheets written instead of Table (see ExportXLSXSheets)
+	CSVSeparator string        // CSV field separator (default: ",")
+	SheetName    string        // XLSX sheet name of Table (default: "Sheet1")
+	HTMLOptions  HTMLOptions   // Options of the HTML export
+}
+
+// NewArchiveJob creates an ArchiveJob exporting the table to the given format.
+func NewArchiveJob(filename string, format Format, table *Table) *ArchiveJob {
+	return &ArchiveJob{
+		Filename: filename,
+		Format:   format,
+		Table:    table,
+	}
+}
+
+// WithSheets sets the sheets of an XLSX job, written instead of its table.
+func (j *ArchiveJob) WithSheets(sheets ...Spreadsheet) *ArchiveJob {
+	j.Sheets = sheets
+	return j
+}
+
+// WithCSVSeparator sets the field separator of a CSV job.
+func (j *ArchiveJob) WithCSVSeparator(separator string) *ArchiveJob {
+	j.CSVSeparator = separator
+	return j
+}
+
+// WithSheetName sets the sheet name of an XLSX job.
+func (j *ArchiveJob) WithSheetName(name string) *ArchiveJob {
+	j.SheetName = name
+	return j
+}
+
+// WithHTMLOptions sets the options of an HTML job.
+func (j *ArchiveJob) WithHTMLOptions(opts HTMLOptions) *ArchiveJob {
+	j.HTMLOptions = opts
+	return j
+}
+
+// entryName returns the name of the job's archive entry, e.g. "report.xlsx".
+func (j *ArchiveJob) entryName() string {
+	return SanitizeFilename(j.Filename) + "." + j.Format.String()
+}
+
+// export runs the job with the given parameters.
+func (j *ArchiveJob) export(params FileWriteParams) (*FileWriteResult, error) {
+	switch j.Format {
+	case FormatCSV:
+		separator := j.CSVSeparator
+		if separator == "" {
+			separator = ","
+		}
+		return ExportCSV(separator, j.Table, params)
+	case FormatXSLX:
+		if len(j.Sheets) > 0 {
+			return ExportXLSXSheets(j.Sheets, params)
+		}
+		sheetName := j.SheetName
+		if sheetName == "" {
+			sheetName = "Sheet1"
+		}
+		return ExportXLSX(NewSpreadsheetExcelize(sheetName, j.Table), params)
+	case FormatHTML:
+		return ExportHTML(j.Table, j.HTMLOptions, params)
+	case FormatParquet:
+		return ExportParquet(j.Table, params)
+	case FormatMarkdown:
+		return ExportMarkdown(j.Table, params)
+	}
+	if name, e, ok := registeredExporter(j.Format); ok && j.Table != nil {
+		return j.Table.exportWith(name, e, params)
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", j.Format)
+}
+
+// ExportArchive writes the jobs as the entries of a single ZIP archive, in order. The archive is
+// written like any export (file, temp file or params.Writer sink); the ".zip" extension is added
+// when params.Extension is empty. Every job shares params.OnWarning and the seed of the run, and
+// the result lists the written entries in Entries along with their warnings. The first failing
+// job aborts the export.
+func ExportArchive(jobs []*ArchiveJob, params FileWriteParams) (*FileWriteResult, error) {
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no archive jobs provided")
+	}
+	names := make(map[string]bool, len(jobs))
+	for i, job := range jobs {
+		if job == nil || (job.Table == nil && len(job.Sheets) == 0) {
+			return nil, fmt.Errorf("archive job %d has no table", i)
+		}
+		if !job.Format.exportable() {
+			return nil, fmt.Errorf("unsupported export format for archive job %d: %s", i, job.Format)
+		}
+		name := job.entryName()
+		if names[name] {
+			return nil, fmt.Errorf("duplicate archive entry: %s", name)
+		}
+		names[name] = true
+	}
+
+	if params.Extension == "" {
+		params.Extension = "zip"
+	}
+	// Every entry shares the seed of the run, so the whole archive can be reproduced
+	params.Seed = params.resolveSeed()
+	// Entries count against the limits of the run; their bytes are counted once compressed
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+
+	params.logger().Info("Starting archive export to file", String("filename", params.Filename), Int("entries", len(jobs)))
+
+	var entries []FileWriteResult
+	var warnings []ExportWarning
+	var sparse []SparseColumn
+	writeFunc := func(writer io.Writer) error {
+		archive := zip.NewWriter(writer)
+		for _, job := range jobs {
+			name := job.entryName()
+			entry, err := archive.Create(name)
+			if err != nil {
+				return fmt.Errorf("failed to create archive entry %s: %w", name, err)
+			}
+			result, err := job.export(FileWriteParams{
+				Filename:  job.Filename,
+				Writer:    entry,
+				OnWarning: params.OnWarning,
+				Seed:      params.Seed,
+				Logger:    params.Logger,
+				quota:     params.quota,
+				progress:  params.progress,
+				timings:   params.timings,
+				entry:     true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to export archive entry %s: %w", name, err)
+			}
+			entries = append(entries, *result)
+			warnings = append(warnings, result.Warnings...)
+			sparse = append(sparse, result.SparseColumns...)
+		}
+		return archive.Close()
+	}
+
+	result, err := params.WriteToFile(writeFunc)
+	if err != nil {
+		params.logger().Error("Failed to write archive to file", Error(err))
+		return nil, err
+	}
+	result.Entries = entries
+	result.Warnings = warnings
+	result.SparseColumns = sparse
+
+	params.logger().Info("Archive export completed", String("filename", params.Filename))
+	return result, nil
+}
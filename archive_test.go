@@ -0,0 +1,99 @@
+package spit
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportArchive(t *testing.T) {
+	table := NewTable(DataSlice{{"name": "Alice", "score": 10}}, Columns{NewColumn("name", "Name"), NewColumn("score", "Score")}, true)
+	jobs := []*ArchiveJob{
+		NewArchiveJob("extract", FormatCSV, table).WithCSVSeparator(";"),
+		NewArchiveJob("report", FormatXSLX, table).WithSheetName("Scores"),
+	}
+
+	res, err := ExportArchive(jobs, FileWriteParams{Filename: "batch", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	if res.Filename != "batch.zip" || len(res.Entries) != 2 || res.Entries[1].Filename != "report.xlsx" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	archive, err := zip.OpenReader(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = archive.Close() }()
+	if len(archive.File) != 2 || archive.File[0].Name != "extract.csv" || archive.File[1].Name != "report.xlsx" {
+		t.Fatalf("unexpected entries: %v", archive.File)
+	}
+
+	csvContent := readArchiveEntry(t, archive.File[0])
+	if want := "Name;Score\nAlice;10\n"; string(csvContent) != want {
+		t.Errorf("extract.csv = %q, want %q", csvContent, want)
+	}
+	f, err := excelize.OpenReader(bytes.NewReader(readArchiveEntry(t, archive.File[1])))
+	if err != nil {
+		t.Fatalf("report.xlsx is not a valid workbook: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if got, _ := f.GetCellValue("Scores", "A2"); got != "Alice" {
+		t.Errorf("Scores!A2 = %q, want Alice", got)
+	}
+}
+
+func TestExportArchive_sink(t *testing.T) {
+	table := NewTable(DataSlice{{"name": "Alice"}}, Columns{NewColumn("name", "Name")}, true)
+	var buf bytes.Buffer
+	res, err := ExportArchive([]*ArchiveJob{NewArchiveJob("page", FormatHTML, table)}, FileWriteParams{Filename: "batch", Writer: &buf})
+	if err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	if res.Filepath != "" || res.Entries[0].Filename != "page.html" {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil || len(archive.File) != 1 || archive.File[0].Name != "page.html" {
+		t.Fatalf("unexpected archive: %v, %v", archive, err)
+	}
+}
+
+func TestExportArchive_invalidJobs(t *testing.T) {
+	table := NewTable(DataSlice{{"name": "Alice"}}, Columns{NewColumn("name", "Name")}, true)
+	tests := []struct {
+		name string
+		jobs []*ArchiveJob
+	}{
+		{"no jobs", nil},
+		{"no table", []*ArchiveJob{NewArchiveJob("a", FormatCSV, nil)}},
+		{"unsupported format", []*ArchiveJob{NewArchiveJob("a", FormatGoogleSheets, table)}},
+		{"duplicate entry", []*ArchiveJob{NewArchiveJob("a", FormatCSV, table), NewArchiveJob("a", FormatCSV, table)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExportArchive(tt.jobs, FileWriteParams{Filename: "batch", Writer: io.Discard}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// readArchiveEntry returns the content of an archive entry.
+func readArchiveEntry(t *testing.T, file *zip.File) []byte {
+	t.Helper()
+	r, err := file.Open()
+	if err != nil {
+		t.Fatalf("Open(%s) failed: %v", file.Name, err)
+	}
+	defer func() { _ = r.Close() }()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll(%s) failed: %v", file.Name, err)
+	}
+	return content
+}
@@ -0,0 +1,160 @@
+// batch.go - Concurrent export of independent files.
+//
+// This file implements ExportBatch, which writes many independent files (e.g. one workbook per
+// customer) with a pool of workers instead of one after the other. Each job runs the regular
+// exporter with its own FileWriteParams, so jobs share nothing but the pool: seeds, limits,
+// progress, warnings and results are per job. Failures are collected per job rather than
+// aborting the batch, unless BatchParams.StopOnError is set.
+
+package spit
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ErrBatchJobSkipped is the error of the batch jobs not started because an earlier job failed
+// (see BatchParams.StopOnError).
+var ErrBatchJobSkipped = errors.New("job skipped after an earlier failure")
+
+// BatchJob describes a single file of a batch export.
+type BatchJob struct {
+	Job    *ArchiveJob     // What to export: format, table or sheets and their options
+	Params FileWriteParams // Where and how the file is written; Filename defaults to Job.Filename
+}
+
+// NewBatchJob creates a BatchJob writing the job with the given parameters.
+func NewBatchJob(job *ArchiveJob, params FileWriteParams) *BatchJob {
+	return &BatchJob{
+		Job:    job,
+		Params: params,
+	}
+}
+
+// BatchParams contains the parameters of an ExportBatch run.
+type BatchParams struct {
+	Workers     int  // Jobs exported concurrently (default: runtime.GOMAXPROCS(0))
+	StopOnError bool // Skip the jobs not started yet once a job failed (see ErrBatchJobSkipped)
+}
+
+// BatchJobError is the error of a failed batch job.
+type BatchJobError struct {
+	Index    int    // Index of the job in the batch
+	Filename string // Filename of the job
+	Cause    error  // Underlying error
+}
+
+// Error returns the error as a single line, e.g. "batch job 3 (report): ...".
+func (e *BatchJobError) Error() string {
+	return fmt.Sprintf("batch job %d (%s): %v", e.Index, e.Filename, e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchJobError) Unwrap() error {
+	return e.Cause
+}
+
+// BatchResult is the outcome of an ExportBatch run.
+type BatchResult struct {
+	Results []*FileWriteResult // Results in job order; nil for the jobs that failed or were skipped
+	Errors  []*BatchJobError   // Errors of the failed and skipped jobs, in job order
+}
+
+// Err returns the job errors joined into a single error (see errors.Join), or nil when every job
+// succeeded.
+func (r *BatchResult) Err() error {
+	if r == nil || len(r.Errors) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+// ExportBatch exports independent jobs concurrently with a pool of params.Workers workers and
+// returns their results in job order. Jobs must not share spreadsheets or destinations, and the
+// callbacks of the run (formatters, stylers, OnWarning, OnProgress) must be safe for concurrent
+// use when several jobs share them. A failing job does not stop the others unless params.StopOnError is set; the returned error
+// joins the job errors (see BatchResult.Err), while the result always lists every job.
+func ExportBatch(jobs []*BatchJob, params BatchParams) (*BatchResult, error) {
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no batch jobs provided")
+	}
+	for i, job := range jobs {
+		if job == nil || job.Job == nil || (job.Job.Table == nil && len(job.Job.Sheets) == 0) {
+			return nil, fmt.Errorf("batch job %d has no table", i)
+		}
+		if !job.Job.Format.exportable() {
+			return nil, fmt.Errorf("unsupported export format for batch job %d: %s", i, job.Job.Format)
+		}
+	}
+
+	workers := params.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(jobs))
+
+	results := make([]*FileWriteResult, len(jobs))
+	errs := make([]error, len(jobs))
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	indexes := make(chan int)
+
+	// Each worker writes the result and error of the jobs it runs only
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = jobs[i].export()
+				if errs[i] != nil && params.StopOnError {
+					stopOnce.Do(func() { close(stop) })
+				}
+			}
+		}()
+	}
+	for i := range jobs {
+		select {
+		case <-stop:
+			errs[i] = ErrBatchJobSkipped
+			continue
+		default:
+		}
+		select {
+		case indexes <- i:
+		case <-stop:
+			errs[i] = ErrBatchJobSkipped
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := &BatchResult{Results: results}
+	for i, err := range errs {
+		if err != nil {
+			result.Errors = append(result.Errors, &BatchJobError{Index: i, Filename: jobs[i].filename(), Cause: err})
+		}
+	}
+	return result, result.Err()
+}
+
+// filename returns the filename of the job: Params.Filename, or Job.Filename when empty.
+func (j *BatchJob) filename() string {
+	if j.Params.Filename != "" {
+		return j.Params.Filename
+	}
+	return j.Job.Filename
+}
+
+// export writes the job.
+func (j *BatchJob) export() (*FileWriteResult, error) {
+	params := j.Params
+	params.Filename = j.filename()
+	return j.Job.export(params)
+}
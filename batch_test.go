@@ -0,0 +1,72 @@
+package spit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestExportBatch(t *testing.T) {
+	dir := t.TempDir()
+	var jobs []*BatchJob
+	for i := range 12 {
+		format := []Format{FormatCSV, FormatXSLX, FormatMarkdown}[i%3]
+		job := NewArchiveJob(fmt.Sprintf("report_%d", i), format, fixtureTable("region", "country", "sales"))
+		jobs = append(jobs, NewBatchJob(job, FileWriteParams{Filepath: dir}))
+	}
+
+	res, err := ExportBatch(jobs, BatchParams{Workers: 4})
+	if err != nil {
+		t.Fatalf("ExportBatch failed: %v", err)
+	}
+	if len(res.Results) != len(jobs) || len(res.Errors) != 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	for i, result := range res.Results {
+		if want := fmt.Sprintf("report_%d.%s", i, jobs[i].Job.Format); result.Filename != want {
+			t.Errorf("result %d = %s, want %s", i, result.Filename, want)
+		}
+		if _, err := os.Stat(result.Filepath); err != nil {
+			t.Errorf("result %d was not written: %v", i, err)
+		}
+	}
+}
+
+func TestExportBatch_errors(t *testing.T) {
+	job := func(filename string, maxRows int) *BatchJob {
+		params := FileWriteParams{Filepath: t.TempDir(), Limits: &Limits{MaxRows: maxRows}}
+		return NewBatchJob(NewArchiveJob(filename, FormatCSV, fixtureTable("region", "country", "sales")), params)
+	}
+
+	t.Run("failures are collected", func(t *testing.T) {
+		res, err := ExportBatch([]*BatchJob{job("ok", 0), job("too-big", 1), job("also-ok", 0)}, BatchParams{Workers: 2})
+		var jobErr *BatchJobError
+		var quotaErr *QuotaExceededError
+		if !errors.As(err, &jobErr) || jobErr.Index != 1 || jobErr.Filename != "too-big" || !errors.As(err, &quotaErr) {
+			t.Fatalf("error = %v, want the quota error of job 1", err)
+		}
+		if res.Results[0] == nil || res.Results[1] != nil || res.Results[2] == nil || len(res.Errors) != 1 {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		res, err := ExportBatch([]*BatchJob{job("too-big", 1), job("skipped", 0), job("skipped-too", 0)},
+			BatchParams{Workers: 1, StopOnError: true})
+		if !errors.Is(err, ErrBatchJobSkipped) || len(res.Errors) != 3 {
+			t.Fatalf("error = %v, want the later jobs skipped", err)
+		}
+		if res.Errors[2].Index != 2 || !errors.Is(res.Errors[2], ErrBatchJobSkipped) {
+			t.Errorf("job error = %v, want job 2 skipped", res.Errors[2])
+		}
+	})
+
+	t.Run("invalid jobs", func(t *testing.T) {
+		for _, jobs := range [][]*BatchJob{nil, {nil}, {NewBatchJob(NewArchiveJob("x", FormatGoogleSheets, fixtureTable("region", "country", "sales")), FileWriteParams{})}} {
+			if _, err := ExportBatch(jobs, BatchParams{}); err == nil {
+				t.Errorf("ExportBatch(%v) should fail", jobs)
+			}
+		}
+	})
+}
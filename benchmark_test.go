@@ -0,0 +1,123 @@
+package spit
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+// benchmarkTable returns a table of the given number of rows whose first columns hold runs of
+// identical values, so merging has ranges to find.
+func benchmarkTable(rows int, merge, style bool) *Table {
+	data := make(DataSlice, rows)
+	for i := range data {
+		data[i] = Data{
+			"region":  fmt.Sprintf("Region %d", i/50),
+			"country": fmt.Sprintf("Country %d", i/10),
+			"product": fmt.Sprintf("Product %d", i),
+			"units":   i % 97,
+			"price":   float64(i%1000) / 10,
+		}
+	}
+	columns := Columns{
+		NewColumn("region", "Region"),
+		NewColumn("country", "Country"),
+		NewColumn("product", "Product"),
+		NewColumn("units", "Units"),
+		NewColumn("price", "Price"),
+	}
+	if merge {
+		rules := NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)
+		columns[0].WithMerge(rules)
+		columns[1].WithMerge(rules)
+	}
+	table := NewTable(data, columns, true)
+	if style {
+		columns[3].WithStyle(&Style{Bold: true})
+		columns[4].WithStyle(&Style{NumFmt: "0.00"})
+		table.WithHeaderOptions(NewHeaderOptions().WithStyle(&Style{Bold: true, BackgroundColor: "#DDEEFF"})).
+			WithRowStyler(func(rowIndex int, row Data) *Style {
+				if rowIndex%2 == 1 {
+					return &Style{BackgroundColor: "#F5F5F5"}
+				}
+				return nil
+			})
+	}
+	return table
+}
+
+// reportTimings reports the average time spent per phase by the benchmarked exports.
+func reportTimings(b *testing.B, total Timings) {
+	n := float64(b.N)
+	b.ReportMetric(float64(total.Prepare.Nanoseconds())/n, "prepare-ns/op")
+	b.ReportMetric(float64(total.Write.Nanoseconds())/n, "write-ns/op")
+	b.ReportMetric(float64(total.Merge.Nanoseconds())/n, "merge-ns/op")
+	b.ReportMetric(float64(total.Style.Nanoseconds())/n, "style-ns/op")
+	b.ReportMetric(float64(total.Save.Nanoseconds())/n, "save-ns/op")
+}
+
+// addTimings adds the timings of an export to a running total.
+func addTimings(total *Timings, res *FileWriteResult) {
+	if res == nil || res.Timings == nil {
+		return
+	}
+	total.Prepare += res.Timings.Prepare
+	total.Write += res.Timings.Write
+	total.Merge += res.Timings.Merge
+	total.Style += res.Timings.Style
+	total.Save += res.Timings.Save
+}
+
+func BenchmarkExportXLSX(b *testing.B) {
+	cases := []struct {
+		name         string
+		merge, style bool
+		streaming    StreamingMode
+	}{
+		{name: "plain", streaming: StreamingNever},
+		{name: "merged", merge: true, streaming: StreamingNever},
+		{name: "styled", style: true, streaming: StreamingNever},
+		{name: "merged and styled", merge: true, style: true, streaming: StreamingNever},
+		{name: "streamed", merge: true, style: true, streaming: StreamingAlways},
+	}
+	for _, rows := range []int{1000, 10000} {
+		for _, bc := range cases {
+			b.Run(fmt.Sprintf("%s/%d rows", bc.name, rows), func(b *testing.B) {
+				table := benchmarkTable(rows, bc.merge, bc.style)
+				params := FileWriteParams{Filename: "bench", Writer: io.Discard, Streaming: bc.streaming, CollectTimings: true}
+				var total Timings
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), params)
+					if err != nil {
+						b.Fatalf("ExportXLSX failed: %v", err)
+					}
+					addTimings(&total, res)
+				}
+				reportTimings(b, total)
+			})
+		}
+	}
+}
+
+func BenchmarkExport(b *testing.B) {
+	formats := []Format{FormatCSV, FormatHTML, FormatMarkdown, FormatParquet}
+	for _, format := range formats {
+		b.Run(fmt.Sprintf("%s/10000 rows", format), func(b *testing.B) {
+			table := benchmarkTable(10000, true, true)
+			params := FileWriteParams{Filename: "bench", Writer: io.Discard, CollectTimings: true}
+			var total Timings
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				res, err := Export(format, table, params)
+				if err != nil {
+					b.Fatalf("Export(%s) failed: %v", format, err)
+				}
+				addTimings(&total, res)
+			}
+			reportTimings(b, total)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// builder.go - Fluent report builder.
+//
+// This file implements a builder assembling common report shapes without spelling out Columns,
+// RowOptionsMap and CellOptionsMap by hand (see Build): sheets, columns and column groups are
+// declared in order, and styles and merges refer to columns by name. The builder only produces
+// regular tables, so anything it does not cover is set on Builder.Table with the usual methods.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+)
+
+// Builder assembles the tables of a report fluently (see Build). Errors, such as a style referring
+// to an unknown column, are kept and returned by Tables and the export methods.
+type Builder struct {
+	sheets []*builderSheet
+	err    error
+}
+
+// builderSheet is a sheet declared by a Builder, and the styles and merges resolved by column
+// name once every column is declared.
+type builderSheet struct {
+	name       string
+	table      *Table
+	cellStyles []builderCellStyle
+	merged     []string
+}
+
+// builderCellStyle is a cell style declared by column name.
+type builderCellStyle struct {
+	column string
+	row    int
+	style  *Style
+}
+
+// Build starts a report. Columns, data and styles apply to the current sheet, the last one
+// started with Sheet, or a sheet named "Sheet1" when none was started:
+//
+//	spit.Build().
+//		Sheet("Report").
+//		Column("name", "Name").
+//		Group("Q1", spit.NewColumn("jan", "Jan"), spit.NewColumn("feb", "Feb")).
+//		HeaderStyle(&spit.Style{Bold: true, BackgroundColor: "#DDEBF7"}).
+//		Data(rows).
+//		Export(spit.FormatXSLX, w)
+func Build() *Builder {
+	return &Builder{}
+}
+
+// Sheet starts a new sheet with the given name. Only XLSX exports write several sheets.
+func (b *Builder) Sheet(name string) *Builder {
+	b.sheets = append(b.sheets, &builderSheet{name: name, table: NewTable(nil, nil, true)})
+	return b
+}
+
+// current returns the current sheet, starting the default one if none was started.
+func (b *Builder) current() *builderSheet {
+	if len(b.sheets) == 0 {
+		b.Sheet("Sheet1")
+	}
+	return b.sheets[len(b.sheets)-1]
+}
+
+// Table returns the table of the current sheet, to set the options the builder does not cover.
+// Styles and merges declared by column name are applied when the report is exported (see Tables).
+func (b *Builder) Table() *Table {
+	return b.current().table
+}
+
+// Data sets the data rows of the current sheet.
+func (b *Builder) Data(rows DataSlice) *Builder {
+	b.current().table.Data = rows
+	return b
+}
+
+// Column adds a column to the current sheet.
+func (b *Builder) Column(name, label string) *Builder {
+	return b.Columns(NewColumn(name, label))
+}
+
+// Columns adds configured columns to the current sheet, e.g.
+// NewColumn("amount", "Amount").WithType(ColumnTypeCurrency).
+func (b *Builder) Columns(columns ...*Column) *Builder {
+	table := b.current().table
+	table.Columns = append(table.Columns, columns...)
+	return b
+}
+
+// Group adds a column group to the current sheet: a header spanning the given columns.
+func (b *Builder) Group(label string, columns ...*Column) *Builder {
+	return b.Columns(NewColumn("", label).WithSubColumns(columns))
+}
+
+// HeaderStyle sets the style of the header cells of the current sheet.
+func (b *Builder) HeaderStyle(style *Style) *Builder {
+	table := b.current().table
+	if table.HeaderOptions == nil {
+		table.HeaderOptions = NewHeaderOptions()
+	}
+	table.HeaderOptions.Style = style
+	return b
+}
+
+// HeaderBorders sets the borders of the header cells of the current sheet.
+func (b *Builder) HeaderBorders(borders *Borders) *Builder {
+	table := b.current().table
+	if table.HeaderOptions == nil {
+		table.HeaderOptions = NewHeaderOptions()
+	}
+	table.HeaderOptions.Borders = borders
+	return b
+}
+
+// NoHeader leaves out the header rows of the current sheet.
+func (b *Builder) NoHeader() *Builder {
+	b.current().table.WriteHeader = false
+	return b
+}
+
+// Footer writes a footer row after the data of the current sheet (see Table.WithFooter). Columns
+// declare their aggregate with Column.WithAggregate.
+func (b *Builder) Footer(options *FooterOptions) *Builder {
+	b.current().table.WithFooter(options)
+	return b
+}
+
+// Zebra alternates the styles of the data rows of the current sheet (see Table.WithZebra).
+func (b *Builder) Zebra(even, odd *Style) *Builder {
+	b.current().table.WithZebra(even, odd)
+	return b
+}
+
+// Sort sorts the data rows of the current sheet (see Table.WithSort).
+func (b *Builder) Sort(keys ...SortKey) *Builder {
+	b.current().table.WithSort(keys...)
+	return b
+}
+
+// MergeRepeated merges the identical consecutive values of the named leaf columns of the current
+// sheet vertically, e.g. the region of rows listed by region.
+func (b *Builder) MergeRepeated(columns ...string) *Builder {
+	sheet := b.current()
+	sheet.merged = append(sheet.merged, columns...)
+	return b
+}
+
+// RowStyle sets the style of a data row (0-based) of the current sheet.
+func (b *Builder) RowStyle(row int, style *Style) *Builder {
+	table := b.current().table
+	if table.RowOptionsMap == nil {
+		table.RowOptionsMap = RowOptionsMap{}
+	}
+	options := table.RowOptionsMap[row]
+	options.RowIndex = row
+	options.Style = style
+	table.RowOptionsMap[row] = options
+	return b
+}
+
+// CellStyle sets the style of the cell of the named leaf column in a data row (0-based) of the
+// current sheet.
+func (b *Builder) CellStyle(column string, row int, style *Style) *Builder {
+	sheet := b.current()
+	sheet.cellStyles = append(sheet.cellStyles, builderCellStyle{column: column, row: row, style: style})
+	return b
+}
+
+// resolve applies the styles and merges declared by column name to the table of the sheet.
+func (s *builderSheet) resolve() error {
+	flatColumns := s.table.Columns.GetFlattenedColumns()
+	leafIndex := func(name string) (int, error) {
+		for i, column := range flatColumns {
+			if column.Name == name {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("sheet %q: unknown column %q", s.name, name)
+	}
+
+	for _, name := range s.merged {
+		i, err := leafIndex(name)
+		if err != nil {
+			return err
+		}
+		if flatColumns[i].Merge == nil {
+			flatColumns[i].Merge = NewMergeRules(nil, nil)
+		}
+		flatColumns[i].Merge.Vertical = append(flatColumns[i].Merge.Vertical, MergeConditionIdentical)
+	}
+	s.merged = nil
+
+	for _, cell := range s.cellStyles {
+		i, err := leafIndex(cell.column)
+		if err != nil {
+			return err
+		}
+		if s.table.CellOptionsMap == nil {
+			s.table.CellOptionsMap = CellOptionsMap{}
+		}
+		col := i + 1 // Cell options are keyed by 1-based column
+		if s.table.CellOptionsMap[col] == nil {
+			s.table.CellOptionsMap[col] = map[int]CellOptions{}
+		}
+		options := s.table.CellOptionsMap[col][cell.row]
+		options.RowIndex, options.ColIndex, options.Style = cell.row, i, cell.style
+		s.table.CellOptionsMap[col][cell.row] = options
+	}
+	s.cellStyles = nil
+	return nil
+}
+
+// Tables returns the tables of the sheets, in declaration order, with the styles and merges
+// declared by column name applied, or the first error of the builder.
+func (b *Builder) Tables() ([]*Table, error) {
+	b.current()
+	if b.err == nil {
+		for _, sheet := range b.sheets {
+			if b.err = sheet.resolve(); b.err != nil {
+				break
+			}
+		}
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	tables := make([]*Table, len(b.sheets))
+	for i, sheet := range b.sheets {
+		tables[i] = sheet.table
+	}
+	return tables, nil
+}
+
+// Export writes the report to w in the given format, named after the first sheet. XLSX exports
+// write every sheet; other formats write a single one.
+func (b *Builder) Export(format Format, w io.Writer) (*FileWriteResult, error) {
+	b.current()
+	filename := SanitizeFilename(b.sheets[0].name)
+	if filename == "" {
+		filename = "report"
+	}
+	return b.ExportFile(format, FileWriteParams{Filename: filename, Writer: w})
+}
+
+// ExportFile writes the report in the given format with the given file parameters (see Export).
+func (b *Builder) ExportFile(format Format, params FileWriteParams) (*FileWriteResult, error) {
+	tables, err := b.Tables()
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatXSLX {
+		sheets := make([]Spreadsheet, len(tables))
+		for i, table := range tables {
+			sheets[i] = NewSpreadsheetExcelize(b.sheets[i].name, table)
+		}
+		return ExportXLSXSheets(sheets, params)
+	}
+	if len(tables) > 1 {
+		return nil, fmt.Errorf("format %s writes a single sheet, the report has %d", format, len(tables))
+	}
+	return Export(format, tables[0], params)
+}
@@ -0,0 +1,87 @@
+package spit
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestBuilder_ExportXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := Build().
+		Sheet("Sales").
+		Column("region", "Region").
+		Group("Q1", NewColumn("jan", "Jan"), NewColumn("feb", "Feb")).
+		HeaderStyle(&Style{Bold: true, BackgroundColor: "#DDEBF7"}).
+		MergeRepeated("region").
+		CellStyle("feb", 1, &Style{BackgroundColor: "#FFC7CE"}).
+		Data(DataSlice{
+			{"region": "EU", "jan": 1, "feb": 2},
+			{"region": "EU", "jan": 3, "feb": 4},
+		}).
+		Sheet("Notes").
+		Column("note", "Note").
+		Data(DataSlice{{"note": "preliminary"}}).
+		Export(FormatXSLX, &buf)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if got, want := f.GetSheetList(), []string{"Sales", "Notes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sheets = %v, want %v", got, want)
+	}
+	rows, _ := f.GetRows("Sales")
+	if want := [][]string{{"Region", "Q1"}, {"", "Jan", "Feb"}, {"EU", "1", "2"}, {"", "3", "4"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("Sales rows = %q, want %q", rows, want)
+	}
+	merged, _ := f.GetMergeCells("Sales")
+	var ranges []string
+	for _, cell := range merged {
+		ranges = append(ranges, cell.GetStartAxis()+":"+cell.GetEndAxis())
+	}
+	if !strings.Contains(strings.Join(ranges, " "), "A3:A4") {
+		t.Errorf("merged ranges = %v, want the repeated region A3:A4", ranges)
+	}
+	styleID, _ := f.GetCellStyle("Sales", "C4")
+	if style, _ := f.GetStyle(styleID); style == nil || len(style.Fill.Color) == 0 || style.Fill.Color[0] != "FFC7CE" {
+		t.Errorf("C4 style = %+v, want the cell style fill", style)
+	}
+}
+
+func TestBuilder_ExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	res, err := Build().
+		Sheet("Daily report").
+		Column("name", "Name").
+		Columns(NewColumn("amount", "Amount").WithAggregate(AggregateSum)).
+		Footer(nil).
+		Sort(SortKey{Field: "amount", Direction: SortDescending}).
+		Data(DataSlice{{"name": "a", "amount": 1}, {"name": "b", "amount": 2}}).
+		Export(FormatCSV, &buf)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if got, want := buf.String(), "Name,Amount\nb,2\na,1\nTotal,3\n"; got != want {
+		t.Errorf("Export() = %q, want %q", got, want)
+	}
+	if res.Filename != "Daily_report.csv" {
+		t.Errorf("Filename = %q", res.Filename)
+	}
+}
+
+func TestBuilder_errors(t *testing.T) {
+	if _, err := Build().Column("a", "A").MergeRepeated("b").Export(FormatCSV, &bytes.Buffer{}); err == nil || err.Error() != `sheet "Sheet1": unknown column "b"` {
+		t.Errorf("Export() error = %v, want the unknown column", err)
+	}
+	if _, err := Build().Sheet("A").Column("a", "A").Sheet("B").Column("b", "B").Export(FormatCSV, &bytes.Buffer{}); err == nil {
+		t.Error("Export() of two sheets to CSV succeeded")
+	}
+}
@@ -0,0 +1,273 @@
+// capabilities.go - Backend capability negotiation.
+//
+// This file implements the capabilities of export backends (merged cells, styles, images,
+// formulas, streaming). Spreadsheet implementations and registered exporters report theirs through
+// CapabilityReporter; the export pipeline queries them up front and degrades the features a
+// backend lacks (merged ranges repeat their value, images become their text value, formulas keep
+// their computed value, styles are skipped) instead of failing mid-export. Each degraded feature
+// is reported once as a warning (see WarningPhaseCapability).
+
+package spit
+
+import "sync"
+
+// Capabilities lists the features an export backend supports.
+type Capabilities struct {
+	Merges    bool // Merged cells; otherwise every cell of a merged range repeats its value
+	Styles    bool // Styles and borders; otherwise they are skipped
+	Images    bool // Images; otherwise their text value (URL or alt text) is written
+	Formulas  bool // Formulas; otherwise computed values are written
+	Streaming bool // Output is written as rows are produced rather than built in memory
+}
+
+// CapabilityReporter is implemented by Spreadsheet implementations and Exporters reporting the
+// features they support. Backends not implementing it are assumed to support every feature.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// AllCapabilities returns capabilities supporting every feature.
+func AllCapabilities() Capabilities {
+	return Capabilities{Merges: true, Styles: true, Images: true, Formulas: true, Streaming: true}
+}
+
+// Capabilities returns the features supported by a built-in or registered export format.
+func (f Format) Capabilities() Capabilities {
+	if name, ok := registeredName(f); ok {
+		if e, ok := LookupExporter(name); ok {
+			return capabilitiesOf(e, AllCapabilities())
+		}
+	}
+	switch f {
+	case FormatCSV:
+		return Capabilities{Streaming: true}
+	case FormatXSLX, FormatGoogleSheets:
+		return Capabilities{Merges: true, Styles: true, Images: true, Formulas: true}
+	case FormatHTML:
+		return Capabilities{Merges: true, Styles: true, Images: true}
+	}
+	return Capabilities{}
+}
+
+// capabilitiesOf returns the capabilities reported by a backend, or fallback.
+func capabilitiesOf(backend interface{}, fallback Capabilities) Capabilities {
+	if reporter, ok := backend.(CapabilityReporter); ok {
+		return reporter.Capabilities()
+	}
+	return fallback
+}
+
+// covers reports whether c supports every rendering feature (streaming aside).
+func (c Capabilities) covers() bool {
+	return c.Merges && c.Styles && c.Images && c.Formulas
+}
+
+// degraded returns ops wrapped to degrade the features the capabilities lack, or ops itself when
+// every feature is supported. ops receives sheet coordinates (wrap before Table.Offset).
+func (t *Table) degraded(ops TableOperations, caps Capabilities) TableOperations {
+	if caps.covers() {
+		return ops
+	}
+	return &degradedOperations{TableOperations: ops, table: t, caps: caps}
+}
+
+// degradedOperations decorates a TableOperations implementation lacking some capabilities: the
+// unsupported operations are emulated or skipped, and reported once per feature.
+type degradedOperations struct {
+	TableOperations
+	table    *Table
+	caps     Capabilities
+	mu       sync.Mutex
+	reported map[string]bool
+}
+
+// report warns once that a feature is degraded.
+func (d *degradedOperations) report(feature, message string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.reported[feature] {
+		return
+	}
+	if d.reported == nil {
+		d.reported = map[string]bool{}
+	}
+	d.reported[feature] = true
+	d.table.warn(WarningPhaseCapability, "", message, nil, String("feature", feature))
+}
+
+// MergeCells merges the range, or repeats its first value in every cell without merges.
+func (d *degradedOperations) MergeCells(startCol, startRow, endCol, endRow int) error {
+	if d.caps.Merges {
+		return d.TableOperations.MergeCells(startCol, startRow, endCol, endRow)
+	}
+	d.report("merges", "Backend does not support merged cells, merged ranges repeat their value")
+	value, err := d.TableOperations.GetCellValue(startCol, startRow)
+	if err != nil {
+		return err
+	}
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			if col == startCol && row == startRow {
+				continue
+			}
+			if err := d.TableOperations.SetCellValue(col, row, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IsCellMerged reports whether the cell is part of a merged range; never without merges.
+func (d *degradedOperations) IsCellMerged(col, row int) bool {
+	return d.caps.Merges && d.TableOperations.IsCellMerged(col, row)
+}
+
+// IsCellMergedHorizontally reports whether the cell is merged horizontally; never without merges.
+func (d *degradedOperations) IsCellMergedHorizontally(col, row int) bool {
+	return d.caps.Merges && d.TableOperations.IsCellMergedHorizontally(col, row)
+}
+
+// ApplyBorderToCell applies a border to the cell, or does nothing without styles.
+func (d *degradedOperations) ApplyBorderToCell(col, row int, side string, border *Border) error {
+	if !d.caps.Styles {
+		d.report("styles", "Backend does not support styles, borders are skipped")
+		return nil
+	}
+	return d.TableOperations.ApplyBorderToCell(col, row, side, border)
+}
+
+// ApplyBordersToRange applies borders to the range, or does nothing without styles.
+func (d *degradedOperations) ApplyBordersToRange(startCol, startRow, endCol, endRow int, borders Borders) error {
+	if !d.caps.Styles {
+		d.report("styles", "Backend does not support styles, borders are skipped")
+		return nil
+	}
+	return d.TableOperations.ApplyBordersToRange(startCol, startRow, endCol, endRow, borders)
+}
+
+// HasExistingBorder reports whether the cell has a border on the side; never without styles.
+func (d *degradedOperations) HasExistingBorder(col, row int, side string) bool {
+	return d.caps.Styles && d.TableOperations.HasExistingBorder(col, row, side)
+}
+
+// ApplyStyleToCell styles the cell, or does nothing without styles.
+func (d *degradedOperations) ApplyStyleToCell(col, row int, style Style) error {
+	if !d.caps.Styles {
+		d.report("styles", "Backend does not support styles, styles are skipped")
+		return nil
+	}
+	return d.TableOperations.ApplyStyleToCell(col, row, style)
+}
+
+// ApplyStyleToRange styles the range, or does nothing without styles.
+func (d *degradedOperations) ApplyStyleToRange(startCol, startRow, endCol, endRow int, style Style) error {
+	if !d.caps.Styles {
+		d.report("styles", "Backend does not support styles, styles are skipped")
+		return nil
+	}
+	return d.TableOperations.ApplyStyleToRange(startCol, startRow, endCol, endRow, style)
+}
+
+// SetCellFormula sets the formula of the cell, or writes it as text without formulas.
+func (d *degradedOperations) SetCellFormula(col, row int, formula string) error {
+	if !d.caps.Formulas {
+		d.report("formulas", "Backend does not support formulas, formulas are written as text")
+		return d.TableOperations.SetCellValue(col, row, formula)
+	}
+	return d.TableOperations.SetCellFormula(col, row, formula)
+}
+
+// SetCellImage places the image, or writes its text value without images.
+func (d *degradedOperations) SetCellImage(col, row int, img Image) error {
+	if !d.caps.Images {
+		d.report("images", "Backend does not support images, their text value is written")
+		return d.TableOperations.SetCellValue(col, row, img.TextValue())
+	}
+	return d.TableOperations.SetCellImage(col, row, img)
+}
+
+// CellRef returns the reference of the cell in the addressing scheme of the wrapped operations.
+func (d *degradedOperations) CellRef(col, row int) string {
+	return AddresserFor(d.TableOperations).CellRef(col, row)
+}
+
+// degrade returns the table with the data-carried features the capabilities lack converted, for
+// exporters reading the table directly: images become their text value, full-width rows repeat
+// their value in every cell and cell formulas are dropped (cells keep their value). The receiver
+// is not modified; it is returned as is when nothing is converted.
+func (t *Table) degrade(caps Capabilities) *Table {
+	if caps.covers() {
+		return t
+	}
+	d := *t
+	flatColumns := t.Columns.GetFlattenedColumns()
+	copied := map[int]bool{}
+	row := func(i int) Data {
+		if !copied[i] {
+			item := make(Data, len(d.Data[i]))
+			for k, v := range d.Data[i] {
+				item[k] = v
+			}
+			d.Data[i] = item
+			copied[i] = true
+		}
+		return d.Data[i]
+	}
+	var images, spans, formulas bool
+
+	if !caps.Images || !caps.Merges {
+		d.Data = append(DataSlice(nil), t.Data...)
+	}
+	if !caps.Images {
+		for i, item := range t.Data {
+			for k, v := range item {
+				if img, ok := asImage(v); ok {
+					row(i)[k] = img.TextValue()
+					images = true
+				}
+			}
+		}
+	}
+	if !caps.Merges && len(t.RowOptionsMap) > 0 {
+		d.RowOptionsMap = make(RowOptionsMap, len(t.RowOptionsMap))
+		for i, rc := range t.RowOptionsMap {
+			if rc.SpanAllColumns && rc.Value != nil && i >= 0 && i < len(d.Data) {
+				for _, column := range flatColumns {
+					row(i)[column.Name] = rc.Value
+				}
+				rc.SpanAllColumns, rc.Value = false, nil
+				spans = true
+			}
+			d.RowOptionsMap[i] = rc
+		}
+	}
+	if !caps.Formulas && len(t.CellOptionsMap) > 0 {
+		d.CellOptionsMap = make(CellOptionsMap, len(t.CellOptionsMap))
+		for col, rows := range t.CellOptionsMap {
+			d.CellOptionsMap[col] = make(map[int]CellOptions, len(rows))
+			for i, cell := range rows {
+				if cell.Formula != "" {
+					cell.Formula = ""
+					formulas = true
+				}
+				d.CellOptionsMap[col][i] = cell
+			}
+		}
+	}
+
+	if images {
+		t.warn(WarningPhaseCapability, "", "Backend does not support images, their text value is written", nil, String("feature", "images"))
+	}
+	if spans {
+		t.warn(WarningPhaseCapability, "", "Backend does not support merged cells, full-width rows repeat their value", nil, String("feature", "merges"))
+	}
+	if formulas {
+		t.warn(WarningPhaseCapability, "", "Backend does not support formulas, cells keep their value", nil, String("feature", "formulas"))
+	}
+	if !images && !spans && !formulas {
+		return t
+	}
+	d.prepared = nil
+	return &d
+}
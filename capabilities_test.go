@@ -0,0 +1,133 @@
+package spit
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// limitedSpreadsheet is an Excelize spreadsheet reporting a reduced set of capabilities.
+type limitedSpreadsheet struct {
+	*SpreadsheetExcelize
+	caps Capabilities
+}
+
+func (l *limitedSpreadsheet) Capabilities() Capabilities {
+	return l.caps
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	if got := capabilitiesOf(struct{}{}, AllCapabilities()); got != AllCapabilities() {
+		t.Errorf("capabilitiesOf(non-reporter) = %+v, want all capabilities", got)
+	}
+	se := NewSpreadsheetExcelize("Sheet1", NewTable(nil, nil, false))
+	if got := capabilitiesOf(se, Capabilities{}); got != FormatXSLX.Capabilities() {
+		t.Errorf("capabilitiesOf(SpreadsheetExcelize) = %+v, want %+v", got, FormatXSLX.Capabilities())
+	}
+	if caps := FormatCSV.Capabilities(); caps.Merges || caps.Styles || !caps.Streaming {
+		t.Errorf("FormatCSV.Capabilities() = %+v, want streaming only", caps)
+	}
+}
+
+func TestExportXLSXDegradedCapabilities(t *testing.T) {
+	table := NewTable(DataSlice{{"team": "A", "name": "x"}, {"team": "A", "name": "y"}},
+		Columns{
+			NewColumn("team", "Team").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+			NewColumn("name", "Name").WithStyle(&Style{Bold: true}),
+		}, true)
+	sheet := &limitedSpreadsheet{
+		SpreadsheetExcelize: NewSpreadsheetExcelize("Sheet1", table),
+		caps:                Capabilities{Images: true, Formulas: true},
+	}
+
+	var buf bytes.Buffer
+	res, err := ExportXLSX(sheet, FileWriteParams{Filename: "report", Writer: &buf})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer f.Close()
+	merges, err := f.GetMergeCells("Sheet1")
+	if err != nil {
+		t.Fatalf("GetMergeCells failed: %v", err)
+	}
+	if len(merges) != 0 {
+		t.Errorf("merged ranges = %d, want none", len(merges))
+	}
+	for _, cell := range []string{"A2", "A3"} {
+		if value, _ := f.GetCellValue("Sheet1", cell); value != "A" {
+			t.Errorf("%s = %q, want the repeated value %q", cell, value, "A")
+		}
+	}
+
+	features := map[string]int{}
+	for _, w := range res.Warnings {
+		if w.Phase == WarningPhaseCapability {
+			features[w.Message]++
+		}
+	}
+	if len(features) != 2 {
+		t.Errorf("capability warnings = %v, want one for merges and one for styles", features)
+	}
+	for message, count := range features {
+		if count != 1 {
+			t.Errorf("warning %q reported %d times, want once", message, count)
+		}
+	}
+}
+
+// textExporter records the table it receives and reports text-only capabilities.
+type textExporter struct {
+	table *Table
+}
+
+func (e *textExporter) Write(table *Table, _ io.Writer, _ FileWriteParams) error {
+	e.table = table
+	return nil
+}
+
+func (e *textExporter) Capabilities() Capabilities {
+	return Capabilities{Streaming: true}
+}
+
+func TestExportFormatDegradedCapabilities(t *testing.T) {
+	exporter := &textExporter{}
+	if err := RegisterFormat("text", exporter); err != nil {
+		t.Fatalf("RegisterFormat failed: %v", err)
+	}
+	defer UnregisterFormat("text")
+
+	logo := NewImageURL("https://example.com/logo.png")
+	table := NewTable(DataSlice{{"logo": logo, "total": 3}, {"logo": logo, "total": 4}},
+		Columns{NewColumn("logo", "Logo"), NewColumn("total", "Total")}, true).
+		WithRowOptions(RowOptionsMap{1: *NewRowOptions(1).WithSpanAllColumns("Section")}).
+		WithCellOptions(CellOptionsMap{1: {0: *NewCellOptions(0, 1).WithFormula("={total}*2")}})
+
+	res, err := ExportFormat("text", table, FileWriteParams{Filename: "report", Writer: io.Discard})
+	if err != nil {
+		t.Fatalf("ExportFormat failed: %v", err)
+	}
+
+	got := exporter.table
+	if got.Data[0]["logo"] != logo.TextValue() {
+		t.Errorf("image = %v, want its text value %q", got.Data[0]["logo"], logo.TextValue())
+	}
+	if got.Data[1]["logo"] != "Section" || got.Data[1]["total"] != "Section" || got.RowOptionsMap[1].SpanAllColumns {
+		t.Errorf("full-width row = %v, want its value repeated in every cell", got.Data[1])
+	}
+	if got.CellOptionsMap[1][0].Formula != "" {
+		t.Errorf("formula = %q, want it dropped", got.CellOptionsMap[1][0].Formula)
+	}
+	if _, ok := table.Data[0]["logo"].(Image); !ok {
+		t.Errorf("source table modified: %v", table.Data[0]["logo"])
+	}
+	if len(res.Warnings) != 3 {
+		t.Errorf("warnings = %v, want one per degraded feature", res.Warnings)
+	}
+}
@@ -0,0 +1,92 @@
+// cell_ref.go - Backend cell addressing.
+//
+// This file abstracts how cells are referenced (e.g. in footer and subtotal formulas) behind the
+// backend: spreadsheets whose formulas use Excel A1 references address cells as "B3", while
+// backends without column letters can use index-based R1C1 references ("R3C2"). Backends opt in
+// by implementing CellAddresser; others are addressed in A1 through GetColumnLetter.
+
+package spit
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellAddresser formats 1-based cell coordinates as references in a backend's addressing scheme.
+type CellAddresser interface {
+	// CellRef returns the reference of a cell, e.g. "B3".
+	CellRef(col, row int) string
+
+	// RangeRef returns the reference of a rectangular range, e.g. "B3:D10".
+	RangeRef(startCol, startRow, endCol, endRow int) string
+}
+
+// A1Addresser addresses cells with Excel-style A1 references ("B3", "B3:D10").
+type A1Addresser struct{}
+
+// CellRef returns the A1 reference of a cell, or an empty string for invalid coordinates.
+func (A1Addresser) CellRef(col, row int) string {
+	ref, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return ""
+	}
+	return ref
+}
+
+// RangeRef returns the A1 reference of a range.
+func (a A1Addresser) RangeRef(startCol, startRow, endCol, endRow int) string {
+	return a.CellRef(startCol, startRow) + ":" + a.CellRef(endCol, endRow)
+}
+
+// R1C1Addresser addresses cells by row and column indices ("R3C2", "R3C2:R10C4").
+type R1C1Addresser struct{}
+
+// CellRef returns the R1C1 reference of a cell.
+func (R1C1Addresser) CellRef(col, row int) string {
+	return fmt.Sprintf("R%dC%d", row, col)
+}
+
+// RangeRef returns the R1C1 reference of a range.
+func (a R1C1Addresser) RangeRef(startCol, startRow, endCol, endRow int) string {
+	return a.CellRef(startCol, startRow) + ":" + a.CellRef(endCol, endRow)
+}
+
+// AddresserFor returns the addresser of ops: ops itself when it implements CellAddresser,
+// otherwise A1 references built from its GetColumnLetter.
+func AddresserFor(ops TableOperations) CellAddresser {
+	if addresser, ok := ops.(CellAddresser); ok {
+		return addresser
+	}
+	return letterAddresser{ops}
+}
+
+// r1c1Reference matches R1C1-style references, including the bare row and column forms ("R",
+// "C", "R2", "RC3").
+var r1c1Reference = regexp.MustCompile(`(?i)^(R[0-9]*)?(C[0-9]*)?$`)
+
+// isCellReference reports whether a name reads as an A1 or R1C1 cell reference (e.g. "A1",
+// "xfd10", "R1C1" or "c"), which sheet names must be quoted for and defined names cannot be.
+func isCellReference(name string) bool {
+	if name != "" && r1c1Reference.MatchString(name) {
+		return true
+	}
+	_, _, err := excelize.CellNameToCoordinates(name)
+	return err == nil
+}
+
+// letterAddresser builds A1 references from the column letters of a TableOperations.
+type letterAddresser struct {
+	ops TableOperations
+}
+
+// CellRef returns the A1 reference of a cell.
+func (l letterAddresser) CellRef(col, row int) string {
+	return fmt.Sprintf("%s%d", l.ops.GetColumnLetter(col), row)
+}
+
+// RangeRef returns the A1 reference of a range.
+func (l letterAddresser) RangeRef(startCol, startRow, endCol, endRow int) string {
+	return l.CellRef(startCol, startRow) + ":" + l.CellRef(endCol, endRow)
+}
@@ -0,0 +1,32 @@
+package spit
+
+import "testing"
+
+func TestAddresserFor(t *testing.T) {
+	table := NewTable(nil, nil, true).WithStartPosition(3, 2)
+	spreadsheet := NewSpreadsheetExcelize("Sheet1", table)
+
+	tests := []struct {
+		name string
+		ops  TableOperations
+		want string
+	}{
+		{"A1 backend", spreadsheet, "B3:D10"},
+		{"offset A1 backend", table.Offset(spreadsheet), "D4:F11"},
+		{"index-based backend", &htmlExport{table: table}, "R3C2:R10C4"},
+		{"letters fallback", letterOnlyOperations{spreadsheet}, "B3:D10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddresserFor(tt.ops).RangeRef(2, 3, 4, 10); got != tt.want {
+				t.Errorf("RangeRef = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// letterOnlyOperations hides the CellAddresser implementation of the wrapped operations.
+type letterOnlyOperations struct {
+	TableOperations
+}
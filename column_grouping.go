@@ -0,0 +1,59 @@
+// column_grouping.go - Automatic column grouping.
+//
+// This file builds hierarchical header groups from flat column labels using a separator
+// convention: "Q1|Revenue" and "Q1|Cost" become the sub-columns "Revenue" and "Cost" of a "Q1"
+// group, saving users from constructing nested Columns by hand.
+
+package spit
+
+import "strings"
+
+// DefaultLabelSeparator is the label separator used by Table.WithLabelGrouping when none is given.
+const DefaultLabelSeparator = "|"
+
+// GroupByLabel returns the columns with labels split on separator turned into nested groups.
+// Consecutive columns sharing the same first label segment are grouped under a parent column named
+// and labeled after that segment; remaining segments are grouped recursively, so "2024|Q1|Revenue"
+// yields three header levels. Leaf order is preserved: columns sharing a prefix but not adjacent
+// form separate groups. Columns without the separator are kept as-is, and existing groups are
+// processed recursively. The receiver is not modified.
+func (c Columns) GroupByLabel(separator string) Columns {
+	if separator == "" {
+		return c
+	}
+
+	grouped := make(Columns, 0, len(c))
+	var created Columns // Groups built from label prefixes
+	var group *Column   // Current group, while consecutive columns share its prefix
+	for _, column := range c {
+		if len(column.Columns) > 0 {
+			copied := *column
+			copied.Columns = column.Columns.GroupByLabel(separator)
+			grouped = append(grouped, &copied)
+			group = nil
+			continue
+		}
+
+		prefix, rest, found := strings.Cut(column.Label, separator)
+		if !found {
+			grouped = append(grouped, column)
+			group = nil
+			continue
+		}
+
+		leaf := *column
+		leaf.Label = rest
+		if group == nil || group.Label != prefix {
+			group = NewColumn(prefix, prefix)
+			grouped = append(grouped, group)
+			created = append(created, group)
+		}
+		group.Columns = append(group.Columns, &leaf)
+	}
+
+	// Split the remaining label segments of each new group
+	for _, column := range created {
+		column.Columns = column.Columns.GroupByLabel(separator)
+	}
+	return grouped
+}
@@ -0,0 +1,98 @@
+package spit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// columnTree renders columns as "label(children...)" for compact comparisons.
+func columnTree(columns Columns) []string {
+	out := make([]string, len(columns))
+	for i, column := range columns {
+		out[i] = column.Label
+		if len(column.Columns) > 0 {
+			out[i] += "(" + strings.Join(columnTree(column.Columns), " ") + ")"
+		}
+	}
+	return out
+}
+
+func TestColumns_GroupByLabel(t *testing.T) {
+	columns := Columns{
+		NewColumn("region", "Region"),
+		NewColumn("q1_rev", "Q1|Revenue"),
+		NewColumn("q1_cost", "Q1|Cost"),
+		NewColumn("q2_rev", "Q2|Revenue"),
+		NewColumn("y_eu", "2024|EU|Sales"),
+		NewColumn("y_us", "2024|US|Sales"),
+		NewColumn("note", "Note"),
+		NewColumn("q1_margin", "Q1|Margin"),
+	}
+
+	tests := []struct {
+		name      string
+		separator string
+		expected  []string
+	}{
+		{
+			name:      "Groups consecutive prefixes recursively",
+			separator: "|",
+			expected:  []string{"Region", "Q1(Revenue Cost)", "Q2(Revenue)", "2024(EU(Sales) US(Sales))", "Note", "Q1(Margin)"},
+		},
+		{
+			name:      "Empty separator keeps columns",
+			separator: "",
+			expected:  []string{"Region", "Q1|Revenue", "Q1|Cost", "Q2|Revenue", "2024|EU|Sales", "2024|US|Sales", "Note", "Q1|Margin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grouped := columns.GroupByLabel(tt.separator)
+			if got := columnTree(grouped); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+			if len(grouped.GetFlattenedColumns()) != len(columns) {
+				t.Error("leaf columns must be preserved")
+			}
+		})
+	}
+
+	if columns[1].Label != "Q1|Revenue" {
+		t.Error("receiver must not be modified")
+	}
+}
+
+func TestTable_WithLabelGrouping(t *testing.T) {
+	table := NewTable(DataSlice{{"region": "EU", "rev": 10, "cost": 4}}, Columns{
+		NewColumn("region", "Region"),
+		NewColumn("rev", "Q1|Revenue"),
+		NewColumn("cost", "Q1|Cost"),
+	}, true).WithLabelGrouping("")
+
+	if table.LabelSeparator != DefaultLabelSeparator {
+		t.Errorf("LabelSeparator = %q, want %q", table.LabelSeparator, DefaultLabelSeparator)
+	}
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "grouped", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	for cell, want := range map[string]string{"A1": "Region", "B1": "Q1", "B2": "Revenue", "C2": "Cost", "C3": "4"} {
+		if got, _ := f.GetCellValue("Sheet1", cell); got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+	if len(table.Columns) != 3 {
+		t.Error("WithLabelGrouping must not modify the table columns")
+	}
+}
@@ -0,0 +1,246 @@
+// column_type.go - Column data types.
+//
+// This file implements the declared data type of a column (see Column.WithType). Values of typed
+// columns are converted to their type once, whatever the Go type the caller used (e.g. numbers
+// held in strings), then written as native cells with a matching number format by the Excelize
+// backend, and as text rendered the same way in every row by CSV, HTML and Markdown exports.
+// Values that cannot be converted are written as in untyped columns.
+
+package spit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType is the declared data type of the values of a column.
+type ColumnType int
+
+const (
+	// ColumnTypeAuto writes values as processed with Column.Format (default).
+	ColumnTypeAuto ColumnType = iota
+
+	// ColumnTypeString writes values as text cells.
+	ColumnTypeString
+
+	// ColumnTypeInt writes values as whole numbers; fractional numbers are rounded.
+	ColumnTypeInt
+
+	// ColumnTypeFloat writes values as numbers.
+	ColumnTypeFloat
+
+	// ColumnTypeBool writes values as booleans; strings such as "yes" or "0" are parsed.
+	ColumnTypeBool
+
+	// ColumnTypeDate writes values as dates; strings in RFC 3339 or "2006-01-02" form are parsed.
+	ColumnTypeDate
+
+	// ColumnTypeDuration writes values as elapsed times; numbers are seconds and strings are parsed
+	// with time.ParseDuration.
+	ColumnTypeDuration
+
+	// ColumnTypePercent writes values as percentages, 0.25 being 25%; strings such as "25%" are parsed.
+	ColumnTypePercent
+
+	// ColumnTypeCurrency writes values as amounts with two decimals.
+	ColumnTypeCurrency
+)
+
+// columnTypeNumFmts maps column types to the number format of their XLSX cells.
+var columnTypeNumFmts = map[ColumnType]string{
+	ColumnTypeString:   "@",
+	ColumnTypeInt:      "0",
+	ColumnTypeDate:     "yyyy-mm-dd",
+	ColumnTypeDuration: "[h]:mm:ss",
+	ColumnTypePercent:  "0.00%",
+	ColumnTypeCurrency: "#,##0.00",
+}
+
+// dateLayouts lists the layouts parsed from the string values of date columns, in order.
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// WithType sets the data type of the column values (see ColumnType).
+func (c *Column) WithType(typ ColumnType) *Column {
+	c.Type = typ
+	return c
+}
+
+// NumFmt returns the number format of the XLSX cells of the type, or "" for the General format.
+func (c ColumnType) NumFmt() string {
+	return columnTypeNumFmts[c]
+}
+
+// numeric reports whether the values of the type are numbers.
+func (c ColumnType) numeric() bool {
+	switch c {
+	case ColumnTypeInt, ColumnTypeFloat, ColumnTypePercent, ColumnTypeCurrency:
+		return true
+	}
+	return false
+}
+
+// typed reports whether the values of the column are converted to its type: typed columns whose
+// format does not write formulas or hyperlinks.
+func (c *Column) typed() bool {
+	return c.Type != ColumnTypeAuto && c.Format != ExcelizeFormatFormula && c.Format != ExcelizeFormatHyperlink
+}
+
+// convert converts a value to the Go type of the column type: string, int64, float64, bool,
+// time.Time or time.Duration. It reports false for empty values and values that cannot be
+// converted.
+func (c ColumnType) convert(value interface{}, format string) (interface{}, bool) {
+	// Durations are converted before normalization, which turns them into strings
+	if d, ok := value.(time.Duration); ok && c == ColumnTypeDuration {
+		return d, true
+	}
+	if _, ok := asImage(value); ok {
+		return nil, false
+	}
+	value = NormalizeValue(value)
+	if t, ok := value.(*time.Time); ok {
+		if t == nil {
+			return nil, false
+		}
+		value = *t
+	}
+	if value == nil || value == "" {
+		return nil, false
+	}
+
+	switch c {
+	case ColumnTypeString:
+		if _, ok := value.([]interface{}); ok {
+			return nil, false
+		}
+		formatted, err := FormatValue(value, format)
+		if err != nil {
+			return nil, false
+		}
+		return fmt.Sprintf("%v", formatted), true
+	case ColumnTypeInt:
+		if i, ok := value.(int64); ok {
+			return i, true
+		}
+		f, ok := toFloat(value)
+		if !ok {
+			return nil, false
+		}
+		return int64(math.Round(f)), true
+	case ColumnTypeFloat, ColumnTypeCurrency:
+		return toFloat(value)
+	case ColumnTypePercent:
+		if s, ok := value.(string); ok && strings.HasSuffix(strings.TrimSpace(s), "%") {
+			f, err := parseAsFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+			return f / 100, err == nil
+		}
+		return toFloat(value)
+	case ColumnTypeBool:
+		b, _ := convertToBool(value)
+		v, ok := b.(bool)
+		return v, ok
+	case ColumnTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v, true
+		case string:
+			for _, layout := range dateLayouts {
+				if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+					return t, true
+				}
+			}
+		}
+	case ColumnTypeDuration:
+		if s, ok := value.(string); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(s))
+			return d, err == nil
+		}
+		if f, ok := toFloat(value); ok {
+			return time.Duration(math.Round(f * float64(time.Second))), true
+		}
+	}
+	return nil, false
+}
+
+// text renders a converted value (see convert) as written by text formats: a registered formatter
+// or, for dates, a time layout in format takes precedence; otherwise numbers are written in full,
+// percentages and amounts with two decimals, dates as "2006-01-02" (with the time of day when set)
+// and durations as "h:mm:ss".
+func (c ColumnType) text(value interface{}, format string) (string, error) {
+	if _, registered := LookupFormatter(format); registered || (format != "" && c == ColumnTypeDate) {
+		formatted, err := FormatValue(value, format)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v", formatted), nil
+	}
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		switch c {
+		case ColumnTypePercent:
+			return strconv.FormatFloat(v*100, 'f', 2, 64) + "%", nil
+		case ColumnTypeCurrency:
+			return strconv.FormatFloat(v, 'f', 2, 64), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format("2006-01-02"), nil
+		}
+		return v.Format("2006-01-02 15:04:05"), nil
+	case time.Duration:
+		sign := ""
+		if v < 0 {
+			sign, v = "-", -v
+		}
+		v = v.Round(time.Second)
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, int64(v/time.Hour), int64(v/time.Minute)%60, int64(v/time.Second)%60), nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// typedText returns the text of a value of a typed column, or false when the column is untyped or
+// the value cannot be converted to its type.
+func (c *Column) typedText(value interface{}) (string, bool, error) {
+	if !c.typed() {
+		return "", false, nil
+	}
+	converted, ok := c.Type.convert(value, c.Format)
+	if !ok {
+		return "", false, nil
+	}
+	text, err := c.Type.text(converted, c.Format)
+	return text, true, err
+}
+
+// nativeCellWriter is implemented by backends storing the values of typed columns as native cells
+// (Excelize sheets); the other backends receive their text (see ColumnType.text).
+type nativeCellWriter interface {
+	writesNativeCells()
+}
+
+// processCellValue processes a value of the column for ops, once converted to the zone of the
+// column (see Table.WithTimeLocation): the converted value or its text for typed columns, the time
+// of serial dates for native backends, otherwise ops.ProcessValue with the column format.
+func (t *Table) processCellValue(ops TableOperations, column *Column, value interface{}) (interface{}, error) {
+	value = t.inLocation(column, value)
+	_, native := ops.(nativeCellWriter)
+	if column.typed() {
+		if converted, ok := column.Type.convert(value, column.Format); ok {
+			if native {
+				return converted, nil
+			}
+			return column.Type.text(converted, column.Format)
+		}
+	}
+	if date, ok := t.serialDate(column, value); ok && native {
+		return date, nil
+	}
+	return ops.ProcessValue(value, column.Format)
+}
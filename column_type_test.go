@@ -0,0 +1,159 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestColumnType_convert(t *testing.T) {
+	day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		typ    ColumnType
+		value  interface{}
+		want   interface{}
+		wantOK bool
+	}{
+		{"int from string", ColumnTypeInt, " 42 ", int64(42), true},
+		{"int rounds floats", ColumnTypeInt, 2.6, int64(3), true},
+		{"float from string", ColumnTypeFloat, "1.5", 1.5, true},
+		{"float from int", ColumnTypeFloat, 3, 3.0, true},
+		{"percent from string", ColumnTypePercent, "25%", 0.25, true},
+		{"currency", ColumnTypeCurrency, "19.9", 19.9, true},
+		{"bool from string", ColumnTypeBool, "yes", true, true},
+		{"date from string", ColumnTypeDate, "2024-03-15", day, true},
+		{"date from pointer", ColumnTypeDate, &day, day, true},
+		{"duration", ColumnTypeDuration, 90 * time.Minute, 90 * time.Minute, true},
+		{"duration from string", ColumnTypeDuration, "1h30m", 90 * time.Minute, true},
+		{"duration from seconds", ColumnTypeDuration, 90, 90 * time.Second, true},
+		{"string from number", ColumnTypeString, 7, "7", true},
+		{"unparseable", ColumnTypeInt, "n/a", nil, false},
+		{"empty", ColumnTypeFloat, "", nil, false},
+		{"nil", ColumnTypeDate, nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.typ.convert(tt.value, "")
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("convert(%v) = %v (%T), %v; want %v (%T), %v", tt.value, got, got, ok, tt.want, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestColumnType_text(t *testing.T) {
+	tests := []struct {
+		typ    ColumnType
+		value  interface{}
+		format string
+		want   string
+	}{
+		{ColumnTypeInt, int64(-12), "", "-12"},
+		{ColumnTypeFloat, 0.1, "", "0.1"},
+		{ColumnTypePercent, 0.125, "", "12.50%"},
+		{ColumnTypeCurrency, 19.9, "", "19.90"},
+		{ColumnTypeBool, false, "", "false"},
+		{ColumnTypeDate, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), "", "2024-03-15"},
+		{ColumnTypeDate, time.Date(2024, 3, 15, 8, 5, 0, 0, time.UTC), "", "2024-03-15 08:05:00"},
+		{ColumnTypeDate, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), "02/01/2006", "15/03/2024"},
+		{ColumnTypeDuration, 26*time.Hour + 3*time.Minute + 4*time.Second, "", "26:03:04"},
+		{ColumnTypeDuration, -90 * time.Second, "", "-0:01:30"},
+	}
+	for _, tt := range tests {
+		got, err := tt.typ.text(tt.value, tt.format)
+		if err != nil || got != tt.want {
+			t.Errorf("%s text(%v) = %q, %v; want %q", tt.typ, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+// typedTestTable returns a table whose values are all strings, typed by their columns.
+func TestExport_columnTypes(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"id": "0042", "amount": "1234.5", "share": "12.5%", "active": "yes", "day": "2024-03-15", "took": "1h30m", "note": "n/a"},
+		{"id": "7", "amount": 3, "share": 0.5, "active": false, "day": "unknown", "took": 45},
+	}, Columns{
+		NewColumn("id", "ID").WithType(ColumnTypeInt),
+		NewColumn("amount", "Amount").WithType(ColumnTypeCurrency),
+		NewColumn("share", "Share").WithType(ColumnTypePercent),
+		NewColumn("active", "Active").WithType(ColumnTypeBool),
+		NewColumn("day", "Day").WithType(ColumnTypeDate),
+		NewColumn("took", "Took").WithType(ColumnTypeDuration),
+		NewColumn("note", "Note").WithType(ColumnTypeFloat),
+	}, true)
+
+	t.Run("xlsx", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "typed", Writer: &buf}); err != nil {
+			t.Fatalf("ExportXLSX() error = %v", err)
+		}
+		f, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatalf("OpenReader failed: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		cells := []struct {
+			cell      string
+			typ       excelize.CellType
+			raw       string
+			numFmt    string
+			formatted string
+		}{
+			{"A2", excelize.CellTypeUnset, "42", "0", "42"},
+			{"B2", excelize.CellTypeUnset, "1234.5", "#,##0.00", "1,234.50"},
+			{"C2", excelize.CellTypeUnset, "0.125", "0.00%", "12.50%"},
+			{"D2", excelize.CellTypeBool, "1", "", "TRUE"},
+			{"E2", excelize.CellTypeUnset, "45366", "yyyy-mm-dd", "2024-03-15"},
+			{"F3", excelize.CellTypeUnset, "0.00052083336", "[h]:mm:ss", "0:00:45"},
+			{"E3", excelize.CellTypeSharedString, "unknown", "yyyy-mm-dd", "unknown"},
+		}
+		for _, c := range cells {
+			typ, _ := f.GetCellType("Data", c.cell)
+			raw, _ := f.GetCellValue("Data", c.cell, excelize.Options{RawCellValue: true})
+			if typ != c.typ || raw != c.raw {
+				t.Errorf("%s = %q (type %v), want %q (type %v)", c.cell, raw, typ, c.raw, c.typ)
+			}
+			id, _ := f.GetCellStyle("Data", c.cell)
+			style, _ := f.GetStyle(id)
+			if got := ""; style != nil && style.CustomNumFmt != nil {
+				got = *style.CustomNumFmt
+				if got != c.numFmt {
+					t.Errorf("%s number format = %q, want %q", c.cell, got, c.numFmt)
+				}
+			} else if c.numFmt != "" {
+				t.Errorf("%s has no number format, want %q", c.cell, c.numFmt)
+			}
+			if got, _ := f.GetCellValue("Data", c.cell); got != c.formatted {
+				t.Errorf("%s displays %q, want %q", c.cell, got, c.formatted)
+			}
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := ExportCSV(",", table, FileWriteParams{Filename: "typed", Writer: &buf}); err != nil {
+			t.Fatalf("ExportCSV() error = %v", err)
+		}
+		want := "ID,Amount,Share,Active,Day,Took,Note\n" +
+			"42,1234.50,12.50%,true,2024-03-15,1:30:00,n/a\n" +
+			"7,3.00,50.00%,false,unknown,0:00:45\n"
+		if buf.String() != want {
+			t.Errorf("expected %q, got %q", want, buf.String())
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		markup, err := RenderMarkdown(table)
+		if err != nil {
+			t.Fatalf("RenderMarkdown() error = %v", err)
+		}
+		if want := "| 42 | 1234.50 | 12.50% | true | 2024-03-15 | 1:30:00 | n/a |"; !strings.Contains(markup, want) {
+			t.Errorf("RenderMarkdown() = %q, want the row %q", markup, want)
+		}
+	})
+}
@@ -0,0 +1,251 @@
+// config.go - JSON and YAML table layouts.
+//
+// This file implements the table layouts defined in configuration files (see LoadTableConfig): the
+// columns, styles, borders, merge rules and serializable options of a table are read from JSON or
+// YAML, so report layouts can be maintained without Go code. Tables marshal to the same layout,
+// without their data and callbacks. Enumerations are written by name (see enums.go).
+
+package spit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tableConfig is the layout of a table in configuration files.
+type tableConfig struct {
+	Columns             Columns                  `json:"columns,omitempty" yaml:"columns,omitempty"`
+	WriteHeader         bool                     `json:"writeHeader" yaml:"writeHeader"`
+	HeaderOptions       *HeaderOptions           `json:"headerOptions,omitempty" yaml:"headerOptions,omitempty"`
+	WriteFooter         bool                     `json:"writeFooter,omitempty" yaml:"writeFooter,omitempty"`
+	FooterOptions       *FooterOptions           `json:"footerOptions,omitempty" yaml:"footerOptions,omitempty"`
+	Limit               int64                    `json:"limit,omitempty" yaml:"limit,omitempty"`
+	ListSeparator       string                   `json:"listSeparator,omitempty" yaml:"listSeparator,omitempty"`
+	StartRow            int                      `json:"startRow,omitempty" yaml:"startRow,omitempty"`
+	StartCol            int                      `json:"startCol,omitempty" yaml:"startCol,omitempty"`
+	LabelSeparator      string                   `json:"labelSeparator,omitempty" yaml:"labelSeparator,omitempty"`
+	GroupBy             []string                 `json:"groupBy,omitempty" yaml:"groupBy,omitempty"`
+	AutoFilter          bool                     `json:"autoFilter,omitempty" yaml:"autoFilter,omitempty"`
+	Transposed          bool                     `json:"transposed,omitempty" yaml:"transposed,omitempty"`
+	Sort                []SortKey                `json:"sort,omitempty" yaml:"sort,omitempty"`
+	Zebra               *ZebraOptions            `json:"zebra,omitempty" yaml:"zebra,omitempty"`
+	DisableAutoContrast bool                     `json:"disableAutoContrast,omitempty" yaml:"disableAutoContrast,omitempty"`
+	CSVMerges           CSVMergeMode             `json:"csvMerges,omitempty" yaml:"csvMerges,omitempty"`
+	CSVDescriptionRow   bool                     `json:"csvDescriptionRow,omitempty" yaml:"csvDescriptionRow,omitempty"`
+	DataName            string                   `json:"dataName,omitempty" yaml:"dataName,omitempty"`
+	ErrorMode           ErrorMode                `json:"errorMode,omitempty" yaml:"errorMode,omitempty"`
+	TimeZone            string                   `json:"timeZone,omitempty" yaml:"timeZone,omitempty"` // IANA name of Table.TimeLocation
+	SerialDates         bool                     `json:"serialDates,omitempty" yaml:"serialDates,omitempty"`
+	NilPlaceholder      *PlaceholderOptions      `json:"nilPlaceholder,omitempty" yaml:"nilPlaceholder,omitempty"`
+	CellOverflow        CellOverflowMode         `json:"cellOverflow,omitempty" yaml:"cellOverflow,omitempty"`
+	FormulaEscape       FormulaEscapeMode        `json:"formulaEscape,omitempty" yaml:"formulaEscape,omitempty"`
+	ChunkSize           int                      `json:"chunkSize,omitempty" yaml:"chunkSize,omitempty"`
+	ChunkMode           ChunkMode                `json:"chunkMode,omitempty" yaml:"chunkMode,omitempty"`
+	Theme               *Theme                   `json:"theme,omitempty" yaml:"theme,omitempty"` // Name of a built-in theme
+	RightToLeft         bool                     `json:"rightToLeft,omitempty" yaml:"rightToLeft,omitempty"`
+	Preamble            PreambleRows             `json:"preamble,omitempty" yaml:"preamble,omitempty"`
+	Preview             *PreviewOptions          `json:"preview,omitempty" yaml:"preview,omitempty"`
+	Protection          *SheetProtection         `json:"protection,omitempty" yaml:"protection,omitempty"`
+	ExcelTable          *ExcelTableOptions       `json:"excelTable,omitempty" yaml:"excelTable,omitempty"`
+	NamedRanges         *NamedRangeOptions       `json:"namedRanges,omitempty" yaml:"namedRanges,omitempty"`
+	SparseColumns       *SparseColumnOptions     `json:"sparseColumns,omitempty" yaml:"sparseColumns,omitempty"`
+	TruncationNotice    *TruncationNoticeOptions `json:"truncationNotice,omitempty" yaml:"truncationNotice,omitempty"`
+	GroupOptions        *GroupOptions            `json:"groupOptions,omitempty" yaml:"groupOptions,omitempty"`
+	RowOptions          RowOptionsMap            `json:"rowOptions,omitempty" yaml:"rowOptions,omitempty"`   // By 0-based data row index
+	CellOptions         CellOptionsMap           `json:"cellOptions,omitempty" yaml:"cellOptions,omitempty"` // By 1-based column, then 0-based data row index
+}
+
+// LoadTableConfig reads a table layout from a JSON or YAML document and returns a table without
+// data; set Table.Data (or build the rows with the usual helpers) before exporting. Documents
+// starting with "{" are read as JSON, anything else as YAML. Unknown fields are rejected, so typos
+// in hand-written layouts are reported rather than ignored. Headers are written unless writeHeader
+// is false. Callbacks (filters, row stylers, comparators, custom aggregates and formatters) cannot
+// be configured: register formatters and merge predicates by name before loading, and refine the
+// returned table in Go for the rest.
+func LoadTableConfig(r io.Reader) (*Table, error) {
+	br := bufio.NewReader(r)
+	config := tableConfig{WriteHeader: true}
+	if isJSONDocument(br) {
+		dec := json.NewDecoder(br)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&config); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON table config: %w", err)
+		}
+	} else {
+		dec := yaml.NewDecoder(br)
+		dec.KnownFields(true)
+		if err := dec.Decode(&config); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to decode YAML table config: %w", err)
+		}
+	}
+
+	table := NewTable(nil, nil, true)
+	if err := table.applyConfig(config); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// isJSONDocument reports whether the first non-blank character of the document is "{".
+func isJSONDocument(br *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		peek, err := br.Peek(n)
+		if len(peek) < n {
+			return false
+		}
+		switch c := peek[n-1]; c {
+		case ' ', '\t', '\r', '\n':
+			if err != nil {
+				return false
+			}
+		case 0xEF, 0xBB, 0xBF: // UTF-8 byte order mark
+		default:
+			return c == '{'
+		}
+	}
+}
+
+// config returns the layout of the table.
+func (t *Table) config() tableConfig {
+	config := tableConfig{
+		Columns:             t.Columns,
+		WriteHeader:         t.WriteHeader,
+		HeaderOptions:       t.HeaderOptions,
+		WriteFooter:         t.WriteFooter,
+		FooterOptions:       t.FooterOptions,
+		Limit:               t.Limit,
+		ListSeparator:       t.ListSeparator,
+		StartRow:            t.StartRow,
+		StartCol:            t.StartCol,
+		LabelSeparator:      t.LabelSeparator,
+		GroupBy:             t.GroupBy,
+		AutoFilter:          t.AutoFilter,
+		Transposed:          t.Transposed,
+		Sort:                t.Sort,
+		Zebra:               t.Zebra,
+		DisableAutoContrast: t.DisableAutoContrast,
+		CSVMerges:           t.CSVMerges,
+		CSVDescriptionRow:   t.CSVDescriptionRow,
+		DataName:            t.DataName,
+		ErrorMode:           t.ErrorMode,
+		SerialDates:         t.SerialDates,
+		NilPlaceholder:      t.NilPlaceholder,
+		CellOverflow:        t.CellOverflow,
+		FormulaEscape:       t.FormulaEscape,
+		ChunkSize:           t.ChunkSize,
+		ChunkMode:           t.ChunkMode,
+		Theme:               t.Theme,
+		RightToLeft:         t.RightToLeft,
+		Preamble:            t.Preamble,
+		Preview:             t.Preview,
+		Protection:          t.Protection,
+		ExcelTable:          t.ExcelTable,
+		NamedRanges:         t.NamedRanges,
+		SparseColumns:       t.SparseColumns,
+		TruncationNotice:    t.TruncationNotice,
+		GroupOptions:        t.GroupOptions,
+		RowOptions:          t.RowOptionsMap,
+		CellOptions:         t.CellOptionsMap,
+	}
+	if t.TimeLocation != nil {
+		config.TimeZone = t.TimeLocation.String()
+	}
+	return config
+}
+
+// applyConfig sets the layout of the table, leaving its data and callbacks unchanged.
+func (t *Table) applyConfig(config tableConfig) error {
+	var location *time.Location
+	if config.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(config.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %q: %w", config.TimeZone, err)
+		}
+	}
+
+	t.Columns = config.Columns
+	t.WriteHeader = config.WriteHeader
+	t.HeaderOptions = config.HeaderOptions
+	t.WriteFooter = config.WriteFooter
+	t.FooterOptions = config.FooterOptions
+	t.Limit = config.Limit
+	t.ListSeparator = config.ListSeparator
+	t.StartRow = config.StartRow
+	t.StartCol = config.StartCol
+	t.LabelSeparator = config.LabelSeparator
+	t.GroupBy = config.GroupBy
+	t.AutoFilter = config.AutoFilter
+	t.Transposed = config.Transposed
+	t.Sort = config.Sort
+	t.Zebra = config.Zebra
+	t.DisableAutoContrast = config.DisableAutoContrast
+	t.CSVMerges = config.CSVMerges
+	t.CSVDescriptionRow = config.CSVDescriptionRow
+	t.DataName = config.DataName
+	t.ErrorMode = config.ErrorMode
+	t.TimeLocation = location
+	t.SerialDates = config.SerialDates
+	t.NilPlaceholder = config.NilPlaceholder
+	t.CellOverflow = config.CellOverflow
+	t.FormulaEscape = config.FormulaEscape
+	t.ChunkSize = config.ChunkSize
+	t.ChunkMode = config.ChunkMode
+	t.Theme = config.Theme
+	t.RightToLeft = config.RightToLeft
+	t.Preamble = config.Preamble
+	t.Preview = config.Preview
+	t.Protection = config.Protection
+	t.ExcelTable = config.ExcelTable
+	t.NamedRanges = config.NamedRanges
+	t.SparseColumns = config.SparseColumns
+	t.TruncationNotice = config.TruncationNotice
+	t.GroupOptions = config.GroupOptions
+	t.RowOptionsMap = config.RowOptions
+	t.CellOptionsMap = config.CellOptions
+	return nil
+}
+
+// MarshalJSON writes the layout of the table (see LoadTableConfig), without its data.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.config())
+}
+
+// UnmarshalJSON sets the layout of the table from JSON (see LoadTableConfig), leaving its data
+// unchanged.
+func (t *Table) UnmarshalJSON(data []byte) error {
+	config := tableConfig{WriteHeader: true}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&config); err != nil {
+		return err
+	}
+	return t.applyConfig(config)
+}
+
+// MarshalYAML writes the layout of the table (see LoadTableConfig), without its data.
+func (t *Table) MarshalYAML() (interface{}, error) {
+	return t.config(), nil
+}
+
+// UnmarshalYAML sets the layout of the table from YAML (see LoadTableConfig), leaving its data
+// unchanged.
+func (t *Table) UnmarshalYAML(value *yaml.Node) error {
+	// Node.Decode ignores unknown fields: the node is decoded again from its text to reject them
+	text, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	config := tableConfig{WriteHeader: true}
+	dec := yaml.NewDecoder(bytes.NewReader(text))
+	dec.KnownFields(true)
+	if err := dec.Decode(&config); err != nil && err != io.EOF {
+		return err
+	}
+	return t.applyConfig(config)
+}
@@ -0,0 +1,221 @@
+package spit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testYAMLConfig = `
+columns:
+  - name: region
+    label: Region
+    merge:
+      vertical: [identical]
+      values: blank
+  - label: Sales
+    columns:
+      - name: amount
+        label: Amount
+        type: currency
+        aggregate: sum
+        style: {bold: true, alignment: right-middle, numFmt: "#,##0.00"}
+        borders:
+          bottom: {style: thick}
+        formats: [xlsx, csv]
+headerOptions:
+  style: {backgroundColor: "#DDEBF7", bold: true}
+writeFooter: true
+sort:
+  - field: amount
+    direction: descending
+timeZone: Europe/Paris
+formulaEscape: quote
+`
+
+func TestLoadTableConfig(t *testing.T) {
+	table, err := LoadTableConfig(strings.NewReader(testYAMLConfig))
+	if err != nil {
+		t.Fatalf("LoadTableConfig() error = %v", err)
+	}
+
+	region := table.Columns[0]
+	if region.Name != "region" || !reflect.DeepEqual(region.Merge, &MergeRules{Vertical: MergeConditions{MergeConditionIdentical}, Values: MergeValuesBlank}) {
+		t.Errorf("region column = %+v, merge %+v", region, region.Merge)
+	}
+	amount := table.Columns[1].Columns[0]
+	wantStyle := &Style{Bold: true, Alignment: AlignmentRightMiddle, NumFmt: "#,##0.00"}
+	if amount.Type != ColumnTypeCurrency || amount.Aggregate.Function != "SUM" || !reflect.DeepEqual(amount.Style, wantStyle) {
+		t.Errorf("amount column = %+v, style %+v", amount, amount.Style)
+	}
+	if amount.Borders.Bottom.Style != BorderStyleThick || !reflect.DeepEqual(amount.Formats, []Format{FormatXSLX, FormatCSV}) {
+		t.Errorf("amount borders %+v, formats %v", amount.Borders, amount.Formats)
+	}
+	if !table.WriteHeader || !table.WriteFooter || table.HeaderOptions.Style.BackgroundColor != "#DDEBF7" {
+		t.Errorf("header %v %+v, footer %v", table.WriteHeader, table.HeaderOptions, table.WriteFooter)
+	}
+	if !reflect.DeepEqual(table.Sort, []SortKey{{Field: "amount", Direction: SortDescending}}) {
+		t.Errorf("sort = %+v", table.Sort)
+	}
+	if table.TimeLocation.String() != "Europe/Paris" || table.FormulaEscape != FormulaEscapeQuote {
+		t.Errorf("time location %v, formula escape %v", table.TimeLocation, table.FormulaEscape)
+	}
+
+	table.Data = DataSlice{{"region": "EU", "amount": 10}, {"region": "EU", "amount": 32}}
+	var buf bytes.Buffer
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "config", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	if got, want := buf.String(), "Region,Sales\n,Amount\nEU,32.00\nEU,10.00\nTotal,42\n"; got != want {
+		t.Errorf("ExportCSV() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadTableConfig_options(t *testing.T) {
+	table, err := LoadTableConfig(strings.NewReader(`
+columns:
+  - {name: region, label: Region}
+preamble:
+  - values: [Quarterly report]
+    style: {bold: true}
+preview: {maxRows: 5, maskColumns: [region], watermark: Sample}
+protection: {password: secret, sort: true}
+excelTable: {name: Sales, bandedRows: true}
+namedRanges: {prefix: Sales_, columns: true}
+sparseColumns: {action: group, otherLabel: Misc}
+truncationNotice: {text: "%d more rows"}
+groupOptions: {collapsed: true, subtotals: true}
+rightToLeft: true
+rowOptions:
+  0: {spanAllColumns: true, value: Europe}
+cellOptions:
+  1:
+    2: {formula: "=1+1", comment: {text: Checked}}
+`))
+	if err != nil {
+		t.Fatalf("LoadTableConfig() error = %v", err)
+	}
+
+	if len(table.Preamble) != 1 || !reflect.DeepEqual(table.Preamble[0].Values, []interface{}{"Quarterly report"}) || !table.Preamble[0].Style.Bold {
+		t.Errorf("preamble = %+v", table.Preamble)
+	}
+	if table.Preview.MaxRows != 5 || table.Preview.Watermark != "Sample" || !reflect.DeepEqual(table.Preview.MaskColumns, []string{"region"}) {
+		t.Errorf("preview = %+v", table.Preview)
+	}
+	if *table.Protection != (SheetProtection{Password: "secret", Sort: true}) || table.ExcelTable.Name != "Sales" || !table.ExcelTable.BandedRows {
+		t.Errorf("protection = %+v, excel table = %+v", table.Protection, table.ExcelTable)
+	}
+	if *table.NamedRanges != (NamedRangeOptions{Prefix: "Sales_", Columns: true}) || *table.SparseColumns != (SparseColumnOptions{Action: SparseColumnsGroup, OtherLabel: "Misc"}) {
+		t.Errorf("named ranges = %+v, sparse columns = %+v", table.NamedRanges, table.SparseColumns)
+	}
+	if table.TruncationNotice.Text != "%d more rows" || !table.GroupOptions.Collapsed || !table.GroupOptions.Subtotals {
+		t.Errorf("truncation notice = %+v, group options = %+v", table.TruncationNotice, table.GroupOptions)
+	}
+	if !table.RightToLeft {
+		t.Error("right to left not loaded")
+	}
+	if ro := table.RowOptionsMap[0]; !ro.SpanAllColumns || ro.Value != "Europe" {
+		t.Errorf("row options = %+v", table.RowOptionsMap)
+	}
+	if co := table.CellOptionsMap[1][2]; co.Formula != "=1+1" || co.Comment == nil || co.Comment.Text != "Checked" {
+		t.Errorf("cell options = %+v", table.CellOptionsMap)
+	}
+
+	for name, marshal := range map[string]func(interface{}) ([]byte, error){"JSON": json.Marshal, "YAML": yaml.Marshal} {
+		t.Run(name, func(t *testing.T) {
+			data, err := marshal(table)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			loaded, err := LoadTableConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("LoadTableConfig(%s) error = %v", data, err)
+			}
+			if !reflect.DeepEqual(loaded.config(), table.config()) {
+				t.Errorf("round trip of %s = %+v", data, loaded.config())
+			}
+		})
+	}
+}
+
+func TestLoadTableConfig_JSON(t *testing.T) {
+	table, err := LoadTableConfig(strings.NewReader(`
+	{
+		"columns": [{"name": "name", "label": "Name", "style": {"textColor": "#FF0000"}}],
+		"writeHeader": false
+	}`))
+	if err != nil {
+		t.Fatalf("LoadTableConfig() error = %v", err)
+	}
+	if table.WriteHeader || len(table.Columns) != 1 || table.Columns[0].Style.TextColor != "#FF0000" {
+		t.Errorf("table = %+v", table)
+	}
+}
+
+func TestLoadTableConfig_errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"unknown field", "columns:\n  - name: a\n    lable: A\n", "field lable not found"},
+		{"unknown JSON field", `{"colums": []}`, `unknown field "colums"`},
+		{"unknown enum", "columns:\n  - name: a\n    style: {alignment: sideways}\n", `unknown alignment "sideways"`},
+		{"unknown aggregate", `{"columns": [{"name": "a", "aggregate": "median"}]}`, `unknown aggregate "median"`},
+		{"unknown time zone", "timeZone: Mars/Olympus\n", `invalid time zone "Mars/Olympus"`},
+		{"unknown nested field", "protection: {pasword: secret}\n", "field pasword not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadTableConfig(strings.NewReader(tt.config)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadTableConfig() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_marshalConfig(t *testing.T) {
+	table, err := LoadTableConfig(strings.NewReader(testYAMLConfig))
+	if err != nil {
+		t.Fatalf("LoadTableConfig() error = %v", err)
+	}
+	table.Data = DataSlice{{"region": "EU"}}
+
+	for name, marshal := range map[string]func(interface{}) ([]byte, error){"JSON": json.Marshal, "YAML": yaml.Marshal} {
+		t.Run(name, func(t *testing.T) {
+			data, err := marshal(table)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if strings.Contains(string(data), "EU") {
+				t.Errorf("Marshal() wrote the data: %s", data)
+			}
+			loaded, err := LoadTableConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("LoadTableConfig(%s) error = %v", data, err)
+			}
+			if again, _ := marshal(loaded); !bytes.Equal(again, data) {
+				t.Errorf("round trip of %s = %s", data, again)
+			}
+			if loaded.TimeLocation.String() != "Europe/Paris" || loaded.Columns[1].Columns[0].Aggregate.Function != "SUM" {
+				t.Errorf("round trip of %s = %+v", data, loaded)
+			}
+		})
+	}
+
+	// Tables nested in YAML documents reject unknown fields as LoadTableConfig does
+	var doc struct {
+		Table *Table `yaml:"table"`
+	}
+	if err := yaml.Unmarshal([]byte("table:\n  colums: []\n"), &doc); err == nil || !strings.Contains(err.Error(), "field colums not found") {
+		t.Errorf("yaml.Unmarshal() error = %v, want the unknown field rejected", err)
+	}
+
+	if _, err := json.Marshal(NewTable(nil, Columns{NewColumn("a", "A").WithAggregate(NewAggregate(nil))}, true)); err == nil {
+		t.Error("json.Marshal() of a custom aggregate succeeded")
+	}
+}
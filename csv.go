@@ -17,54 +17,101 @@ func ExportCSV(separator string, t *Table, params FileWriteParams) (*FileWriteRe
 		params.Extension = FormatCSV.String()
 	}
 
+	if parts := t.chunks(); parts != nil {
+		return exportParts(parts, params, func(part *Table, params FileWriteParams) (*FileWriteResult, error) {
+			return ExportCSV(separator, part, params)
+		})
+	}
+
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+	prepared := params.timings.measure(timingPrepare)
 	csvConfig := &csv{
 		separator: separator,
-		table:     t,
+		table:     t.withSeed(params.Seed).Prepare().ForFormat(FormatCSV).withWarnings("", params.OnWarning, params.Logger),
 		params:    params,
 	}
+	prepared()
+	if err := params.quota.addTable(csvConfig.table); err != nil {
+		return nil, err
+	}
+	params.progress.track(csvConfig.table, progressPhasesFlat)
+
+	// CSV has no preamble area; preview exports carry their watermark as a leading row instead
+	if t.Preview != nil {
+		csvConfig.watermark = t.Preview.GetWatermark()
+	}
 
-	L().Info("Starting CSV export to file", String("filename", csvConfig.params.Filename))
+	params.logger().Info("Starting CSV export to file", String("filename", csvConfig.params.Filename))
 
 	// Create a write function that handles the CSV file creation and writing
 	writeFunc := func(writer io.Writer) error {
-		csvConfig.writer = stdcsv.NewWriter(writer)
-		return csvConfig.writeData()
+		encoded, flush, err := params.Encoding.writer(writer)
+		if err != nil {
+			return fmt.Errorf("error writing CSV byte order mark: %w", err)
+		}
+		csvConfig.writer = stdcsv.NewWriter(encoded)
+		if err := csvConfig.writeData(); err != nil {
+			return err
+		}
+		return flush()
 	}
 
 	// Use the generic file writer to handle the actual file writing
 	result, err := csvConfig.params.WriteToFile(writeFunc)
 	if err != nil {
-		L().Error("Failed to write CSV to file", Error(err))
+		params.logger().Error("Failed to write CSV to file", Error(err))
 		return nil, err
 	}
 
-	L().Info("CSV export completed", String("filename", csvConfig.params.Filename))
+	result.Warnings = csvConfig.table.exportWarnings()
+	result.SparseColumns = csvConfig.table.sparseColumns("")
+
+	params.logger().Info("CSV export completed", String("filename", csvConfig.params.Filename))
 	return result, nil
 }
 
+// CSVMergeMode defines how vertical merge ranges are rendered in CSV exports.
+type CSVMergeMode int
+
+const (
+	// CSVMergeIgnore writes every value of a merge range, as if the column did not merge (default).
+	CSVMergeIgnore CSVMergeMode = iota
+
+	// CSVMergeBlankRepeats writes the first value of each vertical merge range and leaves the rows
+	// covered by the range empty, so the grouping stays visible in plain text.
+	CSVMergeBlankRepeats
+)
+
 // csv contains CSV-specific export parameters and logic.
 type csv struct {
 	writer    *stdcsv.Writer  // Private CSV writer instance
 	separator string          // Separator used for CSV fields, default is comma
 	table     *Table          // Reference to the Table being exported
 	params    FileWriteParams // File write parameters for the CSV export
+	watermark string          // Optional watermark row written before the headers (preview mode)
+	records   [][]string      // Records kept for the final rotation of transposed tables
 }
 
 // writeData writes the provided table data to the CSV writer.
 // Handles headers, data rows, and value formatting.
 func (csv *csv) writeData() error {
-	L().Debug("Writing data to CSV...")
+	csv.params.logger().Debug("Writing data to CSV...")
 
-	// Set the CSV delimiter (comma by default)
-	if csv.separator != "" {
-		csv.writer.Comma = rune(csv.separator[0])
-	} else {
-		csv.writer.Comma = ','
+	csv.setDelimiter()
+
+	// Write the preview watermark first so samples are never mistaken for full data
+	if csv.watermark != "" {
+		if err := csv.writer.Write([]string{csv.watermark}); err != nil {
+			return fmt.Errorf("error writing CSV watermark: %w", err)
+		}
 	}
 
 	// Write headers if requested
 	if csv.table.WriteHeader && len(csv.table.Columns) > 0 {
-		L().Debug("Writing CSV headers...")
+		csv.params.logger().Debug("Writing CSV headers...")
 		if err := csv.writeHeaders(); err != nil {
 			return fmt.Errorf("error writing CSV headers: %w", err)
 		}
@@ -73,30 +120,64 @@ func (csv *csv) writeData() error {
 	// Get flattened columns for data processing
 	flatColumns := csv.table.Columns.GetFlattenedColumns()
 
+	// Cells covered by vertical merge ranges, left empty in blank-on-repeat mode
+	blanked := csv.blankedCells(flatColumns)
+
 	// Write each data row to the CSV
 	for rowIdx, item := range csv.table.Data {
+		if err := csv.table.advance(1); err != nil {
+			return err
+		}
+
+		// Full-width rows with an explicit value hold that value alone, like their merged cell in sheets
+		if rc, ok := csv.table.RowOptionsMap[rowIdx]; ok && rc.SpanAllColumns && rc.Value != nil && len(flatColumns) > 0 {
+			record := make([]string, len(flatColumns))
+			record[0] = csv.table.escapeFormula(flatColumns[0], fmt.Sprintf("%v", rc.Value))
+			if err := csv.write(record); err != nil {
+				return fmt.Errorf("error writing CSV record for row %d: %w", rowIdx, err)
+			}
+			continue
+		}
+
+		record, err := csv.dataRecord(rowIdx, item, flatColumns, blanked)
+		if err != nil {
+			return err
+		}
+
+		// Write the processed record to the CSV file
+		if err := csv.write(record); err != nil {
+			return fmt.Errorf("error writing CSV record for row %d: %w", rowIdx, err)
+		}
+	}
+
+	// Write the aggregate footer row if requested
+	if csv.table.hasFooter() {
 		record := make([]string, 0, len(flatColumns))
-		for _, column := range flatColumns {
-			// Lookup the value for this column in the current row
-			value, err, found := item.Lookup(column.Name)
-			if err == nil && !found {
+		for i, value := range csv.table.FooterValues() {
+			if value == nil {
+				record = append(record, "")
 				continue
 			}
-			if err != nil {
-				return fmt.Errorf("error looking up value for column %s in row %d: %w", column.Name, rowIdx, err)
-			}
+			record = append(record, csv.table.escapeFormula(flatColumns[i], fmt.Sprintf("%v", value)))
+		}
+		if err := csv.write(record); err != nil {
+			return fmt.Errorf("error writing CSV footer: %w", err)
+		}
+	}
 
-			// Process the value based on column format (e.g., date, number)
-			processedValue, err := csv.processValue(value, column.Format)
-			if err != nil {
-				return fmt.Errorf("error processing value for column %s in row %d: %w", column.Name, rowIdx, err)
-			}
-			record = append(record, processedValue)
+	// Truncated tables end with their notice in the first field
+	if csv.table.hasTruncationNotice() {
+		record := make([]string, max(len(flatColumns), 1))
+		record[0] = csv.table.TruncationNotice.GetText(csv.table.truncated)
+		if err := csv.write(record); err != nil {
+			return fmt.Errorf("error writing CSV truncation notice: %w", err)
 		}
+	}
 
-		// Write the processed record to the CSV file
+	// Transposed tables are written once every record is known, one table column per line
+	for i, record := range transposeRecords(csv.records) {
 		if err := csv.writer.Write(record); err != nil {
-			return fmt.Errorf("error writing CSV record for row %d: %w", rowIdx, err)
+			return fmt.Errorf("error writing transposed CSV record %d: %w", i, err)
 		}
 	}
 
@@ -106,26 +187,133 @@ func (csv *csv) writeData() error {
 		return fmt.Errorf("error flushing CSV writer: %w", err)
 	}
 
-	L().Debug("CSV data writing complete.")
+	if err := csv.table.strictErr(); err != nil {
+		return err
+	}
+	csv.table.progress.finish()
+	csv.params.logger().Debug("CSV data writing complete.")
 	return nil
 }
 
+// setDelimiter sets the CSV delimiter (comma by default).
+func (csv *csv) setDelimiter() {
+	if csv.separator != "" {
+		csv.writer.Comma = rune(csv.separator[0])
+	} else {
+		csv.writer.Comma = ','
+	}
+}
+
+// dataRecord returns the record of a data row: the processed value of each leaf column, empty for
+// the cells blanked by vertical merge ranges (see blankedCells).
+func (csv *csv) dataRecord(rowIdx int, item Data, flatColumns []*Column, blanked map[int]map[int]bool) ([]string, error) {
+	record := make([]string, 0, len(flatColumns))
+	for colIdx, column := range flatColumns {
+		if blanked[colIdx][rowIdx] {
+			record = append(record, "")
+			continue
+		}
+
+		// Lookup the value for this column in the current row
+		value, err, found := item.LookupColumn(column)
+		if err != nil {
+			return nil, fmt.Errorf("error looking up value for column %s in row %d: %w", column.Name, rowIdx, err)
+		}
+		if text, ok := csv.table.CellPlaceholder(column, value, found); ok {
+			record = append(record, text)
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		// Process the value based on column type or format (e.g., date, number)
+		value = csv.table.inLocation(column, value)
+		processedValue, typed, err := column.typedText(value)
+		if !typed && err == nil {
+			processedValue, err = csv.processValue(value, column.Format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error processing value for column %s in row %d: %w", column.Name, rowIdx, err)
+		}
+		record = append(record, csv.table.escapeFormula(column, processedValue))
+	}
+	return record, nil
+}
+
+// blankedCells returns, by flattened column index, the data rows covered by a vertical merge range
+// when the table renders merges as blank-on-repeat (see Table.CSVMerges), or nil.
+func (csv *csv) blankedCells(flatColumns []*Column) map[int]map[int]bool {
+	if csv.table.CSVMerges != CSVMergeBlankRepeats {
+		return nil
+	}
+	ops := &htmlExport{table: csv.table}
+	blanked := make(map[int]map[int]bool)
+	for colIdx, column := range flatColumns {
+		if column.Merge == nil || len(column.Merge.Vertical) == 0 {
+			continue
+		}
+		for _, mr := range csv.table.findVerticalMergeRanges(colIdx+1, column, column.Merge.Vertical, ops) {
+			if blanked[colIdx] == nil {
+				blanked[colIdx] = make(map[int]bool)
+			}
+			for _, rowIdx := range mr[1:] {
+				blanked[colIdx][rowIdx] = true
+			}
+		}
+	}
+	return blanked
+}
+
+// write writes a record, or keeps it for the final rotation of transposed tables.
+func (csv *csv) write(record []string) error {
+	if csv.table.Transposed {
+		csv.records = append(csv.records, record)
+		return nil
+	}
+	return csv.writer.Write(record)
+}
+
 // writeHeaders writes header rows to represent the hierarchical column structure
 // Each row corresponds to a level in the column hierarchy, allowing for grouped headers in the CSV output.
 func (csv *csv) writeHeaders() error {
 	maxDepth := csv.table.Columns.GetMaxDepth()
 	totalCols := csv.table.Columns.GetTotalColumnCount()
-	L().Debug("Writing header levels", Int("levels", maxDepth), Int("columns", totalCols))
+	csv.params.logger().Debug("Writing header levels", Int("levels", maxDepth), Int("columns", totalCols))
 
 	// Generate header rows for each level
 	for level := 0; level < maxDepth; level++ {
 		headerRow := make([]string, totalCols)
 		csv.fillHeaderLevel(headerRow, level, 0, 0, csv.table.Columns)
-		if err := csv.writer.Write(headerRow); err != nil {
+		if err := csv.write(headerRow); err != nil {
 			return fmt.Errorf("error writing header row: %w", err)
 		}
 	}
-	L().Debug("CSV headers written successfully.")
+	if csv.table.CSVDescriptionRow {
+		if err := csv.writeDescriptions(); err != nil {
+			return err
+		}
+	}
+	csv.params.logger().Debug("CSV headers written successfully.")
+	return nil
+}
+
+// writeDescriptions writes the descriptions of the leaf columns as a row below the header rows,
+// unless no column has a description (see Table.WithCSVDescriptionRow).
+func (csv *csv) writeDescriptions() error {
+	flatColumns := csv.table.Columns.GetFlattenedColumns()
+	descriptions := make([]string, len(flatColumns))
+	described := false
+	for i, column := range flatColumns {
+		descriptions[i] = column.Description
+		described = described || column.Description != ""
+	}
+	if !described {
+		return nil
+	}
+	if err := csv.write(descriptions); err != nil {
+		return fmt.Errorf("error writing description row: %w", err)
+	}
 	return nil
 }
 
@@ -179,7 +367,13 @@ func (csv *csv) processValue(value interface{}, format string) (string, error) {
 	if img, ok := asImage(value); ok {
 		return img.TextValue(), nil
 	}
+	if text, ok := FormatDuration(value, format); ok {
+		return text, nil
+	}
+	value = NormalizeValue(value)
 	switch v := value.(type) {
+	case nil:
+		return "", nil
 	case []interface{}:
 		if csv.table.ListSeparator != "" {
 			return ConvertSliceToString(v, format, csv.table.ListSeparator)
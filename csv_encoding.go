@@ -0,0 +1,82 @@
+// csv_encoding.go - Character encodings of CSV exports.
+//
+// This file implements the character encoding of CSV files (see FileWriteParams.Encoding). CSV
+// files are UTF-8 by default, which Excel on Windows reads in the legacy code page of the system
+// unless the file starts with a byte order mark, mangling accented characters. The encodings
+// transform the written text on the fly, so exports are still streamed.
+
+package spit
+
+import (
+	"io"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+// Encoding is the character encoding of a text export.
+type Encoding int
+
+const (
+	// EncodingUTF8 writes UTF-8 without byte order mark (default).
+	EncodingUTF8 Encoding = iota
+
+	// EncodingUTF8BOM writes UTF-8 starting with a byte order mark, read as UTF-8 by Excel.
+	EncodingUTF8BOM
+
+	// EncodingUTF16LE writes little-endian UTF-16 starting with a byte order mark.
+	EncodingUTF16LE
+
+	// EncodingWindows1252 writes the Windows-1252 (Western European) code page; characters it
+	// lacks are written as "?".
+	EncodingWindows1252
+
+	// EncodingISO88591 writes ISO-8859-1 (Latin-1); characters it lacks are written as "?".
+	EncodingISO88591
+)
+
+// utf8BOM is the byte order mark starting UTF-8 files written with EncodingUTF8BOM.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// encoder returns the transformer encoding UTF-8 text, or nil for UTF-8.
+func (e Encoding) encoder() transform.Transformer {
+	switch e {
+	case EncodingUTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder()
+	case EncodingWindows1252:
+		return codePageEncoder(charmap.Windows1252)
+	case EncodingISO88591:
+		return codePageEncoder(charmap.ISO8859_1)
+	}
+	return nil
+}
+
+// codePageEncoder returns the transformer encoding UTF-8 text into a single-byte code page, the
+// characters it lacks replaced with "?".
+func codePageEncoder(cm *charmap.Charmap) transform.Transformer {
+	unsupported := runes.Map(func(r rune) rune {
+		if _, ok := cm.EncodeRune(r); !ok {
+			return '?'
+		}
+		return r
+	})
+	return transform.Chain(unsupported, cm.NewEncoder())
+}
+
+// writer returns a writer encoding UTF-8 text written to it into w, and the function flushing it
+// once the text is written.
+func (e Encoding) writer(w io.Writer) (io.Writer, func() error, error) {
+	if e == EncodingUTF8BOM {
+		if _, err := w.Write(utf8BOM); err != nil {
+			return nil, nil, err
+		}
+	}
+	enc := e.encoder()
+	if enc == nil {
+		return w, func() error { return nil }, nil
+	}
+	encoded := transform.NewWriter(w, enc)
+	return encoded, encoded.Close, nil
+}
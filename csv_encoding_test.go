@@ -0,0 +1,37 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExportCSV_encoding(t *testing.T) {
+	table := func() *Table {
+		return NewTable(DataSlice{{"city": "Zürich", "note": "Crème ✓"}}, Columns{
+			NewColumn("city", "Ville"),
+			NewColumn("note", "Note"),
+		}, true)
+	}
+	tests := []struct {
+		encoding Encoding
+		want     []byte
+	}{
+		{EncodingUTF8, []byte("Ville,Note\nZürich,Crème ✓\n")},
+		{EncodingUTF8BOM, append([]byte{0xEF, 0xBB, 0xBF}, "Ville,Note\nZürich,Crème ✓\n"...)},
+		{EncodingUTF16LE, []byte{0xFF, 0xFE, 'V', 0, 'i', 0, 'l', 0, 'l', 0, 'e', 0, ',', 0, 'N', 0, 'o', 0, 't', 0, 'e', 0, '\n', 0,
+			'Z', 0, 0xFC, 0, 'r', 0, 'i', 0, 'c', 0, 'h', 0, ',', 0, 'C', 0, 'r', 0, 0xE8, 0, 'm', 0, 'e', 0, ' ', 0, 0x13, 0x27, '\n', 0}},
+		{EncodingWindows1252, []byte("Ville,Note\nZ\xfcrich,Cr\xe8me ?\n")},
+		{EncodingISO88591, []byte("Ville,Note\nZ\xfcrich,Cr\xe8me ?\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := ExportCSV(",", table(), FileWriteParams{Filename: "cities", Writer: &buf, Encoding: tt.encoding}); err != nil {
+				t.Fatalf("ExportCSV() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, buf.Bytes())
+			}
+		})
+	}
+}
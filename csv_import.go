@@ -0,0 +1,251 @@
+// csv_import.go - CSV import.
+//
+// This file implements ImportCSV, which reads a CSV document into a Table: the columns come from
+// the header record (given, absent or detected) and the data rows from the following records,
+// optionally converted to typed values. The result can be transformed and exported like any other
+// table, e.g. to re-export a CSV extract as a styled XLSX report.
+
+package spit
+
+import (
+	stdcsv "encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CSVHeaderMode defines how the first record of an imported CSV is interpreted.
+type CSVHeaderMode int
+
+const (
+	// CSVHeaderDetect treats the first record as a header when its values do not look like the
+	// data below them (e.g. text above numeric columns) (default).
+	CSVHeaderDetect CSVHeaderMode = iota
+
+	// CSVHeaderPresent always treats the first record as a header.
+	CSVHeaderPresent
+
+	// CSVHeaderAbsent treats every record as data; columns are named column_1, column_2, ...
+	CSVHeaderAbsent
+)
+
+// CSVImportOptions contains the parameters of ImportCSV.
+type CSVImportOptions struct {
+	Separator string        // CSV field separator (default: ",")
+	Header    CSVHeaderMode // How the first record is interpreted (default: CSVHeaderDetect)
+	// InferTypes converts the values of each column to int64, float64, bool or time.Time when
+	// every non-empty value of the column parses as that type; empty values become nil. Numeric
+	// and boolean columns use ExcelizeFormatDefault so XLSX exports keep them native. Otherwise
+	// every value is kept as a string.
+	InferTypes bool
+}
+
+// ImportCSV reads a CSV document into a table whose header is written on export. Column names and
+// labels are the header values (column_1, column_2, ... for missing or absent headers); duplicate
+// names are suffixed with their position. Records may have fewer or more fields than the header:
+// missing fields are absent from the row and extra fields get columns of their own.
+func ImportCSV(r io.Reader, opts CSVImportOptions) (*Table, error) {
+	reader := stdcsv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if opts.Separator != "" {
+		comma, size := utf8.DecodeRuneInString(opts.Separator)
+		if size != len(opts.Separator) {
+			return nil, fmt.Errorf("invalid CSV separator %q: must be a single character", opts.Separator)
+		}
+		reader.Comma = comma
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	var header []string
+	switch opts.Header {
+	case CSVHeaderPresent:
+		header = firstRecord(records)
+	case CSVHeaderDetect:
+		if detectCSVHeader(records) {
+			header = firstRecord(records)
+		}
+	}
+	if header != nil {
+		records = records[1:]
+	}
+
+	width := len(header)
+	for _, record := range records {
+		width = max(width, len(record))
+	}
+	columns := make(Columns, width)
+	used := make(map[string]bool, width)
+	for i := range columns {
+		label := ""
+		if i < len(header) {
+			label = header[i]
+		}
+		columns[i] = newImportedColumn(used, label, i+1)
+	}
+
+	data := make(DataSlice, len(records))
+	for i, record := range records {
+		row := make(Data, len(record))
+		for j, value := range record {
+			row[columns[j].Name] = value
+		}
+		data[i] = row
+	}
+
+	if opts.InferTypes {
+		for j, column := range columns {
+			values := make([]string, len(records))
+			for i, record := range records {
+				if j < len(record) {
+					values[i] = record[j]
+				}
+			}
+			typ, ok := inferCSVType(values)
+			if !ok {
+				continue
+			}
+			column.Format = typ.format
+			for i, record := range records {
+				if j < len(record) {
+					data[i][column.Name] = typ.convert(record[j])
+				}
+			}
+		}
+	}
+
+	L().Debug("Imported CSV", Int("rows", len(data)), Int("columns", len(columns)))
+	return NewTable(data, columns, true), nil
+}
+
+// newImportedColumn returns a leaf column for an imported label at a 1-based position: named and
+// labelled after the label (column_<position> when blank), with the position appended to names
+// already used, then a numeric suffix while that name is taken too (see uniqueName).
+func newImportedColumn(used map[string]bool, label string, position int) *Column {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = fmt.Sprintf("column_%d", position)
+	}
+	name := label
+	if used[name] {
+		name = fmt.Sprintf("%s_%d", label, position)
+	}
+	return NewColumn(uniqueName(name, used), label)
+}
+
+// firstRecord returns the first record, or nil when there is none.
+func firstRecord(records [][]string) []string {
+	if len(records) == 0 {
+		return nil
+	}
+	return records[0]
+}
+
+// csvType is a value type inferred by ImportCSV.
+type csvType struct {
+	parse  func(string) (interface{}, error)
+	format string // Column format keeping the values native in XLSX exports
+}
+
+// csvTypes are the value types inferred by ImportCSV, most specific first.
+var csvTypes = []csvType{
+	{func(s string) (interface{}, error) { return parseAsInt(s) }, ExcelizeFormatDefault},
+	{func(s string) (interface{}, error) { return parseAsFloat(s) }, ExcelizeFormatDefault},
+	{func(s string) (interface{}, error) { return parseAsBool(s) }, ExcelizeFormatDefault},
+	{func(s string) (interface{}, error) { return parseCSVTime(s) }, ""},
+}
+
+// inferCSVType returns the type of a column: the first type parsing every non-empty value, or
+// false when no type does.
+func inferCSVType(values []string) (csvType, bool) {
+	for _, typ := range csvTypes {
+		if typedColumn(values, typ.parse) {
+			return typ, true
+		}
+	}
+	return csvType{}, false
+}
+
+// convert returns the typed value of s, or nil when s is empty.
+func (typ csvType) convert(s string) interface{} {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	v, _ := typ.parse(s)
+	return v
+}
+
+// typedColumn reports whether parse accepts every non-empty value, with at least one of them.
+func typedColumn(values []string, parse func(string) (interface{}, error)) bool {
+	typed := false
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if _, err := parse(value); err != nil {
+			return false
+		}
+		typed = true
+	}
+	return typed
+}
+
+// parseCSVTime parses a date or timestamp: RFC 3339, a plain date (2006-01-02) or a format
+// accepted by ParseDate.
+func parseCSVTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return ParseDate(s)
+}
+
+// detectCSVHeader reports whether the first record looks like a header. Each column whose data
+// values share a type votes for a header when the first value does not parse as that type, and
+// against it otherwise. Without typed columns, the first record is a header when its values are
+// non-empty, distinct and not numeric.
+func detectCSVHeader(records [][]string) bool {
+	if len(records) == 0 {
+		return false
+	}
+	first := records[0]
+	votes := 0
+	for j, value := range first {
+		values := make([]string, 0, len(records)-1)
+		for _, record := range records[1:] {
+			if j < len(record) {
+				values = append(values, record[j])
+			}
+		}
+		if typ, ok := inferCSVType(values); ok {
+			if _, err := typ.parse(value); err != nil {
+				votes++
+			} else {
+				votes--
+			}
+		}
+	}
+	if votes != 0 {
+		return votes > 0
+	}
+
+	seen := make(map[string]bool, len(first))
+	for _, value := range first {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			return false
+		}
+		if _, err := parseAsFloat(value); err == nil {
+			return false
+		}
+		seen[value] = true
+	}
+	return len(first) > 0
+}
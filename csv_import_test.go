@@ -0,0 +1,153 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestImportCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		opts       CSVImportOptions
+		wantLabels []string
+		wantData   DataSlice
+	}{
+		{
+			name:       "detected header",
+			input:      "name,age\nAda,36\nAlan,41\n",
+			opts:       CSVImportOptions{InferTypes: true},
+			wantLabels: []string{"name", "age"},
+			wantData:   DataSlice{{"name": "Ada", "age": int64(36)}, {"name": "Alan", "age": int64(41)}},
+		},
+		{
+			name:       "detected text header",
+			input:      "first,last\nAda,Lovelace\n",
+			wantLabels: []string{"first", "last"},
+			wantData:   DataSlice{{"first": "Ada", "last": "Lovelace"}},
+		},
+		{
+			name:       "detected headerless",
+			input:      "Ada,36\nAlan,41\n",
+			wantLabels: []string{"column_1", "column_2"},
+			wantData:   DataSlice{{"column_1": "Ada", "column_2": "36"}, {"column_1": "Alan", "column_2": "41"}},
+		},
+		{
+			name:       "absent header",
+			input:      "first,last\nAda,Lovelace\n",
+			opts:       CSVImportOptions{Header: CSVHeaderAbsent},
+			wantLabels: []string{"column_1", "column_2"},
+			wantData:   DataSlice{{"column_1": "first", "column_2": "last"}, {"column_1": "Ada", "column_2": "Lovelace"}},
+		},
+		{
+			name:       "present header with duplicates and gaps",
+			input:      "id;id;\n1;2;3\n",
+			opts:       CSVImportOptions{Header: CSVHeaderPresent, Separator: ";"},
+			wantLabels: []string{"id", "id", "column_3"},
+			wantData:   DataSlice{{"id": "1", "id_2": "2", "column_3": "3"}},
+		},
+		{
+			name:       "present header with a suffixed name already taken",
+			input:      "name,name_3,name\na,b,c\n",
+			opts:       CSVImportOptions{Header: CSVHeaderPresent},
+			wantLabels: []string{"name", "name_3", "name"},
+			wantData:   DataSlice{{"name": "a", "name_3": "b", "name_3_2": "c"}},
+		},
+		{
+			name:       "ragged records",
+			input:      "a,b\n1\n2,3,4\n",
+			opts:       CSVImportOptions{Header: CSVHeaderPresent},
+			wantLabels: []string{"a", "b", "column_3"},
+			wantData:   DataSlice{{"a": "1"}, {"a": "2", "b": "3", "column_3": "4"}},
+		},
+		{
+			name:       "inferred types",
+			input:      "n,x,ok,day,note\n1,1.5,true,2024-03-01,a\n,2,no,2024-03-02T10:00:00Z,1\n",
+			opts:       CSVImportOptions{InferTypes: true},
+			wantLabels: []string{"n", "x", "ok", "day", "note"},
+			wantData: DataSlice{
+				{"n": int64(1), "x": 1.5, "ok": true, "day": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "note": "a"},
+				{"n": nil, "x": 2.0, "ok": false, "day": time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC), "note": "1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ImportCSV(strings.NewReader(tt.input), tt.opts)
+			if err != nil {
+				t.Fatalf("ImportCSV failed: %v", err)
+			}
+			if len(table.Columns) != len(tt.wantLabels) {
+				t.Fatalf("columns = %d, want %d", len(table.Columns), len(tt.wantLabels))
+			}
+			for i, column := range table.Columns {
+				if column.Label != tt.wantLabels[i] {
+					t.Errorf("column %d label = %q, want %q", i, column.Label, tt.wantLabels[i])
+				}
+			}
+			if len(table.Data) != len(tt.wantData) {
+				t.Fatalf("rows = %d, want %d", len(table.Data), len(tt.wantData))
+			}
+			for i, want := range tt.wantData {
+				got := table.Data[i]
+				if len(got) != len(want) {
+					t.Errorf("row %d = %v, want %v", i, got, want)
+					continue
+				}
+				for k, v := range want {
+					if gotTime, ok := got[k].(time.Time); ok {
+						if !gotTime.Equal(v.(time.Time)) {
+							t.Errorf("row %d %s = %v, want %v", i, k, got[k], v)
+						}
+					} else if got[k] != v {
+						t.Errorf("row %d %s = %#v, want %#v", i, k, got[k], v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestImportCSV_errors(t *testing.T) {
+	if _, err := ImportCSV(strings.NewReader("a,b\n"), CSVImportOptions{Separator: ";;"}); err == nil {
+		t.Error("ImportCSV with a multi-character separator succeeded, want an error")
+	}
+	if _, err := ImportCSV(strings.NewReader("a,\"b\n"), CSVImportOptions{}); err == nil {
+		t.Error("ImportCSV with an unterminated quote succeeded, want an error")
+	}
+}
+
+func TestImportCSV_roundTrip(t *testing.T) {
+	table, err := ImportCSV(strings.NewReader("region,sales\nNorth,10\nSouth,20\n"), CSVImportOptions{InferTypes: true})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	table.Columns[0].WithStyle(&Style{Bold: true})
+
+	var buf bytes.Buffer
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "report", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	want := [][]string{{"region", "sales"}, {"North", "10"}, {"South", "20"}}
+	for i, row := range want {
+		if strings.Join(rows[i], ",") != strings.Join(row, ",") {
+			t.Errorf("row %d = %v, want %v", i+1, rows[i], row)
+		}
+	}
+	if cellType, _ := f.GetCellType("Sheet1", "B2"); cellType != excelize.CellTypeUnset && cellType != excelize.CellTypeNumber {
+		t.Errorf("B2 type = %v, want a number", cellType)
+	}
+}
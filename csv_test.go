@@ -2,8 +2,10 @@ package spit
 
 import (
 	"bytes"
+	"compress/gzip"
 	stdcsv "encoding/csv"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -188,6 +190,37 @@ func TestCSV_writeData(t *testing.T) {
 			t.Error("Should format date according to column format")
 		}
 	})
+
+	t.Run("BlankRepeats", func(t *testing.T) {
+		merge := NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)
+		newTable := func(mode CSVMergeMode) *Table {
+			return NewTable(DataSlice{
+				{"region": "EU", "city": "Paris"},
+				{"region": "EU", "city": "Rome"},
+				{"region": "US", "city": "Austin"},
+				{"region": "EU", "city": "Berlin"},
+			}, Columns{
+				NewColumn("region", "Region").WithMerge(merge),
+				NewColumn("city", "City"),
+			}, false).WithCSVMerges(mode)
+		}
+
+		csvInstance, buf := createCSVInstance(newTable(CSVMergeBlankRepeats), ",")
+		if err := csvInstance.writeData(); err != nil {
+			t.Fatalf("writeData should not return error, got: %v", err)
+		}
+		if want := "EU,Paris\n,Rome\nUS,Austin\nEU,Berlin\n"; buf.String() != want {
+			t.Errorf("expected %q, got %q", want, buf.String())
+		}
+
+		csvInstance, buf = createCSVInstance(newTable(CSVMergeIgnore), ",")
+		if err := csvInstance.writeData(); err != nil {
+			t.Fatalf("writeData should not return error, got: %v", err)
+		}
+		if want := "EU,Paris\nEU,Rome\nUS,Austin\nEU,Berlin\n"; buf.String() != want {
+			t.Errorf("expected %q, got %q", want, buf.String())
+		}
+	})
 }
 
 // TestCSV_writeHeaders tests header generation
@@ -416,6 +449,12 @@ func TestCSV_processValue(t *testing.T) {
 			expected: "2023-01-15",
 			wantErr:  false,
 		},
+		{
+			name:     "Nil value",
+			value:    nil,
+			expected: "",
+			wantErr:  false,
+		},
 		{
 			name:     "Nil time pointer",
 			value:    (*time.Time)(nil),
@@ -450,3 +489,82 @@ func TestCSV_processValue(t *testing.T) {
 		})
 	}
 }
+
+// TestExportCSV_Gzip tests that gzip-compressed CSV exports produce a readable .csv.gz file
+func TestExportCSV_Gzip(t *testing.T) {
+	dir := t.TempDir()
+	result, err := ExportCSV(",", testTable, FileWriteParams{
+		Filename:  "report",
+		Filepath:  dir,
+		UseGzip:   true,
+		GzipLevel: 9,
+	})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if result.Filename != "report.csv.gz" {
+		t.Errorf("expected report.csv.gz, got %s", result.Filename)
+	}
+
+	file, err := os.Open(filepath.Join(dir, result.Filename))
+	if err != nil {
+		t.Fatalf("failed to open result: %v", err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("gzip.NewReader failed: %v", err)
+	}
+	if reader.Name != "report.csv" {
+		t.Errorf("expected gzip header name report.csv, got %q", reader.Name)
+	}
+	records, err := stdcsv.NewReader(reader).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "Name" || records[2][2] != "Los Angeles" {
+		t.Errorf("unexpected records: %v", records)
+	}
+
+	// An invalid level fails the export instead of writing a broken stream
+	_, err = ExportCSV(",", testTable, FileWriteParams{
+		Filename:  "invalid",
+		Filepath:  dir,
+		UseGzip:   true,
+		GzipLevel: 42,
+	})
+	if err == nil {
+		t.Error("expected an error for an invalid gzip level")
+	}
+}
+
+func TestExportCSV_descriptionRow(t *testing.T) {
+	columns := func() Columns {
+		return Columns{
+			NewColumn("name", "Name").WithDescription("Full name"),
+			NewColumn("", "Location").WithSubColumns(Columns{
+				NewColumn("city", "City"),
+			}),
+		}
+	}
+	tests := []struct {
+		name  string
+		table *Table
+		want  string
+	}{
+		{"description row", NewTable(testData, columns(), true).WithCSVDescriptionRow(true), "Name,Location\n,City\nFull name,\nJohn,New York\nJane,Los Angeles\n"},
+		{"disabled", NewTable(testData, columns(), true), "Name,Location\n,City\nJohn,New York\nJane,Los Angeles\n"},
+		{"no description", NewTable(testData, Columns{NewColumn("name", "Name")}, true).WithCSVDescriptionRow(true), "Name\nJohn\nJane\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := ExportCSV(",", tt.table, FileWriteParams{Filename: "descriptions", Writer: &buf}); err != nil {
+				t.Fatalf("ExportCSV() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("ExportCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// duration.go - Duration formats.
+//
+// This file implements the built-in formats of time.Duration values (see DurationFormatClock and
+// DurationFormatHuman). Durations are otherwise written as their Go string (e.g. "1h23m0s"), since
+// NormalizeValue turns them into text, so backends format them before normalizing values.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DurationFormatClock writes durations as elapsed hours, minutes and seconds, e.g. "01:23:45";
+	// hours are not bounded to a day. XLSX writes a native cell with the "[h]:mm:ss" number format.
+	DurationFormatClock = "hh:mm:ss"
+
+	// DurationFormatHuman writes durations as their non-zero hours, minutes and seconds, e.g.
+	// "1h 23m", in every backend.
+	DurationFormatHuman = "human"
+)
+
+// durationOf returns the duration held by a time.Duration or non-nil *time.Duration value.
+func durationOf(value interface{}) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case *time.Duration:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return 0, false
+}
+
+// FormatDuration returns the text of a duration value in a duration format (DurationFormatClock or
+// DurationFormatHuman), or false when the value is not a duration or the format is another one.
+// Durations are rounded to the second.
+func FormatDuration(value interface{}, format string) (string, bool) {
+	if format != DurationFormatClock && format != DurationFormatHuman {
+		return "", false
+	}
+	d, ok := durationOf(value)
+	if !ok {
+		return "", false
+	}
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d = d.Round(time.Second)
+	hours, minutes, seconds := int64(d/time.Hour), int64(d/time.Minute)%60, int64(d/time.Second)%60
+	if format == DurationFormatClock {
+		return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds), true
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		amount int64
+		unit   string
+	}{{hours, "h"}, {minutes, "m"}, {seconds, "s"}} {
+		if part.amount != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", part.amount, part.unit))
+		}
+	}
+	if len(parts) == 0 {
+		return "0s", true
+	}
+	return sign + strings.Join(parts, " "), true
+}
+
+// nativeDuration returns the duration written as a native XLSX cell for a value of the format, or
+// false when the value is written as usual.
+func nativeDuration(value interface{}, format string) (time.Duration, bool) {
+	if format != DurationFormatClock {
+		return 0, false
+	}
+	return durationOf(value)
+}
@@ -0,0 +1,81 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestFormatDuration(t *testing.T) {
+	took := time.Hour + 23*time.Minute
+	tests := []struct {
+		value  interface{}
+		format string
+		want   string
+		wantOK bool
+	}{
+		{took, DurationFormatClock, "01:23:00", true},
+		{took, DurationFormatHuman, "1h 23m", true},
+		{&took, DurationFormatHuman, "1h 23m", true},
+		{30*time.Hour + 4*time.Second, DurationFormatClock, "30:00:04", true},
+		{30*time.Hour + 4*time.Second, DurationFormatHuman, "30h 4s", true},
+		{-1500 * time.Millisecond, DurationFormatClock, "-00:00:02", true},
+		{-90 * time.Second, DurationFormatHuman, "-1m 30s", true},
+		{400 * time.Millisecond, DurationFormatHuman, "0s", true},
+		{took, "", "", false},
+		{took, "2006-01-02", "", false},
+		{"1h23m", DurationFormatHuman, "", false},
+		{(*time.Duration)(nil), DurationFormatClock, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := FormatDuration(tt.value, tt.format)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("FormatDuration(%v, %q) = %q, %v; want %q, %v", tt.value, tt.format, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestExport_durations(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"clock": 90 * time.Minute, "human": 90 * time.Minute, "raw": 90 * time.Minute, "list": []interface{}{time.Minute, 2 * time.Hour}},
+	}, Columns{
+		NewColumn("clock", "Clock").WithFormat(DurationFormatClock),
+		NewColumn("human", "Human").WithFormat(DurationFormatHuman),
+		NewColumn("raw", "Raw"),
+		NewColumn("list", "List").WithFormat(DurationFormatHuman),
+	}, true)
+	table.ListSeparator = "|"
+
+	var buf bytes.Buffer
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "durations", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	if want := "Clock,Human,Raw,List\n01:30:00,1h 30m,1h30m0s,1m|2h\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "durations", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if raw, _ := f.GetCellValue("Data", "A2", excelize.Options{RawCellValue: true}); raw != "0.0625" {
+		t.Errorf("A2 = %q, want the duration as a fraction of a day", raw)
+	}
+	id, _ := f.GetCellStyle("Data", "A2")
+	if style, _ := f.GetStyle(id); style == nil || style.CustomNumFmt == nil || *style.CustomNumFmt != "[h]:mm:ss" {
+		t.Errorf("A2 style = %+v, want the [h]:mm:ss number format", style)
+	}
+	for cell, want := range map[string]string{"A2": "1:30:00", "B2": "1h 30m", "C2": "1h30m0s", "D2": "1m|2h"} {
+		if got, _ := f.GetCellValue("Data", cell); got != want {
+			t.Errorf("%s displays %q, want %q", cell, got, want)
+		}
+	}
+}
@@ -0,0 +1,745 @@
+// enums.go - Symbolic names for enumerations.
+//
+// This file gives every exported enumeration a String method and a matching parser, so that
+// configuration layers (YAML/JSON files, CLI flags) can round-trip options symbolically. Parsers
+// are lenient: matching ignores case, spaces, dashes and underscores, and accepts the Go constant
+// name as well (e.g. "thin", "Thin", "BorderStyleThin" and "border-style-thin" all parse).
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// borderStyleNames maps BorderStyle values to their symbolic names.
+var borderStyleNames = map[BorderStyle]string{
+	BorderStyleNone:   "none",
+	BorderStyleThin:   "thin",
+	BorderStyleMedium: "medium",
+	BorderStyleDashed: "dashed",
+	BorderStyleDotted: "dotted",
+	BorderStyleThick:  "thick",
+	BorderStyleDouble: "double",
+}
+
+// alignmentNames maps Alignment values to their symbolic names.
+var alignmentNames = map[Alignment]string{
+	AlignmentNone:         "none",
+	AlignmentLeft:         "left",
+	AlignmentCenter:       "center",
+	AlignmentRight:        "right",
+	AlignmentTop:          "top",
+	AlignmentMiddle:       "middle",
+	AlignmentBottom:       "bottom",
+	AlignmentCenterMiddle: "center-middle",
+	AlignmentLeftMiddle:   "left-middle",
+	AlignmentRightMiddle:  "right-middle",
+}
+
+// mergeConditionNames lists the known merge conditions.
+var mergeConditionNames = map[MergeCondition]string{
+	MergeConditionIdentical: string(MergeConditionIdentical),
+	MergeConditionEmpty:     string(MergeConditionEmpty),
+}
+
+// mergeValueModeNames maps MergeValueMode values to their symbolic names.
+var mergeValueModeNames = map[MergeValueMode]string{
+	MergeValuesKeep:   "keep",
+	MergeValuesBlank:  "blank",
+	MergeValuesRepeat: "repeat",
+}
+
+// htmlThemeNames maps HTMLTheme values to their symbolic names.
+var htmlThemeNames = map[HTMLTheme]string{
+	HTMLThemeNone:    "none",
+	HTMLThemeDefault: "default",
+}
+
+// layoutDirectionNames maps LayoutDirection values to their symbolic names.
+var layoutDirectionNames = map[LayoutDirection]string{
+	LayoutVertical:   "vertical",
+	LayoutHorizontal: "horizontal",
+}
+
+// logLevelNames maps LogLevel values to their symbolic names.
+var logLevelNames = map[LogLevel]string{
+	LevelOff:   "off",
+	LevelError: "error",
+	LevelWarn:  "warn",
+	LevelInfo:  "info",
+	LevelDebug: "debug",
+}
+
+// parquetTypeNames maps ParquetType values to their symbolic names.
+var parquetTypeNames = map[ParquetType]string{
+	ParquetTypeAuto:      "auto",
+	ParquetTypeString:    "string",
+	ParquetTypeInt64:     "int64",
+	ParquetTypeDouble:    "double",
+	ParquetTypeBoolean:   "boolean",
+	ParquetTypeTimestamp: "timestamp",
+	ParquetTypeDate:      "date",
+}
+
+// conflictPolicyNames maps ConflictPolicy values to their symbolic names.
+var conflictPolicyNames = map[ConflictPolicy]string{
+	ConflictOverwrite:       "overwrite",
+	ConflictError:           "error",
+	ConflictClearSheetFirst: "clear-sheet-first",
+	ConflictAppendBelow:     "append-below",
+}
+
+// csvHeaderModeNames maps CSVHeaderMode values to their symbolic names.
+var csvHeaderModeNames = map[CSVHeaderMode]string{
+	CSVHeaderDetect:  "detect",
+	CSVHeaderPresent: "present",
+	CSVHeaderAbsent:  "absent",
+}
+
+// csvMergeModeNames maps CSVMergeMode values to their symbolic names.
+var csvMergeModeNames = map[CSVMergeMode]string{
+	CSVMergeIgnore:       "ignore",
+	CSVMergeBlankRepeats: "blank-repeats",
+}
+
+// sortDirectionNames maps SortDirection values to their symbolic names.
+var sortDirectionNames = map[SortDirection]string{
+	SortAscending:  "ascending",
+	SortDescending: "descending",
+}
+
+// chartTypeNames maps ChartType values to their symbolic names.
+var chartTypeNames = map[ChartType]string{
+	ChartColumn: "column",
+	ChartBar:    "bar",
+	ChartLine:   "line",
+	ChartArea:   "area",
+	ChartPie:    "pie",
+}
+
+// errorModeNames maps ErrorMode values to their symbolic names.
+var errorModeNames = map[ErrorMode]string{
+	ErrorLenient: "lenient",
+	ErrorStrict:  "strict",
+}
+
+// streamingModeNames maps StreamingMode values to their symbolic names.
+var streamingModeNames = map[StreamingMode]string{
+	StreamingAuto:   "auto",
+	StreamingAlways: "always",
+	StreamingNever:  "never",
+}
+
+// sparseColumnActionNames maps SparseColumnAction values to their symbolic names.
+var sparseColumnActionNames = map[SparseColumnAction]string{
+	SparseColumnsKeep:  "keep",
+	SparseColumnsDrop:  "drop",
+	SparseColumnsHide:  "hide",
+	SparseColumnsGroup: "group",
+}
+
+// columnTypeNames maps ColumnType values to their symbolic names.
+var columnTypeNames = map[ColumnType]string{
+	ColumnTypeAuto:     "auto",
+	ColumnTypeString:   "string",
+	ColumnTypeInt:      "int",
+	ColumnTypeFloat:    "float",
+	ColumnTypeBool:     "bool",
+	ColumnTypeDate:     "date",
+	ColumnTypeDuration: "duration",
+	ColumnTypePercent:  "percent",
+	ColumnTypeCurrency: "currency",
+}
+
+// chunkModeNames maps ChunkMode values to their symbolic names.
+var chunkModeNames = map[ChunkMode]string{
+	ChunkFiles:  "files",
+	ChunkSheets: "sheets",
+}
+
+// formulaEscapeModeNames maps FormulaEscapeMode values to their symbolic names.
+var formulaEscapeModeNames = map[FormulaEscapeMode]string{
+	FormulaEscapeNone:  "none",
+	FormulaEscapeQuote: "quote",
+	FormulaEscapeTab:   "tab",
+}
+
+// cellOverflowModeNames maps CellOverflowMode values to their symbolic names.
+var cellOverflowModeNames = map[CellOverflowMode]string{
+	CellOverflowTruncate: "truncate",
+	CellOverflowError:    "error",
+}
+
+// encodingNames maps Encoding values to their symbolic names.
+var encodingNames = map[Encoding]string{
+	EncodingUTF8:        "utf-8",
+	EncodingUTF8BOM:     "utf-8-bom",
+	EncodingUTF16LE:     "utf-16le",
+	EncodingWindows1252: "windows-1252",
+	EncodingISO88591:    "iso-8859-1",
+}
+
+// textDirectionNames maps TextDirection values to their symbolic names.
+var textDirectionNames = map[TextDirection]string{
+	TextDirectionContext: "context",
+	TextDirectionLTR:     "ltr",
+	TextDirectionRTL:     "rtl",
+}
+
+// vertAlignNames maps VertAlign values to their symbolic names.
+var vertAlignNames = map[VertAlign]string{
+	VertAlignBaseline:    "baseline",
+	VertAlignSuperscript: "superscript",
+	VertAlignSubscript:   "subscript",
+}
+
+// printOrientationNames maps PrintOrientation values to their symbolic names.
+var printOrientationNames = map[PrintOrientation]string{
+	PrintPortrait:  "portrait",
+	PrintLandscape: "landscape",
+}
+
+// String returns the symbolic name of the border style (e.g. "thin").
+func (b BorderStyle) String() string {
+	if name, ok := borderStyleNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("BorderStyle(%d)", b)
+}
+
+// String returns the symbolic name of the alignment (e.g. "center-middle").
+func (a Alignment) String() string {
+	if name, ok := alignmentNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Alignment(%d)", a)
+}
+
+// String returns the symbolic name of the merge condition (e.g. "identical").
+func (m MergeCondition) String() string {
+	return string(m)
+}
+
+// String returns the symbolic name of the merge value mode (e.g. "blank").
+func (m MergeValueMode) String() string {
+	if name, ok := mergeValueModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("MergeValueMode(%d)", m)
+}
+
+// String returns the symbolic name of the theme (e.g. "default").
+func (h HTMLTheme) String() string {
+	if name, ok := htmlThemeNames[h]; ok {
+		return name
+	}
+	return fmt.Sprintf("HTMLTheme(%d)", h)
+}
+
+// String returns the symbolic name of the direction (e.g. "horizontal").
+func (d LayoutDirection) String() string {
+	if name, ok := layoutDirectionNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("LayoutDirection(%d)", d)
+}
+
+// String returns the symbolic name of the log level (e.g. "warn").
+func (l LogLevel) String() string {
+	if name, ok := logLevelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("LogLevel(%d)", l)
+}
+
+// String returns the symbolic name of the Parquet type (e.g. "int64").
+func (pt ParquetType) String() string {
+	if name, ok := parquetTypeNames[pt]; ok {
+		return name
+	}
+	return fmt.Sprintf("ParquetType(%d)", pt)
+}
+
+// String returns the symbolic name of the conflict policy (e.g. "append-below").
+func (c ConflictPolicy) String() string {
+	if name, ok := conflictPolicyNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ConflictPolicy(%d)", c)
+}
+
+// String returns the symbolic name of the CSV header mode (e.g. "detect").
+func (m CSVHeaderMode) String() string {
+	if name, ok := csvHeaderModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("CSVHeaderMode(%d)", m)
+}
+
+// String returns the symbolic name of the CSV merge mode (e.g. "blank-repeats").
+func (m CSVMergeMode) String() string {
+	if name, ok := csvMergeModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("CSVMergeMode(%d)", m)
+}
+
+// String returns the symbolic name of the sort direction (e.g. "descending").
+func (d SortDirection) String() string {
+	if name, ok := sortDirectionNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("SortDirection(%d)", d)
+}
+
+// String returns the symbolic name of the chart type (e.g. "line").
+func (c ChartType) String() string {
+	if name, ok := chartTypeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ChartType(%d)", c)
+}
+
+// String returns the symbolic name of the error mode (e.g. "strict").
+func (m ErrorMode) String() string {
+	if name, ok := errorModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorMode(%d)", m)
+}
+
+// String returns the symbolic name of the streaming mode (e.g. "always").
+func (m StreamingMode) String() string {
+	if name, ok := streamingModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("StreamingMode(%d)", m)
+}
+
+// String returns the symbolic name of the sparse column action (e.g. "hide").
+func (a SparseColumnAction) String() string {
+	if name, ok := sparseColumnActionNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("SparseColumnAction(%d)", a)
+}
+
+// String returns the symbolic name of the column type (e.g. "currency").
+func (c ColumnType) String() string {
+	if name, ok := columnTypeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ColumnType(%d)", c)
+}
+
+// String returns the symbolic name of the chunk mode (e.g. "sheets").
+func (m ChunkMode) String() string {
+	if name, ok := chunkModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("ChunkMode(%d)", m)
+}
+
+// String returns the symbolic name of the formula escape mode (e.g. "quote").
+func (m FormulaEscapeMode) String() string {
+	if name, ok := formulaEscapeModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("FormulaEscapeMode(%d)", m)
+}
+
+// String returns the symbolic name of the cell overflow mode (e.g. "truncate").
+func (m CellOverflowMode) String() string {
+	if name, ok := cellOverflowModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("CellOverflowMode(%d)", m)
+}
+
+// String returns the symbolic name of the encoding (e.g. "utf-8-bom").
+func (e Encoding) String() string {
+	if name, ok := encodingNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("Encoding(%d)", e)
+}
+
+// String returns the symbolic name of the text direction (e.g. "rtl").
+func (d TextDirection) String() string {
+	if name, ok := textDirectionNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("TextDirection(%d)", d)
+}
+
+// String returns the symbolic name of the vertical alignment (e.g. "superscript").
+func (v VertAlign) String() string {
+	if name, ok := vertAlignNames[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("VertAlign(%d)", v)
+}
+
+// String returns the symbolic name of the print orientation (e.g. "landscape").
+func (o PrintOrientation) String() string {
+	if name, ok := printOrientationNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("PrintOrientation(%d)", o)
+}
+
+// ParseBorderStyle parses a border style name (e.g. "thin", "BorderStyleDashed").
+func ParseBorderStyle(s string) (BorderStyle, error) {
+	return parseEnum(s, "border style", "BorderStyle", borderStyleNames)
+}
+
+// ParseAlignment parses an alignment name (e.g. "right", "CenterMiddle", "center_middle").
+func ParseAlignment(s string) (Alignment, error) {
+	return parseEnum(s, "alignment", "Alignment", alignmentNames)
+}
+
+// ParseMergeCondition parses a merge condition name (e.g. "identical", "MergeConditionEmpty"), or a
+// custom condition spelled "custom:<name>" (see MergeConditionCustom).
+func ParseMergeCondition(s string) (MergeCondition, error) {
+	if name, ok := strings.CutPrefix(strings.TrimSpace(s), customMergePrefix); ok && name != "" {
+		return MergeConditionCustom(name), nil
+	}
+	return parseEnum(s, "merge condition", "MergeCondition", mergeConditionNames)
+}
+
+// ParseMergeValueMode parses a merge value mode name (e.g. "blank", "MergeValuesRepeat").
+func ParseMergeValueMode(s string) (MergeValueMode, error) {
+	return parseEnum(s, "merge value mode", "MergeValues", mergeValueModeNames)
+}
+
+// ParseHTMLTheme parses an HTML theme name (e.g. "default").
+func ParseHTMLTheme(s string) (HTMLTheme, error) {
+	return parseEnum(s, "HTML theme", "HTMLTheme", htmlThemeNames)
+}
+
+// ParseLayoutDirection parses a layout direction name (e.g. "vertical", "LayoutHorizontal").
+func ParseLayoutDirection(s string) (LayoutDirection, error) {
+	return parseEnum(s, "layout direction", "Layout", layoutDirectionNames)
+}
+
+// ParseLogLevel parses a log level name (e.g. "debug", "LevelWarn"); "warning" is accepted too.
+func ParseLogLevel(s string) (LogLevel, error) {
+	if normalizeEnumName(s) == "warning" {
+		return LevelWarn, nil
+	}
+	return parseEnum(s, "log level", "Level", logLevelNames)
+}
+
+// ParseParquetType parses a Parquet column type name (e.g. "int64", "Timestamp", "ParquetTypeDate").
+func ParseParquetType(s string) (ParquetType, error) {
+	return parseEnum(s, "Parquet type", "ParquetType", parquetTypeNames)
+}
+
+// ParseConflictPolicy parses a conflict policy name (e.g. "error", "AppendBelow", "ConflictClearSheetFirst").
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	return parseEnum(s, "conflict policy", "Conflict", conflictPolicyNames)
+}
+
+// ParseCSVHeaderMode parses a CSV header mode name (e.g. "present", "CSVHeaderAbsent").
+func ParseCSVHeaderMode(s string) (CSVHeaderMode, error) {
+	return parseEnum(s, "CSV header mode", "CSVHeader", csvHeaderModeNames)
+}
+
+// ParseCSVMergeMode parses a CSV merge mode name (e.g. "blank-repeats", "CSVMergeIgnore").
+func ParseCSVMergeMode(s string) (CSVMergeMode, error) {
+	return parseEnum(s, "CSV merge mode", "CSVMerge", csvMergeModeNames)
+}
+
+// ParseSortDirection parses a sort direction name (e.g. "descending", "SortAscending"); "asc" and
+// "desc" are accepted too.
+func ParseSortDirection(s string) (SortDirection, error) {
+	switch normalizeEnumName(s) {
+	case "asc":
+		return SortAscending, nil
+	case "desc":
+		return SortDescending, nil
+	}
+	return parseEnum(s, "sort direction", "Sort", sortDirectionNames)
+}
+
+// ParseChartType parses a chart type name (e.g. "pie", "ChartLine").
+func ParseChartType(s string) (ChartType, error) {
+	return parseEnum(s, "chart type", "Chart", chartTypeNames)
+}
+
+// ParseErrorMode parses an error mode name (e.g. "strict", "ErrorLenient").
+func ParseErrorMode(s string) (ErrorMode, error) {
+	return parseEnum(s, "error mode", "Error", errorModeNames)
+}
+
+// ParseStreamingMode parses a streaming mode name (e.g. "never", "StreamingAlways").
+func ParseStreamingMode(s string) (StreamingMode, error) {
+	return parseEnum(s, "streaming mode", "Streaming", streamingModeNames)
+}
+
+// ParseSparseColumnAction parses a sparse column action name (e.g. "drop", "SparseColumnsGroup").
+func ParseSparseColumnAction(s string) (SparseColumnAction, error) {
+	return parseEnum(s, "sparse column action", "SparseColumns", sparseColumnActionNames)
+}
+
+// ParseColumnType parses a column type name (e.g. "date", "ColumnTypePercent").
+func ParseColumnType(s string) (ColumnType, error) {
+	return parseEnum(s, "column type", "ColumnType", columnTypeNames)
+}
+
+// ParseChunkMode parses a chunk mode name (e.g. "files", "ChunkSheets").
+func ParseChunkMode(s string) (ChunkMode, error) {
+	return parseEnum(s, "chunk mode", "Chunk", chunkModeNames)
+}
+
+// ParseFormulaEscapeMode parses a formula escape mode name (e.g. "quote", "FormulaEscapeTab").
+func ParseFormulaEscapeMode(s string) (FormulaEscapeMode, error) {
+	return parseEnum(s, "formula escape mode", "FormulaEscape", formulaEscapeModeNames)
+}
+
+// ParseCellOverflowMode parses a cell overflow mode name (e.g. "error", "CellOverflowTruncate").
+func ParseCellOverflowMode(s string) (CellOverflowMode, error) {
+	return parseEnum(s, "cell overflow mode", "CellOverflow", cellOverflowModeNames)
+}
+
+// ParseEncoding parses an encoding name (e.g. "utf-8-bom", "UTF16LE", "EncodingWindows1252").
+func ParseEncoding(s string) (Encoding, error) {
+	return parseEnum(s, "encoding", "Encoding", encodingNames)
+}
+
+// ParseTextDirection parses a text direction name (e.g. "rtl", "TextDirectionLTR").
+func ParseTextDirection(s string) (TextDirection, error) {
+	return parseEnum(s, "text direction", "TextDirection", textDirectionNames)
+}
+
+// ParseVertAlign parses a vertical alignment name (e.g. "superscript", "VertAlignSubscript").
+func ParseVertAlign(s string) (VertAlign, error) {
+	return parseEnum(s, "vertical alignment", "VertAlign", vertAlignNames)
+}
+
+// ParsePrintOrientation parses a print orientation name (e.g. "landscape", "PrintPortrait").
+func ParsePrintOrientation(s string) (PrintOrientation, error) {
+	return parseEnum(s, "print orientation", "Print", printOrientationNames)
+}
+
+// ParseFormat parses an export format name (e.g. "csv", "XLSX", "FormatHTML").
+func ParseFormat(s string) (Format, error) {
+	return parseEnum(s, "format", "Format", formats)
+}
+
+// parseEnum looks up the value whose name matches s, ignoring case and separators. The Go
+// constant prefix (e.g. "BorderStyle") may be included; kind names the enumeration in errors.
+func parseEnum[E comparable](s, kind, prefix string, names map[E]string) (E, error) {
+	name := normalizeEnumName(s)
+	if trimmed, ok := strings.CutPrefix(name, strings.ToLower(prefix)); ok && trimmed != "" {
+		name = trimmed
+	}
+	for value, candidate := range names {
+		if normalizeEnumName(candidate) == name {
+			return value, nil
+		}
+	}
+	var zero E
+	return zero, fmt.Errorf("unknown %s %q", kind, s)
+}
+
+// normalizeEnumName lowercases a name and drops spaces, dashes and underscores.
+func normalizeEnumName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// marshalEnum returns the symbolic name of value, failing for values without one.
+func marshalEnum[E comparable](value E, kind string, names map[E]string) ([]byte, error) {
+	if name, ok := names[value]; ok {
+		return []byte(name), nil
+	}
+	return nil, fmt.Errorf("%s %v has no name", kind, value)
+}
+
+// The enumerations of table layouts marshal to their symbolic names and unmarshal leniently, so
+// layouts round-trip through JSON and YAML configuration files (see LoadTableConfig).
+
+// MarshalText returns the symbolic name of the border style.
+func (b BorderStyle) MarshalText() ([]byte, error) {
+	return marshalEnum(b, "border style", borderStyleNames)
+}
+
+// UnmarshalText parses a border style (see ParseBorderStyle).
+func (b *BorderStyle) UnmarshalText(text []byte) (err error) {
+	*b, err = ParseBorderStyle(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the alignment.
+func (a Alignment) MarshalText() ([]byte, error) {
+	return marshalEnum(a, "alignment", alignmentNames)
+}
+
+// UnmarshalText parses an alignment (see ParseAlignment).
+func (a *Alignment) UnmarshalText(text []byte) (err error) {
+	*a, err = ParseAlignment(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the merge value mode.
+func (m MergeValueMode) MarshalText() ([]byte, error) {
+	return marshalEnum(m, "merge value mode", mergeValueModeNames)
+}
+
+// UnmarshalText parses a merge value mode (see ParseMergeValueMode).
+func (m *MergeValueMode) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseMergeValueMode(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the Parquet type.
+func (pt ParquetType) MarshalText() ([]byte, error) {
+	return marshalEnum(pt, "Parquet type", parquetTypeNames)
+}
+
+// UnmarshalText parses a Parquet type (see ParseParquetType).
+func (pt *ParquetType) UnmarshalText(text []byte) (err error) {
+	*pt, err = ParseParquetType(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the CSV merge mode.
+func (m CSVMergeMode) MarshalText() ([]byte, error) {
+	return marshalEnum(m, "CSV merge mode", csvMergeModeNames)
+}
+
+// UnmarshalText parses a CSV merge mode (see ParseCSVMergeMode).
+func (m *CSVMergeMode) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseCSVMergeMode(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the sort direction.
+func (d SortDirection) MarshalText() ([]byte, error) {
+	return marshalEnum(d, "sort direction", sortDirectionNames)
+}
+
+// UnmarshalText parses a sort direction (see ParseSortDirection).
+func (d *SortDirection) UnmarshalText(text []byte) (err error) {
+	*d, err = ParseSortDirection(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the error mode.
+func (m ErrorMode) MarshalText() ([]byte, error) {
+	return marshalEnum(m, "error mode", errorModeNames)
+}
+
+// UnmarshalText parses an error mode (see ParseErrorMode).
+func (m *ErrorMode) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseErrorMode(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the column type.
+func (c ColumnType) MarshalText() ([]byte, error) {
+	return marshalEnum(c, "column type", columnTypeNames)
+}
+
+// UnmarshalText parses a column type (see ParseColumnType).
+func (c *ColumnType) UnmarshalText(text []byte) (err error) {
+	*c, err = ParseColumnType(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the chunk mode.
+func (m ChunkMode) MarshalText() ([]byte, error) {
+	return marshalEnum(m, "chunk mode", chunkModeNames)
+}
+
+// UnmarshalText parses a chunk mode (see ParseChunkMode).
+func (m *ChunkMode) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseChunkMode(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the formula escape mode.
+func (m FormulaEscapeMode) MarshalText() ([]byte, error) {
+	return marshalEnum(m, "formula escape mode", formulaEscapeModeNames)
+}
+
+// UnmarshalText parses a formula escape mode (see ParseFormulaEscapeMode).
+func (m *FormulaEscapeMode) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseFormulaEscapeMode(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the cell overflow mode.
+func (m CellOverflowMode) MarshalText() ([]byte, error) {
+	return marshalEnum(m, "cell overflow mode", cellOverflowModeNames)
+}
+
+// UnmarshalText parses a cell overflow mode (see ParseCellOverflowMode).
+func (m *CellOverflowMode) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseCellOverflowMode(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the text direction.
+func (d TextDirection) MarshalText() ([]byte, error) {
+	return marshalEnum(d, "text direction", textDirectionNames)
+}
+
+// UnmarshalText parses a text direction (see ParseTextDirection).
+func (d *TextDirection) UnmarshalText(text []byte) (err error) {
+	*d, err = ParseTextDirection(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the vertical alignment.
+func (v VertAlign) MarshalText() ([]byte, error) {
+	return marshalEnum(v, "vertical alignment", vertAlignNames)
+}
+
+// UnmarshalText parses a vertical alignment (see ParseVertAlign).
+func (v *VertAlign) UnmarshalText(text []byte) (err error) {
+	*v, err = ParseVertAlign(string(text))
+	return err
+}
+
+// MarshalText returns the symbolic name of the sparse column action.
+func (a SparseColumnAction) MarshalText() ([]byte, error) {
+	return marshalEnum(a, "sparse column action", sparseColumnActionNames)
+}
+
+// UnmarshalText parses a sparse column action (see ParseSparseColumnAction).
+func (a *SparseColumnAction) UnmarshalText(text []byte) (err error) {
+	*a, err = ParseSparseColumnAction(string(text))
+	return err
+}
+
+// MarshalText returns the name of the format, registered formats included.
+func (f Format) MarshalText() ([]byte, error) {
+	if _, ok := formats[f]; ok {
+		return []byte(f.String()), nil
+	}
+	if name, ok := registeredName(f); ok {
+		return []byte(name), nil
+	}
+	return nil, fmt.Errorf("format %v has no name", f)
+}
+
+// UnmarshalText parses a format (see ParseFormat), or looks up a registered format.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		var ok bool
+		if format, ok = LookupFormat(string(text)); !ok {
+			return err
+		}
+	}
+	*f = format
+	return nil
+}
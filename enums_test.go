@@ -0,0 +1,200 @@
+package spit
+
+import "testing"
+
+func TestEnums_roundTrip(t *testing.T) {
+	for value := range borderStyleNames {
+		if got, err := ParseBorderStyle(value.String()); err != nil || got != value {
+			t.Errorf("ParseBorderStyle(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range alignmentNames {
+		if got, err := ParseAlignment(value.String()); err != nil || got != value {
+			t.Errorf("ParseAlignment(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range mergeConditionNames {
+		if got, err := ParseMergeCondition(value.String()); err != nil || got != value {
+			t.Errorf("ParseMergeCondition(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range mergeValueModeNames {
+		if got, err := ParseMergeValueMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseMergeValueMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range htmlThemeNames {
+		if got, err := ParseHTMLTheme(value.String()); err != nil || got != value {
+			t.Errorf("ParseHTMLTheme(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range layoutDirectionNames {
+		if got, err := ParseLayoutDirection(value.String()); err != nil || got != value {
+			t.Errorf("ParseLayoutDirection(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range logLevelNames {
+		if got, err := ParseLogLevel(value.String()); err != nil || got != value {
+			t.Errorf("ParseLogLevel(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range parquetTypeNames {
+		if got, err := ParseParquetType(value.String()); err != nil || got != value {
+			t.Errorf("ParseParquetType(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range conflictPolicyNames {
+		if got, err := ParseConflictPolicy(value.String()); err != nil || got != value {
+			t.Errorf("ParseConflictPolicy(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range csvHeaderModeNames {
+		if got, err := ParseCSVHeaderMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseCSVHeaderMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range csvMergeModeNames {
+		if got, err := ParseCSVMergeMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseCSVMergeMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range sortDirectionNames {
+		if got, err := ParseSortDirection(value.String()); err != nil || got != value {
+			t.Errorf("ParseSortDirection(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range chartTypeNames {
+		if got, err := ParseChartType(value.String()); err != nil || got != value {
+			t.Errorf("ParseChartType(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range errorModeNames {
+		if got, err := ParseErrorMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseErrorMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range streamingModeNames {
+		if got, err := ParseStreamingMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseStreamingMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range sparseColumnActionNames {
+		if got, err := ParseSparseColumnAction(value.String()); err != nil || got != value {
+			t.Errorf("ParseSparseColumnAction(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range columnTypeNames {
+		if got, err := ParseColumnType(value.String()); err != nil || got != value {
+			t.Errorf("ParseColumnType(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range chunkModeNames {
+		if got, err := ParseChunkMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseChunkMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range formulaEscapeModeNames {
+		if got, err := ParseFormulaEscapeMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseFormulaEscapeMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range cellOverflowModeNames {
+		if got, err := ParseCellOverflowMode(value.String()); err != nil || got != value {
+			t.Errorf("ParseCellOverflowMode(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range encodingNames {
+		if got, err := ParseEncoding(value.String()); err != nil || got != value {
+			t.Errorf("ParseEncoding(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range textDirectionNames {
+		if got, err := ParseTextDirection(value.String()); err != nil || got != value {
+			t.Errorf("ParseTextDirection(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range vertAlignNames {
+		if got, err := ParseVertAlign(value.String()); err != nil || got != value {
+			t.Errorf("ParseVertAlign(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range printOrientationNames {
+		if got, err := ParsePrintOrientation(value.String()); err != nil || got != value {
+			t.Errorf("ParsePrintOrientation(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+	for value := range formats {
+		if got, err := ParseFormat(value.String()); err != nil || got != value {
+			t.Errorf("ParseFormat(%q) = %v, %v", value.String(), got, err)
+		}
+	}
+}
+
+func TestEnums_lenientParsing(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) (interface{}, error)
+		input string
+		want  interface{}
+	}{
+		{"border case", parseAny(ParseBorderStyle), " THIN ", BorderStyleThin},
+		{"border constant", parseAny(ParseBorderStyle), "BorderStyleDashed", BorderStyleDashed},
+		{"border separators", parseAny(ParseBorderStyle), "border-style-double", BorderStyleDouble},
+		{"alignment camel case", parseAny(ParseAlignment), "CenterMiddle", AlignmentCenterMiddle},
+		{"alignment snake case", parseAny(ParseAlignment), "right_middle", AlignmentRightMiddle},
+		{"alignment constant", parseAny(ParseAlignment), "AlignmentLeft", AlignmentLeft},
+		{"merge constant", parseAny(ParseMergeCondition), "MergeConditionEmpty", MergeConditionEmpty},
+		{"merge values constant", parseAny(ParseMergeValueMode), "MergeValuesRepeat", MergeValuesRepeat},
+		{"theme", parseAny(ParseHTMLTheme), "Default", HTMLThemeDefault},
+		{"layout constant", parseAny(ParseLayoutDirection), "LayoutHorizontal", LayoutHorizontal},
+		{"log level alias", parseAny(ParseLogLevel), "Warning", LevelWarn},
+		{"format constant", parseAny(ParseFormat), "FormatHTML", FormatHTML},
+		{"format case", parseAny(ParseFormat), "XLSX", FormatXSLX},
+		{"csv header constant", parseAny(ParseCSVHeaderMode), "CSVHeaderAbsent", CSVHeaderAbsent},
+		{"sort direction alias", parseAny(ParseSortDirection), "DESC", SortDescending},
+		{"sort direction constant", parseAny(ParseSortDirection), "SortAscending", SortAscending},
+		{"chart type constant", parseAny(ParseChartType), "ChartLine", ChartLine},
+		{"csv merge constant", parseAny(ParseCSVMergeMode), "CSVMergeBlankRepeats", CSVMergeBlankRepeats},
+		{"error mode constant", parseAny(ParseErrorMode), "ErrorStrict", ErrorStrict},
+		{"streaming mode case", parseAny(ParseStreamingMode), "Always", StreamingAlways},
+		{"chunk mode constant", parseAny(ParseChunkMode), "ChunkSheets", ChunkSheets},
+		{"column type constant", parseAny(ParseColumnType), "ColumnTypeCurrency", ColumnTypeCurrency},
+		{"encoding case", parseAny(ParseEncoding), "UTF8BOM", EncodingUTF8BOM},
+		{"encoding constant", parseAny(ParseEncoding), "EncodingWindows1252", EncodingWindows1252},
+		{"cell overflow constant", parseAny(ParseCellOverflowMode), "CellOverflowError", CellOverflowError},
+		{"formula escape constant", parseAny(ParseFormulaEscapeMode), "FormulaEscapeTab", FormulaEscapeTab},
+		{"text direction constant", parseAny(ParseTextDirection), "TextDirectionRTL", TextDirectionRTL},
+		{"vertical alignment constant", parseAny(ParseVertAlign), "VertAlignSuperscript", VertAlignSuperscript},
+		{"print orientation constant", parseAny(ParsePrintOrientation), "PrintLandscape", PrintLandscape},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.input)
+			if err != nil || got != tt.want {
+				t.Errorf("parse(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnums_unknown(t *testing.T) {
+	if _, err := ParseBorderStyle("wavy"); err == nil {
+		t.Error("ParseBorderStyle accepted an unknown name")
+	}
+	if _, err := ParseAlignment(""); err == nil {
+		t.Error("ParseAlignment accepted an empty name")
+	}
+	if _, err := ParseMergeCondition("similar"); err == nil {
+		t.Error("ParseMergeCondition accepted an unknown name")
+	}
+	if got := BorderStyle(42).String(); got != "BorderStyle(42)" {
+		t.Errorf("String() = %q for an unknown value", got)
+	}
+}
+
+// parseAny adapts a typed parser for table-driven tests.
+func parseAny[E any](parse func(string) (E, error)) func(string) (interface{}, error) {
+	return func(s string) (interface{}, error) {
+		return parse(s)
+	}
+}
@@ -0,0 +1,190 @@
+// errors.go - Typed export errors and export reports.
+//
+// This file implements the errors locating what failed in the written sheet: a CellWriteError
+// fails the export when a data cell cannot be written, while MergeError and StyleError are the
+// underlying errors of the merge and style warnings. They carry absolute sheet coordinates (start
+// position included), so callers can inspect what failed and where with errors.As. An ExportReport
+// groups the warnings of an export for inspection.
+
+package spit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellWriteError is returned by exports failing to write a data cell.
+type CellWriteError struct {
+	Sheet  string // Sheet name (XLSX only)
+	Col    int    // 1-based sheet column
+	Row    int    // 1-based sheet row
+	Column string // Name of the table column
+	Cause  error  // Underlying error
+}
+
+// Error returns the error as a single line, e.g. "failed to write cell Sheet1!B3 (column amount): ...".
+func (e *CellWriteError) Error() string {
+	return fmt.Sprintf("failed to write cell %s (column %s): %v", errorLocation(e.Sheet, e.Cell()), e.Column, e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *CellWriteError) Unwrap() error {
+	return e.Cause
+}
+
+// Cell returns the sheet reference of the cell (e.g. "B3").
+func (e *CellWriteError) Cell() string {
+	ref, _ := excelize.CoordinatesToCellName(e.Col, e.Row)
+	return ref
+}
+
+// MergeError is the error of a merge warning (see WarningPhaseMerge) tied to a range.
+type MergeError struct {
+	Sheet string    // Sheet name (XLSX only)
+	Range CellRange // Sheet range that could not be merged or filled
+	Cause error     // Underlying error
+}
+
+// Error returns the error as a single line, e.g. "failed to merge Sheet1!A2:A5: ...".
+func (e *MergeError) Error() string {
+	return fmt.Sprintf("failed to merge %s: %v", errorLocation(e.Sheet, e.Range.String()), e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *MergeError) Unwrap() error {
+	return e.Cause
+}
+
+// StyleError is the error of a style warning (see WarningPhaseStyle) tied to a cell.
+type StyleError struct {
+	Sheet string // Sheet name (XLSX only)
+	Col   int    // 1-based sheet column
+	Row   int    // 1-based sheet row
+	Cause error  // Underlying error
+}
+
+// Error returns the error as a single line, e.g. "failed to style Sheet1!B3: ...".
+func (e *StyleError) Error() string {
+	return fmt.Sprintf("failed to style %s: %v", errorLocation(e.Sheet, e.Cell()), e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *StyleError) Unwrap() error {
+	return e.Cause
+}
+
+// Cell returns the sheet reference of the cell (e.g. "B3").
+func (e *StyleError) Cell() string {
+	ref, _ := excelize.CoordinatesToCellName(e.Col, e.Row)
+	return ref
+}
+
+// errorLocation returns a cell or range reference qualified by its sheet, when known.
+func errorLocation(sheet, ref string) string {
+	if sheet == "" {
+		return ref
+	}
+	return quoteSheetName(sheet) + "!" + ref
+}
+
+// sheetName returns the sheet of the current export run, empty for formats without sheets.
+func (t *Table) sheetName() string {
+	if t.warnings == nil {
+		return ""
+	}
+	return t.warnings.sheet
+}
+
+// cellError returns a CellWriteError for a 1-based, table-relative data cell of column.
+func (t *Table) cellError(col, row int, column *Column, err error) error {
+	col, row = t.sheetCoords(col, row)
+	return &CellWriteError{Sheet: t.sheetName(), Col: col, Row: row, Column: column.Name, Cause: err}
+}
+
+// mergeError returns a MergeError for a 1-based, table-relative range.
+func (t *Table) mergeError(startCol, startRow, endCol, endRow int, err error) error {
+	r := t.sheetRange(CellRange{StartCol: startCol, StartRow: startRow, EndCol: endCol, EndRow: endRow})
+	return &MergeError{Sheet: t.sheetName(), Range: r, Cause: err}
+}
+
+// styleError returns a StyleError for a 1-based, table-relative cell.
+func (t *Table) styleError(col, row int, err error) error {
+	col, row = t.sheetCoords(col, row)
+	return &StyleError{Sheet: t.sheetName(), Col: col, Row: row, Cause: err}
+}
+
+// ExportReport groups the non-fatal warnings of an export (see FileWriteResult.Report).
+type ExportReport struct {
+	Warnings []ExportWarning // Warnings in report order
+}
+
+// Report returns the warnings of the export as an ExportReport.
+func (r *FileWriteResult) Report() *ExportReport {
+	if r == nil {
+		return &ExportReport{}
+	}
+	return &ExportReport{Warnings: r.Warnings}
+}
+
+// OK reports whether the export completed without warnings.
+func (r *ExportReport) OK() bool {
+	return len(r.Warnings) == 0
+}
+
+// Phase returns the warnings reported by the given export step.
+func (r *ExportReport) Phase(phase WarningPhase) []ExportWarning {
+	var warnings []ExportWarning
+	for _, w := range r.Warnings {
+		if w.Phase == phase {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// Sheet returns the warnings reported for the given sheet.
+func (r *ExportReport) Sheet(name string) []ExportWarning {
+	var warnings []ExportWarning
+	for _, w := range r.Warnings {
+		if w.Sheet == name {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// MergeErrors returns the ranges that could not be merged, in report order.
+func (r *ExportReport) MergeErrors() []*MergeError {
+	var merges []*MergeError
+	for _, w := range r.Warnings {
+		var err *MergeError
+		if errors.As(w.Err, &err) {
+			merges = append(merges, err)
+		}
+	}
+	return merges
+}
+
+// StyleErrors returns the cells that could not be styled, in report order.
+func (r *ExportReport) StyleErrors() []*StyleError {
+	var styles []*StyleError
+	for _, w := range r.Warnings {
+		var err *StyleError
+		if errors.As(w.Err, &err) {
+			styles = append(styles, err)
+		}
+	}
+	return styles
+}
+
+// Err returns the warnings joined into a single error (see errors.Join), or nil without warnings,
+// e.g. to fail a job on any warning.
+func (r *ExportReport) Err() error {
+	errs := make([]error, len(r.Warnings))
+	for i, w := range r.Warnings {
+		errs[i] = w
+	}
+	return errors.Join(errs...)
+}
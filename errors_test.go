@@ -0,0 +1,95 @@
+package spit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTypedErrors_Error(t *testing.T) {
+	cause := errors.New("boom")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "cell write",
+			err:  &CellWriteError{Sheet: "Data", Col: 2, Row: 3, Column: "amount", Cause: cause},
+			want: "failed to write cell Data!B3 (column amount): boom",
+		},
+		{
+			name: "cell write without sheet",
+			err:  &CellWriteError{Col: 1, Row: 2, Column: "name", Cause: cause},
+			want: "failed to write cell A2 (column name): boom",
+		},
+		{
+			name: "merge",
+			err:  &MergeError{Sheet: "Q1 Sales", Range: CellRange{StartCol: 1, StartRow: 2, EndCol: 1, EndRow: 5}, Cause: cause},
+			want: "failed to merge 'Q1 Sales'!A2:A5: boom",
+		},
+		{
+			name: "style",
+			err:  &StyleError{Sheet: "Data", Col: 3, Row: 4, Cause: cause},
+			want: "failed to style Data!C4: boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if !errors.Is(tt.err, cause) {
+				t.Error("errors should unwrap to their cause")
+			}
+		})
+	}
+}
+
+func TestExportXLSX_cellWriteError(t *testing.T) {
+	table := NewTable(DataSlice{{"name": "a", "info": Data{"id": 1}}, {"name": "b", "info": "flat"}},
+		Columns{NewColumn("name", "Name"), NewColumn("info.id", "ID")}, true).WithStartPosition(2, 3)
+
+	_, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "errors", Filepath: t.TempDir()})
+	var cellErr *CellWriteError
+	if !errors.As(err, &cellErr) {
+		t.Fatalf("error = %v, want a CellWriteError", err)
+	}
+	if cellErr.Sheet != "Data" || cellErr.Cell() != "C5" || cellErr.Column != "info.id" {
+		t.Errorf("CellWriteError = %+v (cell %s), want Data!C5 of column info.id", cellErr, cellErr.Cell())
+	}
+}
+
+func TestExportReport(t *testing.T) {
+	cause := errors.New("boom")
+	table := NewTable(nil, nil, true).WithStartPosition(2, 3).withWarnings("Data", nil, nil)
+	table.warn(WarningPhaseMerge, table.cellRef(1, 2), "Failed to merge cells vertically", table.mergeError(1, 2, 1, 4, cause))
+	table.warn(WarningPhaseStyle, table.cellRef(2, 2), "Failed to apply cell style", table.styleError(2, 2, cause))
+	table.warn(WarningPhaseSheet, "", "Charts require data rows, charts skipped", nil)
+
+	report := (&FileWriteResult{Warnings: table.exportWarnings()}).Report()
+	if report.OK() {
+		t.Fatal("OK() = true, want false with warnings")
+	}
+	if got := len(report.Phase(WarningPhaseMerge)); got != 1 {
+		t.Errorf("Phase(merge) has %d warnings, want 1", got)
+	}
+	if got := len(report.Sheet("Data")); got != 3 {
+		t.Errorf("Sheet(Data) has %d warnings, want 3", got)
+	}
+
+	merges := report.MergeErrors()
+	if len(merges) != 1 || merges[0].Range.String() != "B4:B6" {
+		t.Errorf("MergeErrors() = %v, want B4:B6", merges)
+	}
+	styles := report.StyleErrors()
+	if len(styles) != 1 || styles[0].Cell() != "C4" {
+		t.Errorf("StyleErrors() = %v, want C4", styles)
+	}
+	if err := report.Err(); !errors.Is(err, cause) {
+		t.Errorf("Err() = %v, want the joined warnings", err)
+	}
+
+	if empty := (*FileWriteResult)(nil).Report(); !empty.OK() || empty.Err() != nil {
+		t.Errorf("empty report = %+v, want OK", empty)
+	}
+}
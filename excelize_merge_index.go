@@ -0,0 +1,68 @@
+// excelize_merge_index.go - Merged-range lookups for the Excelize adapter.
+//
+// Merge-aware steps (borders, styles, autofit, merging itself) ask whether each cell of the table
+// is merged. Scanning the merged ranges of the sheet for every cell costs O(cells × ranges), so
+// the ranges are indexed by the cells they cover: the index is read from the sheet once, kept up
+// to date as the adapter merges cells, and answers every lookup with a single map access.
+
+package spit
+
+import "github.com/xuri/excelize/v2"
+
+// mergeIndex maps the cells of a sheet to the merged ranges covering them.
+type mergeIndex struct {
+	file   *excelize.File    // Workbook the index belongs to
+	sheet  string            // Sheet the index belongs to
+	ranges []CellRange       // Merged ranges, in sheet order
+	cells  map[cellCoord]int // 1-based sheet coordinates to the index of the first range covering them
+}
+
+// cellCoord identifies a cell by its 1-based sheet coordinates.
+type cellCoord struct {
+	col, row int
+}
+
+// merges returns the merge index of the current file and sheet, reading the merged ranges of the
+// sheet the first time or when the file or sheet changed. Errors are not cached.
+func (e *TableExcelize) merges() (*mergeIndex, error) {
+	if e.mergeIndex != nil && e.mergeIndex.file == e.File && e.mergeIndex.sheet == e.SheetName {
+		return e.mergeIndex, nil
+	}
+	mergeCells, err := e.File.GetMergeCells(e.SheetName)
+	if err != nil {
+		return nil, err
+	}
+	index := &mergeIndex{file: e.File, sheet: e.SheetName, cells: make(map[cellCoord]int)}
+	for _, mergeCell := range mergeCells {
+		startCol, startRow, err1 := excelize.CellNameToCoordinates(mergeCell.GetStartAxis())
+		endCol, endRow, err2 := excelize.CellNameToCoordinates(mergeCell.GetEndAxis())
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		index.add(CellRange{StartCol: startCol, StartRow: startRow, EndCol: endCol, EndRow: endRow})
+	}
+	e.mergeIndex = index
+	return index, nil
+}
+
+// add indexes a merged range. Cells already covered by a range keep it, matching the first-match
+// order of a scan over the merged ranges.
+func (m *mergeIndex) add(r CellRange) {
+	m.ranges = append(m.ranges, r)
+	for row := r.StartRow; row <= r.EndRow; row++ {
+		for col := r.StartCol; col <= r.EndCol; col++ {
+			if _, ok := m.cells[cellCoord{col, row}]; !ok {
+				m.cells[cellCoord{col, row}] = len(m.ranges) - 1
+			}
+		}
+	}
+}
+
+// lookup returns the merged range covering a cell at 1-based sheet coordinates, if any.
+func (m *mergeIndex) lookup(col, row int) (CellRange, bool) {
+	i, ok := m.cells[cellCoord{col, row}]
+	if !ok {
+		return CellRange{}, false
+	}
+	return m.ranges[i], true
+}
@@ -0,0 +1,66 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTableExcelize_mergeIndex(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.MergeCell("Sheet1", "A1", "C1"); err != nil {
+		t.Fatalf("MergeCell failed: %v", err)
+	}
+	e := NewTableExcelize("Sheet1", nil).WithFile(f)
+
+	// The index is read from the sheet on the first lookup, then updated by MergeCells
+	if !e.IsCellMergedHorizontally(1, 1) {
+		t.Error("existing merge A1:C1 should be indexed")
+	}
+	if err := e.MergeCells(2, 5, 2, 3); err != nil {
+		t.Fatalf("MergeCells failed: %v", err)
+	}
+	if err := e.MergeCells(4, 3, 3, 3); err != nil {
+		t.Fatalf("MergeCells failed: %v", err)
+	}
+	if e.mergeIndex == nil || len(e.mergeIndex.ranges) != 3 {
+		t.Fatalf("merge index = %+v, want the existing range and both merges", e.mergeIndex)
+	}
+
+	tests := []struct {
+		name               string
+		col, row           int
+		merged, horizontal bool
+	}{
+		{name: "vertical merge start", col: 2, row: 3, merged: true},
+		{name: "vertical merge end", col: 2, row: 5, merged: true},
+		{name: "horizontal merge", col: 4, row: 3, merged: true, horizontal: true},
+		{name: "not merged", col: 3, row: 4},
+		{name: "out of the sheet", col: 0, row: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.IsCellMerged(tt.col, tt.row); got != tt.merged {
+				t.Errorf("IsCellMerged() = %v, want %v", got, tt.merged)
+			}
+			if got := e.IsCellMergedHorizontally(tt.col, tt.row); got != tt.horizontal {
+				t.Errorf("IsCellMergedHorizontally() = %v, want %v", got, tt.horizontal)
+			}
+		})
+	}
+
+	merged, _ := f.GetMergeCells("Sheet1")
+	if len(merged) != 3 || merged[1].GetStartAxis() != "B3" || merged[1].GetEndAxis() != "B5" {
+		t.Errorf("sheet merges = %v, want B3:B5", merged)
+	}
+
+	// A sheet change rebuilds the index
+	if _, err := f.NewSheet("Other"); err != nil {
+		t.Fatalf("NewSheet failed: %v", err)
+	}
+	e.SheetName = "Other"
+	if e.IsCellMerged(2, 3) {
+		t.Error("merges of Sheet1 should not apply to Other")
+	}
+}
@@ -9,6 +9,7 @@ package spit
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -16,10 +17,14 @@ import (
 // SpreadsheetExcelize provides Excelize-specific operations for spreadsheet handling.
 // Implements the Spreadsheet interface using github.com/xuri/excelize.
 type SpreadsheetExcelize struct {
-	File      *excelize.File // Single Excelize file object for all sheets
-	SheetName string         // Current sheet name
-	Table     *TableExcelize // Current Table for Excelize
-	isNewFile bool           // internal: true only for files created by CreateNewFile(), false for user-provided files
+	File           *excelize.File // Single Excelize file object for all sheets
+	SheetName      string         // Current sheet name
+	Table          *TableExcelize // Current Table for Excelize
+	StartCell      string         // Optional top-left cell (e.g. "B5") or workbook defined name where the table is written (default: "A1")
+	ConflictPolicy ConflictPolicy // Optional: how the table is written when the sheet already holds content (default: ConflictOverwrite)
+	isNewFile      bool           // internal: true only for files created by CreateNewFile(), false for user-provided files
+	startCol       int            // internal: column of the resolved StartCell, 0 when unset (see applyStartCell)
+	startRow       int            // internal: row of the resolved StartCell, 0 when unset
 }
 
 // NewSpreadsheetExcelize creates a new SpreadsheetExcelize instance for a given sheet name and table.
@@ -30,6 +35,18 @@ func NewSpreadsheetExcelize(sheetName string, t *Table) *SpreadsheetExcelize {
 	}
 }
 
+// NewSpreadsheetsExcelizeLayout creates one SpreadsheetExcelize per table of the layout, all targeting
+// the same sheet, with start positions computed by SheetLayout.Arrange.
+// Pass the result to ExportXLSXSheets to write every table into that sheet.
+func NewSpreadsheetsExcelizeLayout(sheetName string, layout *SheetLayout) []Spreadsheet {
+	tables := layout.Arrange()
+	spreadsheets := make([]Spreadsheet, len(tables))
+	for i, t := range tables {
+		spreadsheets[i] = NewSpreadsheetExcelize(sheetName, t)
+	}
+	return spreadsheets
+}
+
 // WithFile sets an existing Excelize file to the SpreadsheetExcelize instance.
 // Keeps the TableExcelize adapter in sync with the spreadsheet file.
 func (e *SpreadsheetExcelize) WithFile(file *excelize.File) *SpreadsheetExcelize {
@@ -39,11 +56,37 @@ func (e *SpreadsheetExcelize) WithFile(file *excelize.File) *SpreadsheetExcelize
 	return e
 }
 
+// NewSpreadsheetExcelizeFromTemplate opens an existing XLSX template and returns a SpreadsheetExcelize
+// that writes the table into it. The template's formatting, charts and other sheets are preserved.
+// Combine with WithStartCell to place the table inside the template. The caller is responsible for
+// closing the returned spreadsheet once exported.
+func NewSpreadsheetExcelizeFromTemplate(path, sheetName string, t *Table) (*SpreadsheetExcelize, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template %s: %w", path, err)
+	}
+	return NewSpreadsheetExcelize(sheetName, t).WithFile(f), nil
+}
+
+// WithStartCell sets where the table is written: either a cell reference (e.g. "B5") or the name of
+// a workbook defined name (e.g. "ReportData"), in which case the table starts at the top-left cell of
+// the named region and targets the region's sheet.
+func (e *SpreadsheetExcelize) WithStartCell(cell string) *SpreadsheetExcelize {
+	e.StartCell = cell
+	return e
+}
+
 // GetTable returns the underlying Table object.
 func (e *SpreadsheetExcelize) GetTable() *Table {
 	return e.Table.GetTable()
 }
 
+// Capabilities reports the features supported by Excelize: everything but streaming, as the
+// workbook is built in memory before being saved.
+func (e *SpreadsheetExcelize) Capabilities() Capabilities {
+	return FormatXSLX.Capabilities()
+}
+
 // GetFile returns the underlying Excelize file object.
 func (e *SpreadsheetExcelize) GetFile() interface{} {
 	return e.File
@@ -75,14 +118,22 @@ func (e *SpreadsheetExcelize) GetSheetName() string {
 }
 
 // SetSheetName sets the active sheet name.
+// Keeps the TableExcelize adapter in sync so cell operations target the renamed sheet.
 func (e *SpreadsheetExcelize) SetSheetName(name string) {
 	e.SheetName = name
+	if e.Table != nil {
+		e.Table.SheetName = name
+	}
 }
 
 // CreateSheet creates a new sheet with the current sheet name if it does not already exist.
 // When the file was just created and the sheet name differs from the Excelize default "Sheet1",
 // the default "Sheet1" is removed so the file only contains the intended sheets.
 func (e *SpreadsheetExcelize) CreateSheet() error {
+	if err := e.applyStartCell(); err != nil {
+		return err
+	}
+
 	index, err := e.File.GetSheetIndex(e.SheetName)
 	if err != nil || index == -1 {
 		_, err = e.File.NewSheet(e.SheetName)
@@ -102,6 +153,61 @@ func (e *SpreadsheetExcelize) CreateSheet() error {
 	return nil
 }
 
+// applyStartCell resolves StartCell (a cell reference or a defined name) into the start position
+// given to the table of the export (see startPosition). A defined name also selects the sheet it
+// refers to.
+func (e *SpreadsheetExcelize) applyStartCell() error {
+	e.startCol, e.startRow = 0, 0
+	if e.StartCell == "" {
+		return nil
+	}
+
+	cell := e.StartCell
+	if _, _, err := excelize.CellNameToCoordinates(cell); err != nil {
+		sheet, ref, found := e.lookupDefinedName(e.StartCell)
+		if !found {
+			return fmt.Errorf("start cell %q is neither a cell reference nor a defined name", e.StartCell)
+		}
+		if sheet != "" {
+			e.SetSheetName(sheet)
+		}
+		cell = ref
+	}
+
+	col, row, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		return fmt.Errorf("invalid start cell %q: %w", e.StartCell, err)
+	}
+	e.startCol, e.startRow = col, row
+	return nil
+}
+
+// startPosition returns the position resolved from StartCell by CreateSheet, if any. The export
+// applies it to its own copy of the table, leaving the caller's table untouched.
+func (e *SpreadsheetExcelize) startPosition() (col, row int, ok bool) {
+	return e.startCol, e.startRow, e.startCol > 0 && e.startRow > 0
+}
+
+// lookupDefinedName finds a workbook defined name and returns the sheet and top-left cell it refers to.
+// A reference such as "'My Sheet'!$B$5:$D$10" yields ("My Sheet", "B5").
+func (e *SpreadsheetExcelize) lookupDefinedName(name string) (sheet, cell string, found bool) {
+	for _, dn := range e.File.GetDefinedName() {
+		if dn.Name != name {
+			continue
+		}
+		ref := dn.RefersTo
+		if i := strings.LastIndex(ref, "!"); i >= 0 {
+			sheet = strings.Trim(strings.TrimPrefix(ref[:i], "="), "'")
+			ref = ref[i+1:]
+		}
+		if i := strings.Index(ref, ":"); i >= 0 {
+			ref = ref[:i]
+		}
+		return sheet, strings.ReplaceAll(ref, "$", ""), true
+	}
+	return "", "", false
+}
+
 // SetActiveSheet sets the active sheet for subsequent operations.
 func (e *SpreadsheetExcelize) SetActiveSheet() error {
 	index, err := e.File.GetSheetIndex(e.SheetName)
@@ -112,11 +218,185 @@ func (e *SpreadsheetExcelize) SetActiveSheet() error {
 	return nil
 }
 
+// SetDefinedName adds a defined name scoped to the sheet, referring to refersTo
+// (e.g. "Sheet1!$B$3") with an optional comment.
+func (e *SpreadsheetExcelize) SetDefinedName(name, refersTo, comment string) error {
+	return e.File.SetDefinedName(&excelize.DefinedName{
+		Name:     name,
+		RefersTo: refersTo,
+		Comment:  comment,
+		Scope:    e.SheetName,
+	})
+}
+
+// SetWorkbookDefinedName adds a defined name scoped to the workbook, referring to refersTo
+// (e.g. "Sheet1!$B$3:$D$20").
+func (e *SpreadsheetExcelize) SetWorkbookDefinedName(name, refersTo string) error {
+	return e.File.SetDefinedName(&excelize.DefinedName{Name: name, RefersTo: refersTo})
+}
+
+// SetRowOutlineLevel sets the outline (grouping) level of a 1-based row.
+func (e *SpreadsheetExcelize) SetRowOutlineLevel(row, level int) error {
+	return e.File.SetRowOutlineLevel(e.SheetName, row, uint8(level))
+}
+
+// SetRowVisible shows or hides a 1-based row.
+func (e *SpreadsheetExcelize) SetRowVisible(row int, visible bool) error {
+	return e.File.SetRowVisible(e.SheetName, row, visible)
+}
+
+// SetOutlineSummaryAbove places outline summary rows above their detail rows, so group
+// headers carry the expand/collapse buttons.
+func (e *SpreadsheetExcelize) SetOutlineSummaryAbove() error {
+	below := false
+	return e.File.SetSheetProps(e.SheetName, &excelize.SheetPropsOptions{OutlineSummaryBelow: &below})
+}
+
+// SetAutoFilter applies an auto-filter over a range of the sheet (e.g. "A2:D20"); the first row of
+// the range holds the filter buttons.
+func (e *SpreadsheetExcelize) SetAutoFilter(rangeRef string) error {
+	return e.File.AutoFilter(e.SheetName, rangeRef, nil)
+}
+
+// ProtectSheet protects the sheet against edits of its locked cells.
+func (e *SpreadsheetExcelize) ProtectSheet(protection SheetProtection) error {
+	return e.File.ProtectSheet(e.SheetName, &excelize.SheetProtectionOptions{
+		Password:            protection.Password,
+		FormatCells:         protection.FormatCells,
+		FormatColumns:       protection.FormatColumns,
+		FormatRows:          protection.FormatRows,
+		InsertRows:          protection.InsertRows,
+		DeleteRows:          protection.DeleteRows,
+		Sort:                protection.Sort,
+		AutoFilter:          protection.AutoFilter,
+		SelectLockedCells:   protection.SelectLockedCells,
+		SelectUnlockedCells: true,
+	})
+}
+
+// AddExcelTable adds a native Excel table (ListObject) over a range of the sheet.
+func (e *SpreadsheetExcelize) AddExcelTable(spec ExcelTableSpec) error {
+	rowStripes := spec.BandedRows
+	return e.File.AddTable(e.SheetName, &excelize.Table{
+		Range:             spec.Range,
+		Name:              spec.Name,
+		StyleName:         spec.Style,
+		ShowColumnStripes: spec.BandedColumns,
+		ShowFirstColumn:   spec.FirstColumn,
+		ShowLastColumn:    spec.LastColumn,
+		ShowRowStripes:    &rowStripes,
+	})
+}
+
+// AddPivotTable adds a native pivot table, creating its sheet when missing. The pivot sheet is
+// left inactive so the data sheet stays selected.
+func (e *SpreadsheetExcelize) AddPivotTable(spec ExcelPivotSpec) error {
+	sheet, _, _ := strings.Cut(spec.PivotRange, "!")
+	if index, err := e.File.GetSheetIndex(sheet); err != nil {
+		return err
+	} else if index < 0 {
+		if _, err := e.File.NewSheet(sheet); err != nil {
+			return err
+		}
+	}
+	pivotFields := func(labels []string) []excelize.PivotTableField {
+		fields := make([]excelize.PivotTableField, len(labels))
+		for i, label := range labels {
+			fields[i] = excelize.PivotTableField{Data: label}
+		}
+		return fields
+	}
+	function := pivotSubtotals[spec.Function]
+	return e.File.AddPivotTable(&excelize.PivotTableOptions{
+		DataRange:       spec.DataRange,
+		PivotTableRange: spec.PivotRange,
+		Name:            spec.Name,
+		Rows:            pivotFields(spec.Rows),
+		Columns:         pivotFields(spec.Columns),
+		Data: []excelize.PivotTableField{{
+			Data:     spec.Values,
+			Name:     function + " of " + spec.Values,
+			Subtotal: function,
+		}},
+		RowGrandTotals:      spec.Totals,
+		ColGrandTotals:      spec.Totals,
+		ShowDrill:           true,
+		ShowRowHeaders:      true,
+		ShowColHeaders:      true,
+		ShowLastColumn:      true,
+		PivotTableStyleName: spec.Style,
+	})
+}
+
+// pivotSubtotals maps the spreadsheet functions of built-in aggregates to pivot table subtotals.
+var pivotSubtotals = map[string]string{
+	"SUM":     "Sum",
+	"AVERAGE": "Average",
+	"COUNTA":  "Count",
+	"MIN":     "Min",
+	"MAX":     "Max",
+}
+
+// AddChart adds a chart, creating its sheet when missing.
+func (e *SpreadsheetExcelize) AddChart(spec ChartSpec) error {
+	if index, err := e.File.GetSheetIndex(spec.Sheet); err != nil {
+		return err
+	} else if index < 0 {
+		if _, err := e.File.NewSheet(spec.Sheet); err != nil {
+			return err
+		}
+	}
+	chart := &excelize.Chart{
+		Type:      excelizeChartTypes[spec.Type],
+		Dimension: excelize.ChartDimension{Width: spec.Width, Height: spec.Height},
+		Legend:    excelize.ChartLegend{Position: "bottom"},
+	}
+	if spec.Title != "" {
+		chart.Title = []excelize.RichTextRun{{Text: spec.Title}}
+	}
+	for _, series := range spec.Series {
+		chart.Series = append(chart.Series, excelize.ChartSeries{
+			Name:       series.Name,
+			Categories: series.Categories,
+			Values:     series.Values,
+		})
+	}
+	return e.File.AddChart(spec.Sheet, spec.Cell, chart)
+}
+
+// excelizeChartTypes maps chart types to Excelize chart types.
+var excelizeChartTypes = map[ChartType]excelize.ChartType{
+	ChartColumn: excelize.Col,
+	ChartBar:    excelize.Bar,
+	ChartLine:   excelize.Line,
+	ChartArea:   excelize.Area,
+	ChartPie:    excelize.Pie,
+}
+
+// AddComment attaches a comment to the cell at the given 1-based column and row.
+func (e *SpreadsheetExcelize) AddComment(col, row int, comment Comment) error {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
+	return e.File.AddComment(e.SheetName, excelize.Comment{Cell: cell, Author: comment.Author, Text: comment.Text})
+}
+
+// GetSheetIndex returns the 0-based index of the sheet in the workbook.
+func (e *SpreadsheetExcelize) GetSheetIndex() (int, error) {
+	return e.File.GetSheetIndex(e.SheetName)
+}
+
 // SetColumnWidth sets the width of a column by its letter (e.g., "A", "B").
 func (e *SpreadsheetExcelize) SetColumnWidth(colLetter string, width float64) error {
 	return e.File.SetColWidth(e.SheetName, colLetter, colLetter, width)
 }
 
+// SetColumnHidden hides a column by its letter (e.g. "C").
+func (e *SpreadsheetExcelize) SetColumnHidden(colLetter string) error {
+	return e.File.SetColVisible(e.SheetName, colLetter, false)
+}
+
 // InitWithFile initializes this spreadsheet with an existing file from another spreadsheet.
 // Expects file to be a *excelize.File; returns an error if the type does not match.
 func (e *SpreadsheetExcelize) InitWithFile(file interface{}) error {
@@ -181,6 +461,16 @@ func (e *SpreadsheetExcelize) ApplyStyleToRange(startCol, startRow, endCol, endR
 	return e.Table.ApplyStyleToRange(startCol, startRow, endCol, endRow, style)
 }
 
+// CellRef returns the A1 reference of a cell (see CellAddresser).
+func (e *SpreadsheetExcelize) CellRef(col, row int) string {
+	return A1Addresser{}.CellRef(col, row)
+}
+
+// RangeRef returns the A1 reference of a range (see CellAddresser).
+func (e *SpreadsheetExcelize) RangeRef(startCol, startRow, endCol, endRow int) string {
+	return A1Addresser{}.RangeRef(startCol, startRow, endCol, endRow)
+}
+
 // GetColumnLetter returns the Excel column letter for a given column index.
 func (e *SpreadsheetExcelize) GetColumnLetter(col int) string {
 	return e.Table.GetColumnLetter(col)
@@ -191,6 +481,9 @@ func (e *SpreadsheetExcelize) ProcessValue(value interface{}, format string) (in
 	return e.Table.ProcessValue(value, format)
 }
 
+// writesNativeCells marks Excelize sheets as writing typed values as native cells (see Column.Type).
+func (e *SpreadsheetExcelize) writesNativeCells() {}
+
 // SetCellFormula sets the formula of a cell at the given column and row.
 func (e *SpreadsheetExcelize) SetCellFormula(col, row int, formula string) error {
 	return e.Table.SetCellFormula(col, row, formula)
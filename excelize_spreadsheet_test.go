@@ -928,3 +928,107 @@ func TestSpreadsheetExcelize_initWithFile_InvalidType(t *testing.T) {
 		t.Error("InitWithFile should return error for unsupported file type")
 	}
 }
+
+// writeTemplate saves a small XLSX template with a styled title cell and a "ReportData" defined name.
+func writeTemplate(t *testing.T, path string) {
+	t.Helper()
+	f := excelize.NewFile()
+	defer func() { _ = f.Close() }()
+	if err := f.SetCellValue("Sheet1", "A1", "Quarterly report"); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+	styleID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		t.Fatalf("NewStyle failed: %v", err)
+	}
+	if err := f.SetCellStyle("Sheet1", "A1", "A1", styleID); err != nil {
+		t.Fatalf("SetCellStyle failed: %v", err)
+	}
+	if err := f.SetDefinedName(&excelize.DefinedName{Name: "ReportData", RefersTo: "Sheet1!$C$4:$E$20"}); err != nil {
+		t.Fatalf("SetDefinedName failed: %v", err)
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatalf("SaveAs failed: %v", err)
+	}
+}
+
+func TestSpreadsheetExcelize_template(t *testing.T) {
+	tests := []struct {
+		name      string
+		startCell string
+		header    string
+		firstData string
+	}{
+		{name: "cell reference", startCell: "B5", header: "B5", firstData: "B6"},
+		{name: "defined name", startCell: "ReportData", header: "C4", firstData: "C5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			templatePath := dir + "/template.xlsx"
+			writeTemplate(t, templatePath)
+
+			table := NewTable(DataSlice{{"name": "Alice", "team": "A"}, {"name": "Bob", "team": "A"}},
+				Columns{NewColumn("name", "Name"), NewColumn("team", "Team").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil))}, true)
+			s, err := NewSpreadsheetExcelizeFromTemplate(templatePath, "Sheet1", table)
+			if err != nil {
+				t.Fatalf("NewSpreadsheetExcelizeFromTemplate failed: %v", err)
+			}
+			defer func() { _ = s.Close() }()
+			s.WithStartCell(tt.startCell)
+
+			res, err := ExportXLSX(s, FileWriteParams{Filename: "report", Filepath: dir, OverwriteFile: true})
+			if err != nil {
+				t.Fatalf("ExportXLSX failed: %v", err)
+			}
+
+			f, err := excelize.OpenFile(res.Filepath)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			if table.StartCol != 0 || table.StartRow != 0 {
+				t.Errorf("table start position = (%d, %d), want the caller's table untouched", table.StartCol, table.StartRow)
+			}
+
+			if v, _ := f.GetCellValue("Sheet1", "A1"); v != "Quarterly report" {
+				t.Errorf("template title lost, A1 = %q", v)
+			}
+			if styleID, _ := f.GetCellStyle("Sheet1", "A1"); styleID == 0 {
+				t.Error("template formatting lost on A1")
+			}
+			if v, _ := f.GetCellValue("Sheet1", tt.header); v != "Name" {
+				t.Errorf("header at %s = %q, want Name", tt.header, v)
+			}
+			if v, _ := f.GetCellValue("Sheet1", tt.firstData); v != "Alice" {
+				t.Errorf("first data cell at %s = %q, want Alice", tt.firstData, v)
+			}
+
+			merges, err := f.GetMergeCells("Sheet1")
+			if err != nil {
+				t.Fatalf("GetMergeCells failed: %v", err)
+			}
+			if len(merges) != 1 {
+				t.Fatalf("expected 1 merged range, got %d", len(merges))
+			}
+			col, row, _ := excelize.CellNameToCoordinates(tt.firstData)
+			wantStart, _ := excelize.CoordinatesToCellName(col+1, row)
+			if merges[0].GetStartAxis() != wantStart {
+				t.Errorf("merge starts at %s, want %s", merges[0].GetStartAxis(), wantStart)
+			}
+		})
+	}
+}
+
+func TestSpreadsheetExcelize_invalidStartCell(t *testing.T) {
+	s := NewSpreadsheetExcelize("Sheet1", &Table{}).WithStartCell("Unknown")
+	if err := s.CreateNewFile(); err != nil {
+		t.Fatalf("CreateNewFile failed: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+	if err := s.CreateSheet(); err == nil {
+		t.Error("expected an error for an unknown start cell")
+	}
+}
@@ -0,0 +1,764 @@
+// excelize_stream.go - Streaming writes of large XLSX sheets.
+//
+// This file implements the streaming write mode of XLSX exports (see FileWriteParams.Streaming).
+// Writing cells one by one through Excelize builds a full cell model per cell, which gets slow and
+// memory-hungry on sheets of hundreds of thousands of rows. A streamed sheet instead records the
+// cell values, formulas, style IDs, merged ranges and row outlines in a compact grid while the
+// usual pipeline (headers, data, footer, merging, styling) runs against it, and writes its rows
+// through Excelize's StreamWriter. Sheet features (column widths, filters, protection, comments,
+// images, charts, Excel and pivot tables) are applied to the workbook as usual and kept by the
+// stream. A sheet holding a single table writes its rows by windows as soon as no vertical merge
+// can reach them anymore (see streamWindow), so the grid only holds the current window; the rows of
+// sheets shared by several tables are written once every table is in place.
+
+package spit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// StreamingMode defines when XLSX exports write sheets through Excelize's StreamWriter.
+type StreamingMode int
+
+const (
+	// StreamingAuto streams the sheets of tables holding at least FileWriteParams.StreamingThreshold
+	// data rows (default).
+	StreamingAuto StreamingMode = iota
+
+	// StreamingAlways streams every sheet that can be streamed.
+	StreamingAlways
+
+	// StreamingNever writes every sheet cell by cell.
+	StreamingNever
+)
+
+// DefaultStreamingThreshold is the number of data rows from which StreamingAuto streams a sheet
+// when FileWriteParams.StreamingThreshold is zero.
+const DefaultStreamingThreshold = 100000
+
+// streamCell is a cell of a streamed sheet.
+type streamCell struct {
+	value interface{} // Value as set, written by the stream writer
+	style int32       // Excelize style ID (0 = default style)
+	merge int32       // 1-based index of the merged range holding the cell (0 = none)
+}
+
+// streamRef identifies a cell of a streamed sheet by its 1-based sheet coordinates.
+type streamRef struct {
+	col, row int
+}
+
+// streamSheet is a sheet of an Excelize workbook written in streaming mode. Cell operations are
+// recorded until flush writes the rows; the other operations apply to the workbook as usual.
+type streamSheet struct {
+	*SpreadsheetExcelize
+	rows      [][]streamCell           // Cells of the rows not written yet, by 0-based row from first and column
+	first     int                      // 1-based sheet row of rows[0]; the rows above are written
+	keep      int                      // Last 1-based sheet row whose cells are kept once written (see seedHeader)
+	kept      map[int][]streamCell     // Cells of the written rows up to keep, by 1-based sheet row
+	writer    *excelize.StreamWriter   // Stream writer of the sheet, started by the first written row
+	formulas  map[streamRef]string     // Cell formulas
+	merges    []CellRange              // Merged ranges, in merge order
+	rowOpts   map[int]excelize.RowOpts // Outline level and visibility of 1-based sheet rows
+	colStyles map[int]int32            // Style IDs of the styled 1-based sheet columns, initial style of their cells
+}
+
+// newStreamSheet returns a streamed sheet writing the current sheet of s.
+func newStreamSheet(s *SpreadsheetExcelize) *streamSheet {
+	return &streamSheet{
+		SpreadsheetExcelize: s,
+		first:               1,
+		kept:                make(map[int][]streamCell),
+		formulas:            make(map[streamRef]string),
+		rowOpts:             make(map[int]excelize.RowOpts),
+	}
+}
+
+// streamedSheets lists the sheets streamed by an export, in the order they were started.
+type streamedSheets struct {
+	sheets []*streamSheet
+}
+
+// lookup returns the streamed sheet of the workbook file with the given name, or nil.
+func (s *streamedSheets) lookup(file *excelize.File, name string) *streamSheet {
+	for _, sheet := range s.sheets {
+		if sheet.File == file && sheet.SheetName == name {
+			return sheet
+		}
+	}
+	return nil
+}
+
+// flush writes the rows of every streamed sheet.
+func (s *streamedSheets) flush() error {
+	for _, sheet := range s.sheets {
+		if err := sheet.flush(); err != nil {
+			return fmt.Errorf("failed to stream sheet %q: %w", sheet.SheetName, err)
+		}
+	}
+	return nil
+}
+
+// streamSheet returns the streamed sheet the table is written to, or nil when the sheet is written
+// cell by cell. Sheets are streamed according to FileWriteParams.Streaming when the export writes
+// them through Excelize and they hold no content yet; a sheet already streamed by the export
+// receives its following tables too.
+func (xlsx *xlsx) streamSheet(t *Table) *streamSheet {
+	s, ok := xlsx.spreadsheet.(*SpreadsheetExcelize)
+	if !ok || xlsx.streams == nil {
+		return nil
+	}
+	if sheet := xlsx.streams.lookup(s.File, s.SheetName); sheet != nil {
+		sheet.SpreadsheetExcelize = s
+		return sheet
+	}
+
+	switch xlsx.params.Streaming {
+	case StreamingNever:
+		return nil
+	case StreamingAuto:
+		threshold := xlsx.params.StreamingThreshold
+		if threshold <= 0 {
+			threshold = DefaultStreamingThreshold
+		}
+		if len(t.Data) < threshold {
+			return nil
+		}
+	}
+
+	// The stream writer replaces the cells of the sheet: existing content would be lost
+	rows, err := s.File.GetRows(s.SheetName)
+	if err == nil && len(rows) == 0 {
+		var merged []excelize.MergeCell
+		if merged, err = s.File.GetMergeCells(s.SheetName); err == nil && len(merged) > 0 {
+			err = fmt.Errorf("sheet holds %d merged ranges", len(merged))
+		}
+	} else if err == nil {
+		err = fmt.Errorf("sheet holds %d rows", len(rows))
+	}
+	if err != nil {
+		t.warn(WarningPhaseSheet, "", "Sheet already holds content, written cell by cell instead of streamed", err)
+		return nil
+	}
+
+	xlsx.params.logger().Debug("Streaming sheet", String("sheet", s.SheetName), Int("rows", len(t.Data)))
+	sheet := newStreamSheet(s)
+	xlsx.streams.sheets = append(xlsx.streams.sheets, sheet)
+	return sheet
+}
+
+// at returns 1-based sheet coordinates, or an error when they are out of the sheet.
+func (s *streamSheet) at(col, row int) (int, int, error) {
+	if col < 1 || row < 1 || col > excelize.MaxColumns || row > excelize.TotalRows {
+		return 0, 0, fmt.Errorf("invalid cell coordinates (%d, %d)", col, row)
+	}
+	return col, row, nil
+}
+
+// open returns 1-based sheet coordinates of a cell that can still be modified, or an error when
+// they are out of the sheet or its row is already written.
+func (s *streamSheet) open(col, row int) (int, int, error) {
+	col, row, err := s.at(col, row)
+	if err == nil && row < s.first {
+		err = fmt.Errorf("row %d is already written to the stream", row)
+	}
+	return col, row, err
+}
+
+// cell returns the cell at 1-based sheet coordinates of a row not written yet, growing the grid as
+// needed. The pointer is valid until the grid grows again.
+func (s *streamSheet) cell(col, row int) *streamCell {
+	s.growRows(row)
+	index := row - s.first
+	if cells := s.rows[index]; len(cells) < col {
+		s.rows[index] = append(cells, make([]streamCell, col-len(cells))...)
+		for c := len(cells) + 1; c <= col; c++ {
+			s.rows[index][c-1].style = s.colStyles[c]
+		}
+	}
+	return &s.rows[index][col-1]
+}
+
+// growRows grows the grid up to a 1-based sheet row.
+func (s *streamSheet) growRows(row int) {
+	if rows := row - s.first + 1; len(s.rows) < rows {
+		s.rows = append(s.rows, make([][]streamCell, rows-len(s.rows))...)
+	}
+}
+
+// peek returns the cell at 1-based sheet coordinates, or nil when it was never set or its row was
+// written without being kept.
+func (s *streamSheet) peek(col, row int) *streamCell {
+	cells := s.kept[row]
+	if row >= s.first && row-s.first < len(s.rows) {
+		cells = s.rows[row-s.first]
+	}
+	if col > len(cells) {
+		return nil
+	}
+	return &cells[col-1]
+}
+
+// GetCellValue returns the value of a cell as written, without number formats applied.
+func (s *streamSheet) GetCellValue(col, row int) (string, error) {
+	col, row, err := s.at(col, row)
+	if err != nil {
+		return "", err
+	}
+	if c := s.peek(col, row); c != nil && c.value != nil {
+		return cellText(c.value), nil
+	}
+	return "", nil
+}
+
+// SetCellValue sets the value of a cell. Dates and durations get the number format Excelize
+// gives them when writing cells one by one.
+func (s *streamSheet) SetCellValue(col, row int, value interface{}) error {
+	col, row, err := s.open(col, row)
+	if err != nil {
+		return err
+	}
+	c := s.cell(col, row)
+	c.value = value
+	numFmt, ok := defaultNumFmt(value)
+	if !ok {
+		return nil
+	}
+	existingID := int(c.style)
+	styleID, err := s.Table.derivedStyle(existingID, "numfmt:"+strconv.Itoa(numFmt), func() *excelize.Style {
+		excelStyle, err := s.File.GetStyle(existingID)
+		if existingID == 0 || excelStyle == nil || err != nil {
+			excelStyle = &excelize.Style{}
+		}
+		excelStyle.NumFmt = numFmt
+		return excelStyle
+	})
+	if err != nil {
+		return err
+	}
+	c.style = int32(styleID)
+	return nil
+}
+
+// defaultNumFmt returns the built-in number format Excelize applies to date and duration values.
+func defaultNumFmt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		if v.Before(time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC)) {
+			return 0, false // Written as text
+		}
+		if v.Day() == 1 && v.AddDate(0, 1, 0).Day() == 1 && v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return 17, true // Whole months
+		}
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return 14, true // Whole days
+		}
+		return 22, true
+	case time.Duration:
+		if v >= 24*time.Hour {
+			return 46, true
+		}
+		if v%time.Minute == 0 {
+			return 20, true // Whole minutes
+		}
+		return 21, true
+	}
+	return 0, false
+}
+
+// SetCellFormula sets the formula of a cell, keeping its value as the cached result.
+func (s *streamSheet) SetCellFormula(col, row int, formula string) error {
+	col, row, err := s.open(col, row)
+	if err != nil {
+		return err
+	}
+	s.cell(col, row)
+	ref := streamRef{col: col, row: row}
+	if formula == "" {
+		delete(s.formulas, ref)
+		return nil
+	}
+	s.formulas[ref] = formula
+	return nil
+}
+
+// MergeCells merges a range of cells. Like Excelize, the values and formulas of the cells covered
+// by the top-left one are cleared.
+func (s *streamSheet) MergeCells(startCol, startRow, endCol, endRow int) error {
+	startCol, startRow, err1 := s.open(startCol, startRow)
+	endCol, endRow, err2 := s.open(endCol, endRow)
+	if err1 != nil || err2 != nil {
+		return fmt.Errorf("failed to convert coordinates: %v, %v", err1, err2)
+	}
+	startCol, endCol = min(startCol, endCol), max(startCol, endCol)
+	startRow, endRow = min(startRow, endRow), max(startRow, endRow)
+
+	s.merges = append(s.merges, CellRange{StartCol: startCol, StartRow: startRow, EndCol: endCol, EndRow: endRow})
+	index := int32(len(s.merges))
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			c := s.cell(col, row)
+			c.merge = index
+			if col != startCol || row != startRow {
+				c.value = ""
+				delete(s.formulas, streamRef{col: col, row: row})
+			}
+		}
+	}
+	return nil
+}
+
+// merged returns the merged range holding a cell, if any.
+func (s *streamSheet) merged(col, row int) (CellRange, bool) {
+	col, row, err := s.at(col, row)
+	if err != nil {
+		return CellRange{}, false
+	}
+	c := s.peek(col, row)
+	if c == nil || c.merge == 0 {
+		return CellRange{}, false
+	}
+	return s.merges[c.merge-1], true
+}
+
+// IsCellMerged reports whether a cell is part of a merged range.
+func (s *streamSheet) IsCellMerged(col, row int) bool {
+	_, ok := s.merged(col, row)
+	return ok
+}
+
+// IsCellMergedHorizontally reports whether a cell is part of a merged range spanning a single row.
+func (s *streamSheet) IsCellMergedHorizontally(col, row int) bool {
+	r, ok := s.merged(col, row)
+	return ok && r.StartRow == r.EndRow && r.StartCol != r.EndCol
+}
+
+// ApplyBorderToCell applies a border to a side of a cell, preserving its style.
+func (s *streamSheet) ApplyBorderToCell(col, row int, side string, border *Border) error {
+	col, row, err := s.open(col, row)
+	if err != nil {
+		return err
+	}
+	sides, err := cellBorderSides(side, border)
+	if err != nil || len(sides) == 0 {
+		return err
+	}
+	return s.applyBorders(col, row, sides)
+}
+
+// ApplyBordersToRange applies the borders of a range to the cells on its sides.
+func (s *streamSheet) ApplyBordersToRange(startCol, startRow, endCol, endRow int, borders Borders) error {
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			sides := rangeBorderSides(col, row, startCol, startRow, endCol, endRow, borders)
+			if len(sides) == 0 {
+				continue
+			}
+			sheetCol, sheetRow, err := s.open(col, row)
+			if err != nil {
+				return err
+			}
+			if err := s.applyBorders(sheetCol, sheetRow, sides); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyBorders adds border sides to the style of the cell at 1-based sheet coordinates.
+func (s *streamSheet) applyBorders(col, row int, sides []excelize.Border) error {
+	c := s.cell(col, row)
+	styleID, err := s.Table.borderedStyle(int(c.style), sides)
+	if err != nil {
+		return err
+	}
+	c.style = int32(styleID)
+	return nil
+}
+
+// HasExistingBorder reports whether a cell holds a style.
+func (s *streamSheet) HasExistingBorder(col, row int, side string) bool {
+	col, row, err := s.at(col, row)
+	if err != nil {
+		return false
+	}
+	c := s.peek(col, row)
+	return c != nil && c.style > 0
+}
+
+// ApplyStyleToCell overlays a style on the style of a cell.
+func (s *streamSheet) ApplyStyleToCell(col, row int, style Style) error {
+	return s.ApplyStyleToRange(col, row, col, row, style)
+}
+
+// ApplyStyleToRange overlays a style on the style of every cell of a range.
+func (s *streamSheet) ApplyStyleToRange(startCol, startRow, endCol, endRow int, style Style) error {
+	inputStyle := convertStyleToExcelizeStyle(style)
+	key := styleKey(inputStyle)
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			sheetCol, sheetRow, err := s.open(col, row)
+			if err != nil {
+				return err
+			}
+			c := s.cell(sheetCol, sheetRow)
+			styleID, err := s.Table.overlayStyle(int(c.style), inputStyle, key)
+			if err != nil {
+				return err
+			}
+			c.style = int32(styleID)
+			c.value = vertAlignValue(c.value, inputStyle.Font)
+		}
+	}
+	return nil
+}
+
+// SetRowOutlineLevel sets the outline level of a 1-based sheet row.
+func (s *streamSheet) SetRowOutlineLevel(row, level int) error {
+	if level < 0 || level > 7 {
+		return excelize.ErrOutlineLevel
+	}
+	if _, _, err := s.open(1, row); err != nil {
+		return err
+	}
+	s.growRows(row)
+	opts := s.rowOpts[row]
+	opts.OutlineLevel = level
+	s.rowOpts[row] = opts
+	return nil
+}
+
+// SetRowVisible shows or hides a 1-based sheet row.
+func (s *streamSheet) SetRowVisible(row int, visible bool) error {
+	if _, _, err := s.open(1, row); err != nil {
+		return err
+	}
+	s.growRows(row)
+	opts := s.rowOpts[row]
+	opts.Hidden = !visible
+	s.rowOpts[row] = opts
+	return nil
+}
+
+// AddExcelTable adds a native Excel table over a range of the sheet.
+func (s *streamSheet) AddExcelTable(spec ExcelTableSpec) error {
+	if err := s.seedHeader(spec.Range); err != nil {
+		return err
+	}
+	return s.SpreadsheetExcelize.AddExcelTable(spec)
+}
+
+// AddPivotTable adds a native pivot table over a range of the sheet.
+func (s *streamSheet) AddPivotTable(spec ExcelPivotSpec) error {
+	if err := s.seedHeader(spec.DataRange); err != nil {
+		return err
+	}
+	return s.SpreadsheetExcelize.AddPivotTable(spec)
+}
+
+// seedHeader copies the first row of a range (e.g. "Sheet1!A1:D20") to the workbook, where
+// Excelize reads the column names of Excel and pivot tables. The workbook cells of a streamed
+// sheet are replaced by the stream when it is flushed.
+func (s *streamSheet) seedHeader(ref string) error {
+	if i := strings.LastIndex(ref, "!"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	start, end, _ := strings.Cut(strings.ReplaceAll(ref, "$", ""), ":")
+	startCol, row, err := excelize.CellNameToCoordinates(start)
+	if err != nil {
+		return err
+	}
+	endCol := startCol
+	if end != "" {
+		if endCol, _, err = excelize.CellNameToCoordinates(end); err != nil {
+			return err
+		}
+	}
+	for col := startCol; col <= endCol; col++ {
+		c := s.peek(col, row)
+		if c == nil || c.value == nil {
+			continue
+		}
+		cell, _ := excelize.CoordinatesToCellName(col, row)
+		if err := s.File.SetCellValue(s.SheetName, cell, c.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRows writes the recorded rows up to a 1-based sheet row through the stream writer, started
+// by the first call, and releases them; they cannot be modified afterwards. The cells of the rows up
+// to keep remain readable.
+func (s *streamSheet) writeRows(last int) error {
+	if s.writer == nil {
+		sw, err := s.File.NewStreamWriter(s.SheetName)
+		if err != nil {
+			return err
+		}
+		s.writer = sw
+	}
+
+	var values []interface{}
+	for ; s.first <= last && len(s.rows) > 0; s.first++ {
+		row, cells := s.first, s.rows[0]
+		s.rows[0], s.rows = nil, s.rows[1:]
+		if row <= s.keep {
+			s.kept[row] = cells
+		}
+		opts, hasOpts := s.rowOpts[row]
+		delete(s.rowOpts, row)
+		if len(cells) == 0 && !hasOpts {
+			continue
+		}
+		values = values[:0]
+		for index, c := range cells {
+			ref := streamRef{col: index + 1, row: row}
+			formula := s.formulas[ref]
+			delete(s.formulas, ref)
+			if c.value == nil && c.style == 0 && formula == "" {
+				values = append(values, nil)
+				continue
+			}
+			values = append(values, excelize.Cell{StyleID: int(c.style), Value: c.value, Formula: formula})
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		if err := s.writer.SetRow(cell, values, opts); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", row, err)
+		}
+	}
+	s.first = max(s.first, last+1)
+	return nil
+}
+
+// flush writes the remaining rows and the merged ranges, then ends the stream. The workbook must
+// not modify the cells of the sheet afterwards.
+func (s *streamSheet) flush() error {
+	if err := s.writeRows(s.first + len(s.rows) - 1); err != nil {
+		return err
+	}
+	for _, r := range s.merges {
+		start, _ := excelize.CoordinatesToCellName(r.StartCol, r.StartRow)
+		end, _ := excelize.CoordinatesToCellName(r.EndCol, r.EndRow)
+		if err := s.writer.MergeCell(start, end); err != nil {
+			return err
+		}
+	}
+	return s.writer.Flush()
+}
+
+// streamWindowRows is the number of data rows a streamed sheet buffers before writing the rows no
+// vertical merge can reach anymore (see streamWindow).
+const streamWindowRows = 1000
+
+// sharedSheets returns the Excelize sheets of an export requesting the same sheet as another one.
+// Their streamed rows are written once every table is in place, as a following table may write
+// next to the rows of the previous one.
+func sharedSheets(sheets []Spreadsheet) map[Spreadsheet]bool {
+	requests := make(map[string][]Spreadsheet)
+	for _, sheet := range sheets {
+		s, ok := sheet.(*SpreadsheetExcelize)
+		if !ok {
+			continue
+		}
+		name := s.GetSheetName()
+		if name == "" {
+			name = "Sheet1"
+		}
+		if table := s.GetTable(); table != nil && table.Preview != nil {
+			name = previewSheetKey + name
+		}
+		requests[name] = append(requests[name], sheet)
+	}
+
+	shared := make(map[Spreadsheet]bool)
+	for _, group := range requests {
+		for _, sheet := range group {
+			shared[sheet] = len(group) > 1
+		}
+	}
+	return shared
+}
+
+// streamWindow writes the data rows of a streamed sheet while the table is written. Once a window
+// of rows is written, the rows no vertical merge can still extend over get their formulas, merges,
+// styles and outline levels, and are written through the stream writer: only the rows of the
+// current window are held in memory.
+type streamWindow struct {
+	xlsx     *xlsx
+	sheet    *streamSheet
+	outline  outlineSetter // Sets the outline levels of the rows (nil without outline)
+	formulas bool          // Subtotal rows get SUBTOTAL formulas (see Table.RenderSubtotals)
+	bands    []int         // Zebra bands of the data rows (nil without banding)
+	vertical []int         // 1-based columns merged vertically
+	from     int           // First data row not written yet
+	next     int           // Number of written data rows from which rows are written to the stream
+}
+
+// streamWindow returns the window writing the data rows of the table as they are complete, or nil
+// when the rows are written once every table is in place: on sheets written cell by cell, shared
+// with other tables of the export, or transposed. The preamble and header rows are merged and
+// styled beforehand.
+func (xlsx *xlsx) streamWindow(outline outlineSetter, allowFormulas bool) (*streamWindow, error) {
+	t := xlsx.table
+	sheet, ok := xlsx.spreadsheet.(*streamSheet)
+	if !ok || !xlsx.exclusive || t.Transposed {
+		return nil, nil
+	}
+	ops := t.Offset(xlsx.ops)
+
+	w := &streamWindow{xlsx: xlsx, sheet: sheet, outline: outline, formulas: t.subtotalFormulas(allowFormulas), next: streamWindowRows}
+	for i, column := range t.Columns.GetFlattenedColumns() {
+		if column.Merge != nil && len(column.Merge.Vertical) > 0 {
+			w.vertical = append(w.vertical, i+1)
+		}
+	}
+	if t.Zebra != nil {
+		w.bands = t.zebraBands(ops)
+	}
+
+	merged := t.timings.measure(timingMerge)
+	err := t.mergeHeaders(ops)
+	merged()
+	if err != nil {
+		return nil, fmt.Errorf("failed to process merging: %w", err)
+	}
+	styled := t.timings.measure(timingStyle)
+	err = t.styleHeaders(ops)
+	styled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render styles: %w", err)
+	}
+
+	// Excel and pivot tables read their column names from the header row once the rows are written
+	_, sheet.keep = t.sheetCoords(1, t.GetDataStartRow()-1)
+	return w, nil
+}
+
+// written is called once count data rows are written to the sheet, and writes the complete rows to
+// the stream every streamWindowRows rows. Rows are complete up to the first row of the vertical
+// merges still open; when every row of the window is reachable, the window grows.
+func (w *streamWindow) written(count int) error {
+	if count < w.next {
+		return nil
+	}
+	cut, ranges := w.scan(count)
+	if cut == w.from {
+		w.next = count + (count - w.from)
+		return nil
+	}
+	w.next = max(cut+streamWindowRows, count+1)
+	return w.write(cut, ranges)
+}
+
+// finish writes the remaining data rows to the stream once every data row is written.
+func (w *streamWindow) finish() error {
+	_, ranges := w.scan(len(w.xlsx.table.Data))
+	return w.write(len(w.xlsx.table.Data), ranges)
+}
+
+// scan returns the first data row vertical merges may still extend from, given count written data
+// rows, with the vertical merge ranges of the rows before it by column.
+func (w *streamWindow) scan(count int) (int, map[int][][]int) {
+	t := w.xlsx.table
+	ops := t.Offset(w.xlsx.ops)
+	flatColumns := t.Columns.GetFlattenedColumns()
+
+	// Ranges still open at the last data row are complete
+	cut := count
+	scanned := make(map[int][][]int, len(w.vertical))
+	for _, col := range w.vertical {
+		column := flatColumns[col-1]
+		ranges, open := t.scanVerticalMerges(col, column, column.Merge.Vertical, w.from, count, ops)
+		scanned[col] = ranges
+		if count < len(t.Data) {
+			cut = min(cut, open)
+		}
+	}
+
+	// A range of a column crossing the open range of another one is kept with it
+	for lowered := true; lowered; {
+		lowered = false
+		for _, ranges := range scanned {
+			for _, r := range ranges {
+				if r[0] < cut && cut <= r[len(r)-1] {
+					cut, lowered = r[0], true
+				}
+			}
+		}
+	}
+	for col, ranges := range scanned {
+		complete := ranges[:0]
+		for _, r := range ranges {
+			if r[0] < cut {
+				complete = append(complete, r)
+			}
+		}
+		scanned[col] = complete
+	}
+	return cut, scanned
+}
+
+// write completes the data rows from the first row not written yet to the row to (excluded), with
+// the given vertical merge ranges, and writes them to the stream.
+func (w *streamWindow) write(to int, ranges map[int][][]int) error {
+	xlsx, t := w.xlsx, w.xlsx.table
+	ops := t.Offset(xlsx.ops)
+	dataStartRow := t.GetDataStartRow()
+
+	if w.formulas {
+		if err := t.renderSubtotalRows(w.from, to, ops); err != nil {
+			return fmt.Errorf("failed to write subtotals: %w", err)
+		}
+	}
+	if err := t.renderCellFormulaRows(w.from, to, ops); err != nil {
+		return fmt.Errorf("failed to write cell formulas: %w", err)
+	}
+	if err := t.strictErr(); err != nil {
+		return err
+	}
+
+	merged := t.timings.measure(timingMerge)
+	flatColumns := t.Columns.GetFlattenedColumns()
+	for _, col := range w.vertical {
+		t.applyVerticalMerges(flatColumns[col-1], col, dataStartRow, ranges[col], ops)
+	}
+	err := t.strictErr()
+	if err == nil {
+		err = t.mergeDataRows(w.from, to, dataStartRow, ops)
+	}
+	if err == nil {
+		err = t.strictErr()
+	}
+	merged()
+	if err != nil {
+		return fmt.Errorf("failed to process merging: %w", err)
+	}
+
+	styled := t.timings.measure(timingStyle)
+	err = t.styleDataRows(w.from, to, w.bands, ops)
+	if err == nil {
+		err = t.strictErr()
+	}
+	styled()
+	if err != nil {
+		return fmt.Errorf("failed to render styles: %w", err)
+	}
+
+	if w.outline != nil {
+		xlsx.writeOutlineRows(w.outline, w.from, to)
+	}
+
+	_, last := t.sheetCoords(1, dataStartRow+to-1)
+	if err := w.sheet.writeRows(last); err != nil {
+		return fmt.Errorf("failed to stream sheet %q: %w", w.sheet.SheetName, err)
+	}
+	w.from = to
+	return nil
+}
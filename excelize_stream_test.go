@@ -0,0 +1,301 @@
+package spit
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportStreamed exports the sheets with the given streaming mode and opens the workbook.
+func exportStreamed(t *testing.T, mode StreamingMode, sheets ...Spreadsheet) (*FileWriteResult, *excelize.File) {
+	t.Helper()
+	var buf bytes.Buffer
+	res, err := ExportXLSXSheets(sheets, FileWriteParams{Filename: "stream", Writer: &buf, Streaming: mode})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	t.Cleanup(func() { _ = f.Close() })
+	return res, f
+}
+
+// compareSheets fails when the cells, merged ranges, styles, formulas or row outlines of a sheet
+// differ between two workbooks.
+func compareSheets(t *testing.T, sheet string, want, got *excelize.File) {
+	t.Helper()
+	wantRows, _ := want.GetRows(sheet)
+	gotRows, _ := got.GetRows(sheet)
+	if !reflect.DeepEqual(gotRows, wantRows) {
+		t.Fatalf("rows = %q, want %q", gotRows, wantRows)
+	}
+	// Streamed sheets merge the rows of each window in turn: the ranges are compared regardless of order
+	if gotMerges, wantMerges := mergedRanges(got, sheet), mergedRanges(want, sheet); !slices.Equal(gotMerges, wantMerges) {
+		t.Fatalf("merged ranges = %v, want %v", gotMerges, wantMerges)
+	}
+
+	for row := 1; row <= len(wantRows)+1; row++ {
+		wantLevel, _ := want.GetRowOutlineLevel(sheet, row)
+		gotLevel, _ := got.GetRowOutlineLevel(sheet, row)
+		wantVisible, _ := want.GetRowVisible(sheet, row)
+		gotVisible, _ := got.GetRowVisible(sheet, row)
+		if gotLevel != wantLevel || gotVisible != wantVisible {
+			t.Errorf("row %d outline = %d (visible %v), want %d (visible %v)", row, gotLevel, gotVisible, wantLevel, wantVisible)
+		}
+		for col := 1; col <= 7; col++ {
+			cell, _ := excelize.CoordinatesToCellName(col, row)
+			wantFormula, _ := want.GetCellFormula(sheet, cell)
+			gotFormula, _ := got.GetCellFormula(sheet, cell)
+			if gotFormula != wantFormula {
+				t.Errorf("%s formula = %q, want %q", cell, gotFormula, wantFormula)
+			}
+			wantID, _ := want.GetCellStyle(sheet, cell)
+			gotID, _ := got.GetCellStyle(sheet, cell)
+			wantStyle, _ := want.GetStyle(wantID)
+			gotStyle, _ := got.GetStyle(gotID)
+			if !reflect.DeepEqual(gotStyle, wantStyle) {
+				t.Errorf("%s style = %+v, want %+v", cell, gotStyle, wantStyle)
+			}
+		}
+	}
+}
+
+// mergedRanges returns the sorted merged ranges of a sheet (e.g. "A2:A3").
+func mergedRanges(f *excelize.File, sheet string) []string {
+	merges, _ := f.GetMergeCells(sheet)
+	ranges := make([]string, len(merges))
+	for i, m := range merges {
+		ranges[i] = m.GetStartAxis() + ":" + m.GetEndAxis()
+	}
+	slices.Sort(ranges)
+	return ranges
+}
+
+// streamWindowTestData returns rows enough for several stream windows, whose regions and zones
+// change every 7 and 5 rows so that their merged ranges cross the window ends.
+func streamWindowTestData(rows int) DataSlice {
+	data := make(DataSlice, rows)
+	for i := range data {
+		data[i] = Data{"region": fmt.Sprintf("R%d", i/7), "zone": fmt.Sprintf("Z%d", (i+3)/5), "sales": i}
+	}
+	return data
+}
+
+func TestExportXLSX_streaming(t *testing.T) {
+	day := time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		table func() *Table
+	}{
+		{
+			name: "merges, styles, dates and footer formulas",
+			table: func() *Table {
+				return NewTable(DataSlice{
+					{"region": "EU", "country": "FR", "sales": 10, "day": day, "took": 90 * time.Second},
+					{"region": "EU", "country": "DE", "sales": 20, "day": day.Add(90 * time.Minute), "took": 1500 * time.Millisecond},
+					{"region": "US", "country": "US", "sales": 40, "day": day.AddDate(0, 0, 1), "took": 36 * time.Hour},
+				}, Columns{
+					NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+					NewColumn("country", "Country"),
+					NewColumn("sales", "Sales").WithStyle(&Style{Bold: true, NumFmt: "0.00"}).WithAggregate(AggregateSum),
+					NewColumn("day", "Day").WithFormat(ExcelizeFormatDefault),
+					NewColumn("took", "Took").WithFormat(ExcelizeFormatDefault).WithStyle(&Style{Bold: true}),
+				}, true).
+					WithStartPosition(2, 2).
+					WithHeaderOptions(NewHeaderOptions().WithStyle(&Style{BackgroundColor: "#DDEEFF"}).
+						WithBorders(&Borders{Bottom: &Border{Style: BorderStyleThin}})).
+					WithRowStyler(func(rowIndex int, row Data) *Style {
+						if row["region"] == "US" {
+							return &Style{Italic: true}
+						}
+						return nil
+					}).
+					WithFooter(NewFooterOptions().WithFormulas(true))
+			},
+		},
+		{
+			name: "grouped rows",
+			table: func() *Table {
+				return fixtureTable("region", "country", "sales").WithGroupBy("region").
+					WithGroupOptions(NewGroupOptions().WithCollapsed(true).WithSubtotals(true))
+			},
+		},
+		{
+			name: "rows beyond a stream window",
+			table: func() *Table {
+				data := streamWindowTestData(2*streamWindowRows + 300)
+				merge := NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)
+				return NewTable(data, Columns{
+					NewColumn("region", "Region").WithMerge(merge),
+					NewColumn("zone", "Zone").WithMerge(merge),
+					NewColumn("sales", "Sales").WithAggregate(AggregateSum).
+						WithBorders(&Borders{Top: &Border{Style: BorderStyleThick}, Bottom: &Border{Style: BorderStyleThick}, Left: &Border{Style: BorderStyleThin}}),
+				}, true).
+					WithZebra(&Style{BackgroundColor: "#EEEEEE"}, nil).
+					WithFooter(NewFooterOptions().WithFormulas(true))
+			},
+		},
+		{
+			name: "grouped rows beyond a stream window",
+			table: func() *Table {
+				data := streamWindowTestData(2*streamWindowRows + 300)
+				for i, row := range data {
+					row["region"] = fmt.Sprintf("R%d", i/450)
+				}
+				return NewTable(data, Columns{
+					NewColumn("region", "Region"),
+					NewColumn("zone", "Zone").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+					NewColumn("sales", "Sales").WithAggregate(AggregateSum),
+				}, true).WithGroupBy("region").
+					WithGroupOptions(NewGroupOptions().WithCollapsed(true).WithSubtotals(true))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, want := exportStreamed(t, StreamingNever, NewSpreadsheetExcelize("Data", tt.table()))
+			res, got := exportStreamed(t, StreamingAlways, NewSpreadsheetExcelize("Data", tt.table()))
+			if !res.Sheets[0].Streamed {
+				t.Fatal("expected the sheet to be streamed")
+			}
+			if len(res.Warnings) > 0 {
+				t.Errorf("unexpected warnings %v", res.Warnings)
+			}
+			compareSheets(t, "Data", want, got)
+		})
+	}
+}
+
+func TestExportXLSX_streamingWindow(t *testing.T) {
+	data := streamWindowTestData(3*streamWindowRows + 10)
+	table := NewTable(data, Columns{
+		NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+		NewColumn("sales", "Sales"),
+	}, true)
+	sheet := NewSpreadsheetExcelize("Data", table)
+	if err := sheet.CreateNewFile(); err != nil {
+		t.Fatalf("CreateNewFile failed: %v", err)
+	}
+	defer func() { _ = sheet.File.Close() }()
+	x := &xlsx{spreadsheet: sheet, params: FileWriteParams{Streaming: StreamingAlways}, streams: &streamedSheets{}, exclusive: true}
+
+	// Rows are written to the stream while the data rows are written, the last window excepted
+	var written, buffered int
+	table.AfterRowWrite = func(rowIndex int, _ CellRange) {
+		if rowIndex == len(data)-1 {
+			streamed := x.streams.sheets[0]
+			written, buffered = streamed.first-1, len(streamed.rows)
+		}
+	}
+	if err := x.writeData(); err != nil {
+		t.Fatalf("writeData failed: %v", err)
+	}
+	if written <= 2*streamWindowRows || buffered > streamWindowRows {
+		t.Errorf("%d rows written and %d buffered with the last data row, want the rows of all but the last window written", written, buffered)
+	}
+
+	streamed := x.streams.sheets[0]
+	if err := streamed.SetCellValue(1, 2, "late"); err == nil {
+		t.Error("SetCellValue succeeded on a written row, want an error")
+	}
+	if c := streamed.peek(1, 1); c == nil || c.value != "Region" {
+		t.Errorf("header cell = %+v, want the header kept readable", c)
+	}
+	if err := x.streams.flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := sheet.SaveToWriter(&buf); err != nil {
+		t.Fatalf("SaveToWriter failed: %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if merges := mergedRanges(f, "Data"); len(merges) != (len(data)+6)/7 || !slices.Contains(merges, "A996:A1002") {
+		t.Errorf("%d merged ranges, want one per region including A996:A1002", len(merges))
+	}
+	if got, _ := f.GetCellValue("Data", "B3011"); got != "3009" {
+		t.Errorf("B3011 = %q, want 3009", got)
+	}
+}
+
+func TestExportXLSX_streamingTables(t *testing.T) {
+	table := fixtureTable("region", "year", "quarter", "sales").WithExcelTable(nil).
+		WithExcelPivot(NewExcelPivotOptions(NewPivotOptions([]string{"region"}, nil, "sales")))
+	res, f := exportStreamed(t, StreamingAlways, NewSpreadsheetExcelize("Sales", table))
+	if len(res.Warnings) > 0 {
+		t.Errorf("unexpected warnings %v", res.Warnings)
+	}
+
+	tables, err := f.GetTables("Sales")
+	if err != nil || len(tables) != 1 || tables[0].Range != "A1:D6" {
+		t.Errorf("GetTables() = %+v, %v; want a table over A1:D6", tables, err)
+	}
+	pivots, err := f.GetPivotTables("Sales Pivot")
+	if err != nil || len(pivots) != 1 || len(pivots[0].Rows) != 1 || pivots[0].Rows[0].Data != "Region" {
+		t.Errorf("GetPivotTables() = %+v, %v; want a pivot over Region", pivots, err)
+	}
+}
+
+func TestExportXLSX_streamingMode(t *testing.T) {
+	table := func() *Table { return fixtureTable("region", "country", "sales") }
+	tests := []struct {
+		name      string
+		params    FileWriteParams
+		want      bool
+		wantWarns int
+	}{
+		{name: "auto below threshold", params: FileWriteParams{}},
+		{name: "auto above threshold", params: FileWriteParams{StreamingThreshold: 5}, want: true},
+		{name: "never", params: FileWriteParams{Streaming: StreamingNever, StreamingThreshold: 1}},
+		{name: "always", params: FileWriteParams{Streaming: StreamingAlways}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.Filename, params.Writer = "mode", &bytes.Buffer{}
+			res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table()), params)
+			if err != nil {
+				t.Fatalf("ExportXLSX failed: %v", err)
+			}
+			if got := res.Sheets[0].Streamed; got != tt.want {
+				t.Errorf("Streamed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("tables sharing a sheet", func(t *testing.T) {
+		second := fixtureTable("region", "country", "sales").WithStartPosition(5, 1)
+		res, f := exportStreamed(t, StreamingAlways, NewSpreadsheetExcelize("Data", table()), NewSpreadsheetExcelize("Data", second))
+		if !res.Sheets[0].Streamed || !res.Sheets[1].Streamed {
+			t.Errorf("Streamed = %v, %v; want both tables streamed", res.Sheets[0].Streamed, res.Sheets[1].Streamed)
+		}
+		for cell, want := range map[string]string{"A2": "US", "E1": "Region", "G6": "15"} {
+			if got, _ := f.GetCellValue("Data", cell); got != want {
+				t.Errorf("%s = %q, want %q", cell, got, want)
+			}
+		}
+	})
+
+	t.Run("sheet with content", func(t *testing.T) {
+		file := excelize.NewFile()
+		defer func() { _ = file.Close() }()
+		_ = file.SetCellValue("Sheet1", "A10", "kept")
+		res, f := exportStreamed(t, StreamingAlways, NewSpreadsheetExcelize("Sheet1", table()).WithFile(file))
+		if res.Sheets[0].Streamed || len(res.Report().Phase(WarningPhaseSheet)) != 1 {
+			t.Errorf("Streamed = %v with warnings %v; want a cell by cell write and a warning", res.Sheets[0].Streamed, res.Warnings)
+		}
+		if got, _ := f.GetCellValue("Sheet1", "A10"); got != "kept" {
+			t.Errorf("A10 = %q, want the existing content kept", got)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// excelize_style_cache.go - Style deduplication for the Excelize adapter.
+//
+// Styling a cell merges the applied style or borders into the cell's current style and registers
+// the result in the workbook. Large tables apply the same few combinations to many cells, so the
+// resulting style IDs are cached: identical styles share one ID, and applying the same change to
+// cells holding the same style reuses the ID computed for the first one without decoding,
+// merging or registering the style again.
+
+package spit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// styleCache maps styles to the IDs registered in a workbook.
+type styleCache struct {
+	file    *excelize.File          // Workbook the IDs belong to
+	ids     map[string]int          // Style key (see styleKey) to style ID
+	derived map[derivedStyleKey]int // Style ID resulting from a change applied to a base style ID
+}
+
+// derivedStyleKey identifies a change (style overlay or border sides) applied to a base style.
+type derivedStyleKey struct {
+	base   int
+	change string
+}
+
+// styles returns the style cache of the current file, resetting it when the file changed.
+func (e *TableExcelize) styles() *styleCache {
+	if e.styleCache == nil || e.styleCache.file != e.File {
+		e.styleCache = &styleCache{
+			file:    e.File,
+			ids:     make(map[string]int),
+			derived: make(map[derivedStyleKey]int),
+		}
+	}
+	return e.styleCache
+}
+
+// newStyle returns the ID of a style, registering it in the workbook only the first time.
+func (e *TableExcelize) newStyle(style *excelize.Style) (int, error) {
+	cache := e.styles()
+	key := styleKey(style)
+	if id, ok := cache.ids[key]; ok {
+		return id, nil
+	}
+	id, err := e.File.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	cache.ids[key] = id
+	return id, nil
+}
+
+// derivedStyle returns the ID of the style resulting from a change applied to the base style ID.
+// build computes that style the first time the change is applied to the base.
+func (e *TableExcelize) derivedStyle(base int, change string, build func() *excelize.Style) (int, error) {
+	cache := e.styles()
+	key := derivedStyleKey{base: base, change: change}
+	if id, ok := cache.derived[key]; ok {
+		return id, nil
+	}
+	id, err := e.newStyle(build())
+	if err != nil {
+		return 0, err
+	}
+	cache.derived[key] = id
+	return id, nil
+}
+
+// styleKey returns a key identifying a value by content (e.g. a style or border sides), pointed
+// values included.
+func styleKey(v interface{}) string {
+	key, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(key)
+}
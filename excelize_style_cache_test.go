@@ -0,0 +1,61 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTableExcelize_styleCache(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	e := NewTableExcelize("Sheet1", nil).WithFile(f)
+
+	bold := Style{Bold: true}
+	border := &Border{Style: BorderStyleThin}
+	for row := 1; row <= 50; row++ {
+		if err := e.ApplyStyleToCell(1, row, bold); err != nil {
+			t.Fatalf("ApplyStyleToCell failed: %v", err)
+		}
+		if err := e.ApplyBorderToCell(1, row, "left", border); err != nil {
+			t.Fatalf("ApplyBorderToCell failed: %v", err)
+		}
+	}
+	if err := e.ApplyStyleToRange(2, 1, 3, 50, bold); err != nil {
+		t.Fatalf("ApplyStyleToRange failed: %v", err)
+	}
+
+	styleID := func(cell string) int {
+		id, err := f.GetCellStyle("Sheet1", cell)
+		if err != nil {
+			t.Fatalf("GetCellStyle(%s) failed: %v", cell, err)
+		}
+		return id
+	}
+	if styleID("A1") != styleID("A50") {
+		t.Errorf("identical cells have styles %d and %d, want a shared style", styleID("A1"), styleID("A50"))
+	}
+	if styleID("B1") != styleID("C50") || styleID("A1") == styleID("B1") {
+		t.Errorf("styles A1=%d B1=%d C50=%d, want bold cells shared and bordered cells distinct",
+			styleID("A1"), styleID("B1"), styleID("C50"))
+	}
+
+	// Borders are kept when the style is merged into a bordered cell
+	style, err := f.GetStyle(styleID("A50"))
+	if err != nil || style.Font == nil || !style.Font.Bold || len(style.Border) != 1 {
+		t.Errorf("A50 style = %+v, %v; want bold with a left border", style, err)
+	}
+
+	// Two styles registered: bold, then bold with a left border
+	if got := len(e.styleCache.ids); got != 2 {
+		t.Errorf("cached %d styles, want 2", got)
+	}
+
+	// The cache belongs to its workbook
+	other := excelize.NewFile()
+	defer other.Close()
+	e.WithFile(other)
+	if cache := e.styles(); cache.file != other || len(cache.ids) != 0 {
+		t.Error("style cache should reset for another file")
+	}
+}
@@ -16,11 +16,11 @@ import (
 // Implements TableOperations for Excel spreadsheets using github.com/xuri/excelize.
 // TableExcelize instances must not be shared across goroutines without external synchronization.
 type TableExcelize struct {
-	File                  *excelize.File       // Underlying Excelize file object
-	SheetName             string               // Current sheet name
-	Table                 *Table               // Reference to the generic Table struct
-	mergedCells           []excelize.MergeCell // Cached merged-cell list for IsCellMerged lookups
-	mergedCellsCachedName string               // Sheet name for which mergedCells is valid; reset on MergeCell call or SheetName change to invalidate cache
+	File       *excelize.File // Underlying Excelize file object
+	SheetName  string         // Current sheet name
+	Table      *Table         // Reference to the generic Table struct
+	mergeIndex *mergeIndex    // Merged ranges indexed by cell for IsCellMerged lookups (see excelize_merge_index.go)
+	styleCache *styleCache    // Style IDs reused across identical cells (see excelize_style_cache.go)
 }
 
 // NewTableExcelize creates a new TableExcelize instance for a given sheet name and table.
@@ -39,6 +39,11 @@ func (e *TableExcelize) WithFile(file *excelize.File) *TableExcelize {
 	return e
 }
 
+// cellName converts 1-based coordinates to an Excel cell reference.
+func (e *TableExcelize) cellName(col, row int) (string, error) {
+	return excelize.CoordinatesToCellName(col, row)
+}
+
 // GetTable returns the underlying Table struct for direct access/manipulation.
 func (e *TableExcelize) GetTable() *Table {
 	return e.Table
@@ -47,7 +52,7 @@ func (e *TableExcelize) GetTable() *Table {
 // GetCellValue returns the value of a cell at the given column and row (1-based indices).
 // Converts coordinates to Excel cell reference and retrieves the value from the sheet.
 func (e *TableExcelize) GetCellValue(col, row int) (string, error) {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return "", err
 	}
@@ -57,7 +62,7 @@ func (e *TableExcelize) GetCellValue(col, row int) (string, error) {
 // SetCellValue sets the value of a cell at the given column and row.
 // Converts coordinates to Excel cell reference and sets the value in the sheet.
 func (e *TableExcelize) SetCellValue(col, row int, value interface{}) error {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return err
 	}
@@ -67,104 +72,87 @@ func (e *TableExcelize) SetCellValue(col, row int, value interface{}) error {
 // MergeCells merges a rectangular range of cells from start to end coordinates.
 // Converts coordinates to Excel cell references and merges the specified range.
 func (e *TableExcelize) MergeCells(startCol, startRow, endCol, endRow int) error {
-	startCell, err1 := excelize.CoordinatesToCellName(startCol, startRow)
-	endCell, err2 := excelize.CoordinatesToCellName(endCol, endRow)
+	startCell, err1 := e.cellName(startCol, startRow)
+	endCell, err2 := e.cellName(endCol, endRow)
 	if err1 != nil || err2 != nil {
 		return fmt.Errorf("failed to convert coordinates: %v, %v", err1, err2)
 	}
-	err := e.File.MergeCell(e.SheetName, startCell, endCell)
-	e.mergedCellsCachedName = "" // Invalidate the merged-cells cache
-	return err
+	if err := e.File.MergeCell(e.SheetName, startCell, endCell); err != nil {
+		return err
+	}
+	// Keep the merge index up to date instead of reading the merged ranges again
+	if index := e.mergeIndex; index != nil && index.file == e.File && index.sheet == e.SheetName {
+		index.add(CellRange{
+			StartCol: min(startCol, endCol), StartRow: min(startRow, endRow),
+			EndCol: max(startCol, endCol), EndRow: max(startRow, endRow),
+		})
+	}
+	return nil
 }
 
-// getMergedCells returns the list of merged cells for the current sheet, using a cache to avoid
-// repeated calls to GetMergeCells. The cache is invalidated whenever MergeCells is called or
-// SheetName changes.
-func (e *TableExcelize) getMergedCells() ([]excelize.MergeCell, error) {
-	if e.mergedCellsCachedName != e.SheetName {
-		cells, err := e.File.GetMergeCells(e.SheetName)
-		if err != nil {
-			return nil, err
-		}
-		e.mergedCells = cells
-		e.mergedCellsCachedName = e.SheetName
+// merged returns the merged range holding a cell, if any.
+func (e *TableExcelize) merged(col, row int) (CellRange, bool) {
+	if _, err := e.cellName(col, row); err != nil {
+		return CellRange{}, false
 	}
-	return e.mergedCells, nil
+	index, err := e.merges()
+	if err != nil {
+		return CellRange{}, false
+	}
+	return index.lookup(col, row)
 }
 
 // IsCellMerged checks if a cell at the given column and row is merged with others.
 // Returns true if the cell is part of a merged range, false otherwise.
 func (e *TableExcelize) IsCellMerged(col, row int) bool {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
-	if err != nil {
-		return false
-	}
-
-	mergedCells, err := e.getMergedCells()
-	if err != nil {
-		return false
-	}
-	for _, mergeCell := range mergedCells {
-		if isCellInRange(cellRef, mergeCell.GetStartAxis(), mergeCell.GetEndAxis()) {
-			return true
-		}
-	}
-	return false
+	_, ok := e.merged(col, row)
+	return ok
 }
 
 // IsCellMergedHorizontally checks if a cell at the given column and row is merged horizontally.
 // Returns true if the cell is part of a horizontally merged range, false otherwise.
 func (e *TableExcelize) IsCellMergedHorizontally(col, row int) bool {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
-	if err != nil {
-		return false
-	}
-
-	mergedCells, err := e.getMergedCells()
-	if err != nil {
-		return false
-	}
-	for _, mergeCell := range mergedCells {
-		if isCellInRange(cellRef, mergeCell.GetStartAxis(), mergeCell.GetEndAxis()) {
-			startCol, startRow, _ := excelize.CellNameToCoordinates(mergeCell.GetStartAxis())
-			endCol, endRow, _ := excelize.CellNameToCoordinates(mergeCell.GetEndAxis())
-			return startRow == endRow && startCol != endCol
-		}
-	}
-	return false
+	r, ok := e.merged(col, row)
+	return ok && r.StartRow == r.EndRow && r.StartCol != r.EndCol
 }
 
 // ApplyBorderToCell applies a border to a specific side of a cell at the given column and row.
 // The border style is defined by the Border parameter. Only non-none styles are applied.
 func (e *TableExcelize) ApplyBorderToCell(col, row int, side string, border *Border) error {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
-	if err != nil {
+	if _, err := e.cellName(col, row); err != nil {
+		return err
+	}
+	sides, err := cellBorderSides(side, border)
+	if err != nil || len(sides) == 0 {
 		return err
 	}
+	return e.applyBordersToCell(col, row, sides)
+}
+
+// cellBorderSides returns the excelize border applied to a side of a cell, or none when the border
+// is nil or BorderStyleNone.
+func cellBorderSides(side string, border *Border) ([]excelize.Border, error) {
 	if border == nil || border.Style == BorderStyleNone {
-		return nil
+		return nil, nil
 	}
 
 	// Validate side before doing any expensive work
 	switch side {
-	case "left", "right", "top", "bottom":
+	case "left", "right", "top", "bottom", "diagonalUp", "diagonalDown":
 	default:
-		return fmt.Errorf("unsupported border side: %s", side)
+		return nil, fmt.Errorf("unsupported border side: %s", side)
 	}
 
-	// Get current style and preserve existing properties
-	excelStyle, err := e.getCellStyle(col, row)
-	if excelStyle == nil || err != nil {
-		excelStyle = &excelize.Style{}
-	}
-	excelStyle.Border = append(excelStyle.Border, excelize.Border{Type: side, Color: "000000", Style: int(border.Style)})
+	return []excelize.Border{excelBorder(side, border)}, nil
+}
 
-	styleID, err := e.File.NewStyle(excelStyle)
-	if err != nil {
-		return err
+// excelBorder converts a border of a cell side to an excelize border, black unless colored.
+func excelBorder(side string, border *Border) excelize.Border {
+	color := "000000"
+	if border.Color != "" {
+		color = border.Color
 	}
-
-	return e.File.SetCellStyle(e.SheetName, cellRef, cellRef, styleID)
+	return excelize.Border{Type: side, Color: color, Style: int(border.Style)}
 }
 
 // ApplyBordersToRange applies borders to a range of cells defined by start and end coordinates.
@@ -173,42 +161,13 @@ func (e *TableExcelize) ApplyBorderToCell(col, row int, side string, border *Bor
 func (e *TableExcelize) ApplyBordersToRange(startCol, startRow, endCol, endRow int, borders Borders) error {
 	for row := startRow; row <= endRow; row++ {
 		for col := startCol; col <= endCol; col++ {
-			// Collect which border sides apply to this cell in the range
-			var sides []excelize.Border
-			if col == startCol && borders.Left != nil && borders.Left.Style != BorderStyleNone {
-				sides = append(sides, excelize.Border{Type: "left", Color: "000000", Style: int(borders.Left.Style)})
-			}
-			if col == endCol && borders.Right != nil && borders.Right.Style != BorderStyleNone {
-				sides = append(sides, excelize.Border{Type: "right", Color: "000000", Style: int(borders.Right.Style)})
-			}
-			if row == startRow && borders.Top != nil && borders.Top.Style != BorderStyleNone {
-				sides = append(sides, excelize.Border{Type: "top", Color: "000000", Style: int(borders.Top.Style)})
-			}
-			if row == endRow && borders.Bottom != nil && borders.Bottom.Style != BorderStyleNone {
-				sides = append(sides, excelize.Border{Type: "bottom", Color: "000000", Style: int(borders.Bottom.Style)})
-			}
-
+			sides := rangeBorderSides(col, row, startCol, startRow, endCol, endRow, borders)
 			if len(sides) == 0 {
 				continue
 			}
 
 			// Apply all sides in one style read/write cycle
-			cellRef, err := excelize.CoordinatesToCellName(col, row)
-			if err != nil {
-				return err
-			}
-
-			excelStyle, err := e.getCellStyle(col, row)
-			if excelStyle == nil || err != nil {
-				excelStyle = &excelize.Style{}
-			}
-			excelStyle.Border = append(excelStyle.Border, sides...)
-
-			styleID, err := e.File.NewStyle(excelStyle)
-			if err != nil {
-				return err
-			}
-			if err := e.File.SetCellStyle(e.SheetName, cellRef, cellRef, styleID); err != nil {
+			if err := e.applyBordersToCell(col, row, sides); err != nil {
 				return err
 			}
 		}
@@ -216,10 +175,67 @@ func (e *TableExcelize) ApplyBordersToRange(startCol, startRow, endCol, endRow i
 	return nil
 }
 
+// rangeBorderSides returns the excelize borders of a cell of a range: the sides of the range the
+// cell lies on, with a border set, and the diagonals crossing every cell.
+func rangeBorderSides(col, row, startCol, startRow, endCol, endRow int, borders Borders) []excelize.Border {
+	var sides []excelize.Border
+	if col == startCol && borderSet(borders.Left) {
+		sides = append(sides, excelBorder("left", borders.Left))
+	}
+	if col == endCol && borderSet(borders.Right) {
+		sides = append(sides, excelBorder("right", borders.Right))
+	}
+	if row == startRow && borderSet(borders.Top) {
+		sides = append(sides, excelBorder("top", borders.Top))
+	}
+	if row == endRow && borderSet(borders.Bottom) {
+		sides = append(sides, excelBorder("bottom", borders.Bottom))
+	}
+	if borderSet(borders.DiagonalUp) {
+		sides = append(sides, excelBorder("diagonalUp", borders.DiagonalUp))
+	}
+	if borderSet(borders.DiagonalDown) {
+		sides = append(sides, excelBorder("diagonalDown", borders.DiagonalDown))
+	}
+	return sides
+}
+
+// applyBordersToCell adds border sides to the current style of a cell, preserving its other
+// properties. The resulting style ID is shared by cells holding the same style.
+func (e *TableExcelize) applyBordersToCell(col, row int, sides []excelize.Border) error {
+	cellRef, err := e.cellName(col, row)
+	if err != nil {
+		return err
+	}
+	existingID, err := e.File.GetCellStyle(e.SheetName, cellRef)
+	if err != nil {
+		existingID = -1 // Unknown style: the borders are added to an empty style
+	}
+
+	styleID, err := e.borderedStyle(existingID, sides)
+	if err != nil {
+		return err
+	}
+	return e.File.SetCellStyle(e.SheetName, cellRef, cellRef, styleID)
+}
+
+// borderedStyle returns the ID of the style resulting from border sides added to the existing
+// style ID.
+func (e *TableExcelize) borderedStyle(existingID int, sides []excelize.Border) (int, error) {
+	return e.derivedStyle(existingID, "border:"+styleKey(sides), func() *excelize.Style {
+		excelStyle, err := e.File.GetStyle(existingID)
+		if excelStyle == nil || err != nil {
+			excelStyle = &excelize.Style{}
+		}
+		excelStyle.Border = append(excelStyle.Border, sides...)
+		return excelStyle
+	})
+}
+
 // HasExistingBorder checks if a cell at the given column and row has any existing border applied on the specified side.
 // Returns true if there is a border style applied, false otherwise.
 func (e *TableExcelize) HasExistingBorder(col, row int, side string) bool {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return false
 	}
@@ -234,70 +250,116 @@ func (e *TableExcelize) HasExistingBorder(col, row int, side string) bool {
 // ApplyStyleToCell applies a style to a cell at the given column and row.
 // The style properties are defined in the style parameter. Existing borders are preserved.
 func (e *TableExcelize) ApplyStyleToCell(col, row int, style Style) error {
-	return e.applyExcelizeStyleToCell(col, row, convertStyleToExcelizeStyle(style))
+	inputStyle := convertStyleToExcelizeStyle(style)
+	return e.applyExcelizeStyleToCell(col, row, inputStyle, styleKey(inputStyle))
 }
 
-// applyExcelizeStyleToCell applies a pre-converted excelize style to a single cell,
-// merging it with any existing style so that borders and other properties are preserved.
-func (e *TableExcelize) applyExcelizeStyleToCell(col, row int, inputStyle *excelize.Style) error {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+// applyExcelizeStyleToCell applies a pre-converted excelize style, identified by key (see
+// styleKey), to a single cell, merging it with any existing style so that borders and other
+// properties are preserved. The resulting style ID is shared by cells holding the same style.
+func (e *TableExcelize) applyExcelizeStyleToCell(col, row int, inputStyle *excelize.Style, key string) error {
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return err
 	}
-
-	// Fast path: if the cell carries no existing style, apply inputStyle directly without
-	// a round-trip to read and decode the default style.
 	existingID, err := e.File.GetCellStyle(e.SheetName, cellRef)
 	if err != nil {
 		return err
 	}
+	styleID, err := e.overlayStyle(existingID, inputStyle, key)
+	if err != nil {
+		return err
+	}
+	if err := e.File.SetCellStyle(e.SheetName, cellRef, cellRef, styleID); err != nil {
+		return err
+	}
+	return e.applyVertAlign(cellRef, inputStyle.Font)
+}
 
-	var finalStyle *excelize.Style
-	if existingID == 0 {
-		// styleID 0 is the excelize default (no style applied); skip the GetStyle round-trip.
-		finalStyle = inputStyle
-	} else {
+// overlayStyle returns the ID of the style resulting from a pre-converted excelize style,
+// identified by key, overlaid on the existing style ID.
+func (e *TableExcelize) overlayStyle(existingID int, inputStyle *excelize.Style, key string) (int, error) {
+	return e.derivedStyle(existingID, "style:"+key, func() *excelize.Style {
+		// Fast path: if the cell carries no existing style, apply inputStyle directly without
+		// a round-trip to read and decode the default style.
+		if existingID == 0 {
+			// styleID 0 is the excelize default (no style applied); skip the GetStyle round-trip.
+			return inputStyle
+		}
 		excelStyle, err := e.File.GetStyle(existingID)
 		if excelStyle == nil || err != nil {
-			finalStyle = inputStyle
-		} else {
-			// Merge: overlay inputStyle properties on top of the existing style so that
-			// borders (and any other properties not covered by inputStyle) are preserved.
-			if inputStyle.Fill.Color != nil {
-				excelStyle.Fill = inputStyle.Fill
-			}
-			if inputStyle.Font != nil {
-				excelStyle.Font = inputStyle.Font
-			}
-			if inputStyle.Alignment != nil {
-				excelStyle.Alignment = inputStyle.Alignment
-			}
-			if inputStyle.CustomNumFmt != nil {
-				excelStyle.CustomNumFmt = inputStyle.CustomNumFmt
-			}
-			finalStyle = excelStyle
+			return inputStyle
 		}
-	}
-
-	styleID, err := e.File.NewStyle(finalStyle)
-	if err != nil {
-		return err
-	}
-	return e.File.SetCellStyle(e.SheetName, cellRef, cellRef, styleID)
+		// Merge: overlay inputStyle properties on top of the existing style so that
+		// borders (and any other properties not covered by inputStyle) are preserved.
+		if inputStyle.Fill.Color != nil {
+			excelStyle.Fill = inputStyle.Fill
+		}
+		if inputStyle.Font != nil {
+			excelStyle.Font = inputStyle.Font
+		}
+		if inputStyle.Alignment != nil {
+			excelStyle.Alignment = inputStyle.Alignment
+		}
+		if inputStyle.CustomNumFmt != nil {
+			excelStyle.CustomNumFmt = inputStyle.CustomNumFmt
+		}
+		if inputStyle.Protection != nil {
+			excelStyle.Protection = inputStyle.Protection
+		}
+		return excelStyle
+	})
 }
 
 // ApplyStyleToRange applies a style to a range of cells defined by start and end coordinates.
-// The style is converted once and then applied to each cell in the range.
+// The style is converted once and merged into the existing style of each cell; consecutive cells
+// of a row holding the same existing style are set with a single call.
 func (e *TableExcelize) ApplyStyleToRange(startCol, startRow, endCol, endRow int, style Style) error {
 	inputStyle := convertStyleToExcelizeStyle(style)
+	key := styleKey(inputStyle)
 	for row := startRow; row <= endRow; row++ {
-		for col := startCol; col <= endCol; col++ {
-			if err := e.applyExcelizeStyleToCell(col, row, inputStyle); err != nil {
-				return err
+		runStart, runID := 0, 0
+		for col := startCol; col <= endCol+1; col++ {
+			existingID := -1 // Past the range end: closes the last run
+			if col <= endCol {
+				cellRef, err := e.cellName(col, row)
+				if err != nil {
+					return err
+				}
+				if existingID, err = e.File.GetCellStyle(e.SheetName, cellRef); err != nil {
+					return err
+				}
+			}
+			if runStart > 0 && existingID == runID {
+				continue
 			}
+			if runStart > 0 {
+				if err := e.setRunStyle(runStart, col-1, row, runID, inputStyle, key); err != nil {
+					return err
+				}
+			}
+			runStart, runID = col, existingID
 		}
 	}
-	return nil
+	return e.applyVertAlignToRange(startCol, startRow, endCol, endRow, inputStyle.Font)
+}
+
+// setRunStyle overlays a pre-converted excelize style on cells startCol to endCol of a row, all
+// holding the existing style ID.
+func (e *TableExcelize) setRunStyle(startCol, endCol, row, existingID int, inputStyle *excelize.Style, key string) error {
+	startRef, err := e.cellName(startCol, row)
+	if err != nil {
+		return err
+	}
+	endRef, err := e.cellName(endCol, row)
+	if err != nil {
+		return err
+	}
+	styleID, err := e.overlayStyle(existingID, inputStyle, key)
+	if err != nil {
+		return err
+	}
+	return e.File.SetCellStyle(e.SheetName, startRef, endRef, styleID)
 }
 
 // GetColumnLetter returns the Excel-style column letter (A, B, C, ...) for a given column number.
@@ -307,10 +369,18 @@ func (e *TableExcelize) GetColumnLetter(col int) string {
 }
 
 // ProcessValue processes and formats a value according to its type and the specified format.
-// Supports basic types, time.Time, and slices. Formats value for Excel export.
+// Supports basic types, time.Time, slices and rich types (see NormalizeValue). Formats value for Excel export.
 // Special formats ExcelizeFormatFormula and ExcelizeFormatHyperlink return the raw string value.
 // ExcelizeFormatDefault returns the raw value without string conversion, preserving its native type.
+// Durations formatted with DurationFormatClock are returned as is, so Excelize writes a native cell.
 func (e *TableExcelize) ProcessValue(value interface{}, format string) (interface{}, error) {
+	if d, ok := nativeDuration(value, format); ok {
+		return d, nil
+	}
+	if text, ok := FormatDuration(value, format); ok {
+		return text, nil
+	}
+	value = NormalizeValue(value)
 	switch v := value.(type) {
 	case []interface{}:
 		if e.Table.ListSeparator != "" {
@@ -348,10 +418,13 @@ func (e *TableExcelize) ProcessValue(value interface{}, format string) (interfac
 	}
 }
 
+// writesNativeCells marks Excelize tables as writing typed values as native cells (see Column.Type).
+func (e *TableExcelize) writesNativeCells() {}
+
 // SetCellFormula sets the formula of a cell at the given column and row.
 // The formula string should be a valid Excel formula, e.g. "=SUM(A1:A10)".
 func (e *TableExcelize) SetCellFormula(col, row int, formula string) error {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return err
 	}
@@ -361,7 +434,7 @@ func (e *TableExcelize) SetCellFormula(col, row int, formula string) error {
 // SetCellHyperLink sets an external hyperlink on a cell at the given column and row.
 // The cell display value is also set to the link URL.
 func (e *TableExcelize) SetCellHyperLink(col, row int, link string) error {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return err
 	}
@@ -376,7 +449,7 @@ func (e *TableExcelize) SetCellHyperLink(col, row int, link string) error {
 // Note: Excelize only supports pictures anchored over cells; the newer "place in cell"
 // (rich-value IMAGE) embedding is not available for writing, so images float over the cell.
 func (e *TableExcelize) SetCellImage(col, row int, img Image) error {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return err
 	}
@@ -404,7 +477,7 @@ func (e *TableExcelize) SetCellImage(col, row int, img Image) error {
 
 // getCellStyle retrieves the style of a cell at the given column and row.
 func (e *TableExcelize) getCellStyle(col, row int) (*excelize.Style, error) {
-	cellRef, err := excelize.CoordinatesToCellName(col, row)
+	cellRef, err := e.cellName(col, row)
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +493,7 @@ func (e *TableExcelize) getCellStyle(col, row int) (*excelize.Style, error) {
 func convertStyleToExcelizeStyle(style Style) *excelize.Style {
 	excelStyle := &excelize.Style{}
 
-	if style.Bold || style.Italic || style.FontSize > 0 || style.FontFamily != "" || style.TextColor != "" {
+	if style.Bold || style.Italic || style.FontSize > 0 || style.FontFamily != "" || style.TextColor != "" || style.Strikethrough || style.VertAlign != VertAlignBaseline {
 		font := &excelize.Font{}
 		if style.Bold {
 			font.Bold = true
@@ -440,6 +513,9 @@ func convertStyleToExcelizeStyle(style Style) *excelize.Style {
 		if style.Underline != "" {
 			font.Underline = style.Underline
 		}
+		font.Strike = style.Strikethrough
+		// Only honoured by rich text runs (see applyVertAlign)
+		font.VertAlign = style.VertAlign.excelVertAlign()
 		excelStyle.Font = font
 	}
 
@@ -451,21 +527,48 @@ func convertStyleToExcelizeStyle(style Style) *excelize.Style {
 		}
 	}
 
-	if style.Alignment != AlignmentNone {
-		horizontal, vertical := style.Alignment.GetAlignmentValues()
-		excelStyle.Alignment = &excelize.Alignment{
-			Horizontal: horizontal,
-			Vertical:   vertical,
+	if style.Alignment != AlignmentNone || style.WrapText || style.ShrinkToFit || style.TextRotation != 0 || style.TextDirection != TextDirectionContext || style.IndentLevel > 0 {
+		alignment := &excelize.Alignment{
+			WrapText:     style.WrapText,
+			ShrinkToFit:  style.ShrinkToFit,
+			TextRotation: excelTextRotation(style.TextRotation),
+			ReadingOrder: style.TextDirection.excelReadingOrder(),
+			Indent:       min(max(style.IndentLevel, 0), maxExcelIndent),
+		}
+		if style.Alignment != AlignmentNone {
+			alignment.Horizontal, alignment.Vertical = style.Alignment.GetAlignmentValues()
+		} else if alignment.Indent > 0 {
+			// Excel only indents left, right or distributed text
+			alignment.Horizontal = "left"
 		}
+		excelStyle.Alignment = alignment
 	}
 
 	if style.NumFmt != "" {
 		excelStyle.CustomNumFmt = &style.NumFmt
 	}
 
+	if style.Locked != nil || style.HiddenFormula {
+		// Cells are locked unless unlocked explicitly, including those only hiding their formula
+		excelStyle.Protection = &excelize.Protection{Locked: style.Locked == nil || *style.Locked, Hidden: style.HiddenFormula}
+	}
+
 	return excelStyle
 }
 
+// maxExcelIndent is the highest indent level Excel accepts.
+const maxExcelIndent = 250
+
+// excelTextRotation converts a rotation in degrees (counterclockwise, -90 to 90) to the Excel text
+// rotation, which encodes clockwise rotations as 91 to 180. Out of range rotations are clamped.
+func excelTextRotation(degrees int) int {
+	degrees = min(max(degrees, -90), 90)
+	if degrees < 0 {
+		return 90 - degrees
+	}
+	return degrees
+}
+
 // isCellInRange checks if a cell reference is within a given range defined by start and end references.
 // Returns true if the cell is in range, false otherwise.
 func isCellInRange(cellRef, startRef, endRef string) bool {
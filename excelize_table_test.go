@@ -529,6 +529,35 @@ func TestTableExcelize_applyBorderToCell(t *testing.T) {
 	}
 }
 
+func TestTableExcelize_applyBorderToCell_colorAndDiagonal(t *testing.T) {
+	file := excelize.NewFile()
+	defer func() { _ = file.Close() }()
+	tableExcel := NewTableExcelize("Sheet1", &Table{}).WithFile(file)
+
+	if err := tableExcel.ApplyBorderToCell(1, 1, "bottom", NewBorder(BorderStyleThin).WithColor("#FF0000")); err != nil {
+		t.Fatalf("ApplyBorderToCell(bottom) error = %v", err)
+	}
+	if err := tableExcel.ApplyBorderToCell(1, 1, "diagonalDown", NewBorder(BorderStyleThin)); err != nil {
+		t.Fatalf("ApplyBorderToCell(diagonalDown) error = %v", err)
+	}
+
+	styleID, _ := file.GetCellStyle("Sheet1", "A1")
+	style, err := file.GetStyle(styleID)
+	if err != nil {
+		t.Fatalf("GetStyle() error = %v", err)
+	}
+	colors := map[string]string{}
+	for _, border := range style.Border {
+		colors[border.Type] = border.Color
+	}
+	if colors["bottom"] != "FF0000" {
+		t.Errorf("bottom border color = %q, want FF0000", colors["bottom"])
+	}
+	if colors["diagonalDown"] != "000000" {
+		t.Errorf("diagonal down border color = %q, want the default black", colors["diagonalDown"])
+	}
+}
+
 // Add comprehensive test for ApplyBorderToCell error handling
 func TestTableExcelize_applyBorderToCell_ErrorHandling(t *testing.T) {
 	file := excelize.NewFile()
@@ -1923,3 +1952,29 @@ func TestTableExcelize_ProcessValue_ExcelizeFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertStyleToExcelizeStyle_textControl(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  *excelize.Alignment
+	}{
+		{"none", Style{Bold: true}, nil},
+		{"wrap", Style{WrapText: true}, &excelize.Alignment{WrapText: true}},
+		{"shrink with alignment", Style{ShrinkToFit: true, Alignment: AlignmentCenter}, &excelize.Alignment{ShrinkToFit: true, Horizontal: "center", Vertical: "top"}},
+		{"rotation up", Style{TextRotation: 45}, &excelize.Alignment{TextRotation: 45}},
+		{"rotation down", Style{TextRotation: -45}, &excelize.Alignment{TextRotation: 135}},
+		{"rotation clamped", Style{TextRotation: -120}, &excelize.Alignment{TextRotation: 180}},
+		{"indent", Style{IndentLevel: 2}, &excelize.Alignment{Indent: 2, Horizontal: "left"}},
+		{"indent with alignment", Style{IndentLevel: 1, Alignment: AlignmentRightMiddle}, &excelize.Alignment{Indent: 1, Horizontal: "right", Vertical: "center"}},
+		{"indent clamped", Style{IndentLevel: 300}, &excelize.Alignment{Indent: 250, Horizontal: "left"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertStyleToExcelizeStyle(tt.style).Alignment
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Errorf("Alignment = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
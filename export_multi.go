@@ -0,0 +1,112 @@
+// export_multi.go - Multi-format export.
+//
+// This file implements ExportMulti, which writes the same table to several formats in one call
+// (e.g. a report shipped as both CSV and XLSX). The table is prepared and its raw values normalized
+// once, then the snapshot is shared by every format. Formatted values and merged ranges still
+// depend on the output, so each format computes its own from the snapshot.
+
+package spit
+
+import "fmt"
+
+// MultiExportParams contains the parameters of an ExportMulti run.
+// FileWriteParams apply to every format; the Extension is set per format.
+type MultiExportParams struct {
+	FileWriteParams
+	CSVSeparator string      // CSV field separator (default: ",")
+	HTMLOptions  HTMLOptions // Options of the HTML export
+	SheetName    string      // XLSX sheet name (default: "Sheet1")
+}
+
+// ExportMulti writes the table to each of the given formats and returns the results by format.
+// The table is prepared (see Table.Prepare) and its raw values normalized (see NormalizeValue)
+// once for all formats; each format formats the values and computes the merged ranges itself. Duplicate formats are written once; the first failing format aborts the run,
+// leaving the files already written in place.
+func ExportMulti(t *Table, targets []Format, params MultiExportParams) (map[Format]*FileWriteResult, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no table provided")
+	}
+	for _, format := range targets {
+		if !format.exportable() {
+			return nil, fmt.Errorf("unsupported export format: %s", format)
+		}
+	}
+
+	// Every format shares the seed of the run, as the table is prepared once
+	params.Seed = params.resolveSeed()
+	// Every format counts against the limits of the run
+	params.quota = params.resolveQuota()
+	// Every format reports to the progress of the run
+	params.progress = params.resolveProgress()
+	// Every format adds up to the timings of the run
+	params.timings = params.resolveTimings()
+
+	// Shallow copy so the caller's table never keeps the snapshot; chunked tables are split and
+	// prepared by each format
+	prepared := params.timings.measure(timingPrepare)
+	run := *t
+	if t.chunks() == nil {
+		run.prepared = t.withSeed(params.Seed).Prepare().snapshot()
+	}
+	prepared()
+
+	results := make(map[Format]*FileWriteResult, len(targets))
+	for _, format := range targets {
+		if _, done := results[format]; done {
+			continue
+		}
+		fileParams := params.FileWriteParams
+		fileParams.Extension = format.String()
+
+		result, err := run.exportFormat(format, params, fileParams)
+		if err != nil {
+			return results, fmt.Errorf("failed to export %s: %w", format, err)
+		}
+		results[format] = result
+	}
+	return results, nil
+}
+
+// exportFormat writes the table to a single format.
+func (t *Table) exportFormat(format Format, params MultiExportParams, fileParams FileWriteParams) (*FileWriteResult, error) {
+	switch format {
+	case FormatCSV:
+		separator := params.CSVSeparator
+		if separator == "" {
+			separator = ","
+		}
+		return ExportCSV(separator, t, fileParams)
+	case FormatXSLX:
+		sheetName := params.SheetName
+		if sheetName == "" {
+			sheetName = "Sheet1"
+		}
+		return ExportXLSX(NewSpreadsheetExcelize(sheetName, t), fileParams)
+	case FormatHTML:
+		return ExportHTML(t, params.HTMLOptions, fileParams)
+	case FormatParquet:
+		return ExportParquet(t, fileParams)
+	case FormatMarkdown:
+		return ExportMarkdown(t, fileParams)
+	}
+	if name, e, ok := registeredExporter(format); ok {
+		return t.exportWith(name, e, fileParams)
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", format)
+}
+
+// snapshot returns a shallow copy of the table whose data rows hold normalized values, so the
+// rows are converted once rather than by every backend. The original rows are left untouched.
+func (t *Table) snapshot() *Table {
+	s := *t
+	s.prepared = nil
+	s.Data = make(DataSlice, len(t.Data))
+	for i, item := range t.Data {
+		row := make(Data, len(item))
+		for k, v := range item {
+			row[k] = NormalizeValue(v)
+		}
+		s.Data[i] = row
+	}
+	return &s
+}
@@ -0,0 +1,77 @@
+package spit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportMulti(t *testing.T) {
+	data := DataSlice{
+		{"name": "Alice", "status": testStatus(0)},
+		{"name": "Bob", "status": testStatus(1)},
+	}
+	table := NewTable(data, Columns{NewColumn("name", "Name"), NewColumn("status", "Status")}, true)
+	dir := t.TempDir()
+
+	results, err := ExportMulti(table, []Format{FormatCSV, FormatXSLX, FormatHTML, FormatCSV}, MultiExportParams{
+		FileWriteParams: FileWriteParams{Filename: "report", Filepath: dir, OverwriteFile: true},
+		CSVSeparator:    ";",
+		SheetName:       "Report",
+	})
+	if err != nil {
+		t.Fatalf("ExportMulti failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for format, result := range results {
+		if filepath.Ext(result.Filepath) != "."+format.String() {
+			t.Errorf("%s written to %q", format, result.Filepath)
+		}
+	}
+	if table.prepared != nil {
+		t.Error("ExportMulti should not leave a snapshot on the caller's table")
+	}
+	if _, ok := data[0]["status"].(testStatus); !ok {
+		t.Error("ExportMulti should not alter the caller's data")
+	}
+
+	csvContent, err := os.ReadFile(results[FormatCSV].Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(csvContent), "Alice;open") {
+		t.Errorf("unexpected CSV content:\n%s", csvContent)
+	}
+
+	f, err := excelize.OpenFile(results[FormatXSLX].Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if got, _ := f.GetCellValue("Report", "B3"); got != "closed" {
+		t.Errorf("Report!B3 = %q, want closed", got)
+	}
+
+	htmlContent, err := os.ReadFile(results[FormatHTML].Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(htmlContent), "Bob") {
+		t.Error("expected HTML to contain the data rows")
+	}
+}
+
+func TestExportMulti_errors(t *testing.T) {
+	params := MultiExportParams{FileWriteParams: FileWriteParams{Filename: "report", Filepath: t.TempDir()}}
+	if _, err := ExportMulti(nil, []Format{FormatCSV}, params); err == nil {
+		t.Error("expected an error for a nil table")
+	}
+	if _, err := ExportMulti(NewTable(nil, nil, true), []Format{FormatUnknown}, params); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,210 @@
+// exporter.go - Pluggable export formats.
+//
+// This file implements a registry of third-party export formats. An external package implements
+// Exporter (e.g. a proprietary fixed-width format) and registers it under a name; ExportFormat then
+// exports tables to it like a built-in format, with the same preparation, file handling (temp
+// files, gzip, sinks, chunking), warnings, seed and resource limits. Exporters implementing
+// CapabilityReporter receive tables degraded to the features they support.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Exporter writes a table in a third-party format (see RegisterFormat).
+type Exporter interface {
+	// Write writes the table to w. The table is prepared (see Table.Prepare) and reports its
+	// warnings to the run; params are those of the export, with the seed resolved. Returning an
+	// error aborts the export. Exporters may implement CapabilityReporter to receive tables whose
+	// unsupported features are degraded (see Capabilities).
+	Write(table *Table, w io.Writer, params FileWriteParams) error
+}
+
+// ExporterFunc adapts a function to the Exporter interface.
+type ExporterFunc func(table *Table, w io.Writer, params FileWriteParams) error
+
+// Write calls f(table, w, params).
+func (f ExporterFunc) Write(table *Table, w io.Writer, params FileWriteParams) error {
+	return f(table, w, params)
+}
+
+// formatCustomBase is the first Format value assigned to registered formats, leaving room for
+// built-in formats below it.
+const formatCustomBase Format = 128
+
+var (
+	_exporters     = map[string]Exporter{} // Registered exporters by lowercase name
+	_customFormats = map[string]Format{}   // Format values assigned to registered names, kept once unregistered
+	_exportersMu   sync.RWMutex
+)
+
+// RegisterFormat registers an exporter under a format name, used by ExportFormat and as the
+// default file extension. Names are case-insensitive; registering an existing name replaces the
+// previous exporter. The name must not be empty nor name a built-in format (see ParseFormat).
+// The name is assigned a Format value (see LookupFormat), so Export dispatches to it too.
+func RegisterFormat(name string, e Exporter) error {
+	if name == "" {
+		return fmt.Errorf("format name cannot be empty")
+	}
+	if e == nil {
+		return fmt.Errorf("exporter of format %q cannot be nil", name)
+	}
+	if _, err := ParseFormat(name); err == nil {
+		return fmt.Errorf("format name %q is reserved", name)
+	}
+
+	key := strings.ToLower(name)
+	_exportersMu.Lock()
+	defer _exportersMu.Unlock()
+	if _, ok := _customFormats[key]; !ok {
+		if len(_customFormats) > int(^Format(0)-formatCustomBase) {
+			return fmt.Errorf("too many registered formats to register %q", name)
+		}
+		_customFormats[key] = formatCustomBase + Format(len(_customFormats))
+	}
+	_exporters[key] = e
+	return nil
+}
+
+// UnregisterFormat removes a registered format. Unknown names are ignored.
+func UnregisterFormat(name string) {
+	_exportersMu.Lock()
+	defer _exportersMu.Unlock()
+	delete(_exporters, strings.ToLower(name))
+}
+
+// LookupExporter returns the exporter registered under a format name.
+func LookupExporter(name string) (Exporter, bool) {
+	_exportersMu.RLock()
+	defer _exportersMu.RUnlock()
+	e, ok := _exporters[strings.ToLower(name)]
+	return e, ok
+}
+
+// LookupFormat returns the Format value assigned to a registered format name.
+func LookupFormat(name string) (Format, bool) {
+	_exportersMu.RLock()
+	defer _exportersMu.RUnlock()
+	format, ok := _customFormats[strings.ToLower(name)]
+	if ok {
+		_, ok = _exporters[strings.ToLower(name)]
+	}
+	return format, ok
+}
+
+// registeredName returns the name of a registered format value.
+func registeredName(format Format) (string, bool) {
+	if format < formatCustomBase {
+		return "", false
+	}
+	_exportersMu.RLock()
+	defer _exportersMu.RUnlock()
+	for name, f := range _customFormats {
+		if f == format {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// registeredExporter returns the exporter of a registered format value.
+func registeredExporter(format Format) (string, Exporter, bool) {
+	name, ok := registeredName(format)
+	if !ok {
+		return "", nil, false
+	}
+	e, ok := LookupExporter(name)
+	return name, e, ok
+}
+
+// exportable reports whether the format can be written to a file: a built-in file format or a
+// registered format.
+func (f Format) exportable() bool {
+	if _, _, ok := registeredExporter(f); ok {
+		return true
+	}
+	_, ok := formats[f]
+	return ok && f != FormatGoogleSheets
+}
+
+// Export exports the table to a built-in file format with its default options (e.g. FormatCSV,
+// FormatXSLX) or to a registered format (see LookupFormat), so callers need not branch on the
+// format themselves.
+func Export(format Format, t *Table, params FileWriteParams) (*FileWriteResult, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no table provided")
+	}
+	if !format.exportable() {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+	return t.exportFormat(format, MultiExportParams{}, params)
+}
+
+// ExportFormat exports the table to the format registered under name, or to a built-in format
+// with its default options (e.g. "csv", "xlsx"; see ParseFormat). The registered name is used as
+// extension when params.Extension is empty.
+func ExportFormat(name string, t *Table, params FileWriteParams) (*FileWriteResult, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no table provided")
+	}
+	e, ok := LookupExporter(name)
+	if !ok {
+		format, err := ParseFormat(name)
+		if err != nil || format == FormatGoogleSheets {
+			return nil, fmt.Errorf("unsupported export format: %s", name)
+		}
+		return t.exportFormat(format, MultiExportParams{}, params)
+	}
+	return t.exportWith(name, e, params)
+}
+
+// exportWith exports the table through a registered exporter.
+func (t *Table) exportWith(name string, e Exporter, params FileWriteParams) (*FileWriteResult, error) {
+	if params.Extension == "" {
+		params.Extension = strings.ToLower(name)
+	}
+	if parts := t.chunks(); parts != nil {
+		return exportParts(parts, params, func(part *Table, params FileWriteParams) (*FileWriteResult, error) {
+			return part.exportWith(name, e, params)
+		})
+	}
+
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.timings = params.resolveTimings()
+	prepared := params.timings.measure(timingPrepare)
+	table := t.withSeed(params.Seed).Prepare().withoutHidden().withWarnings("", params.OnWarning, params.Logger).degrade(capabilitiesOf(e, AllCapabilities()))
+	prepared()
+	if err := params.quota.addTable(table); err != nil {
+		return nil, err
+	}
+	params.progress.track(table, progressPhasesFlat)
+
+	params.logger().Info("Starting export to file", String("format", name), String("filename", params.Filename))
+
+	result, err := params.WriteToFile(func(writer io.Writer) error {
+		if err := e.Write(table, writer, params); err != nil {
+			return err
+		}
+		if err := table.strictErr(); err != nil {
+			return err
+		}
+		// Registered exporters write rows on their own: their steps complete once written
+		table.progress.finish()
+		return nil
+	})
+	if err != nil {
+		params.logger().Error("Failed to write export to file", String("format", name), Error(err))
+		return nil, fmt.Errorf("failed to export %s: %w", name, err)
+	}
+
+	result.Warnings = table.exportWarnings()
+	result.SparseColumns = table.sparseColumns("")
+
+	params.logger().Info("Export completed", String("format", name), String("filename", params.Filename))
+	return result, nil
+}
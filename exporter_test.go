@@ -0,0 +1,184 @@
+package spit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// pipeExporter writes the leaf column names then one pipe-separated line per data row.
+var pipeExporter = ExporterFunc(func(table *Table, w io.Writer, params FileWriteParams) error {
+	var names []string
+	for _, column := range table.Columns.GetFlattenedColumns() {
+		names = append(names, column.Name)
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(names, "|")); err != nil {
+		return err
+	}
+	for _, item := range table.Data {
+		var values []string
+		for _, column := range table.Columns.GetFlattenedColumns() {
+			value, _, _ := item.LookupColumn(column)
+			values = append(values, fmt.Sprintf("%v", value))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(values, "|")); err != nil {
+			return err
+		}
+	}
+	return nil
+})
+
+func TestRegisterFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		exporter Exporter
+		wantErr  bool
+	}{
+		{"valid", "pipe", pipeExporter, false},
+		{"empty name", "", pipeExporter, true},
+		{"nil exporter", "pipe", nil, true},
+		{"built-in name", "CSV", pipeExporter, true},
+		{"built-in constant", "FormatXLSX", pipeExporter, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer UnregisterFormat(tt.format)
+			err := RegisterFormat(tt.format, tt.exporter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := LookupExporter(strings.ToUpper(tt.format)); ok == tt.wantErr {
+				t.Errorf("LookupExporter() found = %v, want %v", ok, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportFormat(t *testing.T) {
+	if err := RegisterFormat("Pipe", pipeExporter); err != nil {
+		t.Fatalf("RegisterFormat failed: %v", err)
+	}
+	defer UnregisterFormat("pipe")
+
+	table := NewTable(DataSlice{{"a": 1, "b": "x"}, {"a": 2, "b": "y"}},
+		Columns{NewColumn("a", "A"), NewColumn("total", "Total").WithValueExpr("a * 10")}, true)
+
+	t.Run("registered format", func(t *testing.T) {
+		res, err := ExportFormat("pipe", table, FileWriteParams{Filename: "report", Filepath: t.TempDir()})
+		if err != nil {
+			t.Fatalf("ExportFormat failed: %v", err)
+		}
+		if res.Filename != "report.pipe" {
+			t.Errorf("Filename = %q, want report.pipe", res.Filename)
+		}
+		content, err := os.ReadFile(res.Filepath)
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if want := "a|total\n1|10\n2|20\n"; string(content) != want {
+			t.Errorf("content = %q, want %q", content, want)
+		}
+		if res.Usage.Rows != 2 || res.Usage.Bytes != int64(len(content)) {
+			t.Errorf("usage = %+v, want 2 rows and %d bytes", res.Usage, len(content))
+		}
+	})
+
+	t.Run("built-in format", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := ExportFormat("csv", table, FileWriteParams{Filename: "report", Writer: &buf}); err != nil {
+			t.Fatalf("ExportFormat failed: %v", err)
+		}
+		if want := "A,Total\n1,10\n2,20\n"; buf.String() != want {
+			t.Errorf("content = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("exporter error", func(t *testing.T) {
+		failure := errors.New("boom")
+		if err := RegisterFormat("broken", ExporterFunc(func(*Table, io.Writer, FileWriteParams) error { return failure })); err != nil {
+			t.Fatalf("RegisterFormat failed: %v", err)
+		}
+		defer UnregisterFormat("broken")
+		_, err := ExportFormat("broken", table, FileWriteParams{Filename: "report", Writer: io.Discard})
+		if !errors.Is(err, failure) {
+			t.Errorf("error = %v, want %v", err, failure)
+		}
+	})
+
+	t.Run("unsupported formats", func(t *testing.T) {
+		for _, name := range []string{"unknown", "gsheets"} {
+			if _, err := ExportFormat(name, table, FileWriteParams{Filename: "report", Writer: io.Discard}); err == nil {
+				t.Errorf("ExportFormat(%q) succeeded, want an error", name)
+			}
+		}
+	})
+}
+
+func TestExport(t *testing.T) {
+	if err := RegisterFormat("Pipe", pipeExporter); err != nil {
+		t.Fatalf("RegisterFormat failed: %v", err)
+	}
+	defer UnregisterFormat("pipe")
+
+	pipe, ok := LookupFormat("PIPE")
+	if !ok {
+		t.Fatal("LookupFormat() found no format for a registered name")
+	}
+	if pipe.String() != "pipe" {
+		t.Errorf("String() = %q, want pipe", pipe.String())
+	}
+	if err := RegisterFormat("pipe", pipeExporter); err != nil {
+		t.Fatalf("RegisterFormat failed: %v", err)
+	}
+	if again, _ := LookupFormat("pipe"); again != pipe {
+		t.Errorf("re-registered format = %v, want %v", again, pipe)
+	}
+
+	table := NewTable(DataSlice{{"a": 1}, {"a": 2}}, Columns{NewColumn("a", "A")}, true)
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{"built-in format", FormatCSV, "A\n1\n2\n"},
+		{"registered format", pipe, "a\n1\n2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := Export(tt.format, table, FileWriteParams{Filename: "report", Writer: &buf}); err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("content = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run("multi-format export", func(t *testing.T) {
+		results, err := ExportMulti(table, []Format{FormatCSV, pipe}, MultiExportParams{FileWriteParams: FileWriteParams{Filename: "report", Filepath: t.TempDir()}})
+		if err != nil {
+			t.Fatalf("ExportMulti failed: %v", err)
+		}
+		if res := results[pipe]; res == nil || res.Filename != "report.pipe" {
+			t.Errorf("result = %+v, want report.pipe", res)
+		}
+	})
+
+	t.Run("unsupported formats", func(t *testing.T) {
+		UnregisterFormat("pipe")
+		for _, format := range []Format{FormatUnknown, FormatGoogleSheets, pipe} {
+			if _, err := Export(format, table, FileWriteParams{Filename: "report", Writer: io.Discard}); err == nil {
+				t.Errorf("Export(%v) succeeded, want an error", format)
+			}
+		}
+		if _, ok := LookupFormat("pipe"); ok {
+			t.Error("LookupFormat() found an unregistered format")
+		}
+	})
+}
@@ -0,0 +1,866 @@
+// expr.go - Expression language.
+//
+// This file implements a small expression language for report definitions loaded from YAML or
+// JSON, where logic cannot be written in Go: computed columns (Column.ValueExpr), style rules
+// (Column.WithStyleRule) and validations (Column.WithValidation). Expressions read the fields of a
+// data row by name, e.g. "amount * qty", "status == 'late'" or "value >= 0 && value <= 100".
+//
+// Supported syntax, by increasing precedence:
+//
+//	a || b, a or b                  logical or (short-circuit)
+//	a && b, a and b                 logical and (short-circuit)
+//	!a, not a                       logical not
+//	== != < <= > >=                 comparisons (numeric when both sides are numbers or numeric strings)
+//	+ -                             addition and subtraction; + concatenates when a side is a string
+//	* / %                           multiplication, division and remainder
+//	-a                              negation
+//	literals, fields, calls, (a)    42, 1.5, 'text', "text", true, false, null, amount, customer.name, upper(name)
+//
+// Fields are looked up like column names (nested paths included, see Data.LookupKey); missing
+// fields are null. Arithmetic with null, and division by zero, give null. In style rules and
+// validations, value is the value of the cell being checked. Functions: len, lower, upper, trim,
+// contains, startsWith, endsWith, abs, round, min, max, coalesce, if and field (looks up any key,
+// e.g. field("unit price")).
+
+package spit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Expr is a parsed expression, safe for concurrent use.
+type Expr struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpr parses an expression (see the syntax described at the top of expr.go).
+func ParseExpr(source string) (*Expr, error) {
+	p := &exprParser{source: source}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// exprCache holds the expressions parsed by compileExpr, by source.
+var exprCache sync.Map
+
+// compileExpr parses an expression once and reuses it afterwards.
+func compileExpr(source string) (*Expr, error) {
+	if cached, ok := exprCache.Load(source); ok {
+		return cached.(*Expr), nil
+	}
+	e, err := ParseExpr(source)
+	if err != nil {
+		return nil, err
+	}
+	exprCache.Store(source, e)
+	return e, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression against a data row.
+func (e *Expr) Eval(row Data) (interface{}, error) {
+	return e.eval(&exprEnv{row: row})
+}
+
+// EvalBool evaluates the expression against a data row and returns its truth value: null, false,
+// zero and empty strings are false, anything else is true.
+func (e *Expr) EvalBool(row Data) (bool, error) {
+	v, err := e.Eval(row)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v), nil
+}
+
+// evalCell evaluates the expression for a cell, value being the cell value.
+func (e *Expr) evalCell(row Data, value interface{}) (interface{}, error) {
+	return e.eval(&exprEnv{row: row, value: value, hasValue: true})
+}
+
+// eval evaluates the expression, wrapping errors with its source.
+func (e *Expr) eval(env *exprEnv) (interface{}, error) {
+	v, err := e.root.eval(env)
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", e.source, err)
+	}
+	return v, nil
+}
+
+// exprEnv is the evaluation context of an expression.
+type exprEnv struct {
+	row      Data
+	value    interface{} // Value of the cell being checked (style rules, validations)
+	hasValue bool        // Whether value is set; otherwise "value" is looked up as a field
+}
+
+// lookup returns the value of a field of the row, or null when missing.
+func (env *exprEnv) lookup(name string) (interface{}, error) {
+	if name == "value" && env.hasValue {
+		return env.value, nil
+	}
+	v, err, found := env.row.LookupKey(name)
+	if err != nil || !found {
+		return nil, err
+	}
+	return NormalizeValue(v), nil
+}
+
+// Parsing
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string      // Source text (operators, identifiers) or unquoted string
+	num  interface{} // Parsed number (int64 or float64)
+	pos  int         // Byte offset in the source
+}
+
+// exprParser is a recursive-descent parser over the tokens of an expression.
+type exprParser struct {
+	source string
+	pos    int
+	tok    token
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("expression %q: %s at offset %d", p.source, fmt.Sprintf(format, args...), p.tok.pos)
+}
+
+// exprOperators lists the operators, two-character ones first.
+var exprOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", ","}
+
+// next reads the next token.
+func (p *exprParser) next() error {
+	for p.pos < len(p.source) && unicode.IsSpace(rune(p.source[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.source) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	c := p.source[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.' && p.pos+1 < len(p.source) && isDigit(p.source[p.pos+1]):
+		for p.pos < len(p.source) && (isDigit(p.source[p.pos]) || p.source[p.pos] == '.' ||
+			p.source[p.pos] == 'e' || p.source[p.pos] == 'E' ||
+			(p.source[p.pos] == '-' || p.source[p.pos] == '+') && (p.source[p.pos-1] == 'e' || p.source[p.pos-1] == 'E')) {
+			p.pos++
+		}
+		text := p.source[start:p.pos]
+		p.tok = token{kind: tokenNumber, text: text, pos: start}
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			p.tok.num = i
+		} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+			p.tok.num = f
+		} else {
+			return p.errorf("invalid number %q", text)
+		}
+		return nil
+	case c == '\'' || c == '"':
+		var b strings.Builder
+		for p.pos++; p.pos < len(p.source); p.pos++ {
+			switch ch := p.source[p.pos]; {
+			case ch == c:
+				p.pos++
+				p.tok = token{kind: tokenString, text: b.String(), pos: start}
+				return nil
+			case ch == '\\' && p.pos+1 < len(p.source):
+				p.pos++
+				switch escaped := p.source[p.pos]; escaped {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(escaped)
+				}
+			default:
+				b.WriteByte(ch)
+			}
+		}
+		p.tok = token{pos: start}
+		return p.errorf("unterminated string")
+	case c == '_' || c >= 0x80 || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.source) {
+			r, size := utf8.DecodeRuneInString(p.source[p.pos:])
+			if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			p.pos += size
+		}
+		if p.pos > start {
+			p.tok = token{kind: tokenIdent, text: p.source[start:p.pos], pos: start}
+			return nil
+		}
+	}
+	for _, op := range exprOperators {
+		if strings.HasPrefix(p.source[p.pos:], op) {
+			p.pos += len(op)
+			p.tok = token{kind: tokenOperator, text: op, pos: start}
+			return nil
+		}
+	}
+	p.tok = token{pos: start}
+	return p.errorf("unexpected character %q", c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// accept consumes the current token when it is one of the given operators or keywords.
+func (p *exprParser) accept(texts ...string) (string, bool, error) {
+	if p.tok.kind != tokenOperator && p.tok.kind != tokenIdent {
+		return "", false, nil
+	}
+	for _, text := range texts {
+		if p.tok.text == text {
+			return text, true, p.next()
+		}
+	}
+	return "", false, nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, ok, err := p.accept("||", "or")
+		if err != nil || !ok {
+			return left, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{or: true, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, ok, err := p.accept("&&", "and")
+		if err != nil || !ok {
+			return left, err
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if _, ok, err := p.accept("!", "not"); err != nil || ok {
+		if err != nil {
+			return nil, err
+		}
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	op, ok, err := p.accept("==", "!=", "<=", ">=", "<", ">")
+	if err != nil || !ok {
+		return left, err
+	}
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok, err := p.accept("+", "-")
+		if err != nil || !ok {
+			return left, err
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = arithmeticNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok, err := p.accept("*", "/", "%")
+		if err != nil || !ok {
+			return left, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = arithmeticNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if _, ok, err := p.accept("-"); err != nil || ok {
+		if err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return arithmeticNode{op: "-", left: literalNode{int64(0)}, right: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		return literalNode{tok.num}, p.next()
+	case tokenString:
+		return literalNode{tok.text}, p.next()
+	case tokenIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "true":
+			return literalNode{true}, nil
+		case "false":
+			return literalNode{false}, nil
+		case "null", "nil":
+			return literalNode{nil}, nil
+		}
+		if _, ok, err := p.accept("("); err != nil || !ok {
+			return fieldNode{tok.text}, err
+		}
+		return p.parseCall(tok)
+	case tokenOperator:
+		if tok.text == "(" {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok, err := p.accept(")"); err != nil || !ok {
+				if err == nil {
+					err = p.errorf("expected \")\"")
+				}
+				return nil, err
+			}
+			return inner, nil
+		}
+	case tokenEOF:
+		return nil, p.errorf("unexpected end of expression")
+	}
+	return nil, p.errorf("unexpected %q", tok.text)
+}
+
+// parseCall parses the arguments of a function call, the opening parenthesis being consumed.
+func (p *exprParser) parseCall(name token) (exprNode, error) {
+	fn, ok := exprFunctions[name.text]
+	if !ok {
+		p.tok.pos = name.pos
+		return nil, p.errorf("unknown function %q", name.text)
+	}
+	var args []exprNode
+	if _, closed, err := p.accept(")"); err != nil || closed {
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok, err := p.accept(","); err != nil || !ok {
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		if _, ok, err := p.accept(")"); err != nil || !ok {
+			if err == nil {
+				err = p.errorf("expected \")\"")
+			}
+			return nil, err
+		}
+	}
+	if len(args) < fn.minArgs || fn.maxArgs >= 0 && len(args) > fn.maxArgs {
+		p.tok.pos = name.pos
+		return nil, p.errorf("wrong number of arguments for %s: %d", name.text, len(args))
+	}
+	return callNode{name: name.text, fn: fn, args: args}, nil
+}
+
+// Evaluation
+
+type exprNode interface {
+	eval(env *exprEnv) (interface{}, error)
+}
+
+type literalNode struct{ value interface{} }
+
+func (n literalNode) eval(*exprEnv) (interface{}, error) { return n.value, nil }
+
+type fieldNode struct{ name string }
+
+func (n fieldNode) eval(env *exprEnv) (interface{}, error) { return env.lookup(n.name) }
+
+type notNode struct{ operand exprNode }
+
+func (n notNode) eval(env *exprEnv) (interface{}, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(v), nil
+}
+
+type logicalNode struct {
+	or          bool
+	left, right exprNode
+}
+
+func (n logicalNode) eval(env *exprEnv) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if truthy(left) == n.or {
+		return n.or, nil
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(right), nil
+}
+
+type compareNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n compareNode) eval(env *exprEnv) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(left, right), nil
+	case "!=":
+		return !exprEqual(left, right), nil
+	}
+	cmp, ok := exprCompare(left, right)
+	if !ok {
+		return false, nil
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+type arithmeticNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n arithmeticNode) eval(env *exprEnv) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	if n.op == "+" {
+		_, leftString := left.(string)
+		_, rightString := right.(string)
+		if leftString || rightString {
+			return exprString(left) + exprString(right), nil
+		}
+	}
+
+	li, leftInt := exprInt(left)
+	ri, rightInt := exprInt(right)
+	if leftInt && rightInt && n.op != "/" {
+		switch n.op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		case "%":
+			if ri == 0 {
+				return nil, nil
+			}
+			return li % ri, nil
+		}
+	}
+
+	lf, ok := toFloat(left)
+	if !ok {
+		return nil, fmt.Errorf("cannot apply %s to %v", n.op, left)
+	}
+	rf, ok := toFloat(right)
+	if !ok {
+		return nil, fmt.Errorf("cannot apply %s to %v", n.op, right)
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	}
+	if rf == 0 {
+		return nil, nil
+	}
+	if n.op == "%" {
+		return math.Mod(lf, rf), nil
+	}
+	return lf / rf, nil
+}
+
+// exprFunction is a built-in function. Arguments are evaluated by call unless lazy is set.
+type exprFunction struct {
+	minArgs, maxArgs int // maxArgs -1 = variadic
+	lazy             func(env *exprEnv, args []exprNode) (interface{}, error)
+	call             func(args []interface{}) (interface{}, error)
+}
+
+type callNode struct {
+	name string
+	fn   exprFunction
+	args []exprNode
+}
+
+func (n callNode) eval(env *exprEnv) (interface{}, error) {
+	if n.fn.lazy != nil {
+		return n.fn.lazy(env, n.args)
+	}
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn.call(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return v, nil
+}
+
+// stringFunction wraps a string transformation; null stays null.
+func stringFunction(transform func(string) string) exprFunction {
+	return exprFunction{minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return transform(exprString(args[0])), nil
+	}}
+}
+
+// stringPredicate wraps a test between two strings.
+func stringPredicate(test func(s, substr string) bool) exprFunction {
+	return exprFunction{minArgs: 2, maxArgs: 2, call: func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return false, nil
+		}
+		return test(exprString(args[0]), exprString(args[1])), nil
+	}}
+}
+
+// exprExtremum returns the smallest (sign -1) or largest (sign 1) non-null argument.
+func exprExtremum(sign int) exprFunction {
+	return exprFunction{minArgs: 1, maxArgs: -1, call: func(args []interface{}) (interface{}, error) {
+		var result interface{}
+		for _, arg := range args {
+			if arg == nil {
+				continue
+			}
+			if result == nil {
+				result = arg
+				continue
+			}
+			cmp, ok := exprCompare(arg, result)
+			if !ok {
+				return nil, fmt.Errorf("cannot compare %v and %v", arg, result)
+			}
+			if cmp*sign > 0 {
+				result = arg
+			}
+		}
+		return result, nil
+	}}
+}
+
+// exprFunctions lists the built-in functions.
+var exprFunctions map[string]exprFunction
+
+func init() {
+	exprFunctions = map[string]exprFunction{
+		"len": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			switch v := args[0].(type) {
+			case nil:
+				return int64(0), nil
+			case []interface{}:
+				return int64(len(v)), nil
+			}
+			return int64(utf8.RuneCountInString(exprString(args[0]))), nil
+		}},
+		"lower":      stringFunction(strings.ToLower),
+		"upper":      stringFunction(strings.ToUpper),
+		"trim":       stringFunction(strings.TrimSpace),
+		"contains":   stringPredicate(strings.Contains),
+		"startsWith": stringPredicate(strings.HasPrefix),
+		"endsWith":   stringPredicate(strings.HasSuffix),
+		"abs": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			if i, ok := exprInt(args[0]); ok {
+				if i < 0 {
+					return -i, nil
+				}
+				return i, nil
+			}
+			f, ok := toFloat(args[0])
+			if !ok {
+				return nil, fmt.Errorf("not a number: %v", args[0])
+			}
+			return math.Abs(f), nil
+		}},
+		"round": {minArgs: 1, maxArgs: 2, call: func(args []interface{}) (interface{}, error) {
+			if args[0] == nil {
+				return nil, nil
+			}
+			f, ok := toFloat(args[0])
+			if !ok {
+				return nil, fmt.Errorf("not a number: %v", args[0])
+			}
+			digits := int64(0)
+			if len(args) == 2 {
+				if digits, ok = exprInt(args[1]); !ok {
+					return nil, fmt.Errorf("digits must be an integer: %v", args[1])
+				}
+			}
+			scale := math.Pow(10, float64(digits))
+			return math.Round(f*scale) / scale, nil
+		}},
+		"min": exprExtremum(-1),
+		"max": exprExtremum(1),
+		"coalesce": {minArgs: 1, maxArgs: -1, call: func(args []interface{}) (interface{}, error) {
+			for _, arg := range args {
+				if arg != nil && arg != "" {
+					return arg, nil
+				}
+			}
+			return nil, nil
+		}},
+		"if": {minArgs: 3, maxArgs: 3, lazy: func(env *exprEnv, args []exprNode) (interface{}, error) {
+			cond, err := args[0].eval(env)
+			if err != nil {
+				return nil, err
+			}
+			if truthy(cond) {
+				return args[1].eval(env)
+			}
+			return args[2].eval(env)
+		}},
+		"field": {minArgs: 1, maxArgs: 1, lazy: func(env *exprEnv, args []exprNode) (interface{}, error) {
+			name, err := args[0].eval(env)
+			if err != nil {
+				return nil, err
+			}
+			v, err, found := env.row.LookupKey(exprString(name))
+			if err != nil || !found {
+				return nil, err
+			}
+			return NormalizeValue(v), nil
+		}},
+	}
+}
+
+// truthy returns the truth value of an expression value.
+func truthy(v interface{}) bool {
+	switch b := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return b
+	case string:
+		return b != ""
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	return true
+}
+
+// exprInt returns v as an int64 when it holds an integer type (numeric strings excluded).
+func exprInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint:
+		return int64(n), true
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// exprString returns the text of a value.
+func exprString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// exprEqual compares two values: numerically when both are numbers (or numeric strings), by text
+// otherwise. Null only equals null.
+func exprEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	cmp, ok := exprCompare(a, b)
+	return ok && cmp == 0
+}
+
+// exprCompare orders two non-null values: numbers (or numeric strings) numerically, times
+// chronologically, booleans as false < true and anything else by text. ok is false when a value is
+// null.
+func exprCompare(a, b interface{}) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			switch {
+			case af < bf:
+				return -1, true
+			case af > bf:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if at, ok := a.(time.Time); ok {
+		if bt, ok := b.(time.Time); ok {
+			return at.Compare(bt), true
+		}
+	}
+	if ab, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			switch {
+			case ab == bb:
+				return 0, true
+			case bb:
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return strings.Compare(exprString(a), exprString(b)), true
+}
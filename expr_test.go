@@ -0,0 +1,117 @@
+package spit
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpr_Eval(t *testing.T) {
+	row := Data{
+		"amount":     12.5,
+		"qty":        4,
+		"units":      "3",
+		"status":     "late",
+		"name":       "  Widget ",
+		"tags":       []interface{}{"a", "b"},
+		"customer":   Data{"name": "ACME"},
+		"due":        time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		"shipped":    time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		"unit price": 2,
+	}
+
+	tests := []struct {
+		expr string
+		want interface{}
+	}{
+		{"amount * qty", 50.0},
+		{"qty + 1", int64(5)},
+		{"qty / 8", 0.5},
+		{"qty % 3", int64(1)},
+		{"-qty + units", "-43"},
+		{"qty * units", 12.0},
+		{"(qty + 1) * 2", int64(10)},
+		{"1 + 2 * 3", int64(7)},
+		{"status == 'late'", true},
+		{`status != "late"`, false},
+		{"units == 3", true},
+		{"qty >= 4 && amount < 20", true},
+		{"qty > 4 or not (status == 'late')", false},
+		{"!missing", true},
+		{"missing + 1", nil},
+		{"qty / 0", nil},
+		{"missing == null", true},
+		{"missing > 0", false},
+		{"shipped > due", true},
+		{"customer.name", "ACME"},
+		{"'#' + qty", "#4"},
+		{"upper(trim(name))", "WIDGET"},
+		{"len(tags) + len(status)", int64(6)},
+		{"contains(status, 'at') && startsWith(status, 'l') && endsWith(status, 'e')", true},
+		{"abs(-qty)", int64(4)},
+		{"round(amount / 3, 2)", 4.17},
+		{"max(qty, amount, missing)", 12.5},
+		{"min(qty, amount)", 4},
+		{"coalesce(missing, '', status)", "late"},
+		{"if(qty > 3, 'many', 'few')", "many"},
+		{"if(false, qty / missing.x, 'lazy')", "lazy"},
+		{`field("unit price") * 2`, int64(4)},
+		{"1.5e2", 150.0},
+		{"'it\\'s'", "it's"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := ParseExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseExpr failed: %v", err)
+			}
+			got, err := e.Eval(row)
+			if err != nil {
+				t.Fatalf("Eval failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpr_errors(t *testing.T) {
+	parseErrors := []struct {
+		expr, want string
+	}{
+		{"", "unexpected end of expression"},
+		{"qty +", "unexpected end of expression"},
+		{"(qty", `expected ")"`},
+		{"qty qty", `unexpected "qty"`},
+		{"'open", "unterminated string"},
+		{"qty # 2", "unexpected character"},
+		{"nope(qty)", `unknown function "nope"`},
+		{"round()", "wrong number of arguments for round: 0"},
+	}
+	for _, tt := range parseErrors {
+		if _, err := ParseExpr(tt.expr); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseExpr(%q) error = %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+
+	e, err := ParseExpr("status * 2")
+	if err != nil {
+		t.Fatalf("ParseExpr failed: %v", err)
+	}
+	if _, err := e.Eval(Data{"status": "late"}); err == nil || !strings.Contains(err.Error(), `expression "status * 2": cannot apply * to late`) {
+		t.Errorf("Eval error = %v", err)
+	}
+}
+
+func TestExpr_EvalBool(t *testing.T) {
+	for expr, want := range map[string]bool{"1": true, "0": false, "''": false, "'x'": true, "null": false, "qty": true} {
+		e, err := ParseExpr(expr)
+		if err != nil {
+			t.Fatalf("ParseExpr(%q) failed: %v", expr, err)
+		}
+		if got, err := e.EvalBool(Data{"qty": 2}); err != nil || got != want {
+			t.Errorf("EvalBool(%q) = %v, %v, want %v", expr, got, err, want)
+		}
+	}
+}
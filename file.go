@@ -9,6 +9,7 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,14 +22,48 @@ type FileWriteParams struct {
 	Filepath      string // Directory to write file to (used if UseTempFile is false)
 	UseTempFile   bool   // Optional: use temp file (default: false)
 	UseGzip       bool   // Optional: compress with gzip
+	GzipLevel     int    // Optional: gzip compression level, 1 (fastest) to 9 (smallest) (0 = gzip.DefaultCompression)
 	OverwriteFile bool   // Optional: overwrite existing file (default: false)
 	Extension     string // File Extension (e.g., ".csv", ".json")
+
+	Writer    io.Writer           // Optional: write to this sink (network writer, upload stream) instead of a file
+	Chunking  *ChunkOptions       // Optional: forward output in bounded chunks (see ChunkedWriter)
+	OnWarning func(ExportWarning) // Optional: called for each non-fatal issue as it is reported
+	Seed      int64               // Optional: seed of randomized features such as preview sampling (0 = random, see FileWriteResult.Seed)
+	Limits    *Limits             // Optional: resource limits of the run, failing the export with a QuotaExceededError
+	Logger    Logger              // Optional: logger of the run, e.g. carrying the caller's request fields (default: the global logger, see SetLogger)
+
+	Streaming          StreamingMode // Optional: when XLSX sheets are written through a stream writer (default: StreamingAuto)
+	StreamingThreshold int           // Optional: data rows from which StreamingAuto streams a sheet (0 = DefaultStreamingThreshold)
+
+	OnProgress    func(done, total int) // Optional: called with the processed and total row steps of the run as the export progresses
+	ProgressEvery int                   // Optional: row steps between progress reports (0 = DefaultProgressInterval)
+
+	CollectTimings bool // Optional: measure the time spent per phase into FileWriteResult.Timings
+
+	Encoding Encoding // Optional: character encoding of CSV files (default: EncodingUTF8)
+
+	Print *PrintOptions // Optional: page setup of the sheets of XLSX files (orientation, paper size, repeated header rows...)
+
+	quota    *runQuota    // internal: quota of the run, shared with nested exports (see resolveQuota)
+	progress *runProgress // internal: progress of the run, shared with nested exports (see resolveProgress)
+	timings  *runTimings  // internal: timings of the run, shared with nested exports (see resolveTimings)
+	entry    bool         // internal: archive entry, whose bytes are counted by the archive writer
 }
 
 // FileWriteResult contains the result of file writing operation
 type FileWriteResult struct {
-	Filepath string // Full path to the created file
-	Filename string // Final filename (including any modifications)
+	Filepath string            // Full path to the created file
+	Filename string            // Final filename (including any modifications)
+	Sheets   []SheetResult     // Written tables per sheet, in write order (XLSX only, see SheetResult)
+	Warnings []ExportWarning   // Non-fatal issues reported during the export, in report order
+	Seed     int64             // Seed used by randomized features; pass it back in FileWriteParams.Seed to reproduce the export
+	Entries  []FileWriteResult // Files written into the archive, in job order (ExportArchive only)
+	Parts    []FileWriteResult // Files written per part of a chunked table, in row order (see Table.WithChunkSize)
+	Usage    Usage             // Resources used by the run so far (see FileWriteParams.Limits)
+	Timings  *Timings          // Time spent per phase by the run so far (see FileWriteParams.CollectTimings); nil when not collected
+	// SparseColumns lists the columns compacted because they were empty in every row (see Table.WithSparseColumns)
+	SparseColumns []SparseColumn
 }
 
 // SanitizeFilename sanitizes a string to be safe for use as a filename.
@@ -100,6 +135,16 @@ func SanitizeFilename(filename string) string {
 	return result
 }
 
+// resolveSeed returns the seed of an export run: the configured Seed, or a random non-zero seed
+// when unset, so the run can be reproduced from FileWriteResult.Seed.
+func (fwo FileWriteParams) resolveSeed() int64 {
+	seed := fwo.Seed
+	for seed == 0 {
+		seed = rand.Int64()
+	}
+	return seed
+}
+
 // WriteToFile writes data to a file with generic options and returns file info.
 // Handles temp file creation, directory management, gzip compression, and file overwriting.
 // Uses the provided writeFunc to write data to the file (or gzip stream).
@@ -121,12 +166,21 @@ func (fwo FileWriteParams) WriteToFile(writeFunc func(io.Writer) error) (*FileWr
 		tempFilePattern += ".gz"
 	}
 
+	// Sinks get the same content as a file, without touching the filesystem
+	if fwo.Writer != nil {
+		fwo.logger().Debug("writing data to sink", String("fileName", fileName))
+		if err := fwo.writeStream(fwo.Writer, fileName, writeFunc); err != nil {
+			return nil, fmt.Errorf("failed to write data to %s: %w", fileName, err)
+		}
+		return &FileWriteResult{Filename: fileName, Seed: fwo.Seed, Usage: fwo.quota.usage(), Timings: fwo.timings.snapshot()}, nil
+	}
+
 	var filePath string
 	var file *os.File
 	var err error
 
 	if fwo.UseTempFile {
-		L().Debug("creating temp file", String("pattern", tempFilePattern))
+		fwo.logger().Debug("creating temp file", String("pattern", tempFilePattern))
 		file, err = os.CreateTemp(fwo.Filepath, tempFilePattern)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create temp file: %w", err)
@@ -143,7 +197,7 @@ func (fwo FileWriteParams) WriteToFile(writeFunc func(io.Writer) error) (*FileWr
 			return nil, fmt.Errorf("failed to create directory %s: %w", dir, mkErr)
 		}
 		filePath = filepath.Join(dir, fileName)
-		L().Debug("creating regular file", String("filePath", filePath))
+		fwo.logger().Debug("creating regular file", String("filePath", filePath))
 		if !fwo.OverwriteFile {
 			if _, err = os.Stat(filePath); err == nil {
 				return nil, fmt.Errorf("file already exists: %s", filePath)
@@ -157,37 +211,72 @@ func (fwo FileWriteParams) WriteToFile(writeFunc func(io.Writer) error) (*FileWr
 
 	defer func() {
 		if closeErr := file.Close(); closeErr != nil {
-			L().Warn("failed to close file", String("filePath", filePath), Error(closeErr))
+			fwo.logger().Warn("failed to close file", String("filePath", filePath), Error(closeErr))
 		}
 	}()
 
-	var writer io.Writer = file
-	var gzipWriter *gzip.Writer
+	fwo.logger().Debug("writing data to file", String("filePath", filePath), String("fileName", fileName))
+
+	if err = fwo.writeStream(file, filePath, writeFunc); err != nil {
+		return nil, fmt.Errorf("failed to write data to %s: %w", filePath, err)
+	}
+
+	return &FileWriteResult{
+		Filepath: filePath,
+		Filename: fileName,
+		Seed:     fwo.Seed,
+		Usage:    fwo.quota.usage(),
+		Timings:  fwo.timings.snapshot(),
+	}, nil
+}
+
+// writeStream writes data to dst using writeFunc, through the optional chunked writer and gzip
+// compression. The gzip stream is closed and the chunks flushed before returning.
+func (fwo FileWriteParams) writeStream(dst io.Writer, target string, writeFunc func(io.Writer) error) error {
+	if !fwo.entry {
+		dst = fwo.quota.writer(dst)
+	}
+
+	var chunked *ChunkedWriter
+	if fwo.Chunking != nil {
+		chunked = NewChunkedWriter(dst, *fwo.Chunking)
+		dst = chunked
+	}
 
 	// Add gzip compression if requested
+	writer := dst
+	var gzipWriter *gzip.Writer
 	if fwo.UseGzip {
-		L().Debug("enabling gzip compression for file", String("filePath", filePath))
-		gzipWriter = gzip.NewWriter(file)
-		defer func() {
-			if closeErr := gzipWriter.Close(); closeErr != nil {
-				L().Warn("failed to close gzip writer", Error(closeErr))
-			}
-		}()
+		fwo.logger().Debug("enabling gzip compression", String("target", target))
+		level := fwo.GzipLevel
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		var err error
+		if gzipWriter, err = gzip.NewWriterLevel(dst, level); err != nil {
+			return fmt.Errorf("invalid gzip level %d: %w", fwo.GzipLevel, err)
+		}
+		// Record the uncompressed name, restored by e.g. gunzip -N
+		gzipWriter.Name = strings.TrimSuffix(filepath.Base(target), ".gz")
 		writer = gzipWriter
 	}
 
-	L().Debug("writing data to file", String("filePath", filePath), String("fileName", fileName))
-
 	// Write data using the provided write function
-	err = writeFunc(writer)
+	err := writeFunc(writer)
+	if gzipWriter != nil {
+		// Closing writes the gzip footer; without it the output is truncated
+		if closeErr := gzipWriter.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close gzip writer: %w", closeErr)
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to write data to %s: %w", filePath, err)
+		return err
 	}
 
-	return &FileWriteResult{
-		Filepath: filePath,
-		Filename: fileName,
-	}, nil
+	if chunked != nil {
+		return chunked.Flush()
+	}
+	return nil
 }
 
 // RemoveFile safely removes a file with improved error handling and logging
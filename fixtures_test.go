@@ -0,0 +1,31 @@
+package spit
+
+// fixtureTable returns a table over a shared five-row data set, with the columns listed in keys (all
+// of them when keys is empty). Each call builds new columns and rows, so tests can refine them.
+//
+// Rows are ordered so that grouping by region or team splits them, dave has no score nor joined
+// date and his email is empty.
+func fixtureTable(keys ...string) *Table {
+	columns := Columns{
+		NewColumn("name", "Name"),
+		NewColumn("email", "Email"),
+		NewColumn("team", "Team"),
+		NewColumn("region", "Region"),
+		NewColumn("country", "Country"),
+		NewColumn("year", "Year"),
+		NewColumn("quarter", "Quarter"),
+		NewColumn("sales", "Sales"),
+		NewColumn("score", "Score"),
+	}
+	if len(keys) > 0 {
+		columns = columns.SelectColumns(keys...)
+	}
+
+	return NewTable(DataSlice{
+		{"name": "carol", "email": "carol@acme.com", "team": "b", "region": "US", "country": "CA", "year": 2024, "quarter": "Q1", "sales": 30, "score": 7, "joined": "2023-03-01"},
+		{"name": "alice", "email": "alice@acme.com", "team": "a", "region": "EU", "country": "FR", "year": 2024, "quarter": "Q1", "sales": 10, "score": 10, "joined": "2021-06-15"},
+		{"name": "bob", "email": "bob@acme.com", "team": "b", "region": "US", "country": "US", "year": 2025, "quarter": "Q1", "sales": 40, "score": 10, "joined": "2022-01-10"},
+		{"name": "dave", "email": "", "team": "a", "region": "EU", "country": "DE", "year": 2024, "quarter": "Q2", "sales": 20},
+		{"name": "erin", "email": "erin@acme.com", "team": "a", "region": "EU", "country": "FR", "year": 2024, "quarter": "Q1", "sales": 15, "score": 3, "joined": "2020-12-31"},
+	}, columns, true)
+}
@@ -11,24 +11,34 @@ import "fmt"
 type Format uint8
 
 const (
-	FormatUnknown Format = iota // Unknown format (default)
-	FormatCSV                   // CSV format
-	FormatXSLX                  // XLSX format
-	FormatHTML                  // HTML format
+	FormatUnknown      Format = iota // Unknown format (default)
+	FormatCSV                        // CSV format
+	FormatXSLX                       // XLSX format
+	FormatHTML                       // HTML format
+	FormatGoogleSheets               // Google Sheets (gsheets module); not a file format
+	FormatParquet                    // Parquet format
+	FormatMarkdown                   // Markdown (GitHub-flavored table) format
 )
 
 // formats maps Format values to their string representations.
 var formats = map[Format]string{
-	FormatCSV:  "csv",
-	FormatXSLX: "xlsx",
-	FormatHTML: "html",
+	FormatCSV:          "csv",
+	FormatXSLX:         "xlsx",
+	FormatHTML:         "html",
+	FormatGoogleSheets: "gsheets",
+	FormatParquet:      "parquet",
+	FormatMarkdown:     "md",
 }
 
-// String returns the string representation of the Format.
-// If the format is not recognized, returns a generic string with the format value.
+// String returns the string representation of the Format, the lowercase name of registered
+// formats (see RegisterFormat). If the format is not recognized, returns a generic string with the
+// format value.
 func (f Format) String() string {
 	if str, ok := formats[f]; ok {
 		return str
 	}
+	if name, ok := registeredName(f); ok {
+		return name
+	}
 	return fmt.Sprintf("Format(%d)", f)
 }
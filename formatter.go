@@ -0,0 +1,69 @@
+// formatter.go - Named value formatters.
+//
+// This file implements a registry of named formatters. Once registered (e.g. "money_eur"), a
+// formatter can be referenced from Column.Format and is applied by every backend wherever
+// FormatValue is used, including merge comparisons and list elements.
+
+package spit
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FormatterFunc formats a cell value. It receives the normalized value (see NormalizeValue)
+// and returns the value to write; returning an error aborts the export.
+type FormatterFunc func(value interface{}) (interface{}, error)
+
+var (
+	_formatters   = map[string]FormatterFunc{} // Registered formatters by name
+	_formattersMu sync.RWMutex
+)
+
+// reservedFormats lists the Column.Format values with a built-in meaning, which cannot be registered.
+var reservedFormats = []string{
+	ExcelizeFormatDefault,
+	ExcelizeFormatFormula,
+	ExcelizeFormatHyperlink,
+	ExcelizeFormatNumber,
+	ExcelizeFormatBool,
+	DurationFormatClock,
+	DurationFormatHuman,
+}
+
+// RegisterFormatter registers a named formatter that can be referenced from Column.Format.
+// Registering an existing name replaces the previous formatter. The name must not be empty
+// nor one of the built-in ExcelizeFormat constants.
+func RegisterFormatter(name string, formatter FormatterFunc) error {
+	if name == "" {
+		return fmt.Errorf("formatter name cannot be empty")
+	}
+	if formatter == nil {
+		return fmt.Errorf("formatter %q cannot be nil", name)
+	}
+	for _, reserved := range reservedFormats {
+		if name == reserved {
+			return fmt.Errorf("formatter name %q is reserved", name)
+		}
+	}
+
+	_formattersMu.Lock()
+	defer _formattersMu.Unlock()
+	_formatters[name] = formatter
+	return nil
+}
+
+// UnregisterFormatter removes a named formatter. Unknown names are ignored.
+func UnregisterFormatter(name string) {
+	_formattersMu.Lock()
+	defer _formattersMu.Unlock()
+	delete(_formatters, name)
+}
+
+// LookupFormatter returns the formatter registered under name.
+func LookupFormatter(name string) (FormatterFunc, bool) {
+	_formattersMu.RLock()
+	defer _formattersMu.RUnlock()
+	formatter, ok := _formatters[name]
+	return formatter, ok
+}
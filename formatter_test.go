@@ -0,0 +1,69 @@
+package spit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRegisterFormatter(t *testing.T) {
+	noop := func(v interface{}) (interface{}, error) { return v, nil }
+	tests := []struct {
+		name      string
+		formatter FormatterFunc
+		wantErr   bool
+	}{
+		{"money_eur", noop, false},
+		{"", noop, true},
+		{"nil_formatter", nil, true},
+		{ExcelizeFormatNumber, noop, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterFormatter(tt.name, tt.formatter)
+			defer UnregisterFormatter(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterFormatter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := LookupFormatter(tt.name); ok == tt.wantErr {
+				t.Errorf("LookupFormatter() found = %v, want %v", ok, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatValue_namedFormatter(t *testing.T) {
+	if err := RegisterFormatter("money_eur", func(v interface{}) (interface{}, error) {
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, errors.New("not a number")
+		}
+		return fmt.Sprintf("%.2f €", f), nil
+	}); err != nil {
+		t.Fatalf("RegisterFormatter failed: %v", err)
+	}
+	defer UnregisterFormatter("money_eur")
+
+	if got, err := FormatValue(12.5, "money_eur"); err != nil || got != "12.50 €" {
+		t.Errorf("FormatValue() = %v, %v; want \"12.50 €\"", got, err)
+	}
+	if _, err := FormatValue(true, "money_eur"); err == nil {
+		t.Error("FormatValue() should return the formatter error")
+	}
+
+	table := NewTable(DataSlice{{"item": "Coffee", "price": 3}, {"item": "Tea", "price": 2.4}},
+		Columns{NewColumn("item", "Item"), NewColumn("price", "Price").WithFormat("money_eur")}, true)
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "prices", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(content), "Coffee,3.00 €") || !strings.Contains(string(content), "Tea,2.40 €") {
+		t.Errorf("expected formatted prices, got:\n%s", content)
+	}
+}
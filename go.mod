@@ -5,6 +5,8 @@ go 1.24.1
 require (
 	github.com/xuri/excelize/v2 v2.9.1
 	go.uber.org/mock v0.5.2
+	golang.org/x/text v0.28.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -15,5 +17,4 @@ require (
 	github.com/xuri/nfp v0.0.1 // indirect
 	golang.org/x/crypto v0.41.0 // indirect
 	golang.org/x/net v0.43.0 // indirect
-	golang.org/x/text v0.28.0 // indirect
 )
@@ -3,6 +3,7 @@ package gsheets
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	spit "github.com/Zapharaos/go-spit"
 	"google.golang.org/api/sheets/v4"
@@ -12,6 +13,7 @@ import (
 // Sheets CellData, then serializes the grid into batchUpdate requests.
 type gsheetTable struct {
 	table   *spit.Table
+	ops     spit.TableOperations // Cell writes translated by the table start position (see spit.Table.Offset)
 	sheetID int64
 	cells   map[int]map[int]*sheets.CellData // cells[row][col], both 1-based
 	merges  []*sheets.GridRange
@@ -23,11 +25,13 @@ type gsheetTable struct {
 var _ spit.TableOperations = (*gsheetTable)(nil)
 
 func newGSheetTable(t *spit.Table, sheetID int64) *gsheetTable {
-	return &gsheetTable{
+	g := &gsheetTable{
 		table:   t,
 		sheetID: sheetID,
 		cells:   make(map[int]map[int]*sheets.CellData),
 	}
+	g.ops = t.Offset(g)
+	return g
 }
 
 // ---- Grid helpers -----------------------------------------------------------
@@ -90,7 +94,7 @@ func (g *gsheetTable) build() error {
 	if len(t.Preamble) > 0 {
 		for i, row := range t.Preamble {
 			for j, val := range row.Values {
-				if err := g.SetCellValue(j+1, currentRow+i, val); err != nil {
+				if err := g.ops.SetCellValue(j+1, currentRow+i, val); err != nil {
 					return err
 				}
 			}
@@ -118,6 +122,19 @@ func (g *gsheetTable) build() error {
 		currentRow++
 	}
 
+	if err := t.RenderFooter(g, true); err != nil {
+		return fmt.Errorf("write footer: %w", err)
+	}
+	if err := t.RenderTruncationNotice(g); err != nil {
+		return err
+	}
+	if err := t.RenderSubtotals(g, true); err != nil {
+		return fmt.Errorf("write subtotals: %w", err)
+	}
+	if err := t.RenderCellFormulas(g); err != nil {
+		return fmt.Errorf("write cell formulas: %w", err)
+	}
+
 	if err := t.ProcessMerging(g); err != nil {
 		return fmt.Errorf("process merging: %w", err)
 	}
@@ -132,7 +149,7 @@ func (g *gsheetTable) writeHeaders(startRow int) (int, error) {
 	maxDepth := t.Columns.GetMaxDepth()
 	if maxDepth == 1 {
 		for i, column := range t.Columns {
-			if err := g.SetCellValue(i+1, startRow, column.Label); err != nil {
+			if err := g.ops.SetCellValue(i+1, startRow, column.Label); err != nil {
 				return 0, err
 			}
 		}
@@ -147,7 +164,7 @@ func (g *gsheetTable) writeHeaders(startRow int) (int, error) {
 func (g *gsheetTable) writeHeaderRow(columns spit.Columns, currentRow, maxRow, startCol int) error {
 	currentCol := startCol
 	for _, column := range columns {
-		if err := g.SetCellValue(currentCol, currentRow, column.Label); err != nil {
+		if err := g.ops.SetCellValue(currentCol, currentRow, column.Label); err != nil {
 			return err
 		}
 		if column.HasSubColumns() {
@@ -165,36 +182,39 @@ func (g *gsheetTable) writeHeaderRow(columns spit.Columns, currentRow, maxRow, s
 }
 
 func (g *gsheetTable) writeCell(item spit.Data, column *spit.Column, col, row int) error {
-	value, err, found := item.Lookup(column.Name)
-	if err == nil && !found {
-		return nil
-	}
+	value, err, found := item.LookupColumn(column)
 	if err != nil {
 		return err
 	}
+	if text, ok := g.table.CellPlaceholder(column, value, found); ok {
+		return g.ops.SetCellValue(col, row, text)
+	}
+	if !found {
+		return nil
+	}
 
 	// Image values become =IMAGE() formulas (URL) or a text fallback.
 	if img, ok := toImage(value); ok {
-		return g.SetCellImage(col, row, img)
+		return g.ops.SetCellImage(col, row, img)
 	}
 
-	processed, err := g.ProcessValue(value, column.Format)
+	processed, err := g.table.ProcessCellValue(g, item, column, value)
 	if err != nil {
 		return err
 	}
 
 	switch column.Format {
 	case spit.ExcelizeFormatFormula:
-		return g.SetCellFormula(col, row, fmt.Sprintf("%v", processed))
+		return g.ops.SetCellFormula(col, row, fmt.Sprintf("%v", processed))
 	case spit.ExcelizeFormatHyperlink:
 		link := fmt.Sprintf("%v", processed)
-		return g.SetCellHyperLink(col, row, link)
+		return g.ops.SetCellHyperLink(col, row, link)
 	default:
 		// Keep unformatted numeric values native so Sheets treats them as numbers.
-		if column.Format == "" && isNumeric(value) {
-			return g.SetCellValue(col, row, value)
+		if native := spit.NormalizeValue(value); column.Format == "" && isNumeric(native) {
+			return g.ops.SetCellValue(col, row, native)
 		}
-		return g.SetCellValue(col, row, processed)
+		return g.ops.SetCellValue(col, row, processed)
 	}
 }
 
@@ -202,11 +222,14 @@ func (g *gsheetTable) writeCell(item spit.Data, column *spit.Column, col, row in
 func (g *gsheetTable) requests() []*sheets.Request {
 	var reqs []*sheets.Request
 
-	if g.maxRow > 0 && g.maxCol > 0 {
-		rows := make([]*sheets.RowData, 0, g.maxRow)
-		for r := 1; r <= g.maxRow; r++ {
-			values := make([]*sheets.CellData, 0, g.maxCol)
-			for c := 1; c <= g.maxCol; c++ {
+	// Only the area from the table start position is sent, so cells before the table (e.g. other
+	// tables of a spit.SheetLayout) are left untouched.
+	startRow, startCol := g.table.GetStartRow(), g.table.GetStartCol()
+	if g.maxRow >= startRow && g.maxCol >= startCol {
+		rows := make([]*sheets.RowData, 0, g.maxRow-startRow+1)
+		for r := startRow; r <= g.maxRow; r++ {
+			values := make([]*sheets.CellData, 0, g.maxCol-startCol+1)
+			for c := startCol; c <= g.maxCol; c++ {
 				if cell := g.peek(c, r); cell != nil {
 					values = append(values, cell)
 				} else {
@@ -220,8 +243,8 @@ func (g *gsheetTable) requests() []*sheets.Request {
 			Fields: "userEnteredValue,userEnteredFormat",
 			Start: &sheets.GridCoordinate{
 				SheetId:         g.sheetID,
-				RowIndex:        0,
-				ColumnIndex:     0,
+				RowIndex:        int64(startRow - 1),
+				ColumnIndex:     int64(startCol - 1),
 				ForceSendFields: []string{"RowIndex", "ColumnIndex"},
 			},
 		}})
@@ -233,6 +256,13 @@ func (g *gsheetTable) requests() []*sheets.Request {
 			MergeType: "MERGE_ALL",
 		}})
 	}
+
+	if g.table.RightToLeft {
+		reqs = append(reqs, &sheets.Request{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: g.sheetID, RightToLeft: true, ForceSendFields: []string{"SheetId"}},
+			Fields:     "rightToLeft",
+		}})
+	}
 	return reqs
 }
 
@@ -313,11 +343,18 @@ func (g *gsheetTable) ApplyBorderToCell(col, row int, side string, border *spit.
 	if border == nil || border.Style == spit.BorderStyleNone {
 		return nil
 	}
+	if side == "diagonalUp" || side == "diagonalDown" {
+		return nil // Google Sheets has no diagonal borders
+	}
 	cf := g.format(col, row)
 	if cf.Borders == nil {
 		cf.Borders = &sheets.Borders{}
 	}
-	b := &sheets.Border{Style: borderStyle(border.Style), Color: blackColor()}
+	color := hexColor(border.Color)
+	if color == nil {
+		color = blackColor()
+	}
+	b := &sheets.Border{Style: borderStyle(border.Style), Color: color}
 	switch side {
 	case "left":
 		cf.Borders.Left = b
@@ -382,7 +419,18 @@ func (g *gsheetTable) HasExistingBorder(col, row int, side string) bool {
 
 func (g *gsheetTable) GetColumnLetter(col int) string { return columnLetter(col) }
 
+// CellRef and RangeRef address cells in A1 notation, the notation of Google Sheets formulas.
+func (g *gsheetTable) CellRef(col, row int) string { return spit.A1Addresser{}.CellRef(col, row) }
+
+func (g *gsheetTable) RangeRef(startCol, startRow, endCol, endRow int) string {
+	return spit.A1Addresser{}.RangeRef(startCol, startRow, endCol, endRow)
+}
+
 func (g *gsheetTable) ProcessValue(value interface{}, format string) (interface{}, error) {
+	if text, ok := spit.FormatDuration(value, format); ok {
+		return text, nil
+	}
+	value = spit.NormalizeValue(value)
 	switch v := value.(type) {
 	case []interface{}:
 		if g.table.ListSeparator != "" {
@@ -407,7 +455,11 @@ func (g *gsheetTable) ProcessValue(value interface{}, format string) (interface{
 }
 
 func (g *gsheetTable) SetCellFormula(col, row int, formula string) error {
+	// Sheets requires the leading "=" that Excel-style formulas (e.g. footer aggregates) omit
 	f := formula
+	if !strings.HasPrefix(f, "=") {
+		f = "=" + f
+	}
 	g.cell(col, row).UserEnteredValue = &sheets.ExtendedValue{FormulaValue: &f}
 	return nil
 }
@@ -103,6 +103,27 @@ func TestHyperlinkAndFormula(t *testing.T) {
 	}
 }
 
+func TestFooterFormulaAtStartPosition(t *testing.T) {
+	data := spit.DataSlice{{"n": 1}, {"n": 2}}
+	cols := spit.Columns{spit.NewColumn("n", "N").WithAggregate(spit.AggregateSum)}
+	table := spit.NewTable(data, cols, true).
+		WithFooter(spit.NewFooterOptions().WithFormulas(true)).
+		WithStartPosition(2, 3)
+	g := newGSheetTable(table, 0)
+	if err := g.build(); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	uc := findUpdateCells(g.requests())
+	if uc.Start.RowIndex != 2 || uc.Start.ColumnIndex != 1 {
+		t.Errorf("start = (%d, %d), want (2, 1)", uc.Start.RowIndex, uc.Start.ColumnIndex)
+	}
+	// Header at row 3, data at rows 4-5, footer at row 6
+	footer := uc.Rows[3].Values[0]
+	if footer.UserEnteredValue.FormulaValue == nil || *footer.UserEnteredValue.FormulaValue != "=SUM(B4:B5)" {
+		t.Errorf("footer cell = %+v", footer.UserEnteredValue)
+	}
+}
+
 func TestImageBlockFormula(t *testing.T) {
 	data := spit.DataSlice{{"logo": spit.NewImageURL("https://acme.com/l.png")}}
 	cols := spit.Columns{spit.NewColumn("logo", "Logo")}
@@ -131,3 +152,48 @@ func TestExportGoogleSheetsValidation(t *testing.T) {
 		t.Error("expected error for nil table")
 	}
 }
+
+func TestBorderColorAndDiagonals(t *testing.T) {
+	data := spit.DataSlice{{"name": "John"}}
+	borders := &spit.Borders{
+		Bottom:     spit.NewBorder(spit.BorderStyleThin).WithColor("#FF0000"),
+		DiagonalUp: spit.NewBorder(spit.BorderStyleThin),
+	}
+	cols := spit.Columns{spit.NewColumn("name", "Name").WithBorders(borders)}
+	g := newGSheetTable(spit.NewTable(data, cols, true), 0)
+	if err := g.build(); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	uc := findUpdateCells(g.requests())
+	format := uc.Rows[1].Values[0].UserEnteredFormat
+	if format == nil || format.Borders == nil || format.Borders.Bottom == nil {
+		t.Fatalf("data cell format = %+v, want a bottom border", format)
+	}
+	if color := format.Borders.Bottom.Color; color == nil || color.Red != 1 || color.Green != 0 {
+		t.Errorf("bottom border color = %+v, want red", color)
+	}
+}
+
+func TestRightToLeftRequests(t *testing.T) {
+	data := spit.DataSlice{{"name": "سارة"}}
+	cols := spit.Columns{spit.NewColumn("name", "Name").WithStyle(&spit.Style{TextDirection: spit.TextDirectionRTL})}
+	g := newGSheetTable(spit.NewTable(data, cols, true).WithRightToLeft(true), 3)
+	if err := g.build(); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	var props *sheets.UpdateSheetPropertiesRequest
+	for _, r := range g.requests() {
+		if r.UpdateSheetProperties != nil {
+			props = r.UpdateSheetProperties
+		}
+	}
+	if props == nil || !props.Properties.RightToLeft || props.Properties.SheetId != 3 || props.Fields != "rightToLeft" {
+		t.Errorf("sheet properties request = %+v, want a right-to-left sheet", props)
+	}
+	uc := findUpdateCells(g.requests())
+	if format := uc.Rows[1].Values[0].UserEnteredFormat; format == nil || format.TextDirection != "RIGHT_TO_LEFT" {
+		t.Errorf("data cell format = %+v, want the right-to-left text direction", format)
+	}
+}
@@ -72,7 +72,7 @@ func isNumeric(value interface{}) bool {
 // applyStyle overlays a spit.Style onto a Sheets CellFormat, preserving previously set
 // properties (e.g. borders).
 func applyStyle(cf *sheets.CellFormat, s spit.Style) {
-	if s.Bold || s.Italic || s.FontSize > 0 || s.FontFamily != "" || s.TextColor != "" {
+	if s.Bold || s.Italic || s.FontSize > 0 || s.FontFamily != "" || s.TextColor != "" || s.Strikethrough {
 		if cf.TextFormat == nil {
 			cf.TextFormat = &sheets.TextFormat{}
 		}
@@ -92,6 +92,9 @@ func applyStyle(cf *sheets.CellFormat, s spit.Style) {
 		if s.TextColor != "" {
 			tf.ForegroundColor = hexColor(s.TextColor)
 		}
+		if s.Strikethrough {
+			tf.Strikethrough = true
+		}
 	}
 	if s.BackgroundColor != "" {
 		cf.BackgroundColor = hexColor(s.BackgroundColor)
@@ -104,6 +107,18 @@ func applyStyle(cf *sheets.CellFormat, s spit.Style) {
 	if s.NumFmt != "" {
 		cf.NumberFormat = &sheets.NumberFormat{Type: "NUMBER", Pattern: s.NumFmt}
 	}
+	if s.WrapText {
+		cf.WrapStrategy = "WRAP"
+	}
+	if s.TextRotation != 0 {
+		cf.TextRotation = &sheets.TextRotation{Angle: int64(min(max(s.TextRotation, -90), 90))}
+	}
+	switch s.TextDirection {
+	case spit.TextDirectionLTR:
+		cf.TextDirection = "LEFT_TO_RIGHT"
+	case spit.TextDirectionRTL:
+		cf.TextDirection = "RIGHT_TO_LEFT"
+	}
 }
 
 // verticalAlignment maps an internal vertical token to a Sheets vertical alignment.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	spit "github.com/Zapharaos/go-spit"
+	"google.golang.org/api/sheets/v4"
 )
 
 func TestColumnLetter(t *testing.T) {
@@ -65,3 +66,11 @@ func TestExtendedValue(t *testing.T) {
 		t.Errorf("nil -> %+v, want nil", v)
 	}
 }
+
+func TestApplyStyleStrikethrough(t *testing.T) {
+	cf := &sheets.CellFormat{}
+	applyStyle(cf, spit.Style{Strikethrough: true})
+	if cf.TextFormat == nil || !cf.TextFormat.Strikethrough {
+		t.Errorf("TextFormat = %+v, want strikethrough", cf.TextFormat)
+	}
+}
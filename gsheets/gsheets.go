@@ -62,6 +62,10 @@ func ExportGoogleSheets(ctx context.Context, svc *sheets.Service, spreadsheetID
 		if s.Name == "" {
 			s.Name = "Sheet1"
 		}
+		// Preview exports are titled accordingly so samples are never mistaken for full data.
+		if s.Table.Preview != nil {
+			s.Name = s.Table.Preview.TitleFor(s.Name)
+		}
 		sheetsIn[i] = s
 	}
 
@@ -70,7 +74,13 @@ func ExportGoogleSheets(ctx context.Context, svc *sheets.Service, spreadsheetID
 		newSS := &sheets.Spreadsheet{
 			Properties: &sheets.SpreadsheetProperties{Title: opts.Title},
 		}
+		seen := make(map[string]bool)
 		for _, s := range sheetsIn {
+			// Several tables may target the same tab (see spit.SheetLayout)
+			if seen[s.Name] {
+				continue
+			}
+			seen[s.Name] = true
 			newSS.Sheets = append(newSS.Sheets, &sheets.Sheet{
 				Properties: &sheets.SheetProperties{Title: s.Name},
 			})
@@ -91,7 +101,7 @@ func ExportGoogleSheets(ctx context.Context, svc *sheets.Service, spreadsheetID
 	// Build all cell and merge requests across every table.
 	var requests []*sheets.Request
 	for _, s := range sheetsIn {
-		g := newGSheetTable(s.Table, ids[s.Name])
+		g := newGSheetTable(s.Table.Prepare().ForFormat(spit.FormatGoogleSheets).CacheValues(), ids[s.Name])
 		if err := g.build(); err != nil {
 			return nil, fmt.Errorf("gsheets: build sheet %q: %w", s.Name, err)
 		}
@@ -130,6 +140,7 @@ func resolveSheetIDs(ctx context.Context, svc *sheets.Service, spreadsheetID str
 	var addReqs []*sheets.Request
 	for _, s := range in {
 		if _, ok := ids[s.Name]; !ok {
+			ids[s.Name] = -1 // Pending creation; avoids adding the same tab twice
 			addReqs = append(addReqs, &sheets.Request{AddSheet: &sheets.AddSheetRequest{
 				Properties: &sheets.SheetProperties{Title: s.Name},
 			}})
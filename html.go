@@ -31,6 +31,11 @@ type HTMLOptions struct {
 	FragmentOnly    bool      // When true, emit only the title/description/table markup without <!DOCTYPE>, <html>, <head> and <body> wrappers
 	Theme           HTMLTheme // Optional built-in stylesheet applied for a polished default look (default: none)
 	TableOfContents bool      // When true (documents only), render a linked table of contents from the document headings
+
+	seed     int64        // Seed of randomized features of the document tables (see FileWriteParams.Seed)
+	quota    *runQuota    // Quota of the run accounting for the document tables (see FileWriteParams.Limits)
+	progress *runProgress // Progress of the run accounting for the document tables (see FileWriteParams.OnProgress)
+	timings  *runTimings  // Timings of the run measuring the document tables (see FileWriteParams.CollectTimings)
 }
 
 // HTMLTheme selects a built-in stylesheet injected into the document.
@@ -58,34 +63,62 @@ func ExportHTML(t *Table, opts HTMLOptions, params FileWriteParams) (*FileWriteR
 		params.Extension = FormatHTML.String()
 	}
 
-	L().Info("Starting HTML export to file", String("filename", params.Filename))
+	// Preview exports are titled accordingly so samples are never mistaken for full data
+	if t.Preview != nil && opts.Title != "" {
+		opts.Title = t.Preview.TitleFor(opts.Title)
+	}
+
+	if parts := t.chunks(); parts != nil {
+		return exportParts(parts, params, func(part *Table, params FileWriteParams) (*FileWriteResult, error) {
+			return ExportHTML(part, opts, params)
+		})
+	}
+
+	params.logger().Info("Starting HTML export to file", String("filename", params.Filename))
 
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+	prepared := params.timings.measure(timingPrepare)
 	export := &htmlExport{
-		table: t,
+		table: t.withSeed(params.Seed).Prepare().ForFormat(FormatHTML).withoutOffset().CacheValues().withWarnings("", params.OnWarning, params.Logger),
 		opts:  opts,
 		grid:  make(map[int]map[int]*htmlCell),
 	}
+	prepared()
+	if err := params.quota.addTable(export.table); err != nil {
+		return nil, err
+	}
+	params.progress.track(export.table, progressPhasesSheet)
+	params.timings.track(export.table)
 
 	// Populate the in-memory grid and apply merging/styling via the shared pipelines.
 	if err := export.build(); err != nil {
-		L().Error("Failed to build HTML table", Error(err))
+		params.logger().Error("Failed to build HTML table", Error(err))
 		return nil, err
 	}
 
+	saved := params.timings.measure(timingSave)
 	markup := export.render()
+	saved()
 
 	writeFunc := func(writer io.Writer) error {
+		defer params.timings.measure(timingSave)()
 		_, err := io.WriteString(writer, markup)
 		return err
 	}
 
 	result, err := params.WriteToFile(writeFunc)
 	if err != nil {
-		L().Error("Failed to write HTML to file", Error(err))
+		params.logger().Error("Failed to write HTML to file", Error(err))
 		return nil, err
 	}
 
-	L().Info("HTML export completed", String("filename", params.Filename))
+	result.Warnings = export.table.exportWarnings()
+	result.SparseColumns = export.table.sparseColumns("")
+
+	params.logger().Info("HTML export completed", String("filename", params.Filename))
 	return result, nil
 }
 
@@ -100,6 +133,7 @@ type htmlCell struct {
 	rowspan int     // Vertical span (1 = no span); set on a merge origin
 	covered bool    // True when this cell is absorbed by a merge origin and must not be rendered
 	numeric bool    // True when the source value was numeric (used for automatic right alignment)
+	title   string  // Tooltip of the cell (header cells of described columns, see Column.WithDescription)
 }
 
 // htmlExport implements TableOperations on top of an in-memory cell grid and
@@ -111,11 +145,20 @@ type htmlExport struct {
 	grid    map[int]map[int]*htmlCell // grid[row][col], both 1-based
 	maxRow  int
 	maxCol  int
+
+	transposed bool // Whether the grid was rotated after the build (see Table.WithTransposed)
 }
 
 // build populates the grid from the table (preamble, headers, data) and then applies
 // the shared merging and styling pipelines, mirroring the XLSX write flow.
 func (h *htmlExport) build() error {
+	// Transposed tables are built upright, then the grid is rotated
+	if h.table.Transposed {
+		upright := *h.table
+		upright.Transposed = false
+		h.table, h.transposed = &upright, true
+	}
+
 	t := h.table
 	currentRow := 1
 
@@ -143,11 +186,25 @@ func (h *htmlExport) build() error {
 		colIndex := 1
 		for _, column := range flatColumns {
 			if err := h.writeCell(item, column, colIndex, currentRow); err != nil {
-				return fmt.Errorf("failed to write cell: %w", err)
+				return t.cellError(colIndex, currentRow, column, err)
 			}
 			colIndex++
 		}
 		currentRow++
+		if err := t.advance(1); err != nil {
+			return err
+		}
+	}
+
+	// HTML has no formulas: footer aggregates are always written as values
+	if err := t.RenderFooter(h, false); err != nil {
+		return fmt.Errorf("failed to write footer: %w", err)
+	}
+	if err := t.RenderTruncationNotice(h); err != nil {
+		return err
+	}
+	if err := t.strictErr(); err != nil {
+		return err
 	}
 
 	if err := t.ProcessMerging(h); err != nil {
@@ -158,6 +215,11 @@ func (h *htmlExport) build() error {
 		return fmt.Errorf("failed to render styles: %w", err)
 	}
 
+	if h.transposed {
+		h.transpose()
+	}
+
+	t.progress.finish()
 	return nil
 }
 
@@ -171,6 +233,7 @@ func (h *htmlExport) writeHeaders(startRow int) (int, error) {
 			if err := h.SetCellValue(i+1, startRow, column.Label); err != nil {
 				return 0, fmt.Errorf("failed to set header cell value for column %s: %w", column.Name, err)
 			}
+			h.cell(i+1, startRow).title = column.Description
 		}
 		return 1, nil
 	}
@@ -189,6 +252,7 @@ func (h *htmlExport) writeHeaderRow(columns Columns, currentRow, maxRow, startCo
 		if err := h.SetCellValue(currentCol, currentRow, column.Label); err != nil {
 			return fmt.Errorf("failed to set header cell value for column %s at (%d, %d): %w", column.Name, currentCol, currentRow, err)
 		}
+		h.cell(currentCol, currentRow).title = column.Description
 		if column.HasSubColumns() {
 			if currentRow < maxRow {
 				if err := h.writeHeaderRow(column.Columns, currentRow+1, maxRow, currentCol); err != nil {
@@ -206,20 +270,23 @@ func (h *htmlExport) writeHeaderRow(columns Columns, currentRow, maxRow, startCo
 // writeCell writes a single data cell, looking up and formatting its value.
 // The hyperlink format renders the value as a clickable <a> element.
 func (h *htmlExport) writeCell(item Data, column *Column, colIndex, rowIndex int) error {
-	value, err, found := item.Lookup(column.Name)
-	if err == nil && !found {
-		return nil
-	}
+	value, err, found := item.LookupColumn(column)
 	if err != nil {
 		return fmt.Errorf("error looking up value for column %s: %w", column.Name, err)
 	}
+	if text, ok := h.table.CellPlaceholder(column, value, found); ok {
+		return h.SetCellValue(colIndex, rowIndex, text)
+	}
+	if !found {
+		return nil
+	}
 
 	// Image values render as an <img> element rather than text.
 	if img, ok := asImage(value); ok {
 		return h.SetCellImage(colIndex, rowIndex, img)
 	}
 
-	processedValue, err := h.ProcessValue(value, column.Format)
+	processedValue, err := h.table.ProcessCellValue(h, item, column, value)
 	if err != nil {
 		return fmt.Errorf("error processing value for column %s: %w", column.Name, err)
 	}
@@ -230,7 +297,7 @@ func (h *htmlExport) writeCell(item Data, column *Column, colIndex, rowIndex int
 	}
 
 	// Remember numeric source values so they can be right-aligned automatically.
-	if column.Format == "" && isNumericValue(value) {
+	if (column.Format == "" && isNumericValue(NormalizeValue(value))) || column.Type.numeric() {
 		h.cell(colIndex, rowIndex).numeric = true
 	}
 
@@ -286,6 +353,10 @@ func (h *htmlExport) GetCellValue(col, row int) (string, error) {
 
 // SetCellValue sets the display value of a cell.
 func (h *htmlExport) SetCellValue(col, row int, value interface{}) error {
+	if value == nil {
+		h.cell(col, row).value = ""
+		return nil
+	}
 	h.cell(col, row).value = fmt.Sprintf("%v", value)
 	return nil
 }
@@ -337,13 +408,18 @@ func (h *htmlExport) ApplyBorderToCell(col, row int, side string, border *Border
 		c.borders.Top = border
 	case "bottom":
 		c.borders.Bottom = border
+	case "diagonalUp":
+		c.borders.DiagonalUp = border
+	case "diagonalDown":
+		c.borders.DiagonalDown = border
 	default:
 		return fmt.Errorf("unsupported border side: %s", side)
 	}
 	return nil
 }
 
-// ApplyBordersToRange applies edge borders to the outer cells of a range.
+// ApplyBordersToRange applies edge borders to the outer cells of a range, and diagonals to every
+// cell.
 func (h *htmlExport) ApplyBordersToRange(startCol, startRow, endCol, endRow int, borders Borders) error {
 	for row := startRow; row <= endRow; row++ {
 		for col := startCol; col <= endCol; col++ {
@@ -367,6 +443,12 @@ func (h *htmlExport) ApplyBordersToRange(startCol, startRow, endCol, endRow int,
 					return err
 				}
 			}
+			if err := h.ApplyBorderToCell(col, row, "diagonalUp", borders.DiagonalUp); err != nil {
+				return err
+			}
+			if err := h.ApplyBorderToCell(col, row, "diagonalDown", borders.DiagonalDown); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -387,6 +469,10 @@ func (h *htmlExport) HasExistingBorder(col, row int, side string) bool {
 		return borderSet(c.borders.Top)
 	case "bottom":
 		return borderSet(c.borders.Bottom)
+	case "diagonalUp":
+		return borderSet(c.borders.DiagonalUp)
+	case "diagonalDown":
+		return borderSet(c.borders.DiagonalDown)
 	}
 	return false
 }
@@ -407,7 +493,19 @@ func (h *htmlExport) ApplyStyleToRange(startCol, startRow, endCol, endRow int, s
 	return nil
 }
 
+// CellRef returns the index-based reference of a cell, as HTML tables have no column letters
+// (see CellAddresser).
+func (h *htmlExport) CellRef(col, row int) string {
+	return R1C1Addresser{}.CellRef(col, row)
+}
+
+// RangeRef returns the index-based reference of a range (see CellAddresser).
+func (h *htmlExport) RangeRef(startCol, startRow, endCol, endRow int) string {
+	return R1C1Addresser{}.RangeRef(startCol, startRow, endCol, endRow)
+}
+
 // GetColumnLetter returns the spreadsheet-style column letter for a 1-based index.
+// Kept for TableOperations; HTML cells are addressed by index (see CellRef).
 func (h *htmlExport) GetColumnLetter(col int) string {
 	if col <= 0 {
 		return ""
@@ -432,6 +530,10 @@ func (h *htmlExport) ProcessValue(value interface{}, format string) (interface{}
 	if img, ok := asImage(value); ok {
 		return img.TextValue(), nil
 	}
+	if text, ok := FormatDuration(value, format); ok {
+		return text, nil
+	}
+	value = NormalizeValue(value)
 	switch v := value.(type) {
 	case []interface{}:
 		if h.table.ListSeparator != "" {
@@ -547,7 +649,11 @@ func (h *htmlExport) writeTable(b *strings.Builder) {
 	if css := styleToCSS(opts.TableStyle); css != "" {
 		tableStyle += ";" + css
 	}
-	b.WriteString(fmt.Sprintf("<table style=\"%s\">\n", tableStyle))
+	dir := ""
+	if t.RightToLeft {
+		dir = ` dir="rtl"`
+	}
+	b.WriteString(fmt.Sprintf("<table%s style=\"%s\">\n", dir, tableStyle))
 
 	if h.caption != "" {
 		b.WriteString(fmt.Sprintf("<caption>%s</caption>\n", html.EscapeString(h.caption)))
@@ -562,12 +668,28 @@ func (h *htmlExport) writeTable(b *strings.Builder) {
 		headerEnd = headerStart + t.Columns.GetMaxDepth() - 1
 	}
 
+	// Transposed tables have header columns rather than header rows: every row is a body row
+	if h.transposed {
+		b.WriteString("<tbody>\n")
+		for row := 1; row <= h.maxRow; row++ {
+			h.writeRow(b, row, headerStart, headerEnd)
+		}
+		b.WriteString("</tbody>\n</table>\n")
+		return
+	}
+
 	// The <thead> spans every row above the data (preamble rows and header rows);
-	// the <tbody> holds the data rows.
+	// the <tbody> holds the data rows and the <tfoot> the footer and truncation notice rows, if any.
 	theadEnd := headerStart - 1 // preamble rows only
 	if headerEnd >= headerStart {
 		theadEnd = headerEnd
 	}
+	tbodyEnd := h.maxRow
+	if t.hasFooter() {
+		tbodyEnd = t.GetFooterRow() - 1
+	} else if t.hasTruncationNotice() {
+		tbodyEnd = t.GetTruncationNoticeRow() - 1
+	}
 
 	if theadEnd >= 1 {
 		b.WriteString("<thead>\n")
@@ -576,27 +698,38 @@ func (h *htmlExport) writeTable(b *strings.Builder) {
 		}
 		b.WriteString("</thead>\n")
 	}
-	if h.maxRow > theadEnd {
+	if tbodyEnd > theadEnd {
 		b.WriteString("<tbody>\n")
-		for row := theadEnd + 1; row <= h.maxRow; row++ {
+		for row := theadEnd + 1; row <= tbodyEnd; row++ {
 			h.writeRow(b, row, headerStart, headerEnd)
 		}
 		b.WriteString("</tbody>\n")
 	}
+	if h.maxRow > tbodyEnd {
+		b.WriteString("<tfoot>\n")
+		for row := tbodyEnd + 1; row <= h.maxRow; row++ {
+			h.writeRow(b, row, headerStart, headerEnd)
+		}
+		b.WriteString("</tfoot>\n")
+	}
 
 	b.WriteString("</table>\n")
 }
 
-// writeRow serializes a single grid row, skipping cells absorbed by a merge.
+// writeRow serializes a single grid row, skipping cells absorbed by a merge. headerStart and
+// headerEnd delimit the header rows of the table (header columns of the grid once transposed).
 func (h *htmlExport) writeRow(b *strings.Builder, row, headerStart, headerEnd int) {
 	b.WriteString("<tr>\n")
-	isHeader := row >= headerStart && row <= headerEnd
 	for col := 1; col <= h.maxCol; col++ {
 		c := h.peek(col, row)
 		if c != nil && c.covered {
 			continue
 		}
-		h.renderCell(b, c, col, row, isHeader)
+		tableRow := row
+		if h.transposed {
+			tableRow = col
+		}
+		h.renderCell(b, c, col, row, tableRow >= headerStart && tableRow <= headerEnd)
 	}
 	b.WriteString("</tr>\n")
 }
@@ -604,6 +737,9 @@ func (h *htmlExport) writeRow(b *strings.Builder, row, headerStart, headerEnd in
 // writeColgroup emits a <colgroup> mapping each leaf column's Width (in character units)
 // to a CSS ch width. It is skipped entirely when no column has an explicit width.
 func (h *htmlExport) writeColgroup(b *strings.Builder) {
+	if h.transposed {
+		return // Grid columns hold records rather than table columns
+	}
 	flat := h.table.Columns.GetFlattenedColumns()
 	hasWidth := false
 	for _, c := range flat {
@@ -638,6 +774,7 @@ func (h *htmlExport) renderCell(b *strings.Builder, c *htmlCell, col, row int, i
 	var image *Image
 	var style *Style
 	var borders Borders
+	title := ""
 	if c != nil {
 		colspan = max(c.colspan, 1)
 		rowspan = max(c.rowspan, 1)
@@ -646,6 +783,7 @@ func (h *htmlExport) renderCell(b *strings.Builder, c *htmlCell, col, row int, i
 		image = c.image
 		style = c.style
 		borders = h.effectiveBorders(col, row, colspan, rowspan)
+		title = c.title
 	}
 
 	// The theme's stylesheet controls cell padding; otherwise apply a small inline default.
@@ -654,10 +792,14 @@ func (h *htmlExport) renderCell(b *strings.Builder, c *htmlCell, col, row int, i
 		basePadding = ""
 	}
 
-	// Right-align numeric data cells that carry no explicit alignment.
+	// Right-align numeric data cells that carry no explicit alignment, or left-align them in
+	// right-to-left tables, like spreadsheet applications do.
 	numericAlign := ""
 	if c != nil && c.numeric && (style == nil || style.Alignment == AlignmentNone) {
 		numericAlign = "text-align:right"
+		if h.table.RightToLeft {
+			numericAlign = "text-align:left"
+		}
 	}
 
 	css := combineCSS(basePadding, styleToCSS(style), numericAlign, bordersToCSS(borders))
@@ -669,15 +811,29 @@ func (h *htmlExport) renderCell(b *strings.Builder, c *htmlCell, col, row int, i
 	if rowspan > 1 {
 		attrs.WriteString(fmt.Sprintf(" rowspan=\"%d\"", rowspan))
 	}
-	if isHeader {
+	if title != "" {
+		attrs.WriteString(fmt.Sprintf(" title=\"%s\"", html.EscapeString(title)))
+	}
+	if isHeader && h.transposed {
+		attrs.WriteString(" scope=\"row\"")
+	} else if isHeader {
 		attrs.WriteString(" scope=\"col\"")
 	}
+	if h.transposed {
+		attrs.WriteString(h.metaAttributes(row, col))
+	} else {
+		attrs.WriteString(h.metaAttributes(col, row))
+	}
 
 	var content string
 	if image != nil {
 		content = imgTag(*image)
 	} else {
 		content = html.EscapeString(text)
+		if style != nil && style.VertAlign.htmlTag() != "" && content != "" {
+			tag := style.VertAlign.htmlTag()
+			content = fmt.Sprintf("<%s>%s</%s>", tag, content, tag)
+		}
 	}
 	if link != "" {
 		content = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(link), content)
@@ -692,9 +848,13 @@ func (h *htmlExport) renderCell(b *strings.Builder, c *htmlCell, col, row int, i
 
 // effectiveBorders computes the outer borders of a (possibly spanned) cell by
 // scanning the edge cells of its merge range. This preserves borders that the
-// styling pipeline applied to cells later absorbed by a merge.
+// styling pipeline applied to cells later absorbed by a merge. Diagonals are
+// those of the merge origin, crossing the whole spanned cell.
 func (h *htmlExport) effectiveBorders(col, row, colspan, rowspan int) Borders {
 	var res Borders
+	if cell := h.peek(col, row); cell != nil {
+		res.DiagonalUp, res.DiagonalDown = cell.borders.DiagonalUp, cell.borders.DiagonalDown
+	}
 	lastCol := col + colspan - 1
 	lastRow := row + rowspan - 1
 
@@ -826,6 +986,27 @@ func mergeStyleInto(c *htmlCell, style Style) {
 	if style.NumFmt != "" {
 		cur.NumFmt = style.NumFmt
 	}
+	if style.WrapText {
+		cur.WrapText = true
+	}
+	if style.ShrinkToFit {
+		cur.ShrinkToFit = true
+	}
+	if style.TextRotation != 0 {
+		cur.TextRotation = style.TextRotation
+	}
+	if style.TextDirection != TextDirectionContext {
+		cur.TextDirection = style.TextDirection
+	}
+	if style.IndentLevel > 0 {
+		cur.IndentLevel = style.IndentLevel
+	}
+	if style.Strikethrough {
+		cur.Strikethrough = true
+	}
+	if style.VertAlign != VertAlignBaseline {
+		cur.VertAlign = style.VertAlign
+	}
 }
 
 // styleToCSS converts a Style to an inline CSS declaration string (empty if nil/blank).
@@ -840,8 +1021,13 @@ func styleToCSS(s *Style) string {
 	if s.Italic {
 		parts = append(parts, "font-style:italic")
 	}
-	if s.Underline != "" {
+	switch {
+	case s.Underline != "" && s.Strikethrough:
+		parts = append(parts, "text-decoration:underline line-through")
+	case s.Underline != "":
 		parts = append(parts, "text-decoration:underline")
+	case s.Strikethrough:
+		parts = append(parts, "text-decoration:line-through")
 	}
 	if s.TextColor != "" {
 		parts = append(parts, "color:"+cssColor(s.TextColor))
@@ -860,47 +1046,94 @@ func styleToCSS(s *Style) string {
 		parts = append(parts, "text-align:"+horizontal)
 		parts = append(parts, "vertical-align:"+cssVerticalAlign(vertical))
 	}
+	if s.WrapText {
+		parts = append(parts, "white-space:pre-wrap", "overflow-wrap:anywhere")
+	}
+	if direction := s.TextDirection.cssDirection(); direction != "" {
+		parts = append(parts, "direction:"+direction)
+	}
+	if s.IndentLevel > 0 {
+		parts = append(parts, fmt.Sprintf("padding-inline-start:%dem", s.IndentLevel))
+	}
 	return strings.Join(parts, ";")
 }
 
-// bordersToCSS converts a Borders configuration to inline CSS border declarations.
+// bordersToCSS converts a Borders configuration to inline CSS border declarations. CSS has no
+// diagonal borders: diagonals are approximated by solid lines drawn as background gradients.
 func bordersToCSS(b Borders) string {
 	var parts []string
 	if borderSet(b.Left) {
-		parts = append(parts, "border-left:"+borderStyleToCSS(b.Left.Style))
+		parts = append(parts, "border-left:"+borderToCSS(b.Left))
 	}
 	if borderSet(b.Right) {
-		parts = append(parts, "border-right:"+borderStyleToCSS(b.Right.Style))
+		parts = append(parts, "border-right:"+borderToCSS(b.Right))
 	}
 	if borderSet(b.Top) {
-		parts = append(parts, "border-top:"+borderStyleToCSS(b.Top.Style))
+		parts = append(parts, "border-top:"+borderToCSS(b.Top))
 	}
 	if borderSet(b.Bottom) {
-		parts = append(parts, "border-bottom:"+borderStyleToCSS(b.Bottom.Style))
+		parts = append(parts, "border-bottom:"+borderToCSS(b.Bottom))
+	}
+	var diagonals []string
+	if borderSet(b.DiagonalUp) {
+		diagonals = append(diagonals, diagonalToCSS("to top right", b.DiagonalUp))
+	}
+	if borderSet(b.DiagonalDown) {
+		diagonals = append(diagonals, diagonalToCSS("to bottom right", b.DiagonalDown))
+	}
+	if len(diagonals) > 0 {
+		parts = append(parts, "background-image:"+strings.Join(diagonals, ","))
 	}
 	return strings.Join(parts, ";")
 }
 
-// borderStyleToCSS maps a BorderStyle to a CSS border shorthand value.
-func borderStyleToCSS(style BorderStyle) string {
+// borderToCSS maps a Border to a CSS border shorthand value, black unless colored.
+func borderToCSS(b *Border) string {
+	width, line := borderLineCSS(b.Style)
+	if line == "" {
+		return ""
+	}
+	return fmt.Sprintf("%dpx %s %s", width, line, borderColorCSS(b))
+}
+
+// borderLineCSS maps a BorderStyle to a CSS border width in pixels and line style.
+func borderLineCSS(style BorderStyle) (int, string) {
 	switch style {
 	case BorderStyleThin:
-		return "1px solid #000000"
+		return 1, "solid"
 	case BorderStyleMedium:
-		return "2px solid #000000"
+		return 2, "solid"
 	case BorderStyleThick:
-		return "3px solid #000000"
+		return 3, "solid"
 	case BorderStyleDashed:
-		return "1px dashed #000000"
+		return 1, "dashed"
 	case BorderStyleDotted:
-		return "1px dotted #000000"
+		return 1, "dotted"
 	case BorderStyleDouble:
-		return "3px double #000000"
+		return 3, "double"
 	default:
-		return ""
+		return 0, ""
 	}
 }
 
+// borderColorCSS returns the CSS color of a border, black unless colored.
+func borderColorCSS(b *Border) string {
+	if b.Color == "" {
+		return "#000000"
+	}
+	return cssColor(b.Color)
+}
+
+// diagonalToCSS draws a diagonal border as a linear gradient towards the given corner, a solid
+// line of the border width and color.
+func diagonalToCSS(direction string, b *Border) string {
+	width, _ := borderLineCSS(b.Style)
+	color := borderColorCSS(b)
+	half := float64(width) / 2
+	return fmt.Sprintf("linear-gradient(%s,transparent calc(50%% - %gpx),%s calc(50%% - %gpx),%s calc(50%% + %gpx),transparent calc(50%% + %gpx))",
+		direction, half, color, half, color, half, half)
+}
+
 // borderSet reports whether a border is present and visible.
 func borderSet(b *Border) bool {
 	return b != nil && b.Style != BorderStyleNone
@@ -953,3 +1186,18 @@ func combineCSS(parts ...string) string {
 	}
 	return strings.Join(nonEmpty, ";")
 }
+
+// metaAttributes returns the metadata of a data cell (see CellOptions.Meta) as data-* attributes,
+// sorted by key; e.g. the key "kpi.total" becomes data-kpi-total.
+func (h *htmlExport) metaAttributes(col, row int) string {
+	meta := h.table.CellOptionsMap[col][row-h.table.GetDataStartRow()].Meta
+	var b strings.Builder
+	for _, key := range sortedKeys(meta) {
+		attr := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(SanitizeDefinedName(key), "_"), ".", "-"))
+		if attr == "" {
+			continue
+		}
+		b.WriteString(fmt.Sprintf(" data-%s=\"%s\"", attr, html.EscapeString(meta[key])))
+	}
+	return b.String()
+}
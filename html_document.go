@@ -153,24 +153,36 @@ func ExportHTMLDocument(doc *HTMLDocument, params FileWriteParams) (*FileWriteRe
 		params.Extension = FormatHTML.String()
 	}
 
-	L().Info("Starting HTML document export to file", String("filename", params.Filename))
-
-	markup, err := doc.render()
+	params.logger().Info("Starting HTML document export to file", String("filename", params.Filename))
+
+	// Document tables share the seed of the run, so the whole document can be reproduced
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+	rendered := *doc
+	rendered.Options.seed = params.Seed
+	rendered.Options.quota = params.quota
+	rendered.Options.progress = params.progress
+	rendered.Options.timings = params.timings
+
+	markup, err := rendered.render()
 	if err != nil {
-		L().Error("Failed to render HTML document", Error(err))
+		params.logger().Error("Failed to render HTML document", Error(err))
 		return nil, err
 	}
 
 	result, err := params.WriteToFile(func(writer io.Writer) error {
+		defer params.timings.measure(timingSave)()
 		_, werr := io.WriteString(writer, markup)
 		return werr
 	})
 	if err != nil {
-		L().Error("Failed to write HTML document to file", Error(err))
+		params.logger().Error("Failed to write HTML document to file", Error(err))
 		return nil, err
 	}
 
-	L().Info("HTML document export completed", String("filename", params.Filename))
+	params.logger().Info("HTML document export completed", String("filename", params.Filename))
 	return result, nil
 }
 
@@ -456,7 +468,14 @@ func (tc *TableContent) renderHTML(b *strings.Builder, opts HTMLOptions) error {
 	if tc.style != nil {
 		o.TableStyle = tc.style
 	}
-	export := &htmlExport{table: tc.table, opts: o, caption: tc.caption, grid: make(map[int]map[int]*htmlCell)}
+	prepared := opts.timings.measure(timingPrepare)
+	export := &htmlExport{table: tc.table.withSeed(opts.seed).Prepare().ForFormat(FormatHTML).withoutOffset().CacheValues(), opts: o, caption: tc.caption, grid: make(map[int]map[int]*htmlCell)}
+	prepared()
+	if err := opts.quota.addTable(export.table); err != nil {
+		return err
+	}
+	opts.progress.track(export.table, progressPhasesSheet)
+	opts.timings.track(export.table)
 	if err := export.build(); err != nil {
 		return err
 	}
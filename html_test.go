@@ -161,6 +161,10 @@ func TestStyleToCSS(t *testing.T) {
 		{"font size", &Style{FontSize: 12}, "font-size:12pt"},
 		{"font family with space", &Style{FontFamily: "Times New Roman"}, "font-family:'Times New Roman'"},
 		{"align center middle", &Style{Alignment: AlignmentCenterMiddle}, "text-align:center;vertical-align:middle"},
+		{"wrap text", &Style{WrapText: true}, "white-space:pre-wrap;overflow-wrap:anywhere"},
+		{"indent", &Style{IndentLevel: 2}, "padding-inline-start:2em"},
+		{"strikethrough", &Style{Strikethrough: true}, "text-decoration:line-through"},
+		{"underline and strikethrough", &Style{Underline: "single", Strikethrough: true}, "text-decoration:underline line-through"},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -189,6 +193,23 @@ func TestBordersToCSS(t *testing.T) {
 	}
 }
 
+func TestBordersToCSS_colorAndDiagonals(t *testing.T) {
+	got := bordersToCSS(Borders{
+		Top:          NewBorder(BorderStyleMedium).WithColor("1F4E78"),
+		DiagonalUp:   NewBorder(BorderStyleThin).WithColor("#FF0000"),
+		DiagonalDown: NewBorder(BorderStyleThin),
+	})
+	if !strings.Contains(got, "border-top:2px solid #1F4E78") {
+		t.Errorf("missing colored top border in %q", got)
+	}
+	if !strings.Contains(got, "background-image:linear-gradient(to top right,transparent calc(50% - 0.5px),#FF0000 calc(50% - 0.5px)") {
+		t.Errorf("missing diagonal up line in %q", got)
+	}
+	if !strings.Contains(got, ",linear-gradient(to bottom right,transparent calc(50% - 0.5px),#000000") {
+		t.Errorf("missing diagonal down line in %q", got)
+	}
+}
+
 func TestHTMLTheadTbody(t *testing.T) {
 	table := NewTable(testData, Columns{
 		NewColumn("name", "Name"),
@@ -258,3 +279,17 @@ func TestGetColumnLetter(t *testing.T) {
 		}
 	}
 }
+
+func TestHTMLHeaderDescription(t *testing.T) {
+	table := NewTable(testData, Columns{
+		NewColumn("name", "Name").WithDescription(`Full "legal" name`),
+		NewColumn("age", "Age"),
+	}, true)
+	out := buildHTML(t, table, HTMLOptions{})
+	if !strings.Contains(out, `<th title="Full &#34;legal&#34; name" scope="col"`) {
+		t.Errorf("expected the description as the title of the Name header, got:\n%s", out)
+	}
+	if strings.Count(out, "title=") != 1 {
+		t.Errorf("expected a single title attribute, got:\n%s", out)
+	}
+}
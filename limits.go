@@ -0,0 +1,183 @@
+// limits.go - Per-run resource limits.
+//
+// This file implements the resource limits of an export run (FileWriteParams.Limits) and the
+// accounting of the resources it uses (FileWriteResult.Usage), so multi-tenant services can bound
+// the cost of each customer export. Rows and cells are counted per prepared table, bytes as they
+// reach the destination and duration from the start of the run; the first limit exceeded fails
+// the export with a QuotaExceededError. Nested exports (the formats of ExportMulti, the entries of
+// ExportArchive) share the quota of their run.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Limits bounds the resources of an export run. Zero fields are unlimited.
+type Limits struct {
+	MaxRows     int           // Data rows over every table of the run (group and subtotal rows included)
+	MaxCells    int           // Data cells (rows × leaf columns) over every table of the run
+	MaxBytes    int64         // Bytes written to the destination (after compression)
+	MaxDuration time.Duration // Wall-clock time since the start of the run
+}
+
+// LimitKind identifies a resource limit.
+type LimitKind string
+
+const (
+	LimitRows     LimitKind = "rows"     // Limits.MaxRows
+	LimitCells    LimitKind = "cells"    // Limits.MaxCells
+	LimitBytes    LimitKind = "bytes"    // Limits.MaxBytes
+	LimitDuration LimitKind = "duration" // Limits.MaxDuration (values in nanoseconds)
+)
+
+// QuotaExceededError is returned by exports exceeding one of their Limits.
+type QuotaExceededError struct {
+	Limit LimitKind // Limit that tripped
+	Max   int64     // Configured maximum
+	Used  int64     // Amount reached when the limit tripped
+}
+
+// Error returns the error as a single line, e.g. "quota exceeded: rows 1200 > 1000".
+func (e *QuotaExceededError) Error() string {
+	if e.Limit == LimitDuration {
+		return fmt.Sprintf("quota exceeded: %s %s > %s", e.Limit, time.Duration(e.Used), time.Duration(e.Max))
+	}
+	return fmt.Sprintf("quota exceeded: %s %d > %d", e.Limit, e.Used, e.Max)
+}
+
+// Usage is the resources used by an export run.
+type Usage struct {
+	Rows     int           // Data rows exported
+	Cells    int           // Data cells exported
+	Bytes    int64         // Bytes written to the destination
+	Duration time.Duration // Time elapsed since the start of the run
+}
+
+// runQuota tracks the resources used by an export run against its limits. Methods accept a nil
+// quota (runs started outside an export function, e.g. Workbook updates), which tracks nothing.
+type runQuota struct {
+	mu     sync.Mutex
+	limits Limits
+	start  time.Time
+	used   Usage
+}
+
+// resolveQuota returns the quota of the run: the one shared by an enclosing export, or a new one
+// enforcing Limits.
+func (fwo FileWriteParams) resolveQuota() *runQuota {
+	if fwo.quota != nil {
+		return fwo.quota
+	}
+	q := &runQuota{start: time.Now()}
+	if fwo.Limits != nil {
+		q.limits = *fwo.Limits
+	}
+	return q
+}
+
+// addTable accounts for the data rows and cells of a prepared table and lets its phases check the
+// duration limit row by row (see Table.advance).
+func (q *runQuota) addTable(t *Table) error {
+	t.quota = q
+	rows := len(t.Data)
+	return q.addRows(rows, rows*len(t.Columns.GetFlattenedColumns()))
+}
+
+// addRows accounts for data rows and cells, e.g. those of a table streamed row by row.
+func (q *runQuota) addRows(rows, cells int) error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.used.Rows += rows
+	q.used.Cells += cells
+	if err := exceeded(LimitRows, int64(q.limits.MaxRows), int64(q.used.Rows)); err != nil {
+		return err
+	}
+	if err := exceeded(LimitCells, int64(q.limits.MaxCells), int64(q.used.Cells)); err != nil {
+		return err
+	}
+	return q.checkDuration()
+}
+
+// addBytes accounts for n bytes about to be written.
+func (q *runQuota) addBytes(n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := exceeded(LimitBytes, q.limits.MaxBytes, q.used.Bytes+int64(n)); err != nil {
+		return err
+	}
+	q.used.Bytes += int64(n)
+	return q.checkDuration()
+}
+
+// check fails once the run has lasted longer than its limit.
+func (q *runQuota) check() error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.checkDuration()
+}
+
+// checkDuration fails once the run has lasted longer than its limit. The caller holds q.mu.
+func (q *runQuota) checkDuration() error {
+	return exceeded(LimitDuration, int64(q.limits.MaxDuration), int64(time.Since(q.start)))
+}
+
+// advance accounts for n processed row steps of a phase of the table (see runProgress.advance)
+// and fails once the run has lasted longer than its duration limit, so long exports stop while
+// writing their data rather than once done.
+func (t *Table) advance(n int) error {
+	t.progress.advance(n)
+	return t.quota.check()
+}
+
+// usage returns the resources used so far.
+func (q *runQuota) usage() Usage {
+	if q == nil {
+		return Usage{}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	usage := q.used
+	usage.Duration = time.Since(q.start)
+	return usage
+}
+
+// writer returns w counting the written bytes against the quota. Writes that would exceed the
+// byte limit fail without writing anything.
+func (q *runQuota) writer(w io.Writer) io.Writer {
+	if q == nil {
+		return w
+	}
+	return &quotaWriter{w: w, quota: q}
+}
+
+// exceeded returns a QuotaExceededError when used is over a non-zero max.
+func exceeded(limit LimitKind, max, used int64) error {
+	if max > 0 && used > max {
+		return &QuotaExceededError{Limit: limit, Max: max, Used: used}
+	}
+	return nil
+}
+
+// quotaWriter counts the bytes written through it against a run quota.
+type quotaWriter struct {
+	w     io.Writer
+	quota *runQuota
+}
+
+// Write writes p once accounted for.
+func (qw *quotaWriter) Write(p []byte) (int, error) {
+	if err := qw.quota.addBytes(len(p)); err != nil {
+		return 0, err
+	}
+	return qw.w.Write(p)
+}
@@ -0,0 +1,154 @@
+package spit
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLimits(t *testing.T) {
+	table := func() *Table {
+		return NewTable(DataSlice{{"a": 1, "b": "x"}, {"a": 2, "b": "y"}, {"a": 3, "b": "z"}},
+			Columns{NewColumn("a", "A"), NewColumn("b", "B")}, true)
+	}
+
+	tests := []struct {
+		name   string
+		limits Limits
+		export func(params FileWriteParams) (*FileWriteResult, error)
+		want   *QuotaExceededError
+	}{
+		{
+			name:   "rows",
+			limits: Limits{MaxRows: 2},
+			export: func(params FileWriteParams) (*FileWriteResult, error) { return ExportCSV(",", table(), params) },
+			want:   &QuotaExceededError{Limit: LimitRows, Max: 2, Used: 3},
+		},
+		{
+			name:   "cells over every sheet",
+			limits: Limits{MaxCells: 10},
+			export: func(params FileWriteParams) (*FileWriteResult, error) {
+				return ExportXLSXSheets([]Spreadsheet{NewSpreadsheetExcelize("One", table()), NewSpreadsheetExcelize("Two", table())}, params)
+			},
+			want: &QuotaExceededError{Limit: LimitCells, Max: 10, Used: 12},
+		},
+		{
+			name:   "bytes",
+			limits: Limits{MaxBytes: 10},
+			export: func(params FileWriteParams) (*FileWriteResult, error) { return ExportMarkdown(table(), params) },
+			want:   &QuotaExceededError{Limit: LimitBytes, Max: 10},
+		},
+		{
+			name:   "duration",
+			limits: Limits{MaxDuration: time.Nanosecond},
+			export: func(params FileWriteParams) (*FileWriteResult, error) { return ExportParquet(table(), params) },
+			want:   &QuotaExceededError{Limit: LimitDuration, Max: 1},
+		},
+		{
+			name:   "within limits",
+			limits: Limits{MaxRows: 3, MaxCells: 6, MaxBytes: 1 << 20, MaxDuration: time.Minute},
+			export: func(params FileWriteParams) (*FileWriteResult, error) {
+				return ExportHTML(table(), HTMLOptions{}, params)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.export(FileWriteParams{Filename: "limited", Filepath: t.TempDir(), Limits: &tt.limits})
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("export failed: %v", err)
+				}
+				info, err := os.Stat(res.Filepath)
+				if err != nil {
+					t.Fatalf("Stat failed: %v", err)
+				}
+				if res.Usage.Rows != 3 || res.Usage.Cells != 6 || res.Usage.Bytes != info.Size() || res.Usage.Duration <= 0 {
+					t.Errorf("usage = %+v, want 3 rows, 6 cells and %d bytes", res.Usage, info.Size())
+				}
+				return
+			}
+
+			var quotaErr *QuotaExceededError
+			if !errors.As(err, &quotaErr) {
+				t.Fatalf("error = %v, want a QuotaExceededError", err)
+			}
+			if quotaErr.Limit != tt.want.Limit || quotaErr.Max != tt.want.Max || quotaErr.Used <= quotaErr.Max ||
+				tt.want.Used != 0 && quotaErr.Used != tt.want.Used {
+				t.Errorf("error = %+v, want %+v", quotaErr, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimits_nestedExports(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1}, {"a": 2}}, Columns{NewColumn("a", "A")}, true)
+
+	// The formats of a multi-format export share the limits of the run
+	_, err := ExportMulti(table, []Format{FormatCSV, FormatMarkdown}, MultiExportParams{
+		FileWriteParams: FileWriteParams{Filename: "multi", Filepath: t.TempDir(), Limits: &Limits{MaxRows: 3}},
+	})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Limit != LimitRows || quotaErr.Used != 4 {
+		t.Errorf("ExportMulti error = %v, want the rows limit exceeded with 4 rows", err)
+	}
+
+	// Archive bytes are counted once compressed, entry rows add up
+	res, err := ExportArchive([]*ArchiveJob{
+		NewArchiveJob("one", FormatCSV, table),
+		NewArchiveJob("two", FormatCSV, table),
+	}, FileWriteParams{Filename: "bundle", Filepath: t.TempDir(), Limits: &Limits{MaxRows: 4}})
+	if err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	info, err := os.Stat(res.Filepath)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if res.Usage.Rows != 4 || res.Usage.Bytes != info.Size() {
+		t.Errorf("usage = %+v, want 4 rows and %d bytes", res.Usage, info.Size())
+	}
+}
+
+func TestQuotaExceededError_Error(t *testing.T) {
+	tests := []struct {
+		err  *QuotaExceededError
+		want string
+	}{
+		{&QuotaExceededError{Limit: LimitRows, Max: 1000, Used: 1200}, "quota exceeded: rows 1200 > 1000"},
+		{&QuotaExceededError{Limit: LimitDuration, Max: int64(time.Second), Used: int64(1500 * time.Millisecond)}, "quota exceeded: duration 1.5s > 1s"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+// slowValue is a cell value taking a while to format, counting its calls.
+type slowValue struct{ calls *int }
+
+func (v slowValue) String() string {
+	*v.calls++
+	time.Sleep(5 * time.Millisecond)
+	return "slow"
+}
+
+func TestLimits_durationDuringRows(t *testing.T) {
+	calls := 0
+	data := make(DataSlice, 20)
+	for i := range data {
+		data[i] = Data{"a": slowValue{calls: &calls}}
+	}
+	table := NewTable(data, Columns{NewColumn("a", "A")}, true)
+
+	_, err := ExportCSV(",", table, FileWriteParams{Filename: "slow", Filepath: t.TempDir(), Limits: &Limits{MaxDuration: 20 * time.Millisecond}})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Limit != LimitDuration {
+		t.Fatalf("error = %v, want the duration limit exceeded", err)
+	}
+	if calls >= len(data) {
+		t.Errorf("formatted %d rows, want the export stopped while writing rows", calls)
+	}
+}
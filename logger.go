@@ -38,6 +38,44 @@ type Field struct {
 	Value interface{}
 }
 
+// NopLogger discards every message, e.g. to silence a single export (see FileWriteParams.Logger).
+type NopLogger struct{}
+
+func (NopLogger) Debug(string, ...Field) {}
+func (NopLogger) Info(string, ...Field)  {}
+func (NopLogger) Warn(string, ...Field)  {}
+func (NopLogger) Error(string, ...Field) {}
+
+// WithFields returns a logger adding fields to every message of logger, e.g. the request or job
+// identifier of the caller. A nil logger adds them to the global logger, as set when logging.
+func WithFields(logger Logger, fields ...Field) Logger {
+	return &fieldLogger{logger: logger, fields: fields}
+}
+
+// fieldLogger adds fields to the messages of a logger.
+type fieldLogger struct {
+	logger Logger
+	fields []Field
+}
+
+func (l *fieldLogger) Debug(msg string, fields ...Field) { l.target().Debug(msg, l.with(fields)...) }
+func (l *fieldLogger) Info(msg string, fields ...Field)  { l.target().Info(msg, l.with(fields)...) }
+func (l *fieldLogger) Warn(msg string, fields ...Field)  { l.target().Warn(msg, l.with(fields)...) }
+func (l *fieldLogger) Error(msg string, fields ...Field) { l.target().Error(msg, l.with(fields)...) }
+
+// target returns the wrapped logger, or the current global logger.
+func (l *fieldLogger) target() Logger {
+	if l.logger != nil {
+		return l.logger
+	}
+	return L()
+}
+
+// with returns the logger fields followed by the message fields.
+func (l *fieldLogger) with(fields []Field) []Field {
+	return append(append(make([]Field, 0, len(l.fields)+len(fields)), l.fields...), fields...)
+}
+
 // String returns a Field with a string value.
 func String(key, val string) Field {
 	return Field{Key: key, Value: val}
@@ -63,14 +101,26 @@ func Any(key string, val interface{}) Field {
 	return Field{Key: key, Value: val}
 }
 
+// logger returns the logger of the run: Logger when set, the global logger otherwise.
+func (fwo FileWriteParams) logger() Logger {
+	if fwo.Logger != nil {
+		return fwo.Logger
+	}
+	return L()
+}
+
 // L returns the global logger instance.
 func L() Logger {
 	return _logger
 }
 
-// SetLogger replaces the global logger and returns a function to restore the previous one
+// SetLogger replaces the global logger and returns a function to restore the previous one.
+// A nil logger silences every message, whatever the log level.
 func SetLogger(newLogger Logger) func() {
 	prev := _logger
+	if newLogger == nil {
+		newLogger = NopLogger{}
+	}
 	_logger = newLogger
 	return func() { _logger = prev }
 }
@@ -1,8 +1,11 @@
 package spit
 
 import (
+	"bytes"
+	"log/slog"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -163,3 +166,45 @@ func TestResetLogger(t *testing.T) {
 		t.Errorf("ResetLogger() did not reset level to LevelInfo, got %v", GetLogLevel())
 	}
 }
+
+func TestSetLogger_nil(t *testing.T) {
+	restore := SetLogger(nil)
+	defer restore()
+	if _, ok := L().(NopLogger); !ok {
+		t.Errorf("SetLogger(nil) installed %T, want NopLogger", L())
+	}
+	L().Error("silenced")
+}
+
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := WithFields(NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil))), String("job", "42"))
+	logger.Info("Export completed", String("filename", "report"))
+
+	if got := buf.String(); !strings.Contains(got, "job=42 filename=report") {
+		t.Errorf("output = %q, want the logger fields before the message fields", got)
+	}
+}
+
+func TestFileWriteParams_Logger(t *testing.T) {
+	restore := SetLogger(nil)
+	defer restore()
+
+	var buf bytes.Buffer
+	table := NewTable(DataSlice{{"a": -1}}, Columns{NewColumn("a", "A").WithValidation("value >= 0", "")}, true)
+	_, err := ExportCSV(",", table, FileWriteParams{
+		Filename: "logged",
+		Writer:   &bytes.Buffer{},
+		Logger:   WithFields(NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil))), String("request", "r-1")),
+	})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Starting CSV export to file", "level=WARN", "request=r-1", "CSV export completed"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("run logger output %q is missing %q", output, want)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// markdown.go - Markdown export.
+//
+// This file implements the GitHub-flavored markdown export of tables, handy for bots posting
+// summaries into pull requests, issues and chat. Multi-level headers are flattened into a single
+// header row ("Sales / Q1"), column alignments come from the horizontal alignment of the column
+// styles, and values are formatted like CSV values.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownHeaderSeparator joins the labels of parent and leaf columns in flattened headers.
+const MarkdownHeaderSeparator = " / "
+
+// ExportMarkdown exports the table to a markdown file holding a GitHub-flavored markdown table.
+// The ".md" extension is added when params.Extension is empty.
+func ExportMarkdown(t *Table, params FileWriteParams) (*FileWriteResult, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no table provided")
+	}
+	if params.Extension == "" {
+		params.Extension = FormatMarkdown.String()
+	}
+	if parts := t.chunks(); parts != nil {
+		return exportParts(parts, params, ExportMarkdown)
+	}
+
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+	prepared := params.timings.measure(timingPrepare)
+	md := newMarkdown(t.withSeed(params.Seed), params)
+	prepared()
+	if err := params.quota.addTable(md.table); err != nil {
+		return nil, err
+	}
+	params.progress.track(md.table, progressPhasesFlat)
+
+	params.logger().Info("Starting Markdown export to file", String("filename", params.Filename))
+
+	markup, err := md.render()
+	if err != nil {
+		params.logger().Error("Failed to render Markdown table", Error(err))
+		return nil, err
+	}
+	result, err := params.WriteToFile(func(writer io.Writer) error {
+		_, err := io.WriteString(writer, markup)
+		return err
+	})
+	if err != nil {
+		params.logger().Error("Failed to write Markdown to file", Error(err))
+		return nil, err
+	}
+
+	result.Warnings = md.table.exportWarnings()
+	result.SparseColumns = md.table.sparseColumns("")
+
+	params.logger().Info("Markdown export completed", String("filename", params.Filename))
+	return result, nil
+}
+
+// RenderMarkdown returns the table as a GitHub-flavored markdown table, e.g. to post it through
+// an API without writing a file.
+func RenderMarkdown(t *Table) (string, error) {
+	if t == nil {
+		return "", fmt.Errorf("no table provided")
+	}
+	return newMarkdown(t, FileWriteParams{}).render()
+}
+
+// markdown contains Markdown-specific export logic.
+type markdown struct {
+	table     *Table // Prepared table being exported
+	watermark string // Optional watermark paragraph written before the table (preview mode)
+}
+
+// newMarkdown prepares t for a Markdown export.
+func newMarkdown(t *Table, params FileWriteParams) *markdown {
+	md := &markdown{table: t.Prepare().ForFormat(FormatMarkdown).withWarnings("", params.OnWarning, params.Logger)}
+	if t.Preview != nil {
+		md.watermark = t.Preview.GetWatermark()
+	}
+	return md
+}
+
+// render returns the markdown of the table: the optional watermark, then the header row, the
+// delimiter row carrying the alignments, the data rows and the footer row.
+func (md *markdown) render() (string, error) {
+	t := md.table
+	flatColumns := t.Columns.GetFlattenedColumns()
+	if len(flatColumns) == 0 {
+		return "", fmt.Errorf("no columns to export")
+	}
+
+	var records [][]string
+	header := make([]string, len(flatColumns))
+	if t.WriteHeader {
+		md.fillHeaders(header, "", t.Columns, 0)
+	}
+	records = append(records, header)
+
+	for rowIndex, item := range t.Data {
+		if err := t.advance(1); err != nil {
+			return "", err
+		}
+		record := make([]string, len(flatColumns))
+		// Full-width rows with an explicit value hold that value alone, like their merged cell in sheets
+		if rc, ok := t.RowOptionsMap[rowIndex]; ok && rc.SpanAllColumns && rc.Value != nil {
+			record[0] = escapeMarkdown(fmt.Sprintf("%v", rc.Value))
+			records = append(records, record)
+			continue
+		}
+		for i, column := range flatColumns {
+			value, err, found := item.LookupColumn(column)
+			if err != nil {
+				return "", fmt.Errorf("error looking up value for column %s in row %d: %w", column.Name, rowIndex, err)
+			}
+			if text, ok := t.CellPlaceholder(column, value, found); ok {
+				record[i] = escapeMarkdown(text)
+				continue
+			}
+			if !found {
+				continue
+			}
+			value = t.inLocation(column, value)
+			processed, typed, err := column.typedText(value)
+			if typed {
+				processed = escapeMarkdown(processed)
+			} else if err == nil {
+				processed, err = md.processValue(value, column.Format)
+			}
+			if err != nil {
+				return "", fmt.Errorf("error processing value for column %s in row %d: %w", column.Name, rowIndex, err)
+			}
+			record[i] = processed
+		}
+		records = append(records, record)
+	}
+
+	if t.hasFooter() {
+		record := make([]string, len(flatColumns))
+		for i, value := range t.FooterValues() {
+			if value != nil && fmt.Sprintf("%v", value) != "" {
+				record[i] = "**" + escapeMarkdown(fmt.Sprintf("%v", value)) + "**"
+			}
+		}
+		records = append(records, record)
+	}
+
+	// Transposed tables run the labels down the first column; the first record stays the header
+	alignments := make([]string, len(flatColumns))
+	for i, column := range flatColumns {
+		alignments[i] = markdownAlignment(column.Style)
+	}
+	if t.Transposed {
+		records = transposeRecords(records)
+		alignments = make([]string, len(records[0]))
+		for i := range alignments {
+			alignments[i] = markdownAlignment(nil)
+		}
+	}
+
+	var b strings.Builder
+	if md.watermark != "" {
+		b.WriteString("_" + escapeMarkdown(md.watermark) + "_\n\n")
+	}
+	writeMarkdownRow(&b, records[0])
+	writeMarkdownRow(&b, alignments)
+	for _, record := range records[1:] {
+		writeMarkdownRow(&b, record)
+	}
+	if t.hasTruncationNotice() {
+		b.WriteString("\n_" + escapeMarkdown(t.TruncationNotice.GetText(t.truncated)) + "_\n")
+	}
+	if err := t.strictErr(); err != nil {
+		return "", err
+	}
+	t.progress.finish()
+	return b.String(), nil
+}
+
+// fillHeaders fills the flattened label of every leaf column, prefixed by the labels of its
+// parent columns, and returns the next column index.
+func (md *markdown) fillHeaders(header []string, prefix string, columns Columns, col int) int {
+	for _, column := range columns {
+		label := column.Label
+		if prefix != "" {
+			label = prefix + MarkdownHeaderSeparator + label
+		}
+		if column.HasSubColumns() {
+			col = md.fillHeaders(header, label, column.Columns, col)
+			continue
+		}
+		header[col] = escapeMarkdown(label)
+		col++
+	}
+	return col
+}
+
+// processValue processes a value like CSV values, then escapes it for a table cell. Images with a
+// URL are written as markdown images.
+func (md *markdown) processValue(value interface{}, format string) (string, error) {
+	if img, ok := asImage(value); ok {
+		if img.URL != "" {
+			return "![" + escapeMarkdown(img.AltText) + "](" + img.URL + ")", nil
+		}
+		return escapeMarkdown(img.TextValue()), nil
+	}
+	if text, ok := FormatDuration(value, format); ok {
+		return escapeMarkdown(text), nil
+	}
+	value = NormalizeValue(value)
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		if md.table.ListSeparator != "" {
+			joined, err := ConvertSliceToString(v, format, md.table.ListSeparator)
+			if err != nil {
+				return "", err
+			}
+			return escapeMarkdown(joined), nil
+		}
+	default:
+		if format != "" {
+			var err error
+			value, err = FormatValue(value, format)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return escapeMarkdown(fmt.Sprintf("%v", value)), nil
+}
+
+// markdownAlignment returns the delimiter cell matching the horizontal alignment of a style.
+func markdownAlignment(style *Style) string {
+	if style == nil || style.Alignment == AlignmentNone {
+		return "---"
+	}
+	switch horizontal, _ := style.Alignment.GetAlignmentValues(); horizontal {
+	case "center":
+		return ":---:"
+	case "right":
+		return "---:"
+	}
+	return ":---"
+}
+
+// markdownEscaper escapes the characters breaking a table cell: pipes and line breaks.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// escapeMarkdown escapes s for a table cell.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// writeMarkdownRow writes a table row.
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" " + cell + " |")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,92 @@
+package spit
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	data := DataSlice{
+		{"name": "Alice | Bob", "q1": 10, "q2": 20.5, "note": "line\nbreak"},
+		{"name": "Carol", "q1": 30, "q2": 40, "note": nil, "logo": NewImageURL("https://example.com/logo.png").WithAltText("Logo")},
+	}
+	columns := func() Columns {
+		return Columns{
+			NewColumn("name", "Name"),
+			NewColumn("sales", "Sales").WithSubColumns(Columns{
+				NewColumn("q1", "Q1").WithStyle(&Style{Alignment: AlignmentRight}),
+				NewColumn("q2", "Q2").WithStyle(&Style{Alignment: AlignmentCenterMiddle}).WithAggregate(AggregateSum),
+			}),
+			NewColumn("note", "Note").WithStyle(&Style{Alignment: AlignmentLeft}),
+			NewColumn("logo", "Logo"),
+			NewColumn("internal", "Internal").WithFormats(FormatCSV),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		table *Table
+		want  string
+	}{
+		{
+			name:  "Flattened headers, alignments, escaping and footer",
+			table: NewTable(data, columns(), true).WithFooter(nil),
+			want: "| Name | Sales / Q1 | Sales / Q2 | Note | Logo |\n" +
+				"| --- | ---: | :---: | :--- | --- |\n" +
+				"| Alice \\| Bob | 10 | 20.5 | line<br>break |  |\n" +
+				"| Carol | 30 | 40 |  | ![Logo](https://example.com/logo.png) |\n" +
+				"| **Total** |  | **60.5** |  |  |\n",
+		},
+		{
+			name: "Transposed without header",
+			table: NewTable(DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}}, Columns{
+				NewColumn("a", "A"),
+				NewColumn("b", "B"),
+			}, true).WithTransposed(true),
+			want: "| A | 1 | 3 |\n" +
+				"| --- | --- | --- |\n" +
+				"| B | 2 | 4 |\n",
+		},
+		{
+			name: "Preview watermark",
+			table: NewTable(DataSlice{{"a": 1}, {"a": 2}}, Columns{NewColumn("a", "A")}, true).
+				WithPreview(NewPreviewOptions().WithMaxRows(1).WithWatermark("Sample only")),
+			want: "_Sample only_\n\n" +
+				"| A |\n" +
+				"| --- |\n" +
+				"| 1 |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMarkdown(tt.table)
+			if err != nil {
+				t.Fatalf("RenderMarkdown failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderMarkdown() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("a", "A")}, true)
+	res, err := ExportMarkdown(table, FileWriteParams{Filename: "summary", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportMarkdown failed: %v", err)
+	}
+	if res.Filename != "summary.md" {
+		t.Errorf("filename = %q, want summary.md", res.Filename)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if want := "| A |\n| --- |\n| 1 |\n"; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+	if _, err := RenderMarkdown(NewTable(nil, nil, true)); err == nil {
+		t.Error("expected an error for a table without columns")
+	}
+}
@@ -0,0 +1,74 @@
+// merge_predicate.go - Custom merge conditions.
+//
+// This file implements a registry of named merge predicates. Once registered (e.g. "same_day"), a
+// predicate is referenced from MergeRules through MergeConditionCustom and decides whether two
+// adjacent cells merge, in vertical and horizontal passes alike, for domain-specific logic the
+// built-in "identical" and "empty" conditions cannot express.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MergePredicate reports whether two adjacent cells merge. It receives the processed values of the
+// previous cell (above or to the left) and of the current cell, empty values included.
+type MergePredicate func(prev, curr interface{}) bool
+
+// customMergePrefix prefixes the merge conditions referencing a registered predicate.
+const customMergePrefix = "custom:"
+
+var (
+	_mergePredicates   = map[string]MergePredicate{} // Registered merge predicates by name
+	_mergePredicatesMu sync.RWMutex
+)
+
+// MergeConditionCustom returns the merge condition evaluating the predicate registered under name
+// (see RegisterMergePredicate), spelled "custom:<name>". Conditions referencing an unregistered
+// predicate never merge.
+func MergeConditionCustom(name string) MergeCondition {
+	return MergeCondition(customMergePrefix + name)
+}
+
+// RegisterMergePredicate registers a named merge predicate that can be referenced with
+// MergeConditionCustom. Registering an existing name replaces the previous predicate.
+func RegisterMergePredicate(name string, predicate MergePredicate) error {
+	if name == "" {
+		return fmt.Errorf("merge predicate name cannot be empty")
+	}
+	if predicate == nil {
+		return fmt.Errorf("merge predicate %q cannot be nil", name)
+	}
+
+	_mergePredicatesMu.Lock()
+	defer _mergePredicatesMu.Unlock()
+	_mergePredicates[name] = predicate
+	return nil
+}
+
+// UnregisterMergePredicate removes a named merge predicate. Unknown names are ignored.
+func UnregisterMergePredicate(name string) {
+	_mergePredicatesMu.Lock()
+	defer _mergePredicatesMu.Unlock()
+	delete(_mergePredicates, name)
+}
+
+// LookupMergePredicate returns the merge predicate registered under name.
+func LookupMergePredicate(name string) (MergePredicate, bool) {
+	_mergePredicatesMu.RLock()
+	defer _mergePredicatesMu.RUnlock()
+	predicate, ok := _mergePredicates[name]
+	return predicate, ok
+}
+
+// customPredicate returns the predicate referenced by a custom merge condition, or false for
+// built-in conditions and unregistered predicates.
+func (m MergeCondition) customPredicate() (MergePredicate, bool) {
+	name, ok := strings.CutPrefix(string(m), customMergePrefix)
+	if !ok {
+		return nil, false
+	}
+	return LookupMergePredicate(name)
+}
@@ -0,0 +1,76 @@
+package spit
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterMergePredicate(t *testing.T) {
+	always := func(prev, curr interface{}) bool { return true }
+	tests := []struct {
+		name      string
+		predicate MergePredicate
+		wantErr   bool
+	}{
+		{"always", always, false},
+		{"", always, true},
+		{"nil_predicate", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterMergePredicate(tt.name, tt.predicate)
+			defer UnregisterMergePredicate(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterMergePredicate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := LookupMergePredicate(tt.name); ok == tt.wantErr {
+				t.Errorf("LookupMergePredicate() found = %v, want %v", ok, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeConditionCustom(t *testing.T) {
+	sameDay := func(prev, curr interface{}) bool {
+		p, c := fmt.Sprint(prev), fmt.Sprint(curr)
+		return len(p) >= 10 && len(c) >= 10 && p[:10] == c[:10]
+	}
+	if err := RegisterMergePredicate("same_day", sameDay); err != nil {
+		t.Fatalf("RegisterMergePredicate failed: %v", err)
+	}
+	defer UnregisterMergePredicate("same_day")
+
+	condition := MergeConditionCustom("same_day")
+	if parsed, err := ParseMergeCondition("custom:same_day"); err != nil || parsed != condition {
+		t.Errorf("ParseMergeCondition() = %v, %v; want %v", parsed, err, condition)
+	}
+
+	conditions := MergeConditions{condition}
+	if !conditions.ValuesShouldMerge("2024-03-01 09:00", "2024-03-01 17:30") {
+		t.Error("ValuesShouldMerge() = false for the same day, want true")
+	}
+	if conditions.ValuesShouldMerge("2024-03-01 09:00", "2024-03-02 09:00") {
+		t.Error("ValuesShouldMerge() = true for different days, want false")
+	}
+	if (MergeConditions{MergeConditionCustom("unknown")}).ValuesShouldMerge("a", "a") {
+		t.Error("ValuesShouldMerge() = true for an unregistered predicate, want false")
+	}
+
+	data := DataSlice{
+		{"at": "2024-03-01 09:00", "a": "2024-03-05 x", "b": "2024-03-05 y"},
+		{"at": "2024-03-01 17:30", "a": "1", "b": "2"},
+		{"at": "2024-03-02 08:00", "a": "3", "b": "4"},
+	}
+	rules := NewMergeRules(conditions, conditions)
+	at := NewColumn("at", "At").WithMerge(rules)
+	table := NewTable(data, Columns{at, NewColumn("a", "A").WithMerge(rules), NewColumn("b", "B").WithMerge(rules)}, true)
+	ops := &htmlExport{table: table}
+
+	if got, want := table.findVerticalMergeRanges(1, at, conditions, ops), [][]int{{0, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("vertical ranges = %v, want %v", got, want)
+	}
+	if got, want := table.findHorizontalMergeRanges(data[0], table.Columns, conditions, ops), [][]int{{1, 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("horizontal ranges = %v, want %v", got, want)
+	}
+}
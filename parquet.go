@@ -0,0 +1,660 @@
+// parquet.go - Parquet export.
+//
+// This file implements the Parquet export of tables for analytics pipelines (Spark, Athena,
+// DuckDB): each leaf column becomes a typed, nullable Parquet column whose type is inferred from
+// the values or set with Column.WithParquetType. Files hold a single row group of uncompressed,
+// PLAIN-encoded pages, written without third-party dependencies (see parquet_thrift.go); let the
+// consumer rewrite them with its preferred codec when size matters.
+
+package spit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParquetType is the type of a column in Parquet exports.
+type ParquetType uint8
+
+const (
+	ParquetTypeAuto      ParquetType = iota // Inferred from the column values (default)
+	ParquetTypeString                       // UTF-8 string, formatted like CSV values (Column.Format applies)
+	ParquetTypeInt64                        // 64-bit signed integer
+	ParquetTypeDouble                       // 64-bit floating point number
+	ParquetTypeBoolean                      // Boolean
+	ParquetTypeTimestamp                    // UTC timestamp with millisecond precision
+	ParquetTypeDate                         // Calendar date, without time of day
+)
+
+// parquetPageRows is the number of rows per data page.
+const parquetPageRows = 16384
+
+// Parquet physical types, converted types and encodings (see the parquet-format specification).
+const (
+	parquetBoolean   int32 = 0
+	parquetInt32     int32 = 1
+	parquetInt64     int32 = 2
+	parquetDouble    int32 = 5
+	parquetByteArray int32 = 6
+
+	parquetConvertedUTF8            int32 = 0
+	parquetConvertedDate            int32 = 6
+	parquetConvertedTimestampMillis int32 = 9
+
+	parquetEncodingPlain int32 = 0
+	parquetEncodingRLE   int32 = 3
+)
+
+// parquetMagic starts and ends every Parquet file.
+const parquetMagic = "PAR1"
+
+// WithParquetType sets the type of this column in Parquet exports (ParquetTypeAuto infers it).
+func (c *Column) WithParquetType(parquetType ParquetType) *Column {
+	c.ParquetType = parquetType
+	return c
+}
+
+// parquetType returns the Parquet type of the values of a column type, or ParquetTypeAuto when
+// the type has none (ColumnTypeAuto and ColumnTypeDuration).
+func (c ColumnType) parquetType() ParquetType {
+	switch c {
+	case ColumnTypeString:
+		return ParquetTypeString
+	case ColumnTypeInt:
+		return ParquetTypeInt64
+	case ColumnTypeFloat, ColumnTypePercent, ColumnTypeCurrency:
+		return ParquetTypeDouble
+	case ColumnTypeBool:
+		return ParquetTypeBoolean
+	case ColumnTypeDate:
+		return ParquetTypeDate
+	}
+	return ParquetTypeAuto
+}
+
+// ExportParquet exports the table to a Parquet file. Each leaf column becomes a nullable column
+// named after Column.Name; headers, footers, group header and subtotal rows are not written.
+// Values that cannot be converted to the column type are written as nulls and reported as warnings.
+func ExportParquet(t *Table, params FileWriteParams) (*FileWriteResult, error) {
+	if params.Extension == "" {
+		params.Extension = FormatParquet.String()
+	}
+	if parts := t.chunks(); parts != nil {
+		return exportParts(parts, params, ExportParquet)
+	}
+
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+	prepared := params.timings.measure(timingPrepare)
+	p := &parquet{
+		table:  t.withSeed(params.Seed).Prepare().ForFormat(FormatParquet).withWarnings("", params.OnWarning, params.Logger),
+		params: params,
+	}
+	prepared()
+	if err := params.quota.addTable(p.table); err != nil {
+		return nil, err
+	}
+	params.progress.track(p.table, progressPhasesFlat)
+	if t.Preview != nil {
+		p.watermark = t.Preview.GetWatermark()
+	}
+
+	params.logger().Info("Starting Parquet export to file", String("filename", params.Filename))
+
+	result, err := params.WriteToFile(func(writer io.Writer) error {
+		p.writer = &countingWriter{w: writer}
+		return p.writeData()
+	})
+	if err != nil {
+		params.logger().Error("Failed to write Parquet to file", Error(err))
+		return nil, err
+	}
+
+	result.Warnings = p.table.exportWarnings()
+	result.SparseColumns = p.table.sparseColumns("")
+
+	params.logger().Info("Parquet export completed", String("filename", params.Filename))
+	return result, nil
+}
+
+// parquet contains Parquet-specific export parameters and logic.
+type parquet struct {
+	writer    *countingWriter // Destination, tracking the offsets of the written pages
+	table     *Table          // Reference to the Table being exported
+	params    FileWriteParams // File write parameters for the Parquet export
+	watermark string          // Optional watermark stored in the file metadata (preview mode)
+}
+
+// parquetColumn is a column of the written file.
+type parquetColumn struct {
+	column     *Column       // Table column
+	name       string        // Unique column name in the schema
+	typ        ParquetType   // Resolved type (never ParquetTypeAuto)
+	columnType int32         // Parquet physical type of typ
+	values     []interface{} // Converted value of each row (nil for nulls)
+	nullCount  int64         // Number of nulls in values
+	offset     int64         // File offset of the first data page
+	size       int64         // Size of the column chunk, page headers included
+}
+
+// countingWriter counts the bytes written to w, giving the file offsets of pages.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes p to the underlying writer.
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeData writes the table as a Parquet file: the magic number, one column chunk per column
+// and the footer holding the file metadata.
+func (p *parquet) writeData() error {
+	p.params.logger().Debug("Writing data to Parquet...")
+	columns, rows, err := p.columns()
+	if err != nil {
+		return err
+	}
+	if err := p.table.strictErr(); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(p.writer, parquetMagic); err != nil {
+		return fmt.Errorf("error writing Parquet header: %w", err)
+	}
+	for _, column := range columns {
+		if err := p.writeColumn(column, rows); err != nil {
+			return fmt.Errorf("error writing Parquet column %s: %w", column.name, err)
+		}
+	}
+
+	footer := p.fileMetadata(columns, rows)
+	if _, err := p.writer.Write(footer); err != nil {
+		return fmt.Errorf("error writing Parquet footer: %w", err)
+	}
+	trailer := binary.LittleEndian.AppendUint32(nil, uint32(len(footer)))
+	if _, err := p.writer.Write(append(trailer, parquetMagic...)); err != nil {
+		return fmt.Errorf("error writing Parquet footer: %w", err)
+	}
+
+	p.table.progress.finish()
+	p.params.logger().Debug("Parquet data writing complete.", Int("rows", rows), Int("columns", len(columns)))
+	return nil
+}
+
+// columns converts the data rows into typed columns and returns them with the row count. Group
+// header and subtotal rows inserted by grouping are left out.
+func (p *parquet) columns() ([]*parquetColumn, int, error) {
+	t := p.table
+	var items []Data
+	for rowIndex, item := range t.Data {
+		if err := t.advance(1); err != nil {
+			return nil, 0, err
+		}
+		if t.SourceRowIndex(rowIndex) >= 0 {
+			items = append(items, item)
+		}
+	}
+
+	seen := make(map[string]int)
+	flatColumns := t.Columns.GetFlattenedColumns()
+	columns := make([]*parquetColumn, 0, len(flatColumns))
+	for i, column := range flatColumns {
+		name := column.Name
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		if seen[name]++; seen[name] > 1 {
+			unique := fmt.Sprintf("%s_%d", name, seen[name])
+			t.warn(WarningPhaseHeader, "", "Duplicate Parquet column name, renamed", nil, String("column", name), String("name", unique))
+			name = unique
+		}
+
+		raw := make([]interface{}, len(items))
+		for rowIndex, item := range items {
+			value, err, found := item.LookupColumn(column)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error looking up value for column %s in row %d: %w", column.Name, rowIndex, err)
+			}
+			if found {
+				raw[rowIndex] = NormalizeValue(t.inLocation(column, value))
+			}
+		}
+		pc := &parquetColumn{column: column, name: name, typ: column.ParquetType}
+		if pc.typ == ParquetTypeAuto {
+			pc.typ = column.Type.parquetType()
+		}
+		if pc.typ == ParquetTypeAuto {
+			pc.typ = inferParquetType(raw)
+		}
+		pc.columnType = pc.typ.physicalType()
+		pc.values = make([]interface{}, len(raw))
+		for rowIndex, value := range raw {
+			converted, err := p.convertValue(value, pc)
+			if err != nil {
+				t.warn(WarningPhaseData, "", "Failed to convert Parquet value, written as null", err, String("column", name), Int("row", rowIndex))
+			}
+			if converted == nil {
+				pc.nullCount++
+			}
+			pc.values[rowIndex] = converted
+		}
+		columns = append(columns, pc)
+	}
+	return columns, len(items), nil
+}
+
+// inferParquetType returns the narrowest type holding every non-empty value: integers, numbers
+// mixing integers and floats, booleans or times; anything else is a string.
+func inferParquetType(values []interface{}) ParquetType {
+	inferred := ParquetTypeAuto
+	for _, value := range values {
+		var typ ParquetType
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+			return ParquetTypeString
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+			typ = ParquetTypeInt64
+		case uint64:
+			if v > math.MaxInt64 {
+				return ParquetTypeString
+			}
+			typ = ParquetTypeInt64
+		case float32, float64:
+			typ = ParquetTypeDouble
+		case bool:
+			typ = ParquetTypeBoolean
+		case time.Time:
+			typ = ParquetTypeTimestamp
+		case *time.Time:
+			if v == nil {
+				continue
+			}
+			typ = ParquetTypeTimestamp
+		default:
+			return ParquetTypeString
+		}
+		switch {
+		case inferred == ParquetTypeAuto || inferred == typ:
+			inferred = typ
+		case inferred == ParquetTypeInt64 && typ == ParquetTypeDouble, inferred == ParquetTypeDouble && typ == ParquetTypeInt64:
+			inferred = ParquetTypeDouble
+		default:
+			return ParquetTypeString
+		}
+	}
+	if inferred == ParquetTypeAuto {
+		return ParquetTypeString
+	}
+	return inferred
+}
+
+// physicalType returns the Parquet physical type storing the type.
+func (pt ParquetType) physicalType() int32 {
+	switch pt {
+	case ParquetTypeInt64, ParquetTypeTimestamp:
+		return parquetInt64
+	case ParquetTypeDouble:
+		return parquetDouble
+	case ParquetTypeBoolean:
+		return parquetBoolean
+	case ParquetTypeDate:
+		return parquetInt32
+	}
+	return parquetByteArray
+}
+
+// convertedType returns the Parquet converted type annotating the type, if any.
+func (pt ParquetType) convertedType() (int32, bool) {
+	switch pt {
+	case ParquetTypeTimestamp:
+		return parquetConvertedTimestampMillis, true
+	case ParquetTypeDate:
+		return parquetConvertedDate, true
+	case ParquetTypeInt64, ParquetTypeDouble, ParquetTypeBoolean:
+		return 0, false
+	}
+	return parquetConvertedUTF8, true
+}
+
+// convertValue converts a normalized value to the Go type stored by the column: string, int64,
+// float64, bool or int32 (days since the Unix epoch). Nil and empty values are nulls; strings are
+// parsed for typed columns.
+func (p *parquet) convertValue(value interface{}, pc *parquetColumn) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if tp, ok := value.(*time.Time); ok {
+		if tp == nil {
+			return nil, nil
+		}
+		value = *tp
+	}
+	if pc.typ == ParquetTypeString {
+		return p.processValue(value, pc.column.Format)
+	}
+	if s, ok := value.(string); ok {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return nil, nil
+		}
+		value = s
+	}
+
+	switch pc.typ {
+	case ParquetTypeInt64:
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int8:
+			return int64(v), nil
+		case int16:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case uint:
+			return parquetUint(uint64(v))
+		case uint8:
+			return int64(v), nil
+		case uint16:
+			return int64(v), nil
+		case uint32:
+			return int64(v), nil
+		case uint64:
+			return parquetUint(v)
+		case float32, float64:
+			f := toFloat64(v)
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, fmt.Errorf("%v is not an integer", v)
+			}
+			return int64(f), nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case ParquetTypeDouble:
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return toFloat64(v), nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case ParquetTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case ParquetTypeTimestamp, ParquetTypeDate:
+		var date time.Time
+		switch v := value.(type) {
+		case time.Time:
+			date = v
+		case string:
+			var err error
+			if date, err = parseParquetTime(v); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("cannot convert %T to %s", value, pc.typ)
+		}
+		if pc.typ == ParquetTypeDate {
+			day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+			return int32(day.Unix() / 86400), nil
+		}
+		return date.UnixMilli(), nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to %s", value, pc.typ)
+}
+
+// parquetUint converts an unsigned integer to int64, failing when it overflows.
+func parquetUint(v uint64) (interface{}, error) {
+	if v > math.MaxInt64 {
+		return nil, fmt.Errorf("%d overflows int64", v)
+	}
+	return int64(v), nil
+}
+
+// toFloat64 converts a numeric value to float64.
+func toFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return math.NaN()
+}
+
+// parseParquetTime parses a time written as RFC 3339, a date ("2006-01-02") or one of the
+// layouts accepted by ParseDate.
+func parseParquetTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, nil
+		}
+	}
+	return ParseDate(s)
+}
+
+// processValue processes a value of a string column like CSV values: images give their textual
+// value, slices are joined with the list separator and the column format applies.
+func (p *parquet) processValue(value interface{}, format string) (interface{}, error) {
+	if img, ok := asImage(value); ok {
+		return img.TextValue(), nil
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		if p.table.ListSeparator != "" {
+			return ConvertSliceToString(v, format, p.table.ListSeparator)
+		}
+	default:
+		if format != "" {
+			var err error
+			value, err = FormatValue(value, format)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// writeColumn writes the column chunk of a column as data pages of parquetPageRows rows.
+func (p *parquet) writeColumn(pc *parquetColumn, rows int) error {
+	pc.offset = p.writer.n
+	for start := 0; start < rows || start == 0; start += parquetPageRows {
+		end := min(start+parquetPageRows, rows)
+		page := encodeParquetPage(pc.values[start:end])
+
+		header := &thriftWriter{}
+		header.i32(1, 0) // DATA_PAGE
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.beginStruct(5)
+		header.i32(1, int32(end-start))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		if _, err := p.writer.Write(header.Bytes()); err != nil {
+			return err
+		}
+		if _, err := p.writer.Write(page); err != nil {
+			return err
+		}
+	}
+	pc.size = p.writer.n - pc.offset
+	return nil
+}
+
+// encodeParquetPage encodes the definition levels (RLE/bit-packed hybrid, length-prefixed) and
+// the PLAIN-encoded non-null values of a data page.
+func encodeParquetPage(values []interface{}) []byte {
+	var levels []byte
+	defined := 0
+	for _, value := range values {
+		if value != nil {
+			defined++
+		}
+	}
+	switch defined {
+	case len(values), 0:
+		// A single RLE run of 1s (all defined) or 0s (all nulls)
+		levels = binary.AppendUvarint(levels, uint64(len(values))<<1)
+		levels = append(levels, byte(min(defined, 1)))
+	default:
+		// Bit-packed groups of 8 levels, 1 bit per level
+		groups := (len(values) + 7) / 8
+		levels = binary.AppendUvarint(levels, uint64(groups)<<1|1)
+		packed := make([]byte, groups)
+		for i, value := range values {
+			if value != nil {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		levels = append(levels, packed...)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(levels))))
+	buf.Write(levels)
+
+	var bits []byte // Booleans are bit-packed, least significant bit first
+	booleans := 0
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(v))))
+			buf.WriteString(v)
+		case int64:
+			buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(v)))
+		case int32:
+			buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(v)))
+		case float64:
+			buf.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)))
+		case bool:
+			if booleans%8 == 0 {
+				bits = append(bits, 0)
+			}
+			if v {
+				bits[booleans/8] |= 1 << (booleans % 8)
+			}
+			booleans++
+		}
+	}
+	buf.Write(bits)
+	return buf.Bytes()
+}
+
+// fileMetadata encodes the footer of the file: the schema (a root group holding one optional
+// column per table column), the single row group with the column chunk locations and the
+// key/value metadata.
+func (p *parquet) fileMetadata(columns []*parquetColumn, rows int) []byte {
+	w := &thriftWriter{}
+	w.i32(1, 1) // Format version
+
+	w.list(2, thriftStruct, len(columns)+1)
+	w.beginElement()
+	w.str(4, "schema")
+	w.i32(5, int32(len(columns)))
+	w.endStruct()
+	for _, pc := range columns {
+		w.beginElement()
+		w.i32(1, pc.columnType)
+		w.i32(3, 1) // OPTIONAL
+		w.str(4, pc.name)
+		if converted, ok := pc.typ.convertedType(); ok {
+			w.i32(6, converted)
+		}
+		w.endStruct()
+	}
+
+	w.i64(3, int64(rows))
+
+	var totalSize int64
+	w.list(4, thriftStruct, 1)
+	w.beginElement()
+	w.list(1, thriftStruct, len(columns))
+	for _, pc := range columns {
+		totalSize += pc.size
+		w.beginElement()
+		w.i64(2, pc.offset)
+		w.beginStruct(3)
+		w.i32(1, pc.columnType)
+		w.list(2, thriftI32, 2)
+		w.i32Element(parquetEncodingPlain)
+		w.i32Element(parquetEncodingRLE)
+		w.list(3, thriftBinary, 1)
+		w.strElement(pc.name)
+		w.i32(4, 0) // UNCOMPRESSED
+		w.i64(5, int64(rows))
+		w.i64(6, pc.size)
+		w.i64(7, pc.size)
+		w.i64(9, pc.offset)
+		w.beginStruct(12) // Statistics
+		w.i64(3, pc.nullCount)
+		w.endStruct()
+		w.endStruct()
+		w.endStruct()
+	}
+	w.i64(2, totalSize)
+	w.i64(3, int64(rows))
+	w.endStruct()
+
+	if p.watermark != "" {
+		w.list(5, thriftStruct, 1)
+		w.beginElement()
+		w.str(1, "spit.watermark")
+		w.str(2, p.watermark)
+		w.endStruct()
+	}
+	w.str(6, "go-spit")
+	w.endStruct()
+	return w.Bytes()
+}
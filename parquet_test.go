@@ -0,0 +1,358 @@
+package spit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// updateParquetFixture rewrites testdata/parquet/types.parquet from the current writer. Check the
+// new fixture with testdata/parquet/check.py (pyarrow or DuckDB) before committing it.
+var updateParquetFixture = flag.Bool("update-parquet", false, "rewrite the Parquet fixture")
+
+// thriftReader decodes Thrift compact structs into maps of field id to value: int64 for integers,
+// []byte for binaries, []interface{} for lists and map[int16]interface{} for structs.
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1, 2:
+		return typ == 1
+	case 3:
+		r.pos++
+		return int64(int8(r.data[r.pos-1]))
+	case 4, 5, 6:
+		return r.varint()
+	case 7:
+		r.pos += 8
+		return math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos-8:]))
+	case 8:
+		n := int(r.uvarint())
+		r.pos += n
+		return r.data[r.pos-n : r.pos]
+	case 9:
+		header := r.data[r.pos]
+		r.pos++
+		size, elem := int(header>>4), header&0x0F
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case 12:
+		return r.readStruct()
+	}
+	panic(fmt.Sprintf("unsupported thrift type %d", typ))
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var last int16
+	for {
+		header := r.data[r.pos]
+		r.pos++
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(header & 0x0F)
+		last = id
+	}
+}
+
+// readParquet decodes a file written by ExportParquet into its footer and the values of each
+// column (nil for nulls).
+func readParquet(t *testing.T, data []byte) (map[int16]interface{}, map[string][]interface{}) {
+	t.Helper()
+	if string(data[:4]) != "PAR1" || string(data[len(data)-4:]) != "PAR1" {
+		t.Fatalf("missing Parquet magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := (&thriftReader{data: data[len(data)-8-footerLen : len(data)-8]}).readStruct()
+
+	columns := make(map[string][]interface{})
+	rowGroup := footer[4].([]interface{})[0].(map[int16]interface{})
+	for _, chunk := range rowGroup[1].([]interface{}) {
+		meta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		name := string(meta[3].([]interface{})[0].([]byte))
+		physical, numValues := meta[1].(int64), int(meta[5].(int64))
+		r := &thriftReader{data: data, pos: int(meta[9].(int64))}
+
+		var values []interface{}
+		for len(values) < numValues || r.pos == int(meta[9].(int64)) {
+			header := r.readStruct()
+			page := data[r.pos : r.pos+int(header[3].(int64))]
+			r.pos += len(page)
+			count := int(header[5].(map[int16]interface{})[1].(int64))
+
+			// Definition levels: a single RLE run or bit-packed groups
+			levelsLen := int(binary.LittleEndian.Uint32(page))
+			levels := &thriftReader{data: page[4 : 4+levelsLen]}
+			defined := make([]bool, count)
+			runHeader := levels.uvarint()
+			for i := range defined {
+				if runHeader&1 == 0 {
+					defined[i] = levels.data[levels.pos] == 1
+				} else {
+					defined[i] = levels.data[levels.pos+i/8]>>(i%8)&1 == 1
+				}
+			}
+
+			body, booleans := page[4+levelsLen:], 0
+			for _, isDefined := range defined {
+				if !isDefined {
+					values = append(values, nil)
+					continue
+				}
+				switch physical {
+				case 0:
+					values = append(values, body[booleans/8]>>(booleans%8)&1 == 1)
+					booleans++
+				case 1:
+					values = append(values, int32(binary.LittleEndian.Uint32(body)))
+					body = body[4:]
+				case 2:
+					values = append(values, int64(binary.LittleEndian.Uint64(body)))
+					body = body[8:]
+				case 5:
+					values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(body)))
+					body = body[8:]
+				case 6:
+					n := int(binary.LittleEndian.Uint32(body))
+					values = append(values, string(body[4:4+n]))
+					body = body[4+n:]
+				}
+			}
+			if count == 0 {
+				break
+			}
+		}
+		columns[name] = values
+	}
+	return footer, columns
+}
+
+func TestExportParquet(t *testing.T) {
+	created := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	data := DataSlice{
+		{"id": 1, "name": "Alice", "score": 9.5, "active": true, "created": created, "day": "2024-03-15", "tags": []interface{}{"a", "b"}},
+		{"id": 2, "name": nil, "score": 7, "active": false, "created": &created, "day": "2024-03-16", "tags": []interface{}{}},
+		{"id": 3, "name": "Carol", "active": true, "day": "not a date"},
+	}
+	table := NewTable(data, Columns{
+		NewColumn("id", "ID"),
+		NewColumn("name", "Name"),
+		NewColumn("details", "Details").WithSubColumns(Columns{
+			NewColumn("score", "Score"),
+			NewColumn("active", "Active"),
+		}),
+		NewColumn("created", "Created"),
+		NewColumn("day", "Day").WithParquetType(ParquetTypeDate),
+		NewColumn("tags", "Tags"),
+		NewColumn("html", "HTML only").WithFormats(FormatHTML),
+	}, true).WithFooter(nil)
+	table.ListSeparator = ","
+
+	res, err := ExportParquet(table, FileWriteParams{Filename: "report", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportParquet failed: %v", err)
+	}
+	if res.Filename != "report.parquet" {
+		t.Errorf("filename = %q, want report.parquet", res.Filename)
+	}
+	if len(res.Warnings) != 1 || res.Warnings[0].Phase != WarningPhaseData {
+		t.Errorf("expected a single conversion warning, got %v", res.Warnings)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	footer, columns := readParquet(t, content)
+
+	if rows := footer[3].(int64); rows != 3 {
+		t.Errorf("num_rows = %d, want 3", rows)
+	}
+
+	// Schema: root group, then one optional column per exported leaf column
+	schema := footer[2].([]interface{})
+	wantSchema := []struct {
+		name      string
+		physical  int64
+		converted int64 // -1 when absent
+	}{
+		{"id", 2, -1},
+		{"name", 6, 0},
+		{"score", 5, -1},
+		{"active", 0, -1},
+		{"created", 2, 9},
+		{"day", 1, 6},
+		{"tags", 6, 0},
+	}
+	if root := schema[0].(map[int16]interface{}); root[5].(int64) != int64(len(wantSchema)) {
+		t.Fatalf("root has %v children, want %d", root[5], len(wantSchema))
+	}
+	for i, want := range wantSchema {
+		element := schema[i+1].(map[int16]interface{})
+		if name := string(element[4].([]byte)); name != want.name {
+			t.Errorf("schema[%d] name = %q, want %q", i, name, want.name)
+		}
+		if element[1].(int64) != want.physical || element[3].(int64) != 1 {
+			t.Errorf("%s: type %v, repetition %v", want.name, element[1], element[3])
+		}
+		converted, ok := element[6].(int64)
+		if !ok {
+			converted = -1
+		}
+		if converted != want.converted {
+			t.Errorf("%s: converted type %d, want %d", want.name, converted, want.converted)
+		}
+	}
+
+	wantValues := map[string][]interface{}{
+		"id":      {int64(1), int64(2), int64(3)},
+		"name":    {"Alice", nil, "Carol"},
+		"score":   {9.5, 7.0, nil},
+		"active":  {true, false, true},
+		"created": {created.UnixMilli(), created.UnixMilli(), nil},
+		"day":     {int32(19797), int32(19798), nil},
+		"tags":    {"a,b", "", nil},
+	}
+	for name, want := range wantValues {
+		got := columns[name]
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s values = %v, want %v", name, got, want)
+		}
+	}
+	if _, ok := columns["html"]; ok {
+		t.Error("HTML-only column should not be exported")
+	}
+}
+
+func TestExportParquet_pagesAndEmptyTables(t *testing.T) {
+	data := make(DataSlice, parquetPageRows+10)
+	for i := range data {
+		data[i] = Data{"n": i}
+		if i%3 == 0 {
+			data[i]["flag"] = i%2 == 0
+		}
+	}
+	tests := []struct {
+		name  string
+		table *Table
+		rows  int
+	}{
+		{"Several pages", NewTable(data, Columns{NewColumn("n", "N"), NewColumn("flag", "Flag")}, true), len(data)},
+		{"No rows", NewTable(nil, Columns{NewColumn("n", "N")}, true), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sink bytes.Buffer
+			if _, err := ExportParquet(tt.table, FileWriteParams{Filename: "pages", Writer: &sink}); err != nil {
+				t.Fatalf("ExportParquet failed: %v", err)
+			}
+			footer, columns := readParquet(t, sink.Bytes())
+			if rows := footer[3].(int64); rows != int64(tt.rows) {
+				t.Errorf("num_rows = %d, want %d", rows, tt.rows)
+			}
+			if len(columns["n"]) != tt.rows {
+				t.Fatalf("read %d values, want %d", len(columns["n"]), tt.rows)
+			}
+			for i, value := range columns["n"] {
+				if value != int64(i) {
+					t.Fatalf("n[%d] = %v", i, value)
+				}
+			}
+			for i, value := range columns["flag"] {
+				if want := i%3 == 0; (value != nil) != want || want && value != (i%2 == 0) {
+					t.Fatalf("flag[%d] = %v", i, value)
+				}
+			}
+		})
+	}
+}
+
+// TestExportParquet_fixture compares the export with testdata/parquet/types.parquet, a fixture
+// read back by real Parquet readers with testdata/parquet/check.py (make parquet-check).
+func TestExportParquet_fixture(t *testing.T) {
+	created := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	data := DataSlice{
+		{"id": 1, "name": "Alice", "score": 9.5, "active": true, "created": created, "day": "2024-03-15"},
+		{"id": 2, "name": "Zoë", "score": -0.25, "active": false, "day": "2024-03-16"},
+		{"id": 3, "active": true, "created": created.Add(1500 * time.Millisecond)},
+	}
+	table := NewTable(data, Columns{
+		NewColumn("id", "ID"),
+		NewColumn("name", "Name"),
+		NewColumn("score", "Score"),
+		NewColumn("active", "Active"),
+		NewColumn("created", "Created"),
+		NewColumn("day", "Day").WithParquetType(ParquetTypeDate),
+		NewColumn("note", "Note"),
+	}, true)
+
+	var sink bytes.Buffer
+	if _, err := ExportParquet(table, FileWriteParams{Filename: "types", Writer: &sink}); err != nil {
+		t.Fatalf("ExportParquet failed: %v", err)
+	}
+	fixture := filepath.Join("testdata", "parquet", "types.parquet")
+	if *updateParquetFixture {
+		if err := os.WriteFile(fixture, sink.Bytes(), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	want, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !bytes.Equal(sink.Bytes(), want) {
+		t.Errorf("export differs from %s; rerun with -update-parquet and check the fixture with make parquet-check", fixture)
+	}
+}
+
+func TestInferParquetType(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		values []interface{}
+		want   ParquetType
+	}{
+		{[]interface{}{1, int64(2), nil, ""}, ParquetTypeInt64},
+		{[]interface{}{1, 2.5}, ParquetTypeDouble},
+		{[]interface{}{true, nil}, ParquetTypeBoolean},
+		{[]interface{}{now, &now}, ParquetTypeTimestamp},
+		{[]interface{}{1, "x"}, ParquetTypeString},
+		{[]interface{}{1, true}, ParquetTypeString},
+		{[]interface{}{uint64(math.MaxUint64)}, ParquetTypeString},
+		{[]interface{}{nil}, ParquetTypeString},
+	}
+	for _, tt := range tests {
+		if got := inferParquetType(tt.values); got != tt.want {
+			t.Errorf("inferParquetType(%v) = %s, want %s", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// parquet_thrift.go - Thrift compact protocol encoding.
+//
+// This file implements the subset of the Thrift compact protocol needed to write Parquet page
+// headers and file footers (integers, binaries, lists and nested structs), so Parquet exports need
+// no third-party dependency.
+
+package spit
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type identifiers.
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+// thriftWriter encodes a Thrift struct with the compact protocol. Fields must be written in
+// increasing id order within a struct; nested structs are opened with beginStruct (as a field)
+// or beginElement (as a list element) and closed with endStruct.
+type thriftWriter struct {
+	buf    bytes.Buffer
+	last   int16   // Id of the last field written in the current struct
+	parent []int16 // Last field ids of the enclosing structs
+}
+
+// Bytes returns the encoded data.
+func (w *thriftWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// field writes the header of a field, using the short form when the id delta allows it.
+func (w *thriftWriter) field(id int16, typ byte) {
+	if delta := id - w.last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	w.last = id
+}
+
+// uvarint writes an unsigned LEB128 integer.
+func (w *thriftWriter) uvarint(v uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+// varint writes a zigzag-encoded signed integer.
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+// binary writes a length-prefixed binary value.
+func (w *thriftWriter) binary(b []byte) {
+	w.uvarint(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+// i32 writes an i32 field (enums included).
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(int64(v))
+}
+
+// i64 writes an i64 field.
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(v)
+}
+
+// str writes a binary field holding s.
+func (w *thriftWriter) str(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.binary([]byte(s))
+}
+
+// list writes the header of a list field of size elements of type elem. The elements follow,
+// written with i32Element, strElement or beginElement.
+func (w *thriftWriter) list(id int16, elem byte, size int) {
+	w.field(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elem)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elem)
+	w.uvarint(uint64(size))
+}
+
+// i32Element writes an i32 list element.
+func (w *thriftWriter) i32Element(v int32) {
+	w.varint(int64(v))
+}
+
+// strElement writes a binary list element.
+func (w *thriftWriter) strElement(s string) {
+	w.binary([]byte(s))
+}
+
+// beginStruct opens a struct field.
+func (w *thriftWriter) beginStruct(id int16) {
+	w.field(id, thriftStruct)
+	w.beginElement()
+}
+
+// beginElement opens a struct list element.
+func (w *thriftWriter) beginElement() {
+	w.parent = append(w.parent, w.last)
+	w.last = 0
+}
+
+// endStruct closes the current struct with a stop byte.
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	if n := len(w.parent); n > 0 {
+		w.last = w.parent[n-1]
+		w.parent = w.parent[:n-1]
+	}
+}
@@ -0,0 +1,449 @@
+// pivot.go - Pivot tables.
+//
+// This file builds pivoted tables: the data rows of a table are grouped by row keys, spread across
+// columns generated from the distinct values of column keys (one header level per key) and
+// aggregated into cells. The result is a plain Table ready for every export format. XLSX exports of
+// the raw data may also carry a native Excel pivot table (see Table.WithExcelPivot), which readers
+// can refresh and rearrange themselves.
+
+package spit
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Column names of the generated columns of pivoted tables: value columns are named
+// PivotColumnPrefix followed by their 1-based position (pivot_1, pivot_2, ...).
+const (
+	PivotColumnPrefix = "pivot_"
+	PivotTotalColumn  = "pivot_total"
+)
+
+// DefaultExcelPivotStyle is the built-in pivot table style used when ExcelPivotOptions.Style is unset.
+const DefaultExcelPivotStyle = "PivotStyleLight16"
+
+// PivotOptions configures a pivot (see Table.Pivot).
+type PivotOptions struct {
+	Rows      []string   // Row key fields, outermost first; each distinct combination is a row
+	Columns   []string   // Column key fields, outermost first; each adds a header level of distinct values
+	Values    string     // Field aggregated in the cells
+	Aggregate *Aggregate // Aggregate of the values falling in each cell (default: AggregateSum)
+	Totals    bool       // Add a total column and a total row, aggregated over the underlying values
+}
+
+// NewPivotOptions creates a pivot summing the values field by the given row and column keys.
+// Key and value fields are column names of the table, or data field names when no column has
+// that name.
+func NewPivotOptions(rows, columns []string, values string) *PivotOptions {
+	return &PivotOptions{
+		Rows:      rows,
+		Columns:   columns,
+		Values:    values,
+		Aggregate: AggregateSum,
+	}
+}
+
+// WithAggregate sets the aggregate of the values falling in each cell (e.g. AggregateCount).
+func (p *PivotOptions) WithAggregate(aggregate *Aggregate) *PivotOptions {
+	p.Aggregate = aggregate
+	return p
+}
+
+// WithTotals sets whether a total column and a total row are added.
+func (p *PivotOptions) WithTotals(totals bool) *PivotOptions {
+	p.Totals = totals
+	return p
+}
+
+// getAggregate returns the aggregate of the pivot, falling back to AggregateSum.
+func (p *PivotOptions) getAggregate() *Aggregate {
+	if p.Aggregate != nil {
+		return p.Aggregate
+	}
+	return AggregateSum
+}
+
+// pivotGroup is a distinct combination of key values and the values falling into it.
+type pivotGroup struct {
+	key    []interface{}
+	values []interface{}
+}
+
+// pivotGroups collects the distinct combinations of key values in first-seen order.
+type pivotGroups struct {
+	index  map[string]int
+	groups []*pivotGroup
+}
+
+// add appends a value to the group of the given key, creating the group on first sight.
+func (g *pivotGroups) add(key []interface{}, value interface{}) {
+	id := pivotKeyID(key)
+	i, ok := g.index[id]
+	if !ok {
+		if g.index == nil {
+			g.index = make(map[string]int)
+		}
+		i = len(g.groups)
+		g.index[id] = i
+		g.groups = append(g.groups, &pivotGroup{key: key})
+	}
+	if value != nil {
+		g.groups[i].values = append(g.groups[i].values, value)
+	}
+}
+
+// sorted returns the groups ordered by key values (see compareValues).
+func (g *pivotGroups) sorted() []*pivotGroup {
+	groups := slices.Clone(g.groups)
+	slices.SortStableFunc(groups, func(a, b *pivotGroup) int {
+		for level := range a.key {
+			if c := compareValues(a.key[level], b.key[level]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return groups
+}
+
+// pivotKeyID returns a map key identifying a combination of key values. Values are identified by
+// type and text, so 1 and "1", or nil and "", form distinct groups.
+func pivotKeyID(key []interface{}) string {
+	parts := make([]string, len(key))
+	for i, v := range key {
+		parts[i] = fmt.Sprintf("%T:%v", v, v)
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// Pivot returns a new table aggregating the data rows of t (as prepared for export, see Prepare):
+// one row per distinct combination of row key values, then one column per distinct combination of
+// column key values, nested under one header level per column key. Rows and columns are sorted by
+// their key values; cells without values are left empty. Row key columns keep the label and format
+// of the source columns, and value columns the format of the values column (except for counts).
+func (t *Table) Pivot(options *PivotOptions) (*Table, error) {
+	if options == nil || options.Values == "" {
+		return nil, fmt.Errorf("pivot requires a values field")
+	}
+	aggregate := options.getAggregate()
+	if aggregate.Compute == nil {
+		return nil, fmt.Errorf("pivot aggregate has no compute function")
+	}
+
+	source := t.Prepare()
+	rowColumns := make([]*Column, len(options.Rows))
+	for i, name := range options.Rows {
+		rowColumns[i] = source.columnByName(name)
+	}
+	columnColumns := make([]*Column, len(options.Columns))
+	for i, name := range options.Columns {
+		columnColumns[i] = source.columnByName(name)
+	}
+	valueColumn := source.columnByName(options.Values)
+
+	keyValues := func(item Data, columns []*Column) []interface{} {
+		key := make([]interface{}, len(columns))
+		for i, column := range columns {
+			if v, err, found := item.LookupColumn(column); err == nil && found {
+				key[i] = NormalizeValue(v)
+			}
+		}
+		return key
+	}
+
+	var rows, cols pivotGroups
+	var all []interface{}
+	cells := make(map[[2]string][]interface{})
+	for i, item := range source.Data {
+		if source.SourceRowIndex(i) < 0 {
+			continue
+		}
+		var value interface{}
+		v, err, found := item.LookupColumn(valueColumn)
+		if err != nil {
+			return nil, fmt.Errorf("error looking up pivot value in row %d: %w", i, err)
+		}
+		if found {
+			value = NormalizeValue(v)
+		}
+		rowKey, colKey := keyValues(item, rowColumns), keyValues(item, columnColumns)
+		rows.add(rowKey, value)
+		cols.add(colKey, value)
+		id := [2]string{pivotKeyID(rowKey), pivotKeyID(colKey)}
+		cells[id] = append(cells[id], value)
+		all = append(all, value)
+	}
+
+	format := valueColumn.Format
+	if aggregate == AggregateCount {
+		format = ""
+	}
+
+	// Row key columns, then the value columns nested by column key
+	var columns Columns
+	for _, column := range rowColumns {
+		columns = append(columns, NewColumn(column.Name, column.Label).WithFormat(column.Format))
+	}
+	sortedCols := cols.sorted()
+	leaves := make([]*Column, len(sortedCols))
+	for i, group := range sortedCols {
+		label := valueColumn.Label
+		if len(group.key) > 0 {
+			label = pivotLabel(group.key[len(group.key)-1])
+		}
+		leaves[i] = NewColumn(PivotColumnPrefix+strconv.Itoa(i+1), label).WithFormat(format)
+	}
+	columns = append(columns, pivotColumns(sortedCols, leaves, 0)...)
+	if options.Totals {
+		columns = append(columns, NewColumn(PivotTotalColumn, DefaultFooterLabel).WithFormat(format))
+	}
+
+	sortedRows := rows.sorted()
+	data := make(DataSlice, 0, len(sortedRows)+1)
+	for _, row := range sortedRows {
+		item := make(Data, len(columns))
+		for i, column := range rowColumns {
+			item[column.Name] = row.key[i]
+		}
+		for i, col := range sortedCols {
+			// Empty cells hold nil so every format keeps the row aligned
+			item[leaves[i].Name] = nil
+			if values, ok := cells[[2]string{pivotKeyID(row.key), pivotKeyID(col.key)}]; ok {
+				item[leaves[i].Name] = aggregate.Compute(withoutNil(values))
+			}
+		}
+		if options.Totals {
+			item[PivotTotalColumn] = aggregate.Compute(row.values)
+		}
+		data = append(data, item)
+	}
+
+	pivot := NewTable(data, columns, true)
+	if options.Totals {
+		total := make(Data, len(columns))
+		if len(rowColumns) > 0 {
+			total[rowColumns[0].Name] = DefaultFooterLabel
+		}
+		for i, col := range sortedCols {
+			total[leaves[i].Name] = aggregate.Compute(col.values)
+		}
+		total[PivotTotalColumn] = aggregate.Compute(withoutNil(all))
+		pivot.RowOptionsMap = RowOptionsMap{len(data): RowOptions{RowIndex: len(data), Style: &Style{Bold: true}}}
+		pivot.Data = append(pivot.Data, total)
+	}
+	return pivot, nil
+}
+
+// withoutNil returns the non-nil values of a cell.
+func withoutNil(values []interface{}) []interface{} {
+	kept := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if v != nil {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// pivotColumns nests the leaf columns of the sorted column groups under one group column per
+// distinct key value of each level above the last one.
+func pivotColumns(groups []*pivotGroup, leaves []*Column, level int) Columns {
+	if len(groups) == 0 || level >= len(groups[0].key)-1 {
+		return slices.Clone(leaves)
+	}
+	var columns Columns
+	for start := 0; start < len(groups); {
+		end := start + 1
+		for end < len(groups) && pivotKeyID(groups[end].key[:level+1]) == pivotKeyID(groups[start].key[:level+1]) {
+			end++
+		}
+		name := fmt.Sprintf("%sgroup_%d_%d", PivotColumnPrefix, level+1, len(columns)+1)
+		group := NewColumn(name, pivotLabel(groups[start].key[level]))
+		group.Columns = pivotColumns(groups[start:end], leaves[start:end], level+1)
+		columns = append(columns, group)
+		start = end
+	}
+	return columns
+}
+
+// pivotLabel returns the header label of a key value.
+func pivotLabel(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ExcelPivotOptions configures the native Excel pivot table written along an XLSX export.
+type ExcelPivotOptions struct {
+	PivotOptions        // Row, column and value fields (column names of the table), aggregate and totals
+	Sheet        string // Sheet receiving the pivot table, created when missing (default: the data sheet name followed by " Pivot")
+	Cell         string // Top-left cell of the pivot table (default: "A1")
+	Name         string // Pivot table name (default: the data sheet name followed by "Pivot")
+	Style        string // Built-in pivot table style (default: DefaultExcelPivotStyle)
+}
+
+// NewExcelPivotOptions creates a native pivot table with the fields of the given pivot. Only
+// built-in aggregates (AggregateSum, AggregateAvg, AggregateCount, AggregateMin, AggregateMax)
+// can be written natively.
+func NewExcelPivotOptions(pivot *PivotOptions) *ExcelPivotOptions {
+	options := &ExcelPivotOptions{}
+	if pivot != nil {
+		options.PivotOptions = *pivot
+	}
+	return options
+}
+
+// WithSheet sets the sheet and top-left cell receiving the pivot table.
+func (o *ExcelPivotOptions) WithSheet(sheet, cell string) *ExcelPivotOptions {
+	o.Sheet = sheet
+	o.Cell = cell
+	return o
+}
+
+// WithName sets the name of the pivot table.
+func (o *ExcelPivotOptions) WithName(name string) *ExcelPivotOptions {
+	o.Name = name
+	return o
+}
+
+// WithStyle sets the built-in pivot table style (e.g. "PivotStyleMedium9").
+func (o *ExcelPivotOptions) WithStyle(style string) *ExcelPivotOptions {
+	o.Style = style
+	return o
+}
+
+// WithExcelPivot writes a native Excel pivot table over the header and data rows of XLSX exports
+// (see ExcelPivotOptions). Requires a single header row; the pivot table is computed by the reader
+// when the workbook is opened or refreshed.
+func (t *Table) WithExcelPivot(options *ExcelPivotOptions) *Table {
+	t.ExcelPivot = options
+	return t
+}
+
+// ExcelPivotSpec describes a native pivot table to add to a workbook.
+type ExcelPivotSpec struct {
+	DataRange  string   // Sheet-qualified source range, header included (e.g. "Sheet1!A1:D20")
+	PivotRange string   // Sheet-qualified range where the pivot table is placed (e.g. "Sheet1 Pivot!A1:C3")
+	Name       string   // Pivot table name
+	Rows       []string // Header labels of the row fields
+	Columns    []string // Header labels of the column fields
+	Values     string   // Header label of the value field
+	Function   string   // Spreadsheet function aggregating the values (e.g. "SUM", see Aggregate.Function)
+	Totals     bool     // Show grand totals
+	Style      string   // Built-in pivot table style
+}
+
+// excelPivotAdder is implemented by spreadsheets supporting native pivot tables.
+type excelPivotAdder interface {
+	AddPivotTable(spec ExcelPivotSpec) error
+}
+
+// writeExcelPivot adds the native pivot table over the header and data rows of the written sheet.
+// The source cells stay written when the pivot cannot be added; the failure is reported as a
+// warning, fatal only with ErrorStrict.
+func (xlsx *xlsx) writeExcelPivot(result SheetResult) {
+	t := xlsx.table
+	options := t.ExcelPivot
+	if options == nil {
+		return
+	}
+	if t.Transposed {
+		t.warn(WarningPhaseSheet, "", "Pivot tables are not supported by transposed tables, pivot table skipped", nil, String("sheet", result.Name))
+		return
+	}
+	if result.HeaderRange.IsEmpty() || result.HeaderRange.StartRow != result.HeaderRange.EndRow || result.DataRange.IsEmpty() {
+		t.warn(WarningPhaseSheet, "", "Pivot tables require a single header row and data rows, pivot table skipped", nil, String("sheet", result.Name))
+		return
+	}
+	for _, rowOptions := range t.RowOptionsMap {
+		if rowOptions.SpanAllColumns {
+			t.warn(WarningPhaseSheet, "", "Pivot tables cannot read full-width rows, pivot table skipped", nil, String("sheet", result.Name))
+			return
+		}
+	}
+	aggregate := options.getAggregate()
+	if aggregate.Function == "" {
+		t.warn(WarningPhaseSheet, "", "Pivot tables require a built-in aggregate, pivot table skipped", nil, String("sheet", result.Name))
+		return
+	}
+	adder, ok := xlsx.spreadsheet.(excelPivotAdder)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support pivot tables, pivot table skipped", nil)
+		return
+	}
+
+	// Pivot fields are identified by their header labels
+	labels := make(map[string]string)
+	for _, column := range t.Columns.GetFlattenedColumns() {
+		labels[column.Name] = column.Label
+	}
+	fields := func(names []string) ([]string, bool) {
+		out := make([]string, len(names))
+		for i, name := range names {
+			label, ok := labels[name]
+			if !ok || label == "" {
+				t.warn(WarningPhaseSheet, "", "Unknown pivot field, pivot table skipped", nil, String("field", name))
+				return nil, false
+			}
+			out[i] = label
+		}
+		return out, true
+	}
+	rows, ok := fields(options.Rows)
+	if !ok {
+		return
+	}
+	columns, ok := fields(options.Columns)
+	if !ok {
+		return
+	}
+	values, ok := fields([]string{options.Values})
+	if !ok {
+		return
+	}
+
+	source := result.HeaderRange
+	source.EndRow = result.DataRange.EndRow
+	sheet := options.Sheet
+	if sheet == "" {
+		sheet = result.Name + " Pivot"
+	}
+	cell := options.Cell
+	if cell == "" {
+		cell = "A1"
+	}
+	col, row, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		t.warn(WarningPhaseSheet, "", "Invalid pivot table cell, pivot table skipped", err, String("cell", cell))
+		return
+	}
+	// The placed range only needs to be valid: readers lay the pivot table out when computing it
+	target := CellRange{StartCol: col, StartRow: row, EndCol: col + len(rows) + 1, EndRow: row + 2}
+
+	spec := ExcelPivotSpec{
+		DataRange:  result.Name + "!" + source.String(),
+		PivotRange: sheet + "!" + target.String(),
+		Name:       options.Name,
+		Rows:       rows,
+		Columns:    columns,
+		Values:     values[0],
+		Function:   aggregate.Function,
+		Totals:     options.Totals,
+		Style:      options.Style,
+	}
+	if spec.Name == "" {
+		spec.Name = SanitizeDefinedName(result.Name + "Pivot")
+	}
+	if spec.Style == "" {
+		spec.Style = DefaultExcelPivotStyle
+	}
+	if err := adder.AddPivotTable(spec); err != nil {
+		t.warn(WarningPhaseSheet, "", "Failed to add pivot table", err, String("name", spec.Name), String("range", spec.PivotRange))
+	}
+}
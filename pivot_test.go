@@ -0,0 +1,177 @@
+package spit
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// pivotLabels returns the labels of columns, group labels followed by their nested labels.
+func pivotLabels(columns Columns) []string {
+	var labels []string
+	for _, column := range columns {
+		labels = append(labels, column.Label)
+		if len(column.Columns) > 0 {
+			labels = append(labels, pivotLabels(column.Columns)...)
+		}
+	}
+	return labels
+}
+
+func TestTable_Pivot(t *testing.T) {
+	table := fixtureTable("region", "year", "quarter", "sales")
+	table.Columns[3].WithFormat("#,##0.00")
+	pivot, err := table.Pivot(NewPivotOptions([]string{"region"}, []string{"year", "quarter"}, "sales").WithTotals(true))
+	if err != nil {
+		t.Fatalf("Pivot() error = %v", err)
+	}
+
+	wantLabels := []string{"Region", "2024", "Q1", "Q2", "2025", "Q1", "Total"}
+	if got := pivotLabels(pivot.Columns); !reflect.DeepEqual(got, wantLabels) {
+		t.Errorf("labels = %v, want %v", got, wantLabels)
+	}
+	if pivot.Columns.GetMaxDepth() != 2 {
+		t.Errorf("max depth = %d, want 2", pivot.Columns.GetMaxDepth())
+	}
+	if format := pivot.Columns.GetFlattenedColumns()[1].Format; format != "#,##0.00" {
+		t.Errorf("value columns should keep the values format, got %q", format)
+	}
+
+	want := DataSlice{
+		{"region": "EU", "pivot_1": 25.0, "pivot_2": 20.0, "pivot_3": nil, "pivot_total": 45.0},
+		{"region": "US", "pivot_1": 30.0, "pivot_2": nil, "pivot_3": 40.0, "pivot_total": 70.0},
+		{"region": "Total", "pivot_1": 55.0, "pivot_2": 20.0, "pivot_3": 40.0, "pivot_total": 115.0},
+	}
+	if !reflect.DeepEqual(pivot.Data, want) {
+		t.Errorf("data = %v, want %v", pivot.Data, want)
+	}
+	if ro, ok := pivot.RowOptionsMap[2]; !ok || !ro.Style.Bold {
+		t.Errorf("total row should be bold, got %v", pivot.RowOptionsMap)
+	}
+}
+
+func TestTable_Pivot_options(t *testing.T) {
+	t.Run("count without column keys", func(t *testing.T) {
+		table := fixtureTable("region", "year", "quarter", "sales")
+		table.Columns[3].WithFormat("#,##0.00")
+		pivot, err := table.Pivot(NewPivotOptions([]string{"region", "year"}, nil, "sales").WithAggregate(AggregateCount))
+		if err != nil {
+			t.Fatalf("Pivot() error = %v", err)
+		}
+		if got, want := pivotLabels(pivot.Columns), []string{"Region", "Year", "Sales"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("labels = %v, want %v", got, want)
+		}
+		if format := pivot.Columns[2].Format; format != "" {
+			t.Errorf("counts should not keep the values format, got %q", format)
+		}
+		want := DataSlice{
+			{"region": "EU", "year": 2024, "pivot_1": 3},
+			{"region": "US", "year": 2024, "pivot_1": 1},
+			{"region": "US", "year": 2025, "pivot_1": 1},
+		}
+		if !reflect.DeepEqual(pivot.Data, want) {
+			t.Errorf("data = %v, want %v", pivot.Data, want)
+		}
+	})
+
+	t.Run("filtered source", func(t *testing.T) {
+		table := fixtureTable("region", "year", "quarter", "sales").WithFilter(func(row Data) bool { return row["year"] == 2024 })
+		pivot, err := table.Pivot(NewPivotOptions([]string{"quarter"}, []string{"region"}, "sales"))
+		if err != nil {
+			t.Fatalf("Pivot() error = %v", err)
+		}
+		want := DataSlice{
+			{"quarter": "Q1", "pivot_1": 25.0, "pivot_2": 30.0},
+			{"quarter": "Q2", "pivot_1": 20.0, "pivot_2": nil},
+		}
+		if !reflect.DeepEqual(pivot.Data, want) {
+			t.Errorf("data = %v, want %v", pivot.Data, want)
+		}
+	})
+
+	t.Run("keys of distinct types", func(t *testing.T) {
+		table := NewTable(DataSlice{
+			{"code": 1, "sales": 1},
+			{"code": "1", "sales": 2},
+			{"code": nil, "sales": 4},
+			{"code": "", "sales": 8},
+			{"code": 1, "sales": 16},
+		}, Columns{NewColumn("code", "Code"), NewColumn("sales", "Sales")}, true)
+		pivot, err := table.Pivot(NewPivotOptions([]string{"code"}, nil, "sales"))
+		if err != nil {
+			t.Fatalf("Pivot() error = %v", err)
+		}
+		got := make(map[interface{}]interface{}, len(pivot.Data))
+		for _, row := range pivot.Data {
+			got[row["code"]] = row["pivot_1"]
+		}
+		want := map[interface{}]interface{}{1: 17.0, "1": 2.0, nil: 4.0, "": 8.0}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("groups = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("missing values field", func(t *testing.T) {
+		if _, err := fixtureTable("region", "year", "quarter", "sales").Pivot(NewPivotOptions([]string{"region"}, nil, "")); err == nil {
+			t.Error("expected an error without values field")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		pivot, err := fixtureTable("region", "year", "quarter", "sales").Pivot(NewPivotOptions([]string{"region"}, []string{"quarter"}, "sales"))
+		if err != nil {
+			t.Fatalf("Pivot() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if _, err := ExportCSV(",", pivot, FileWriteParams{Filename: "pivot", Writer: &buf}); err != nil {
+			t.Fatalf("ExportCSV() error = %v", err)
+		}
+		if want := "Region,Q1,Q2\nEU,25,20\nUS,70,\n"; buf.String() != want {
+			t.Errorf("expected %q, got %q", want, buf.String())
+		}
+	})
+}
+
+func TestExportXLSX_excelPivot(t *testing.T) {
+	dir := t.TempDir()
+	table := fixtureTable("region", "year", "quarter", "sales").WithExcelPivot(
+		NewExcelPivotOptions(NewPivotOptions([]string{"region"}, []string{"quarter"}, "sales").WithTotals(true)),
+	)
+	result, err := ExportXLSX(NewSpreadsheetExcelize("Sales", table), FileWriteParams{Filename: "pivot", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	if len(result.Warnings) > 0 {
+		t.Errorf("unexpected warnings %v", result.Warnings)
+	}
+
+	f, err := excelize.OpenFile(filepath.Join(dir, "pivot.xlsx"))
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	defer f.Close()
+	pivots, err := f.GetPivotTables("Sales Pivot")
+	if err != nil || len(pivots) != 1 {
+		t.Fatalf("GetPivotTables() = %v, %v", pivots, err)
+	}
+	p := pivots[0]
+	if p.DataRange != "Sales!A1:D6" || p.Name != "SalesPivot" || len(p.Rows) != 1 || p.Rows[0].Data != "Region" ||
+		len(p.Data) != 1 || p.Data[0].Data != "Sales" || p.Data[0].Subtotal != "Sum" || !p.RowGrandTotals {
+		t.Errorf("unexpected pivot table %+v", p)
+	}
+
+	// Custom aggregates cannot be written natively
+	table = fixtureTable("region", "year", "quarter", "sales").WithExcelPivot(
+		NewExcelPivotOptions(NewPivotOptions([]string{"region"}, nil, "sales").WithAggregate(NewAggregate(func([]interface{}) interface{} { return 0 }))),
+	)
+	result, err = ExportXLSX(NewSpreadsheetExcelize("Sales", table), FileWriteParams{Filename: "custom", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected a warning for custom aggregates, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,228 @@
+// preview.go - Preview (sample) exports.
+//
+// This file defines PreviewOptions, a single switch that turns any export into a safe sample:
+// the dataset is truncated, sensitive columns are obfuscated, a watermark row is written above
+// the table and the sheet/document title is marked accordingly. Intended for sales and demo
+// exports that must never leak full production data.
+
+package spit
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultPreviewRows is the number of data rows kept when PreviewOptions.MaxRows is unset.
+	DefaultPreviewRows = 10
+
+	// DefaultPreviewWatermark is the watermark text used when PreviewOptions.Watermark is unset.
+	DefaultPreviewWatermark = "SAMPLE"
+)
+
+// PreviewOptions configures preview mode for a table export.
+type PreviewOptions struct {
+	MaxRows        int                                 `json:"maxRows,omitempty" yaml:"maxRows,omitempty"`               // Maximum number of data rows kept (0 = DefaultPreviewRows)
+	MaskColumns    []string                            `json:"maskColumns,omitempty" yaml:"maskColumns,omitempty"`       // Names of leaf columns whose values are obfuscated
+	Mask           func(value interface{}) interface{} `json:"-" yaml:"-"`                                               // Optional masking function (default: MaskValue)
+	Watermark      string                              `json:"watermark,omitempty" yaml:"watermark,omitempty"`           // Watermark text written above the table (default: DefaultPreviewWatermark)
+	WatermarkStyle *Style                              `json:"watermarkStyle,omitempty" yaml:"watermarkStyle,omitempty"` // Optional style for the watermark row (default: bold red)
+	Sample         bool                                `json:"sample,omitempty" yaml:"sample,omitempty"`                 // Whether rows are drawn at random rather than taken from the top (seeded by FileWriteParams.Seed)
+}
+
+// NewPreviewOptions creates a new PreviewOptions instance with default settings.
+func NewPreviewOptions() *PreviewOptions {
+	return &PreviewOptions{}
+}
+
+// WithMaxRows sets the maximum number of data rows kept in the preview.
+func (p *PreviewOptions) WithMaxRows(maxRows int) *PreviewOptions {
+	p.MaxRows = maxRows
+	return p
+}
+
+// WithMaskColumns sets the names of the columns whose values are obfuscated.
+func (p *PreviewOptions) WithMaskColumns(names ...string) *PreviewOptions {
+	p.MaskColumns = names
+	return p
+}
+
+// WithSample sets whether the kept rows are drawn at random across the dataset (in their original
+// order) rather than taken from the top. The draw is seeded by FileWriteParams.Seed, so exports
+// are reproducible from the seed reported in FileWriteResult.Seed.
+func (p *PreviewOptions) WithSample(sample bool) *PreviewOptions {
+	p.Sample = sample
+	return p
+}
+
+// WithMask sets a custom masking function applied to values of masked columns.
+func (p *PreviewOptions) WithMask(mask func(value interface{}) interface{}) *PreviewOptions {
+	p.Mask = mask
+	return p
+}
+
+// WithWatermark sets the watermark text written above the table.
+func (p *PreviewOptions) WithWatermark(watermark string) *PreviewOptions {
+	p.Watermark = watermark
+	return p
+}
+
+// WithWatermarkStyle sets the style of the watermark row.
+func (p *PreviewOptions) WithWatermarkStyle(style *Style) *PreviewOptions {
+	p.WatermarkStyle = style
+	return p
+}
+
+// GetWatermark returns the watermark text, falling back to DefaultPreviewWatermark.
+func (p *PreviewOptions) GetWatermark() string {
+	if p.Watermark != "" {
+		return p.Watermark
+	}
+	return DefaultPreviewWatermark
+}
+
+// TitleFor returns the given sheet or document title marked as a preview, e.g. "Sales (SAMPLE)".
+func (p *PreviewOptions) TitleFor(title string) string {
+	if title == "" {
+		return p.GetWatermark()
+	}
+	return fmt.Sprintf("%s (%s)", title, p.GetWatermark())
+}
+
+// sheetTitleFor returns the preview title of a sheet (see TitleFor), shortening the sheet name so
+// the watermark suffix still fits Excel's 31-character limit.
+func (p *PreviewOptions) sheetTitleFor(sheetName string) string {
+	name := []rune(sheetName)
+	if limit := maxSheetNameLength - len([]rune(p.TitleFor("x"))) + 1; len(name) > limit {
+		name = name[:max(limit, 0)]
+	}
+	return p.TitleFor(strings.TrimRight(string(name), " "))
+}
+
+// MaskValue is the default masking function used in preview mode.
+// Nil and empty values are kept as-is so blanks stay blank; any other value is replaced by "****".
+func MaskValue(value interface{}) interface{} {
+	if value == nil || fmt.Sprintf("%v", value) == "" {
+		return value
+	}
+	return "****"
+}
+
+// apply returns a shallow copy of t with the preview transformations applied: rows are truncated,
+// masked columns are obfuscated and a watermark row is prepended to the preamble.
+// The original table and its data rows are left untouched.
+func (p *PreviewOptions) apply(t *Table) *Table {
+	preview := *t
+	preview.Preview = nil
+
+	maxRows := p.MaxRows
+	if maxRows <= 0 {
+		maxRows = DefaultPreviewRows
+	}
+	data := t.Data
+	if len(data) > maxRows {
+		if p.Sample {
+			var picked []int
+			data, picked = sampleRows(data, maxRows, t.seed)
+			if t.sourceRows != nil {
+				// Sorted rows keep reporting their original index
+				preview.sourceRows = make([]int, len(picked))
+				for i, index := range picked {
+					preview.sourceRows[i] = t.SourceRowIndex(index)
+				}
+			}
+		} else {
+			data = data[:maxRows]
+		}
+	}
+
+	// Resolve the masked leaf columns once
+	var masked Columns
+	for _, column := range t.Columns.GetFlattenedColumns() {
+		for _, name := range p.MaskColumns {
+			if column.Name == name {
+				masked = append(masked, column)
+				break
+			}
+		}
+	}
+
+	mask := p.Mask
+	if mask == nil {
+		mask = MaskValue
+	}
+
+	preview.Data = make(DataSlice, len(data))
+	for i, item := range data {
+		if len(masked) == 0 {
+			preview.Data[i] = item
+			continue
+		}
+		// Copy the row so masking never alters the caller's data
+		row := make(Data, len(item))
+		for k, v := range item {
+			row[k] = v
+		}
+		for _, column := range masked {
+			for _, key := range column.LookupKeys() {
+				if maskKey(row, key, mask) {
+					break
+				}
+			}
+		}
+		preview.Data[i] = row
+	}
+
+	style := p.WatermarkStyle
+	if style == nil {
+		style = &Style{Bold: true, TextColor: "#C00000", FontSize: 14}
+	}
+	watermark := NewPreambleRow(p.GetWatermark()).WithStyle(style)
+	preview.Preamble = append(PreambleRows{watermark}, t.Preamble...)
+
+	return &preview
+}
+
+// sampleRows draws n rows of data at random with the given seed, keeping their original order.
+// The indices of the drawn rows are returned along with them.
+func sampleRows(data DataSlice, n int, seed int64) (DataSlice, []int) {
+	r := rand.New(rand.NewPCG(uint64(seed), 0))
+	picked := r.Perm(len(data))[:n]
+	slices.Sort(picked)
+
+	sample := make(DataSlice, n)
+	for i, index := range picked {
+		sample[i] = data[index]
+	}
+	return sample, picked
+}
+
+// maskKey masks the value of a column key in row, resolving nested paths (see Data.LookupKey).
+// Nested maps along the path are copied so masking never alters the caller's data.
+// Returns false when the key is not present.
+func maskKey(row Data, key string, mask func(interface{}) interface{}) bool {
+	if v, ok := row[strings.TrimSpace(key)]; ok || !strings.Contains(key, KeyPathSeparator) {
+		if ok {
+			row[strings.TrimSpace(key)] = mask(v)
+		}
+		return ok
+	}
+
+	path := strings.Split(key, KeyPathSeparator)
+	first := strings.TrimSpace(path[0])
+	nested, ok := asData(row[first])
+	if !ok {
+		return false
+	}
+	copied := make(Data, len(nested))
+	for k, v := range nested {
+		copied[k] = v
+	}
+	if !maskKey(copied, strings.Join(path[1:], KeyPathSeparator), mask) {
+		return false
+	}
+	row[first] = copied
+	return true
+}
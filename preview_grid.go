@@ -0,0 +1,86 @@
+// preview_grid.go - In-memory export previews.
+//
+// This file renders the first rows of a table into an in-memory grid (values, styles, merges)
+// without writing any file, so applications can show "what you'll download" next to the export
+// button. The grid is built by the same pipeline as the HTML export: the table is prepared, then
+// cells are written, merged and styled through TableOperations like in every other backend.
+
+package spit
+
+import "fmt"
+
+// PreviewCell is a cell of a PreviewGrid.
+type PreviewCell struct {
+	Value   string  // Display text (formatted like in exports)
+	Link    string  // Hyperlink target, if any
+	Image   *Image  // Image rendered in the cell, if any
+	Style   *Style  // Resolved style (nil when unstyled)
+	Borders Borders // Per-side borders
+	ColSpan int     // Number of columns covered by a merge starting here (1 = no merge)
+	RowSpan int     // Number of rows covered by a merge starting here (1 = no merge)
+	Covered bool    // Whether the cell is hidden by a merge starting in another cell
+	Numeric bool    // Whether the source value is numeric (e.g. to right-align it)
+}
+
+// PreviewGrid is an in-memory rendering of the first rows of a table.
+type PreviewGrid struct {
+	Cells     [][]PreviewCell // Cells by 0-based row then column, preamble, header and footer included
+	Rows      int             // Number of rows of the grid
+	Cols      int             // Number of columns of the grid
+	DataRows  int             // Number of data rows in the grid
+	TotalRows int             // Number of data rows of the full export
+	Truncated bool            // Whether data rows were left out of the grid
+}
+
+// Preview renders at most maxRows data rows of the table (all of them when maxRows <= 0) into an
+// in-memory grid without writing any file. The table is prepared like for an export (preview mode,
+// grouping, format-specific columns for FormatHTML), so the grid shows the rows users download.
+// Footer aggregates are computed over the rows of the grid.
+func Preview(t *Table, maxRows int) (*PreviewGrid, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no table provided")
+	}
+
+	prepared := t.Prepare().ForFormat(FormatHTML).withoutOffset()
+	total := len(prepared.Data)
+	if maxRows > 0 && total > maxRows {
+		truncated := *prepared
+		truncated.Data = prepared.Data[:maxRows]
+		prepared = &truncated
+	}
+
+	export := &htmlExport{table: prepared.CacheValues(), grid: make(map[int]map[int]*htmlCell)}
+	if err := export.build(); err != nil {
+		return nil, fmt.Errorf("failed to build preview: %w", err)
+	}
+
+	grid := &PreviewGrid{
+		Rows:      export.maxRow,
+		Cols:      export.maxCol,
+		DataRows:  len(prepared.Data),
+		TotalRows: total,
+		Truncated: len(prepared.Data) < total,
+	}
+	grid.Cells = make([][]PreviewCell, export.maxRow)
+	for row := 1; row <= export.maxRow; row++ {
+		cells := make([]PreviewCell, export.maxCol)
+		for col := 1; col <= export.maxCol; col++ {
+			cells[col-1] = PreviewCell{ColSpan: 1, RowSpan: 1}
+			if c := export.peek(col, row); c != nil {
+				cells[col-1] = PreviewCell{
+					Value:   c.value,
+					Link:    c.link,
+					Image:   c.image,
+					Style:   c.style,
+					Borders: c.borders,
+					ColSpan: c.colspan,
+					RowSpan: c.rowspan,
+					Covered: c.covered,
+					Numeric: c.numeric,
+				}
+			}
+		}
+		grid.Cells[row-1] = cells
+	}
+	return grid, nil
+}
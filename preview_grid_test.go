@@ -0,0 +1,36 @@
+package spit
+
+import "testing"
+
+func TestPreview(t *testing.T) {
+	data := DataSlice{
+		{"region": "EU", "sales": 10},
+		{"region": "EU", "sales": 20},
+		{"region": "US", "sales": 30},
+	}
+	table := NewTable(data, Columns{
+		NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+		NewColumn("sales", "Sales").WithStyle(&Style{Italic: true}),
+	}, true)
+
+	grid, err := Preview(table, 2)
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+
+	if grid.Rows != 3 || grid.Cols != 2 || grid.DataRows != 2 || grid.TotalRows != 3 || !grid.Truncated {
+		t.Fatalf("grid = %d x %d, %d/%d rows, truncated %v", grid.Rows, grid.Cols, grid.DataRows, grid.TotalRows, grid.Truncated)
+	}
+	if grid.Cells[0][0].Value != "Region" || grid.Cells[0][0].Style == nil || !grid.Cells[0][0].Style.Bold {
+		t.Errorf("header cell = %+v, want the bold Region label", grid.Cells[0][0])
+	}
+	if c := grid.Cells[1][0]; c.Value != "EU" || c.RowSpan != 2 || !grid.Cells[2][0].Covered {
+		t.Errorf("region cells = %+v / %+v, want EU merged over both rows", c, grid.Cells[2][0])
+	}
+	if c := grid.Cells[2][1]; c.Value != "20" || !c.Numeric || c.Style == nil || !c.Style.Italic {
+		t.Errorf("sales cell = %+v, want the italic numeric value 20", c)
+	}
+	if len(table.Data) != 3 {
+		t.Error("Preview must not modify the table")
+	}
+}
@@ -0,0 +1,222 @@
+package spit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestPreviewOptions_apply(t *testing.T) {
+	table := fixtureTable("name", "email").WithPreview(NewPreviewOptions().WithMaxRows(2).WithMaskColumns("email"))
+
+	preview := table.Prepare()
+	if preview == table {
+		t.Fatal("Prepare() should return a copy in preview mode")
+	}
+	if preview.Preview != nil {
+		t.Error("prepared table should not carry preview options")
+	}
+	if len(preview.Data) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(preview.Data))
+	}
+	if preview.Data[0]["email"] != "****" || preview.Data[0]["name"] != "carol" {
+		t.Errorf("unexpected masked row: %v", preview.Data[0])
+	}
+	if table.Data[0]["email"] != "carol@acme.com" {
+		t.Error("original data must not be modified")
+	}
+	if len(preview.Preamble) != 1 || preview.Preamble[0].Values[0] != DefaultPreviewWatermark {
+		t.Errorf("expected watermark preamble row, got %v", preview.Preamble)
+	}
+	if len(table.Preamble) != 0 {
+		t.Error("original preamble must not be modified")
+	}
+}
+
+func TestPreviewOptions_customMask(t *testing.T) {
+	table := fixtureTable("name", "email").WithPreview(NewPreviewOptions().
+		WithMaskColumns("name").
+		WithMask(func(v interface{}) interface{} { return "x" }).
+		WithWatermark("DEMO"))
+
+	preview := table.Prepare()
+	if len(preview.Data) != 5 {
+		t.Fatalf("expected all 5 rows under the default limit, got %d", len(preview.Data))
+	}
+	for _, row := range preview.Data {
+		if row["name"] != "x" {
+			t.Errorf("expected custom mask, got %v", row["name"])
+		}
+	}
+	if preview.Preamble[0].Values[0] != "DEMO" {
+		t.Errorf("expected custom watermark, got %v", preview.Preamble[0].Values[0])
+	}
+}
+
+func TestPreviewOptions_TitleFor(t *testing.T) {
+	p := NewPreviewOptions()
+	if got := p.TitleFor("Sales"); got != "Sales (SAMPLE)" {
+		t.Errorf("TitleFor() = %q", got)
+	}
+	if got := p.TitleFor(""); got != "SAMPLE" {
+		t.Errorf("TitleFor(\"\") = %q", got)
+	}
+	if got := p.sheetTitleFor("Quarterly revenue by region 2026"); got != "Quarterly revenue by r (SAMPLE)" {
+		t.Errorf("sheetTitleFor() = %q, want the watermark kept within 31 characters", got)
+	}
+}
+
+func TestExportXLSX_previewSheetNames(t *testing.T) {
+	preview := NewSpreadsheetExcelize("Customers", fixtureTable("name", "email").WithPreview(NewPreviewOptions()))
+	for i := 0; i < 2; i++ {
+		res, err := ExportXLSXSheets([]Spreadsheet{
+			NewSpreadsheetExcelize("Customers (SAMPLE)", fixtureTable("name", "email")),
+			preview,
+		}, FileWriteParams{Filename: "preview", Writer: &bytes.Buffer{}})
+		if err != nil {
+			t.Fatalf("ExportXLSXSheets failed: %v", err)
+		}
+		if got := res.Sheets[1].Name; got != "Customers (SAMPLE) (2)" {
+			t.Errorf("export %d: preview sheet = %q, want a unique name", i+1, got)
+		}
+		if got := preview.GetSheetName(); got != "Customers" {
+			t.Errorf("export %d: spreadsheet renamed to %q", i+1, got)
+		}
+	}
+}
+
+func TestMaskValue(t *testing.T) {
+	if MaskValue(nil) != nil {
+		t.Error("nil should stay nil")
+	}
+	if MaskValue("") != "" {
+		t.Error("empty string should stay empty")
+	}
+	if MaskValue(42) != "****" {
+		t.Error("non-empty values should be masked")
+	}
+}
+
+func TestTable_Prepare_noPreview(t *testing.T) {
+	table := fixtureTable("name", "email")
+	if table.Prepare() != table {
+		t.Error("Prepare() should return the table itself when no transformation is configured")
+	}
+}
+
+func TestExportCSV_preview(t *testing.T) {
+	table := fixtureTable("name", "email").WithPreview(NewPreviewOptions().WithMaxRows(1).WithMaskColumns("email"))
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "preview", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	want := "SAMPLE\nName,Email\ncarol,****\n"
+	if string(content) != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", content, want)
+	}
+}
+
+func TestExportXLSX_preview(t *testing.T) {
+	table := fixtureTable("name", "email").WithPreview(NewPreviewOptions().WithMaxRows(1).WithMaskColumns("email"))
+	s := NewSpreadsheetExcelize("Customers", table)
+
+	res, err := ExportXLSX(s, FileWriteParams{Filename: "preview", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	sheet := "Customers (SAMPLE)"
+	if idx, _ := f.GetSheetIndex(sheet); idx == -1 {
+		t.Fatalf("expected sheet %q, got %v", sheet, f.GetSheetList())
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	got := make([]string, len(rows))
+	for i, row := range rows {
+		got[i] = strings.Join(row, ",")
+	}
+	want := []string{"SAMPLE", "Name,Email", "carol,****"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestPreviewOptions_maskNestedKey(t *testing.T) {
+	address := Data{"city": "Paris", "street": "1 rue de Rivoli"}
+	table := NewTable(DataSlice{{"name": "Alice", "address": address}},
+		Columns{NewColumn("name", "Name"), NewColumn("address.street", "Street")}, true).
+		WithPreview(NewPreviewOptions().WithMaskColumns("address.street"))
+
+	preview := table.Prepare()
+	if v, _, _ := preview.Data[0].LookupKey("address.street"); v != "****" {
+		t.Errorf("expected the nested value to be masked, got %v", v)
+	}
+	if v, _, _ := preview.Data[0].LookupKey("address.city"); v != "Paris" {
+		t.Errorf("expected sibling values to be kept, got %v", v)
+	}
+	if address["street"] != "1 rue de Rivoli" {
+		t.Error("masking must not modify the caller's nested data")
+	}
+}
+
+func TestExportCSV_previewSampleSeed(t *testing.T) {
+	data := make(DataSlice, 50)
+	for i := range data {
+		data[i] = Data{"id": i}
+	}
+	table := NewTable(data, Columns{NewColumn("id", "ID")}, true).
+		WithPreview(NewPreviewOptions().WithMaxRows(5).WithSample(true))
+	dir := t.TempDir()
+
+	export := func(name string, seed int64) (*FileWriteResult, string) {
+		res, err := ExportCSV(",", table, FileWriteParams{Filename: name, Filepath: dir, Seed: seed})
+		if err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+		content, err := os.ReadFile(res.Filepath)
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		return res, string(content)
+	}
+
+	first, firstContent := export("first", 0)
+	if first.Seed == 0 {
+		t.Fatal("an unseeded export should report the random seed it used")
+	}
+	replay, replayContent := export("replay", first.Seed)
+	if replay.Seed != first.Seed || replayContent != firstContent {
+		t.Errorf("export with seed %d = %q, want %q", first.Seed, replayContent, firstContent)
+	}
+
+	lines := strings.Split(strings.TrimSpace(firstContent), "\n")
+	if len(lines) != 7 { // Watermark, header and 5 sampled rows
+		t.Fatalf("expected 7 lines, got %d: %q", len(lines), firstContent)
+	}
+	previous := -1
+	for _, line := range lines[2:] {
+		var id int
+		if _, err := fmt.Sscan(line, &id); err != nil || id <= previous {
+			t.Errorf("sampled rows should keep their order, got %q", lines[2:])
+			break
+		}
+		previous = id
+	}
+}
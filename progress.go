@@ -0,0 +1,94 @@
+// progress.go - Export progress reporting.
+//
+// This file implements the progress callback of an export run (FileWriteParams.OnProgress), so UIs
+// and job runners can show progress and detect stalls of long exports. Progress is counted in row
+// steps: each prepared table adds its data rows once per phase run over them (writing, and for
+// sheet formats merging and styling), and each row processed by a phase advances the count. Nested
+// exports (the sheets of a workbook, the formats of ExportMulti, the entries of ExportArchive)
+// share the progress of their run, whose total grows as their tables are prepared.
+
+package spit
+
+import "sync"
+
+// DefaultProgressInterval is the number of row steps between progress reports when
+// FileWriteParams.ProgressEvery is zero.
+const DefaultProgressInterval = 1000
+
+// Phases run over the data rows of a table, per format family.
+const (
+	progressPhasesFlat  = 1 // Data writing only (CSV, Markdown, Parquet, registered exporters)
+	progressPhasesSheet = 3 // Data writing, merging and styling (XLSX, HTML)
+)
+
+// runProgress tracks the row steps of an export run and reports them to its hook. Methods accept
+// a nil progress (runs without OnProgress), which tracks nothing.
+type runProgress struct {
+	mu       sync.Mutex
+	hook     func(done, total int)
+	every    int
+	done     int
+	total    int
+	reported int
+}
+
+// resolveProgress returns the progress of the run: the one shared by an enclosing export, a new
+// one reporting to OnProgress, or nil when no callback is set.
+func (fwo FileWriteParams) resolveProgress() *runProgress {
+	if fwo.progress != nil {
+		return fwo.progress
+	}
+	if fwo.OnProgress == nil {
+		return nil
+	}
+	every := fwo.ProgressEvery
+	if every <= 0 {
+		every = DefaultProgressInterval
+	}
+	return &runProgress{hook: fwo.OnProgress, every: every}
+}
+
+// track adds the data rows of a prepared table, once per phase, to the total of the run and lets
+// the phases of the table advance the progress.
+func (p *runProgress) track(t *Table, phases int) {
+	if p == nil {
+		return
+	}
+	t.progress = p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total += len(t.Data) * phases
+}
+
+// advance accounts for n processed row steps, reporting every interval and at the end of the run.
+func (p *runProgress) advance(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done = min(p.done+n, p.total)
+	if p.done-p.reported >= p.every || (p.done == p.total && p.done > p.reported) {
+		p.report()
+	}
+}
+
+// finish completes the steps of the tracked tables, e.g. rows a phase skipped, and reports them.
+func (p *runProgress) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done = p.total
+	if p.done > p.reported {
+		p.report()
+	}
+}
+
+// report calls the hook with the current progress. The caller holds p.mu, so reports are
+// serialized and never go backwards.
+func (p *runProgress) report() {
+	p.reported = p.done
+	p.hook(p.done, p.total)
+}
@@ -0,0 +1,90 @@
+package spit
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestOnProgress(t *testing.T) {
+	table := func() *Table {
+		data := make(DataSlice, 10)
+		for i := range data {
+			data[i] = Data{"a": i, "b": fmt.Sprintf("row %d", i)}
+		}
+		return NewTable(data, Columns{NewColumn("a", "A"), NewColumn("b", "B")}, true)
+	}
+
+	tests := []struct {
+		name   string
+		every  int
+		export func(params FileWriteParams) (*FileWriteResult, error)
+		want   [][2]int
+	}{
+		{
+			name:   "csv",
+			every:  4,
+			export: func(params FileWriteParams) (*FileWriteResult, error) { return ExportCSV(",", table(), params) },
+			want:   [][2]int{{4, 10}, {8, 10}, {10, 10}},
+		},
+		{
+			name:  "xlsx writes, merges and styles",
+			every: 10,
+			export: func(params FileWriteParams) (*FileWriteResult, error) {
+				return ExportXLSX(NewSpreadsheetExcelize("Data", table()), params)
+			},
+			want: [][2]int{{10, 30}, {20, 30}, {30, 30}},
+		},
+		{
+			name:  "sheets share the run",
+			every: 20,
+			export: func(params FileWriteParams) (*FileWriteResult, error) {
+				return ExportXLSXSheets([]Spreadsheet{NewSpreadsheetExcelize("One", table()), NewSpreadsheetExcelize("Two", table())}, params)
+			},
+			want: [][2]int{{20, 30}, {30, 30}, {50, 60}, {60, 60}},
+		},
+		{
+			name: "html",
+			export: func(params FileWriteParams) (*FileWriteResult, error) {
+				return ExportHTML(table(), HTMLOptions{}, params)
+			},
+			want: [][2]int{{30, 30}},
+		},
+		{
+			name:   "markdown",
+			every:  5,
+			export: func(params FileWriteParams) (*FileWriteResult, error) { return ExportMarkdown(table(), params) },
+			want:   [][2]int{{5, 10}, {10, 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]int
+			_, err := tt.export(FileWriteParams{
+				Filename:      "progress",
+				Writer:        &bytes.Buffer{},
+				OnProgress:    func(done, total int) { got = append(got, [2]int{done, total}) },
+				ProgressEvery: tt.every,
+			})
+			if err != nil {
+				t.Fatalf("export failed: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("progress reports = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnProgress_withoutCallback(t *testing.T) {
+	params := FileWriteParams{ProgressEvery: 1}
+	if p := params.resolveProgress(); p != nil {
+		t.Errorf("resolveProgress() = %v, want nil without OnProgress", p)
+	}
+
+	// A nil progress tracks nothing
+	var p *runProgress
+	p.track(NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("a", "A")}, true), progressPhasesFlat)
+	p.advance(1)
+	p.finish()
+}
@@ -0,0 +1,86 @@
+// sheet_conflict.go - Handling of sheets that already hold data.
+//
+// This file defines ConflictPolicy, which decides what happens when a table targets a sheet of an
+// existing workbook (a template, a file reused across runs) that already holds content: fail,
+// overwrite cell by cell, clear the sheet first or append below the existing rows. The policy
+// applies to the first table written into a sheet by an export; the following tables of the same
+// export (e.g. a SheetLayout) are placed as usual.
+
+package spit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConflictPolicy defines how a table is written into a sheet that already holds content.
+type ConflictPolicy int
+
+const (
+	// ConflictOverwrite writes the table over the existing cells, leaving the other cells untouched (default).
+	ConflictOverwrite ConflictPolicy = iota
+
+	// ConflictError fails the export with ErrSheetNotEmpty.
+	ConflictError
+
+	// ConflictClearSheetFirst removes every row holding content before writing the table. Column
+	// widths and sheet settings are kept.
+	ConflictClearSheetFirst
+
+	// ConflictAppendBelow writes the table below the last row holding content; the table start row
+	// then counts from that row (StartRow 2 leaves one empty row in between).
+	ConflictAppendBelow
+)
+
+// ErrSheetNotEmpty is returned by exports targeting a non-empty sheet with ConflictError.
+var ErrSheetNotEmpty = errors.New("sheet already contains data")
+
+// sheetConflictResolver is implemented by spreadsheets applying a ConflictPolicy (see
+// SpreadsheetExcelize.WithConflictPolicy).
+type sheetConflictResolver interface {
+	// resolveSheetConflict applies the policy to the current sheet and returns the number of rows
+	// to skip before writing the table.
+	resolveSheetConflict() (int, error)
+}
+
+// sheetStartPositioner is implemented by spreadsheets placing the table at a start cell of their
+// own (see SpreadsheetExcelize.WithStartCell), resolved once the sheet is created.
+type sheetStartPositioner interface {
+	startPosition() (col, row int, ok bool)
+}
+
+// WithConflictPolicy sets how the table is written when the sheet already holds content.
+func (e *SpreadsheetExcelize) WithConflictPolicy(policy ConflictPolicy) *SpreadsheetExcelize {
+	e.ConflictPolicy = policy
+	return e
+}
+
+// resolveSheetConflict applies the conflict policy to the current sheet.
+func (e *SpreadsheetExcelize) resolveSheetConflict() (int, error) {
+	if e.ConflictPolicy == ConflictOverwrite {
+		return 0, nil
+	}
+	rows, err := e.File.GetRows(e.SheetName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read sheet %q: %w", e.SheetName, err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	switch e.ConflictPolicy {
+	case ConflictError:
+		return 0, fmt.Errorf("sheet %q: %w", e.SheetName, ErrSheetNotEmpty)
+	case ConflictClearSheetFirst:
+		// Bottom-up, so removing a row never shifts the rows left to remove
+		for row := len(rows); row >= 1; row-- {
+			if err := e.File.RemoveRow(e.SheetName, row); err != nil {
+				return 0, fmt.Errorf("failed to clear row %d of sheet %q: %w", row, e.SheetName, err)
+			}
+		}
+		return 0, nil
+	case ConflictAppendBelow:
+		return len(rows), nil
+	}
+	return 0, fmt.Errorf("unknown conflict policy %v", e.ConflictPolicy)
+}
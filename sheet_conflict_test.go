@@ -0,0 +1,119 @@
+package spit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSpreadsheetExcelize_conflictPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  ConflictPolicy
+		wantErr error
+		cells   map[string]string
+	}{
+		{name: "overwrite", policy: ConflictOverwrite, cells: map[string]string{"A1": "Name", "A2": "Alice"}},
+		{name: "error", policy: ConflictError, wantErr: ErrSheetNotEmpty},
+		{name: "clear sheet first", policy: ConflictClearSheetFirst, cells: map[string]string{"A1": "Name", "A2": "Alice", "B1": "", "A4": ""}},
+		{name: "append below", policy: ConflictAppendBelow, cells: map[string]string{"A1": "Quarterly report", "B1": "Q1", "A4": "Old total", "A5": "Name", "A6": "Alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			templatePath := dir + "/template.xlsx"
+			writeTemplate(t, templatePath)
+
+			// A second row of content, longer than the table, shows what is left of the old data
+			f, err := excelize.OpenFile(templatePath)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			_ = f.SetCellValue("Sheet1", "B1", "Q1")
+			_ = f.SetCellValue("Sheet1", "A4", "Old total")
+			if err := f.Save(); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			_ = f.Close()
+
+			table := NewTable(DataSlice{{"name": "Alice"}}, Columns{NewColumn("name", "Name")}, true)
+			s, err := NewSpreadsheetExcelizeFromTemplate(templatePath, "Sheet1", table)
+			if err != nil {
+				t.Fatalf("NewSpreadsheetExcelizeFromTemplate failed: %v", err)
+			}
+			defer func() { _ = s.Close() }()
+			s.WithConflictPolicy(tt.policy)
+
+			res, err := ExportXLSX(s, FileWriteParams{Filename: "report", Filepath: dir})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ExportXLSX error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExportXLSX failed: %v", err)
+			}
+
+			out, err := excelize.OpenFile(res.Filepath)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer func() { _ = out.Close() }()
+			for cell, want := range tt.cells {
+				if got, _ := out.GetCellValue("Sheet1", cell); got != want {
+					t.Errorf("%s = %q, want %q", cell, got, want)
+				}
+			}
+			if tt.policy == ConflictAppendBelow && res.Sheets[0].HeaderRange.String() != "A5:A5" {
+				t.Errorf("header range = %s, want A5:A5", res.Sheets[0].HeaderRange)
+			}
+		})
+	}
+}
+
+func TestExportXLSXSheets_conflictPolicyLayout(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := dir + "/template.xlsx"
+	writeTemplate(t, templatePath)
+
+	f, err := excelize.OpenFile(templatePath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Only the content found before the export conflicts: the second table follows the layout
+	layout := &SheetLayout{Tables: []*Table{
+		NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("a", "A")}, true),
+		NewTable(DataSlice{{"b": 2}}, Columns{NewColumn("b", "B")}, true),
+	}, Direction: LayoutHorizontal, Gap: 1}
+	sheets := NewSpreadsheetsExcelizeLayout("Sheet1", layout)
+	for _, sheet := range sheets {
+		sheet.(*SpreadsheetExcelize).WithFile(f).WithConflictPolicy(ConflictError)
+	}
+	_, err = ExportXLSXSheets(sheets, FileWriteParams{Filename: "report", Filepath: dir})
+	if !errors.Is(err, ErrSheetNotEmpty) {
+		t.Fatalf("ExportXLSXSheets error = %v, want ErrSheetNotEmpty", err)
+	}
+
+	for _, sheet := range sheets {
+		sheet.(*SpreadsheetExcelize).WithConflictPolicy(ConflictClearSheetFirst)
+	}
+	res, err := ExportXLSXSheets(sheets, FileWriteParams{Filename: "report", Filepath: dir, OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+	out, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = out.Close() }()
+	for cell, want := range map[string]string{"A1": "A", "A2": "1", "C1": "B", "C2": "2"} {
+		if got, _ := out.GetCellValue("Sheet1", cell); got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// sheet_layout.go - Multiple tables per sheet.
+//
+// This file defines SheetLayout, which stacks several tables in the same sheet (e.g. a summary
+// block followed by a detail table) and computes each table's start position automatically from
+// the size of the tables placed before it and the configured gap.
+
+package spit
+
+// LayoutDirection defines how the tables of a SheetLayout are stacked.
+type LayoutDirection int
+
+const (
+	// LayoutVertical places each table below the previous one (default).
+	LayoutVertical LayoutDirection = iota
+
+	// LayoutHorizontal places each table to the right of the previous one.
+	LayoutHorizontal
+)
+
+// SheetLayout arranges an ordered list of tables within a single sheet.
+type SheetLayout struct {
+	Tables    []*Table        // Tables in placement order
+	Direction LayoutDirection // Stacking direction (default: LayoutVertical)
+	Gap       int             // Number of empty rows (vertical) or columns (horizontal) between tables
+	StartRow  int             // 1-based sheet row of the first table (0 = 1)
+	StartCol  int             // 1-based sheet column of the first table (0 = 1)
+}
+
+// NewSheetLayout creates a new vertical SheetLayout for the given tables.
+func NewSheetLayout(tables ...*Table) *SheetLayout {
+	return &SheetLayout{Tables: tables}
+}
+
+// WithDirection sets the stacking direction of the layout.
+func (l *SheetLayout) WithDirection(direction LayoutDirection) *SheetLayout {
+	l.Direction = direction
+	return l
+}
+
+// WithGap sets the number of empty rows (vertical) or columns (horizontal) between tables.
+func (l *SheetLayout) WithGap(gap int) *SheetLayout {
+	l.Gap = gap
+	return l
+}
+
+// WithStartPosition sets the 1-based sheet column and row of the first table.
+func (l *SheetLayout) WithStartPosition(col, row int) *SheetLayout {
+	l.StartCol = col
+	l.StartRow = row
+	return l
+}
+
+// Arrange computes and sets the start position of every table (see Table.WithStartPosition)
+// and returns the tables in placement order. Nil tables are skipped.
+// Sizes are measured on the prepared tables (see Table.Prepare), so preview watermarks and
+// truncation are accounted for.
+func (l *SheetLayout) Arrange() []*Table {
+	col, row := max(l.StartCol, 1), max(l.StartRow, 1)
+	gap := max(l.Gap, 0)
+
+	arranged := make([]*Table, 0, len(l.Tables))
+	for _, t := range l.Tables {
+		if t == nil {
+			continue
+		}
+		t.WithStartPosition(col, row)
+		arranged = append(arranged, t)
+
+		cols, rows := t.Prepare().sheetSize()
+		if l.Direction == LayoutHorizontal {
+			col += cols + gap
+		} else {
+			row += rows + gap
+		}
+	}
+	return arranged
+}
+
+// GetRowCount returns the number of sheet rows the table occupies: preamble, headers, data, footer
+// and truncation notice.
+func (t *Table) GetRowCount() int {
+	if t.hasTruncationNotice() {
+		return t.GetTruncationNoticeRow()
+	}
+	if t.hasFooter() {
+		return t.GetFooterRow()
+	}
+	return t.GetDataStartRow() - 1 + len(t.Data)
+}
+
+// GetColumnCount returns the number of sheet columns the table occupies: the leaf columns, or the
+// widest preamble row when it is wider.
+func (t *Table) GetColumnCount() int {
+	count := t.Columns.GetTotalColumnCount()
+	for _, row := range t.Preamble {
+		count = max(count, len(row.Values))
+	}
+	return count
+}
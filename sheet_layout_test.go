@@ -0,0 +1,103 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func layoutTestTables() (*Table, *Table) {
+	summary := NewTable(
+		DataSlice{{"metric": "Total", "value": 60}},
+		Columns{NewColumn("metric", "Metric"), NewColumn("value", "Value")},
+		true,
+	).WithPreamble(PreambleRows{NewPreambleRow("Summary")})
+	detail := NewTable(
+		DataSlice{{"region": "EU", "sales": 10}, {"region": "EU", "sales": 20}, {"region": "US", "sales": 30}},
+		Columns{
+			NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+			NewColumn("sales", "Sales"),
+			NewColumn("note", "Note"),
+		},
+		true,
+	)
+	return summary, detail
+}
+
+func TestSheetLayout_Arrange(t *testing.T) {
+	tests := []struct {
+		name     string
+		layout   func(summary, detail *Table) *SheetLayout
+		expected [][2]int // {col, row} per table
+	}{
+		{
+			name:     "Vertical with gap",
+			layout:   func(s, d *Table) *SheetLayout { return NewSheetLayout(s, d).WithGap(2) },
+			expected: [][2]int{{1, 1}, {1, 6}},
+		},
+		{
+			name: "Horizontal from start position",
+			layout: func(s, d *Table) *SheetLayout {
+				return NewSheetLayout(s, nil, d).WithDirection(LayoutHorizontal).WithGap(1).WithStartPosition(2, 3)
+			},
+			expected: [][2]int{{2, 3}, {5, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, detail := layoutTestTables()
+			tables := tt.layout(summary, detail).Arrange()
+			if len(tables) != len(tt.expected) {
+				t.Fatalf("expected %d tables, got %d", len(tt.expected), len(tables))
+			}
+			for i, table := range tables {
+				if got := [2]int{table.GetStartCol(), table.GetStartRow()}; got != tt.expected[i] {
+					t.Errorf("table %d start = %v, want %v", i, got, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTable_GetRowColumnCount(t *testing.T) {
+	summary, detail := layoutTestTables()
+	if summary.GetRowCount() != 3 || summary.GetColumnCount() != 2 {
+		t.Errorf("summary size = %dx%d, want 3x2", summary.GetRowCount(), summary.GetColumnCount())
+	}
+	if detail.GetRowCount() != 4 || detail.GetColumnCount() != 3 {
+		t.Errorf("detail size = %dx%d, want 4x3", detail.GetRowCount(), detail.GetColumnCount())
+	}
+}
+
+func TestExportXLSX_sheetLayout(t *testing.T) {
+	summary, detail := layoutTestTables()
+	sheets := NewSpreadsheetsExcelizeLayout("Report", NewSheetLayout(summary, detail).WithGap(1))
+
+	res, err := ExportXLSXSheets(sheets, FileWriteParams{Filename: "layout", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if got := f.GetSheetList(); len(got) != 1 || got[0] != "Report" {
+		t.Fatalf("expected a single Report sheet, got %v", got)
+	}
+	for cell, want := range map[string]string{"A1": "Summary", "A2": "Metric", "B3": "60", "A4": "", "A5": "Region", "A6": "EU", "B8": "30"} {
+		if got, _ := f.GetCellValue("Report", cell); got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+	merges, err := f.GetMergeCells("Report")
+	if err != nil {
+		t.Fatalf("GetMergeCells failed: %v", err)
+	}
+	if len(merges) != 1 || merges[0].GetStartAxis() != "A6" || merges[0].GetEndAxis() != "A7" {
+		t.Errorf("expected a single A6:A7 merge, got %v", merges)
+	}
+}
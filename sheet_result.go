@@ -0,0 +1,140 @@
+// sheet_result.go - Per-sheet export results.
+//
+// This file describes where each table landed in an exported workbook (sheet name, index, header,
+// data and footer ranges) so code post-processing the file (charts, named ranges, conditional
+// formatting) can reference the written cells without recomputing the layout.
+
+package spit
+
+import (
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellRange is a rectangular range of 1-based sheet coordinates (bounds included).
+type CellRange struct {
+	StartCol int
+	StartRow int
+	EndCol   int
+	EndRow   int
+}
+
+// IsEmpty reports whether the range covers no cell (e.g. a table without data rows).
+func (r CellRange) IsEmpty() bool {
+	return r.StartCol < 1 || r.StartRow < 1 || r.EndCol < r.StartCol || r.EndRow < r.StartRow
+}
+
+// String returns the range in A1 notation (e.g. "A1:D20"), or an empty string for an empty range.
+func (r CellRange) String() string {
+	if r.IsEmpty() {
+		return ""
+	}
+	start, _ := excelize.CoordinatesToCellName(r.StartCol, r.StartRow)
+	end, _ := excelize.CoordinatesToCellName(r.EndCol, r.EndRow)
+	return start + ":" + end
+}
+
+// SheetResult describes a table written to a sheet. Ranges are absolute sheet coordinates
+// (start position included) and empty when the corresponding part was not written.
+type SheetResult struct {
+	Name        string    // Final sheet name (after preview titling)
+	Index       int       // 0-based sheet index in the workbook
+	TableRange  CellRange // Whole table: preamble, headers, data and footer
+	HeaderRange CellRange // Header rows
+	DataRange   CellRange // Data rows
+	FooterRange CellRange // Footer row
+	Streamed    bool      // Whether the sheet was written through a stream writer (see FileWriteParams.Streaming)
+}
+
+// RangeRef returns r as a sheet-qualified reference (e.g. "Sheet1!A1:D20"), quoting the sheet
+// name when needed (e.g. "'Q1 Sales'!A1:D20"). Returns an empty string for an empty range.
+func (s SheetResult) RangeRef(r CellRange) string {
+	if r.IsEmpty() {
+		return ""
+	}
+	return quoteSheetName(s.Name) + "!" + r.String()
+}
+
+// DataRef returns the sheet-qualified reference of the data rows.
+func (s SheetResult) DataRef() string {
+	return s.RangeRef(s.DataRange)
+}
+
+// HeaderRef returns the sheet-qualified reference of the header rows.
+func (s SheetResult) HeaderRef() string {
+	return s.RangeRef(s.HeaderRange)
+}
+
+// Sheet returns the first written table of the named sheet.
+func (r *FileWriteResult) Sheet(name string) (SheetResult, bool) {
+	for _, s := range r.Sheets {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return SheetResult{}, false
+}
+
+// quoteSheetName quotes a sheet name for use in a reference when it contains anything other
+// than letters, digits, underscores and dots, starts with a digit or reads as a cell reference.
+func quoteSheetName(name string) string {
+	plain := name != "" && strings.IndexFunc(name, func(r rune) bool {
+		return !(r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}) == -1
+	if plain && !(name[0] >= '0' && name[0] <= '9') && !isCellReference(name) {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// sheetIndexer is implemented by spreadsheets able to report their sheet index.
+type sheetIndexer interface {
+	GetSheetIndex() (int, error)
+}
+
+// rowRange returns the sheet range covering the columns of a table-relative row (a sheet column
+// for transposed tables).
+func (xlsx *xlsx) rowRange(row int) CellRange {
+	t := xlsx.table
+	return t.sheetRange(CellRange{StartCol: 1, StartRow: row, EndCol: t.Columns.GetTotalColumnCount(), EndRow: row})
+}
+
+// sheetResult describes the table written by writeData to sheetName from its table-relative
+// header and data rows (headerRows is 0 when no header was written).
+func (xlsx *xlsx) sheetResult(sheetName string, headerRow, headerRows, dataRow int) SheetResult {
+	t := xlsx.table
+	span := func(firstRow, lastRow, cols int) CellRange {
+		return t.sheetRange(CellRange{StartCol: 1, StartRow: firstRow, EndCol: cols, EndRow: lastRow})
+	}
+	columns := t.Columns.GetTotalColumnCount()
+
+	result := SheetResult{Name: sheetName, Index: -1}
+	_, result.Streamed = xlsx.spreadsheet.(*streamSheet)
+	if indexer, ok := xlsx.spreadsheet.(sheetIndexer); ok {
+		if index, err := indexer.GetSheetIndex(); err == nil {
+			result.Index = index
+		} else {
+			t.warn(WarningPhaseSheet, "", "Failed to resolve sheet index", err, String("sheet", result.Name))
+		}
+	}
+
+	lastRow := dataRow + len(t.Data) - 1
+	if headerRows > 0 {
+		result.HeaderRange = span(headerRow, headerRow+headerRows-1, columns)
+	}
+	if len(t.Data) > 0 {
+		result.DataRange = span(dataRow, lastRow, columns)
+	}
+	if t.hasFooter() {
+		lastRow = t.GetFooterRow()
+		result.FooterRange = span(lastRow, lastRow, columns)
+	}
+	if t.hasTruncationNotice() {
+		lastRow = t.GetTruncationNoticeRow()
+	}
+	if lastRow >= 1 {
+		result.TableRange = span(1, lastRow, t.GetColumnCount())
+	}
+	return result
+}
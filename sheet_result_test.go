@@ -0,0 +1,111 @@
+package spit
+
+import "testing"
+
+func TestCellRange_String(t *testing.T) {
+	tests := []struct {
+		name string
+		r    CellRange
+		want string
+	}{
+		{"simple", CellRange{1, 1, 4, 20}, "A1:D20"},
+		{"single cell", CellRange{3, 2, 3, 2}, "C2:C2"},
+		{"empty", CellRange{}, ""},
+		{"inverted", CellRange{2, 5, 2, 4}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSheetResult_RangeRef(t *testing.T) {
+	r := CellRange{1, 1, 4, 20}
+	for name, want := range map[string]string{
+		"Sheet1":   "Sheet1!A1:D20",
+		"Q1 Sales": "'Q1 Sales'!A1:D20",
+		"Bob's":    "'Bob''s'!A1:D20",
+		"2024":     "'2024'!A1:D20",
+		"1Q":       "'1Q'!A1:D20",
+		"AB12":     "'AB12'!A1:D20",
+		"q1":       "'q1'!A1:D20",
+		"R1C1":     "'R1C1'!A1:D20",
+		"Q1_Sales": "Q1_Sales!A1:D20",
+	} {
+		if got := (SheetResult{Name: name}).RangeRef(r); got != want {
+			t.Errorf("RangeRef() for %q = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestExportXLSXSheets_sheetResults(t *testing.T) {
+	data := DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}, {"a": 5, "b": 6}}
+	columns := Columns{NewColumn("a", "A").WithAggregate(AggregateSum), NewColumn("b", "B")}
+	first := NewTable(data, columns, true).
+		WithPreamble(PreambleRows{NewPreambleRow("Report")}).
+		WithStartPosition(2, 3).
+		WithFooter(nil)
+	second := NewTable(data[:1], columns, true)
+
+	res, err := ExportXLSXSheets([]Spreadsheet{
+		NewSpreadsheetExcelize("Report Q1", first),
+		NewSpreadsheetExcelize("Raw", second),
+	}, FileWriteParams{Filename: "results", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+	if len(res.Sheets) != 2 {
+		t.Fatalf("expected 2 sheet results, got %d", len(res.Sheets))
+	}
+
+	report, ok := res.Sheet("Report Q1")
+	if !ok {
+		t.Fatal("Sheet(\"Report Q1\") not found")
+	}
+	for got, want := range map[string]string{
+		report.RangeRef(report.TableRange):  "'Report Q1'!B3:C8",
+		report.HeaderRef():                  "'Report Q1'!B4:C4",
+		report.DataRef():                    "'Report Q1'!B5:C7",
+		report.RangeRef(report.FooterRange): "'Report Q1'!B8:C8",
+	} {
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+
+	raw := res.Sheets[1]
+	if raw.Name != "Raw" || raw.Index <= report.Index || raw.DataRef() != "Raw!A2:B2" || !raw.FooterRange.IsEmpty() {
+		t.Errorf("unexpected result for second sheet: %+v", raw)
+	}
+	if _, ok := res.Sheet("missing"); ok {
+		t.Error("Sheet(\"missing\") should not be found")
+	}
+}
+
+func TestExportXLSX_afterRowWrite(t *testing.T) {
+	refs := make(map[int]string)
+	table := fixtureTable("region", "country", "sales").
+		WithGroupBy("region").
+		WithStartPosition(2, 1).
+		WithAfterRowWrite(func(rowIndex int, ref CellRange) {
+			refs[rowIndex] = ref.String()
+		})
+
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "rows", Filepath: t.TempDir()}); err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+
+	// Header on row 1, EU group (header row 2, rows 1, 3, 4) then US group (header row 6, rows 0, 2)
+	want := map[int]string{1: "B3:D3", 3: "B4:D4", 4: "B5:D5", 0: "B7:D7", 2: "B8:D8"}
+	if len(refs) != len(want) {
+		t.Fatalf("refs = %v, want %v", refs, want)
+	}
+	for rowIndex, ref := range want {
+		if refs[rowIndex] != ref {
+			t.Errorf("row %d landed in %q, want %q", rowIndex, refs[rowIndex], ref)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// sink.go - Chunked writing to slow sinks.
+//
+// This file implements ChunkedWriter, a bounded buffer placed in front of slow destinations
+// (network connections, object storage uploads): data is forwarded in chunks of a fixed size, or
+// sooner once the flush interval has elapsed, so large exports neither issue many tiny writes nor
+// hold unbounded data in memory. An optional hook runs after every flush.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultChunkSize is the chunk size used when ChunkOptions.ChunkSize is unset (64 KiB).
+const DefaultChunkSize = 64 * 1024
+
+// ChunkOptions configures chunked writing to a sink.
+type ChunkOptions struct {
+	ChunkSize     int                                  // Bytes buffered before a chunk is written (0 = DefaultChunkSize)
+	FlushInterval time.Duration                        // Maximum age of buffered data, checked on each write (0 = flush on full chunks only)
+	OnFlush       func(flushed int, total int64) error // Optional hook run after each flush; an error aborts the export
+}
+
+// NewChunkOptions creates a new ChunkOptions instance with the given chunk size.
+func NewChunkOptions(chunkSize int) *ChunkOptions {
+	return &ChunkOptions{ChunkSize: chunkSize}
+}
+
+// WithFlushInterval sets the maximum time buffered data waits before being flushed.
+func (c *ChunkOptions) WithFlushInterval(interval time.Duration) *ChunkOptions {
+	c.FlushInterval = interval
+	return c
+}
+
+// WithOnFlush sets the hook run after each flush with the number of bytes flushed and the total
+// written so far (e.g. to report progress or to sync a remote upload).
+func (c *ChunkOptions) WithOnFlush(hook func(flushed int, total int64) error) *ChunkOptions {
+	c.OnFlush = hook
+	return c
+}
+
+// ChunkedWriter buffers writes to an underlying writer and forwards them in chunks.
+// Memory use is bounded by the chunk size. It is not safe for concurrent use.
+type ChunkedWriter struct {
+	writer    io.Writer
+	options   ChunkOptions
+	buffer    []byte
+	total     int64
+	lastFlush time.Time
+}
+
+// NewChunkedWriter creates a ChunkedWriter forwarding to w. Call Flush once done writing.
+func NewChunkedWriter(w io.Writer, options ChunkOptions) *ChunkedWriter {
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = DefaultChunkSize
+	}
+	return &ChunkedWriter{
+		writer:    w,
+		options:   options,
+		buffer:    make([]byte, 0, options.ChunkSize),
+		lastFlush: time.Now(),
+	}
+}
+
+// Write buffers p, writing every full chunk to the underlying writer. Buffered data is also
+// flushed when the flush interval has elapsed since the previous flush.
+func (c *ChunkedWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), c.options.ChunkSize-len(c.buffer))
+		c.buffer = append(c.buffer, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(c.buffer) == c.options.ChunkSize {
+			if err := c.Flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	if c.options.FlushInterval > 0 && len(c.buffer) > 0 && time.Since(c.lastFlush) >= c.options.FlushInterval {
+		if err := c.Flush(); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Flush writes the buffered data to the underlying writer, flushes it too when it supports
+// flushing (e.g. *bufio.Writer), then runs the OnFlush hook.
+func (c *ChunkedWriter) Flush() error {
+	c.lastFlush = time.Now()
+	flushed := len(c.buffer)
+	if flushed > 0 {
+		if _, err := c.writer.Write(c.buffer); err != nil {
+			return fmt.Errorf("failed to write chunk: %w", err)
+		}
+		c.total += int64(flushed)
+		c.buffer = c.buffer[:0]
+	}
+	if flusher, ok := c.writer.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return fmt.Errorf("failed to flush sink: %w", err)
+		}
+	}
+	if c.options.OnFlush != nil && flushed > 0 {
+		if err := c.options.OnFlush(flushed, c.total); err != nil {
+			return fmt.Errorf("flush hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Written returns the number of bytes written to the underlying writer so far.
+func (c *ChunkedWriter) Written() int64 {
+	return c.total
+}
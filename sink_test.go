@@ -0,0 +1,103 @@
+package spit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingWriter records the size of every write it receives.
+type recordingWriter struct {
+	bytes.Buffer
+	writes []int
+}
+
+func (r *recordingWriter) Write(p []byte) (int, error) {
+	r.writes = append(r.writes, len(p))
+	return r.Buffer.Write(p)
+}
+
+func TestChunkedWriter(t *testing.T) {
+	sink := &recordingWriter{}
+	var flushes []int64
+	w := NewChunkedWriter(sink, *NewChunkOptions(4).WithOnFlush(func(flushed int, total int64) error {
+		flushes = append(flushes, total)
+		return nil
+	}))
+
+	if _, err := w.Write([]byte("abcdefghij")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if sink.String() != "abcdefgh" {
+		t.Errorf("sink = %q before Flush, want the full chunks only", sink.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if sink.String() != "abcdefghij" || w.Written() != 10 {
+		t.Errorf("sink = %q (%d bytes), want all data", sink.String(), w.Written())
+	}
+	if want := []int{4, 4, 2}; len(sink.writes) != len(want) || sink.writes[0] != 4 || sink.writes[2] != 2 {
+		t.Errorf("writes = %v, want %v", sink.writes, want)
+	}
+	if len(flushes) != 3 || flushes[2] != 10 {
+		t.Errorf("flush hook totals = %v, want [4 8 10]", flushes)
+	}
+}
+
+func TestChunkedWriter_flushInterval(t *testing.T) {
+	sink := &recordingWriter{}
+	w := NewChunkedWriter(sink, ChunkOptions{ChunkSize: 1024, FlushInterval: time.Nanosecond})
+	time.Sleep(time.Millisecond)
+	if _, err := w.Write([]byte("abc")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if sink.String() != "abc" {
+		t.Errorf("sink = %q, want data flushed once the interval elapsed", sink.String())
+	}
+}
+
+func TestChunkedWriter_hookError(t *testing.T) {
+	w := NewChunkedWriter(io.Discard, ChunkOptions{ChunkSize: 2, OnFlush: func(int, int64) error {
+		return errors.New("upload failed")
+	}})
+	if _, err := w.Write([]byte("abc")); err == nil || !strings.Contains(err.Error(), "upload failed") {
+		t.Errorf("Write error = %v, want the hook error", err)
+	}
+}
+
+func TestExportCSV_sink(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1}, {"a": 2}}, Columns{NewColumn("a", "A")}, true)
+	sink := &recordingWriter{}
+	res, err := ExportCSV(",", table, FileWriteParams{
+		Filename: "report",
+		Writer:   sink,
+		UseGzip:  true,
+		Chunking: NewChunkOptions(8),
+	})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if res.Filepath != "" || res.Filename != "report.csv.gz" {
+		t.Errorf("result = %+v, want no file path and the sink filename", res)
+	}
+	for _, n := range sink.writes {
+		if n > 8 {
+			t.Errorf("sink received a %d-byte write, want chunks of at most 8 bytes", n)
+		}
+	}
+
+	reader, err := gzip.NewReader(&sink.Buffer)
+	if err != nil {
+		t.Fatalf("gzip.NewReader failed: %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	if string(content) != "A\n1\n2\n" {
+		t.Errorf("content = %q, want the CSV export", content)
+	}
+}
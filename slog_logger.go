@@ -0,0 +1,50 @@
+package spit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger implements Logger on top of a log/slog logger, so go-spit messages follow the
+// handler, level and attributes of the application logger. Fields become slog attributes.
+type SlogLogger struct {
+	Logger *slog.Logger // Target logger (nil = slog.Default())
+}
+
+// NewSlogLogger returns a Logger writing to the given slog logger (nil = slog.Default()).
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{Logger: logger}
+}
+
+// Debug logs a debug-level message with optional fields.
+func (l *SlogLogger) Debug(msg string, fields ...Field) {
+	l.log(slog.LevelDebug, msg, fields)
+}
+
+// Info logs an info-level message with optional fields.
+func (l *SlogLogger) Info(msg string, fields ...Field) {
+	l.log(slog.LevelInfo, msg, fields)
+}
+
+// Warn logs a warning-level message with optional fields.
+func (l *SlogLogger) Warn(msg string, fields ...Field) {
+	l.log(slog.LevelWarn, msg, fields)
+}
+
+// Error logs an error-level message with optional fields.
+func (l *SlogLogger) Error(msg string, fields ...Field) {
+	l.log(slog.LevelError, msg, fields)
+}
+
+// log converts the fields to attributes and logs the message at the given level.
+func (l *SlogLogger) log(level slog.Level, msg string, fields []Field) {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = slog.Any(field.Key, field.Value)
+	}
+	logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
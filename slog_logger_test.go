@@ -0,0 +1,26 @@
+package spit
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	logger.Debug("hidden")
+	logger.Warn("Failed to merge cells vertically", String("cell", "B3"), Int("row", 3))
+
+	output := buf.String()
+	if strings.Contains(output, "hidden") {
+		t.Errorf("debug message logged below the handler level: %s", output)
+	}
+	for _, want := range []string{"level=WARN", `msg="Failed to merge cells vertically"`, "cell=B3", "row=3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output %q is missing %q", output, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// style_contrast.go - Contrast-aware text colors.
+//
+// This file implements automatic text color selection: when a style sets a BackgroundColor but
+// no TextColor, black or white text is chosen from the background luminance so cells stay readable.
+// The behavior is enabled by default and can be disabled per table (see Table.WithAutoContrast).
+
+package spit
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContrastTextDark is the text color used on light backgrounds.
+	ContrastTextDark = "#000000"
+
+	// ContrastTextLight is the text color used on dark backgrounds.
+	ContrastTextLight = "#FFFFFF"
+)
+
+// ContrastTextColor returns a readable text color (ContrastTextDark or ContrastTextLight) for the
+// given background color, based on its WCAG relative luminance.
+// Accepts "#RRGGBB", "RRGGBB", "#RGB" and "RGB"; returns an empty string for any other value.
+func ContrastTextColor(background string) string {
+	r, g, b, ok := parseHexColor(background)
+	if !ok {
+		return ""
+	}
+	luminance := 0.2126*linearize(r) + 0.7152*linearize(g) + 0.0722*linearize(b)
+
+	// Pick the color with the higher contrast ratio: (L1 + 0.05) / (L2 + 0.05)
+	if (luminance+0.05)/0.05 >= 1.05/(luminance+0.05) {
+		return ContrastTextDark
+	}
+	return ContrastTextLight
+}
+
+// contrastStyle returns style with a contrast-aware TextColor when it sets a BackgroundColor
+// without a TextColor and auto contrast is enabled for the table.
+func (t *Table) contrastStyle(style Style) Style {
+	if t.DisableAutoContrast || style.TextColor != "" || style.BackgroundColor == "" {
+		return style
+	}
+	style.TextColor = ContrastTextColor(style.BackgroundColor)
+	return style
+}
+
+// parseHexColor parses a hex color into its 0-255 red, green and blue components.
+func parseHexColor(color string) (r, g, b uint8, ok bool) {
+	hex := strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
+
+// linearize converts an sRGB channel (0-255) to its linear value for luminance computation.
+func linearize(channel uint8) float64 {
+	c := float64(channel) / 255
+	if c <= 0.03928 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
@@ -0,0 +1,46 @@
+package spit
+
+import "testing"
+
+func TestContrastTextColor(t *testing.T) {
+	tests := []struct {
+		background string
+		expected   string
+	}{
+		{background: "#FFFFFF", expected: ContrastTextDark},
+		{background: "#000000", expected: ContrastTextLight},
+		{background: "#FFFF00", expected: ContrastTextDark},
+		{background: "#00008B", expected: ContrastTextLight},
+		{background: "C00000", expected: ContrastTextLight},
+		{background: "#eee", expected: ContrastTextDark},
+		{background: "red", expected: ""},
+		{background: "#12345", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.background, func(t *testing.T) {
+			if got := ContrastTextColor(tt.background); got != tt.expected {
+				t.Errorf("ContrastTextColor(%q) = %q, want %q", tt.background, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTable_contrastStyle(t *testing.T) {
+	table := &Table{}
+
+	if got := table.contrastStyle(Style{BackgroundColor: "#1F1F1F"}); got.TextColor != ContrastTextLight {
+		t.Errorf("expected light text on dark background, got %q", got.TextColor)
+	}
+	if got := table.contrastStyle(Style{BackgroundColor: "#1F1F1F", TextColor: "#FF0000"}); got.TextColor != "#FF0000" {
+		t.Errorf("explicit text color must be kept, got %q", got.TextColor)
+	}
+	if got := table.contrastStyle(Style{Bold: true}); got.TextColor != "" {
+		t.Errorf("styles without background must be left untouched, got %q", got.TextColor)
+	}
+
+	table.WithAutoContrast(false)
+	if got := table.contrastStyle(Style{BackgroundColor: "#1F1F1F"}); got.TextColor != "" {
+		t.Errorf("auto contrast disabled, got %q", got.TextColor)
+	}
+}
@@ -0,0 +1,220 @@
+// style_explain.go - Style resolution diagnostics.
+//
+// This file explains how the style of a cell is resolved: ExplainCellStyle lists every style
+// source RenderStyles considers for a cell, in precedence order, along with the winning style.
+// Intended for debugging why a cell looks the way it does without reading the styling code.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StyleSourceKind identifies where a style considered for a cell comes from.
+type StyleSourceKind string
+
+const (
+	StyleSourcePreamble StyleSourceKind = "preamble" // PreambleRow.Style
+	StyleSourceHeader   StyleSourceKind = "header"   // HeaderOptions.Style or the default header style
+	StyleSourceBand     StyleSourceKind = "band"     // ZebraOptions.Even or ZebraOptions.Odd (see Table.WithZebra)
+	StyleSourceColumn   StyleSourceKind = "column"   // Column.Style
+	StyleSourceRow      StyleSourceKind = "row"      // RowOptions.Style (including group header and subtotal rows) or Table.RowStyler
+	StyleSourceRule     StyleSourceKind = "rule"     // Column.StyleRules (the first rule matching the row)
+	StyleSourceCell     StyleSourceKind = "cell"     // CellOptions.Style
+	StyleSourceFooter   StyleSourceKind = "footer"   // FooterOptions.Style or the default footer style
+	StyleSourceNotice   StyleSourceKind = "notice"   // TruncationNoticeOptions.Style or the default notice style
+	StyleSourceContrast StyleSourceKind = "contrast" // Automatic text color (see Table.WithAutoContrast)
+	StyleSourceTheme    StyleSourceKind = "theme"    // Theme header, data, band or footer style filling one the table leaves unset (see Table.WithTheme)
+)
+
+// StyleSource is a style source considered when resolving the style of a cell.
+type StyleSource struct {
+	Kind    StyleSourceKind // Where the style comes from
+	Style   *Style          // The style set by the source (nil when the source sets none)
+	Applied bool            // Whether this source determined the resolved style
+	Note    string          // Human-readable detail (e.g. the column name or data row index)
+}
+
+// StyleExplanation describes how the style of a cell is resolved.
+type StyleExplanation struct {
+	Col      int           // 1-based, table-relative column
+	Row      int           // 1-based, table-relative row
+	Region   string        // "preamble", "header", "data", "footer" or "outside"
+	Sources  []StyleSource // Sources considered, lowest precedence first
+	Resolved *Style        // The style applied to the cell (nil when the cell is left unstyled)
+}
+
+// String returns a multi-line, human-readable explanation.
+func (e StyleExplanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "cell (%d,%d) in %s\n", e.Col, e.Row, e.Region)
+	for _, source := range e.Sources {
+		marker := " "
+		if source.Applied {
+			marker = "*"
+		}
+		style := "none"
+		if source.Style != nil {
+			style = fmt.Sprintf("%+v", *source.Style)
+		}
+		fmt.Fprintf(&b, "%s %-8s %s", marker, source.Kind, style)
+		if source.Note != "" {
+			fmt.Fprintf(&b, " (%s)", source.Note)
+		}
+		b.WriteString("\n")
+	}
+	if e.Resolved == nil {
+		b.WriteString("=> unstyled\n")
+	} else {
+		fmt.Fprintf(&b, "=> %+v\n", *e.Resolved)
+	}
+	return b.String()
+}
+
+// ExplainCellStyle returns the style sources RenderStyles considers for a cell of the prepared
+// table and the style it resolves to. Coordinates are 1-based and table-relative (row 1 is the
+// first preamble or header row, see Table.WithStartPosition). Data cells follow the precedence
+// cell > style rule > row > column > band; a source is listed even when it sets no style.
+func ExplainCellStyle(t *Table, col, row int) StyleExplanation {
+	t = t.Prepare()
+	e := StyleExplanation{Col: col, Row: row, Region: "outside"}
+	totalColumns := t.Columns.GetTotalColumnCount()
+	dataStartRow := t.GetDataStartRow()
+	headerStartRow := t.GetHeaderStartRow()
+	defaulted := false
+
+	switch {
+	case col < 1 || row < 1:
+	case row <= len(t.Preamble):
+		e.Region = "preamble"
+		preamble := t.Preamble[row-1]
+		source := StyleSource{Kind: StyleSourcePreamble, Style: preamble.Style, Note: fmt.Sprintf("preamble row %d", row)}
+		// Preamble styles only cover the cells holding a value
+		if preamble.Style != nil && col <= len(preamble.Values) {
+			source.Applied = true
+			e.Resolved = preamble.Style
+		}
+		e.Sources = append(e.Sources, source)
+	case col > totalColumns:
+	case t.WriteHeader && len(t.Columns) > 0 && row >= headerStartRow && row < dataStartRow:
+		e.Region = "header"
+		style, note := &Style{Bold: true, BackgroundColor: "#E0E0E0", Alignment: AlignmentCenterMiddle}, "default"
+		defaulted = true
+		if t.HeaderOptions != nil && t.HeaderOptions.Style != nil {
+			style, note, defaulted = t.HeaderOptions.Style, "HeaderOptions", false
+		}
+		e.Sources = append(e.Sources, t.themedSource(StyleSource{Kind: StyleSourceHeader, Style: style, Applied: true, Note: note}))
+		e.Resolved = style
+	case row >= dataStartRow && row < dataStartRow+len(t.Data):
+		e.Region = "data"
+		e.Sources, e.Resolved = t.explainDataCell(col, row-dataStartRow)
+	case t.hasFooter() && row == t.GetFooterRow():
+		e.Region = "footer"
+		style, note := &Style{Bold: true}, "default"
+		defaulted = true
+		if t.FooterOptions != nil && t.FooterOptions.Style != nil {
+			style, note, defaulted = t.FooterOptions.Style, "FooterOptions", false
+		}
+		e.Sources = append(e.Sources, t.themedSource(StyleSource{Kind: StyleSourceFooter, Style: style, Applied: true, Note: note}))
+		e.Resolved = style
+	case t.hasTruncationNotice() && row == t.GetTruncationNoticeRow():
+		e.Region = "notice"
+		if col > 1 {
+			break
+		}
+		style, note := &Style{Italic: true, TextColor: "#808080"}, "default"
+		defaulted = true
+		if t.TruncationNotice.Style != nil {
+			style, note, defaulted = t.TruncationNotice.Style, "TruncationNoticeOptions", false
+		}
+		e.Sources = append(e.Sources, StyleSource{Kind: StyleSourceNotice, Style: style, Applied: true, Note: note})
+		e.Resolved = style
+	}
+
+	// User-provided styles get a contrast-aware text color; built-in defaults are used as-is
+	if e.Resolved != nil && !defaulted {
+		resolved := t.contrastStyle(*e.Resolved)
+		if resolved.TextColor != e.Resolved.TextColor {
+			e.Sources = append(e.Sources, StyleSource{
+				Kind:    StyleSourceContrast,
+				Style:   &Style{TextColor: resolved.TextColor},
+				Applied: true,
+				Note:    fmt.Sprintf("text color for background %s", resolved.BackgroundColor),
+			})
+		}
+		e.Resolved = &resolved
+	}
+	return e
+}
+
+// explainDataCell lists the column, row and cell sources of a data cell, lowest precedence first,
+// following applyCellStyles.
+func (t *Table) explainDataCell(col, dataRow int) ([]StyleSource, *Style) {
+	column := t.Columns.GetFlattenedColumns()[col-1]
+	var sources []StyleSource
+	var band *Style
+	if t.Zebra != nil {
+		// Merge ranges only depend on processed values, rendered the way the HTML export does
+		index := t.zebraBands(&htmlExport{table: t})[dataRow]
+		band = t.Zebra.bandStyle(index)
+		sources = append(sources, t.themedSource(StyleSource{Kind: StyleSourceBand, Style: band, Note: fmt.Sprintf("band %d", index)}))
+	}
+	sources = append(sources, t.themedSource(StyleSource{Kind: StyleSourceColumn, Style: column.Style, Note: fmt.Sprintf("column %q", column.Name)}))
+
+	rowSource := StyleSource{Kind: StyleSourceRow, Note: fmt.Sprintf("data row %d", dataRow)}
+	if rowOptions, ok := t.RowOptionsMap[dataRow]; ok && rowOptions.Style != nil {
+		rowSource.Style = rowOptions.Style
+	} else if style := t.rowStyle(dataRow); style != nil {
+		rowSource.Style, rowSource.Note = style, fmt.Sprintf("data row %d (row styler)", dataRow)
+	}
+	sources = append(sources, rowSource)
+
+	if len(column.StyleRules) > 0 && t.SourceRowIndex(dataRow) >= 0 {
+		ruleSource := StyleSource{Kind: StyleSourceRule, Note: "no matching rule"}
+		rule, err := matchStyleRule(column, t.Data[dataRow])
+		switch {
+		case err != nil:
+			ruleSource.Note = err.Error()
+		case rule != nil:
+			ruleSource.Style, ruleSource.Note = rule.Style, fmt.Sprintf("rule %q", rule.When)
+		}
+		sources = append(sources, ruleSource)
+	}
+
+	cellSource := StyleSource{Kind: StyleSourceCell, Note: fmt.Sprintf("column %d, data row %d", col, dataRow)}
+	if cellOptions, ok := t.CellOptionsMap[col][dataRow]; ok {
+		cellSource.Style = cellOptions.Style
+	}
+	sources = append(sources, cellSource)
+
+	// The most specific source setting a style wins, on top of the band background
+	for i := len(sources) - 1; i >= 0; i-- {
+		if sources[i].Style != nil {
+			sources[i].Applied = true
+			resolved := withBand(sources[i].Style, band)
+			if resolved != sources[i].Style {
+				sources[0].Applied = true
+			}
+			return sources, resolved
+		}
+	}
+	return sources, nil
+}
+
+// themedSource returns the source as a theme source when its style was set by the theme of the
+// table rather than the table itself, noting the source it stands for (e.g. "header: corporate").
+func (t *Table) themedSource(source StyleSource) StyleSource {
+	if source.Style == nil || !t.themeStyles[source.Style] {
+		return source
+	}
+	note := string(source.Kind)
+	if t.Theme != nil && t.Theme.Name != "" {
+		note += ": " + t.Theme.Name
+	}
+	if source.Kind != StyleSourceHeader && source.Kind != StyleSourceFooter {
+		note = source.Note + ", " + note
+	}
+	source.Kind, source.Note = StyleSourceTheme, note
+	return source
+}
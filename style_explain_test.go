@@ -0,0 +1,104 @@
+package spit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainCellStyle(t *testing.T) {
+	columnStyle := &Style{Italic: true}
+	rowStyle := &Style{Bold: true}
+	cellStyle := &Style{BackgroundColor: "#000000"}
+	table := NewTable(DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}}, Columns{
+		NewColumn("a", "A").WithStyle(columnStyle),
+		NewColumn("b", "B"),
+	}, true).
+		WithPreamble(PreambleRows{NewPreambleRow("Report").WithStyle(rowStyle)}).
+		WithRowOptions(RowOptionsMap{1: RowOptions{RowIndex: 1, Style: rowStyle}}).
+		WithCellOptions(CellOptionsMap{2: {1: CellOptions{RowIndex: 1, Style: cellStyle}}}).
+		WithFooter(nil)
+
+	tests := []struct {
+		name     string
+		col, row int
+		region   string
+		applied  StyleSourceKind
+		sources  int
+		resolved *Style
+	}{
+		{"preamble", 1, 1, "preamble", StyleSourcePreamble, 1, rowStyle},
+		{"preamble without value", 2, 1, "preamble", "", 1, nil},
+		{"header default", 1, 2, "header", StyleSourceHeader, 1, &Style{Bold: true, BackgroundColor: "#E0E0E0", Alignment: AlignmentCenterMiddle}},
+		{"column", 1, 3, "data", StyleSourceColumn, 3, columnStyle},
+		{"unstyled", 2, 3, "data", "", 3, nil},
+		{"row over column", 1, 4, "data", StyleSourceRow, 3, rowStyle},
+		{"cell over row", 2, 4, "data", StyleSourceCell, 4, &Style{BackgroundColor: "#000000", TextColor: ContrastTextLight}},
+		{"footer default", 1, 5, "footer", StyleSourceFooter, 1, &Style{Bold: true}},
+		{"outside", 3, 3, "outside", "", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ExplainCellStyle(table, tt.col, tt.row)
+			if e.Region != tt.region {
+				t.Errorf("Region = %q, want %q", e.Region, tt.region)
+			}
+			if len(e.Sources) != tt.sources {
+				t.Errorf("got %d sources, want %d: %s", len(e.Sources), tt.sources, e)
+			}
+			var applied StyleSourceKind
+			for _, source := range e.Sources {
+				if source.Applied && source.Kind != StyleSourceContrast {
+					applied = source.Kind
+				}
+			}
+			if applied != tt.applied {
+				t.Errorf("applied source = %q, want %q", applied, tt.applied)
+			}
+			if (e.Resolved == nil) != (tt.resolved == nil) || e.Resolved != nil && *e.Resolved != *tt.resolved {
+				t.Errorf("Resolved = %v, want %v", e.Resolved, tt.resolved)
+			}
+		})
+	}
+
+	if s := ExplainCellStyle(table, 2, 4).String(); !strings.Contains(s, "* cell") || !strings.Contains(s, "contrast") {
+		t.Errorf("String() = %q, want the applied cell and contrast sources", s)
+	}
+}
+
+func TestExplainCellStyle_theme(t *testing.T) {
+	headerStyle := &Style{Italic: true}
+	table := NewTable(DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}}, Columns{
+		NewColumn("a", "A").WithType(ColumnTypeInt),
+		NewColumn("b", "B").WithStyle(&Style{Bold: true}),
+	}, true).WithTheme(ThemeCorporate).WithFooter(nil)
+
+	tests := []struct {
+		name    string
+		table   *Table
+		col     int
+		row     int
+		applied StyleSourceKind
+		note    string
+	}{
+		{"theme header", table, 1, 1, StyleSourceTheme, "header: corporate"},
+		{"theme number format", table, 1, 2, StyleSourceTheme, `column "a", column: corporate`},
+		{"column over theme", table, 2, 2, StyleSourceColumn, `column "b"`},
+		{"theme band", table, 2, 3, StyleSourceTheme, "band 1, band: corporate"},
+		{"theme footer", table, 1, 4, StyleSourceTheme, "footer: corporate"},
+		{"header options over theme", NewTable(nil, Columns{NewColumn("a", "A")}, true).
+			WithTheme(ThemeCorporate).WithHeaderOptions(&HeaderOptions{Style: headerStyle}), 1, 1, StyleSourceHeader, "HeaderOptions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ExplainCellStyle(tt.table, tt.col, tt.row)
+			found := false
+			for _, source := range e.Sources {
+				found = found || source.Applied && source.Kind == tt.applied && source.Note == tt.note
+			}
+			if !found {
+				t.Errorf("want the %s source (%s) applied:\n%s", tt.applied, tt.note, e)
+			}
+		})
+	}
+}
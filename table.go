@@ -11,7 +11,9 @@ package spit
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 // TableOperations defines table-specific operations interface.
@@ -55,6 +57,7 @@ type TableOperations interface {
 	ApplyStyleToRange(startCol, startRow, endCol, endRow int, style Style) error
 
 	// GetColumnLetter Returns the Excel-style column letter (e.g., "A", "B") for a given column index.
+	// Backends implementing CellAddresser are addressed through it instead (see AddresserFor).
 	GetColumnLetter(col int) string
 
 	// ProcessValue Processes a value for output, applying formatting if needed.
@@ -76,15 +79,89 @@ type TableOperations interface {
 // Table represents a structured data table with configuration for export operations.
 // Contains data rows, column definitions (including hierarchy and formatting), and options for styling, merging, and headers.
 type Table struct {
-	Data           DataSlice      // The actual data rows to be exported
-	Columns        Columns        // Column definitions including hierarchy and formatting
-	RowOptionsMap  RowOptionsMap  // Row-specific options (styling, merging, borders)
-	CellOptionsMap CellOptionsMap // Cell-specific options for fine-grained control
-	HeaderOptions  *HeaderOptions // Optional header configuration (style and borders)
-	Preamble       PreambleRows   // Optional free-form rows written above the header/data area
-	WriteHeader    bool           // Whether to generate headers from column definitions
-	Limit          int64          // Maximum number of data rows to export (0 = no limit)
-	ListSeparator  string         // separator used when rendering slice/array values as strings
+	Data           DataSlice          // The actual data rows to be exported
+	Columns        Columns            // Column definitions including hierarchy and formatting
+	RowOptionsMap  RowOptionsMap      // Row-specific options (styling, merging, borders)
+	CellOptionsMap CellOptionsMap     // Cell-specific options for fine-grained control
+	HeaderOptions  *HeaderOptions     // Optional header configuration (style and borders)
+	Preamble       PreambleRows       // Optional free-form rows written above the header/data area
+	WriteHeader    bool               // Whether to generate headers from column definitions
+	WriteFooter    bool               // Whether to write an aggregate footer row after the data (see Column.Aggregate)
+	FooterOptions  *FooterOptions     // Optional footer configuration (label, style and formulas)
+	Limit          int64              // Maximum number of data rows to export (0 = no limit, see WithLimit)
+	ListSeparator  string             // separator used when rendering slice/array values as strings
+	Preview        *PreviewOptions    // Optional preview mode (truncated, masked and watermarked sample export)
+	StartRow       int                // 1-based sheet row where the table (preamble included) starts (0 = 1)
+	StartCol       int                // 1-based sheet column where the table starts (0 = 1)
+	LabelSeparator string             // Optional label separator building header groups from flat labels (see Columns.GroupByLabel)
+	GroupBy        []string           // Optional column names grouping the data rows, outermost first (see WithGroupBy)
+	GroupOptions   *GroupOptions      // Optional group header configuration
+	AutoFilter     bool               // Whether to apply an auto-filter over the header and data rows (XLSX)
+	ExcelTable     *ExcelTableOptions // Optional native Excel table over the header and data rows (XLSX)
+	NamedRanges    *NamedRangeOptions // Optional defined names over the header, data, footer and column regions (XLSX)
+	DataName       string             // Optional workbook-scoped defined name over the data rows (XLSX, see WithDataName)
+	Protection     *SheetProtection   // Optional protection of the written sheet (XLSX, see WithProtection)
+	Transposed     bool               // Whether labels run down the first column and data rows extend to the right (see WithTransposed)
+	RightToLeft    bool               // Whether the table is displayed right to left, the first column on the right (see WithRightToLeft)
+	// AfterRowWrite is an optional callback run after each data row is written (XLSX, see WithAfterRowWrite)
+	AfterRowWrite func(rowIndex int, ref CellRange)
+	// RowStyler optionally computes the style of each data row from its values (see WithRowStyler)
+	RowStyler func(rowIndex int, row Data) *Style
+	// Filters optionally keep the data rows for which every filter returns true (see WithFilter)
+	Filters []func(row Data) bool
+	// Sort optionally sorts the data rows before export, first key first (see WithSort)
+	Sort []SortKey
+	// Zebra optionally alternates the styles of data rows (see WithZebra)
+	Zebra *ZebraOptions
+	// ExcelPivot optionally writes a native pivot table over the header and data rows (XLSX, see WithExcelPivot)
+	ExcelPivot *ExcelPivotOptions
+	// Charts are optional charts referencing the written table (XLSX, see WithChart)
+	Charts []*Chart
+	// DisableAutoContrast turns off automatic text colors for styles with a background but no text color
+	DisableAutoContrast bool
+	// CSVMerges defines how vertical merge ranges are rendered in CSV exports (see WithCSVMerges)
+	CSVMerges CSVMergeMode
+	// CSVDescriptionRow writes the column descriptions as a row below the CSV header (see WithCSVDescriptionRow)
+	CSVDescriptionRow bool
+	// SparseColumns optionally compacts the leaf columns empty in every exported row (see WithSparseColumns)
+	SparseColumns *SparseColumnOptions
+	// ErrorMode defines whether warnings fail the export (see WithErrorMode)
+	ErrorMode ErrorMode
+	// TruncationNotice optionally writes a notice row after the table when Limit truncates the data (see WithTruncationNotice)
+	TruncationNotice *TruncationNoticeOptions
+	// TimeLocation is the optional zone time values are converted to before formatting (see WithTimeLocation)
+	TimeLocation *time.Location
+	// SerialDates writes time values as native date cells instead of text (XLSX, see WithSerialDates)
+	SerialDates bool
+	// NilPlaceholder optionally sets the texts written for nil and missing values (see WithNilPlaceholder)
+	NilPlaceholder *PlaceholderOptions
+	// CellOverflow defines how XLSX exports write text longer than a cell holds (see WithCellOverflow)
+	CellOverflow CellOverflowMode
+	// FormulaEscape defines how CSV exports escape text values starting like a formula (see WithFormulaEscape)
+	FormulaEscape FormulaEscapeMode
+	// ChunkSize optionally splits exports into parts of at most ChunkSize data rows (see WithChunkSize)
+	ChunkSize int
+	// ChunkMode defines whether XLSX parts are written to separate files or sheets (see WithChunkMode)
+	ChunkMode ChunkMode
+	// Theme optionally fills the header, data and footer styles the table leaves unset (see WithTheme)
+	Theme *Theme
+
+	prepared *Table       // Snapshot returned by Prepare, shared by the formats of an ExportMulti run
+	values   valueCache   // Processed values of the current export run (see CacheValues)
+	warnings *warningLog  // Warnings of the current export run (see ExportWarning)
+	seed     int64        // Seed of randomized features of the current export run (see FileWriteParams.Seed)
+	progress *runProgress // Progress of the current export run (see FileWriteParams.OnProgress)
+	quota    *runQuota    // Quota of the current export run (see FileWriteParams.Limits)
+	timings  *runTimings  // Timings of the current export run (see FileWriteParams.CollectTimings)
+
+	themeStyles map[*Style]bool // Styles set by the theme rather than the table (see withTheme), reported by ExplainCellStyle
+
+	outlineLevels []int                 // Outline level of each data row, set by grouping (see RowOutlineLevel)
+	subtotals     map[int]subtotalRange // Subtotal rows by data row index, set by grouping
+	sourceRows    []int                 // Source data index of each data row, set by grouping (-1 for inserted rows)
+	truncated     int                   // Data rows left out by Limit, set by Prepare (see Truncated)
+	hidden        map[*Column]bool      // Leaf columns written hidden (XLSX) and dropped by other formats
+	sparse        []SparseColumn        // Sparse columns compacted by Prepare (see SparseColumns)
 }
 
 // NewTable creates a new Table instance with the provided data slice and column definitions.
@@ -108,23 +185,204 @@ func (t *Table) WithCellOptions(cellOptions CellOptionsMap) *Table {
 	return t
 }
 
+// WithCSVMerges sets how vertical merge ranges are rendered in CSV exports. CSVMergeBlankRepeats
+// keeps the first value of each range and leaves the covered rows empty; other formats are unaffected.
+func (t *Table) WithCSVMerges(mode CSVMergeMode) *Table {
+	t.CSVMerges = mode
+	return t
+}
+
+// WithCSVDescriptionRow writes the descriptions of the leaf columns (see Column.WithDescription) as
+// a row below the header rows of CSV exports. The row is only written when a column has a
+// description; other formats are unaffected.
+func (t *Table) WithCSVDescriptionRow(enabled bool) *Table {
+	t.CSVDescriptionRow = enabled
+	return t
+}
+
+// WithErrorMode sets how exports handle the issues they report as warnings. ErrorStrict fails the
+// export with the first warning (merge, style, validation, degraded feature...) as soon as the step
+// reporting it completes; ErrorLenient (default) completes the export and returns the warnings.
+func (t *Table) WithErrorMode(mode ErrorMode) *Table {
+	t.ErrorMode = mode
+	return t
+}
+
 // WithHeaderOptions sets the header configuration (style and borders) for the table.
 func (t *Table) WithHeaderOptions(headerOptions *HeaderOptions) *Table {
 	t.HeaderOptions = headerOptions
 	return t
 }
 
+// WithFooter enables the aggregate footer row with the given options (nil uses the defaults).
+func (t *Table) WithFooter(options *FooterOptions) *Table {
+	t.WriteFooter = true
+	t.FooterOptions = options
+	return t
+}
+
 // WithPreamble sets the preamble rows written above the header/data area.
 func (t *Table) WithPreamble(preamble PreambleRows) *Table {
 	t.Preamble = preamble
 	return t
 }
 
+// WithStartPosition sets the 1-based sheet column and row where the table starts.
+// All writing, merging and styling honors this position (see Table.Offset).
+func (t *Table) WithStartPosition(col, row int) *Table {
+	t.StartCol = col
+	t.StartRow = row
+	return t
+}
+
+// WithAutoContrast enables or disables automatic contrast-aware text colors (enabled by default).
+// When enabled, styles that set a BackgroundColor without a TextColor get black or white text
+// depending on the background luminance (see ContrastTextColor).
+func (t *Table) WithAutoContrast(enabled bool) *Table {
+	t.DisableAutoContrast = !enabled
+	return t
+}
+
+// WithAfterRowWrite sets a callback run after each data row is written in XLSX exports, with the
+// row index in Data and the sheet range the row landed in (e.g. to hyperlink or reference records
+// later). Rows inserted by grouping are not reported.
+func (t *Table) WithAfterRowWrite(fn func(rowIndex int, ref CellRange)) *Table {
+	t.AfterRowWrite = fn
+	return t
+}
+
+// WithRowStyler sets a callback computing the style of each data row from its values, with the row
+// index in Data (e.g. to highlight failed records). The style is applied like a row style (see
+// RowOptions.Style): cell styles and style rules take precedence over it, and it takes precedence
+// over column styles. A row style set in RowOptionsMap wins over the callback, which is not called
+// for rows inserted by grouping; returning nil leaves the row unstyled.
+func (t *Table) WithRowStyler(fn func(rowIndex int, row Data) *Style) *Table {
+	t.RowStyler = fn
+	return t
+}
+
+// WithLabelGrouping builds header groups from flat column labels split on separator
+// (DefaultLabelSeparator when empty), e.g. "Q1|Revenue" and "Q1|Cost" under a "Q1" group.
+// Grouping is applied at export time (see Columns.GroupByLabel); the columns are not modified.
+func (t *Table) WithLabelGrouping(separator string) *Table {
+	if separator == "" {
+		separator = DefaultLabelSeparator
+	}
+	t.LabelSeparator = separator
+	return t
+}
+
+// WithPreview enables preview mode for the table (see PreviewOptions).
+func (t *Table) WithPreview(preview *PreviewOptions) *Table {
+	t.Preview = preview
+	return t
+}
+
+// Prepare returns the table as it will be exported.
+// Transformations configured on the table (filters, sorting, row limit, preview mode, label grouping, sparse column compaction, row grouping)
+// are applied to a shallow copy so the original table is left untouched; when none are configured, t itself is
+// returned.
+func (t *Table) Prepare() *Table {
+	if t.prepared != nil {
+		return t.prepared
+	}
+	prepared := t.selectRows()
+	if t.Preview != nil {
+		prepared = t.Preview.apply(prepared)
+	}
+	if t.LabelSeparator != "" {
+		grouped := *prepared
+		grouped.Columns = prepared.Columns.GroupByLabel(t.LabelSeparator)
+		grouped.LabelSeparator = ""
+		prepared = &grouped
+	}
+	if columns, ok := prepared.Columns.inheritStyles(nil, nil); ok {
+		inherited := *prepared
+		inherited.Columns = columns
+		prepared = &inherited
+	}
+	if t.Theme != nil {
+		prepared = prepared.withTheme(t.Theme)
+	}
+	prepared = prepared.withHiddenColumns()
+	if t.SparseColumns != nil && t.SparseColumns.Action != SparseColumnsKeep {
+		prepared = prepared.compactSparseColumns()
+	}
+	if len(t.GroupBy) > 0 {
+		prepared = prepared.applyGrouping()
+	}
+	return prepared
+}
+
+// selectRows returns the table holding the exported data rows: filtered, sorted and limited.
+func (t *Table) selectRows() *Table {
+	selected := t
+	if len(t.Filters) > 0 {
+		selected = selected.applyFilters()
+	}
+	if len(t.Sort) > 0 {
+		selected = selected.applySort()
+	}
+	if t.Limit > 0 && int64(len(selected.Data)) > t.Limit {
+		selected = selected.applyLimit()
+	}
+	return selected
+}
+
+// withSeed returns a shallow copy of the table whose randomized features (e.g. preview sampling)
+// draw from the given seed.
+func (t *Table) withSeed(seed int64) *Table {
+	c := *t
+	c.seed = seed
+	return &c
+}
+
+// ForFormat returns the table as written by the given export format (see Column.Formats).
+// Formats without hidden columns (all but XLSX) drop them. When columns are removed, a shallow
+// copy is returned with the cell options of the remaining columns re-indexed; otherwise t itself
+// is returned.
+func (t *Table) ForFormat(format Format) *Table {
+	f := t
+	if format != FormatXSLX {
+		f = t.withoutHidden()
+	}
+	flatColumns := f.Columns.GetFlattenedColumns()
+	columns := f.Columns.ForFormat(format)
+	if len(columns.GetFlattenedColumns()) == len(flatColumns) {
+		return f
+	}
+	return f.withColumns(columns)
+}
+
+// withColumns returns a shallow copy of the table with the given columns, whose leaf columns are
+// taken from the table's, with the cell options re-indexed to the new leaf positions.
+func (t *Table) withColumns(columns Columns) *Table {
+	f := *t
+	f.prepared = nil
+	f.Columns = columns
+	if t.CellOptionsMap != nil {
+		// Leaf columns are kept as-is, so they are matched by identity
+		positions := make(map[*Column]int)
+		for i, column := range columns.GetFlattenedColumns() {
+			positions[column] = i + 1
+		}
+		f.CellOptionsMap = make(CellOptionsMap, len(t.CellOptionsMap))
+		for i, column := range t.Columns.GetFlattenedColumns() {
+			if position, ok := positions[column]; ok {
+				if cells, ok := t.CellOptionsMap[i+1]; ok {
+					f.CellOptionsMap[position] = cells
+				}
+			}
+		}
+	}
+	return &f
+}
+
 // PreambleRow represents a single free-form row written above the table header.
 // Each row can carry an arbitrary number of cell values and an optional style.
 type PreambleRow struct {
-	Values []interface{} // Cell values for this row (one entry per column position)
-	Style  *Style        // Optional style applied to every non-empty cell in this row
+	Values []interface{} `json:"values,omitempty" yaml:"values,omitempty"` // Cell values for this row (one entry per column position)
+	Style  *Style        `json:"style,omitempty" yaml:"style,omitempty"`   // Optional style applied to every non-empty cell in this row
 }
 
 // PreambleRows is a slice of PreambleRow.
@@ -144,8 +402,8 @@ func (p *PreambleRow) WithStyle(style *Style) *PreambleRow {
 // HeaderOptions represents option settings for table header rows.
 // When configured, it overrides the default header style and border settings.
 type HeaderOptions struct {
-	Style   *Style   // Optional style for header cells (overrides default bold/grey/centered style when set)
-	Borders *Borders // Optional border configuration for header cells (overrides default thin boundaries when set)
+	Style   *Style   `json:"style,omitempty" yaml:"style,omitempty"`     // Optional style for header cells (overrides default bold/grey/centered style when set)
+	Borders *Borders `json:"borders,omitempty" yaml:"borders,omitempty"` // Optional border configuration for header cells (overrides default thin boundaries when set)
 }
 
 // NewHeaderOptions creates a new HeaderOptions instance.
@@ -198,11 +456,15 @@ func (t *Table) GetDataIndexFromRowIndex(rowIndex int) int {
 // Data represents a single row of table data as a map from column name to value.
 type Data map[string]interface{}
 
+// KeyPathSeparator separates the keys of a nested path in a column name or key (e.g. "address.city").
+const KeyPathSeparator = "."
+
 // DataSlice is a slice of Data rows.
 type DataSlice []Data
 
 // Lookup recursively looks up a key in a nested map structure.
-// Supports multi-level key access for hierarchical data.
+// Supports multi-level key access for hierarchical data; nested values may be Data or
+// map[string]interface{} (e.g. decoded JSON).
 func (d Data) Lookup(ks ...string) (rval interface{}, err error, found bool) {
 	var ok bool
 	if len(ks) == 0 {
@@ -212,25 +474,93 @@ func (d Data) Lookup(ks ...string) (rval interface{}, err error, found bool) {
 		return nil, nil, false
 	} else if len(ks) == 1 {
 		return rval, nil, true
-	} else if d, ok = rval.(Data); !ok {
+	} else if d, ok = asData(rval); !ok {
 		return nil, fmt.Errorf("malformed structure at %#v", rval), false
 	} else {
 		return d.Lookup(ks[1:]...)
 	}
 }
 
+// LookupKey looks up a column key in this row. A key present as-is wins; otherwise a key
+// containing KeyPathSeparator is resolved as a nested path (e.g. "address.city").
+func (d Data) LookupKey(key string) (rval interface{}, err error, found bool) {
+	if rval, found = d[strings.TrimSpace(key)]; found || !strings.Contains(key, KeyPathSeparator) {
+		return rval, nil, found
+	}
+	return d.Lookup(strings.Split(key, KeyPathSeparator)...)
+}
+
+// asData returns a nested map value as Data.
+func asData(value interface{}) (Data, bool) {
+	switch v := value.(type) {
+	case Data:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	}
+	return nil, false
+}
+
+// LookupColumn looks up the value for a column in this row.
+// The column's Name is tried first, then each fallback key from Keys in order; the first key
+// present in the row wins. Keys may be nested paths (see LookupKey).
+// Returns found=false when none of the keys exist. Computed columns (see Column.ValueExpr)
+// evaluate their expression, unless the row already holds a value under the column name.
+func (d Data) LookupColumn(column *Column) (rval interface{}, err error, found bool) {
+	if column.ValueExpr != "" {
+		if rval, found = d[column.Name]; found {
+			return rval, nil, true
+		}
+		return d.computeColumn(column)
+	}
+	for _, key := range column.LookupKeys() {
+		if rval, err, found = d.LookupKey(key); err != nil || found {
+			return rval, err, found
+		}
+	}
+	return nil, nil, false
+}
+
 // Column represents a single column definition for table exports.
 // Columns can be nested to create hierarchical structures, allowing for
 // complex header layouts and grouped data organization.
 type Column struct {
-	Name    string      // Field name in the data source (for leaf columns)
-	Label   string      // Display label for headers
-	Format  string      // Format specification for value processing (e.g., date format)
-	Width   float64     // Optional column width in character units (0 = use default)
-	Merge   *MergeRules // Optional merge configuration for this column
-	Borders *Borders    // Borders configuration
-	Style   *Style      // Optional content style
-	Columns Columns     // Sub-columns for hierarchical structures
+	Name      string      `json:"name,omitempty" yaml:"name,omitempty"`           // Field name in the data source (for leaf columns)
+	Keys      []string    `json:"keys,omitempty" yaml:"keys,omitempty"`           // Optional fallback field names, tried in order when Name is absent from a row
+	Label     string      `json:"label,omitempty" yaml:"label,omitempty"`         // Display label for headers
+	Format    string      `json:"format,omitempty" yaml:"format,omitempty"`       // Format specification for value processing (e.g., date format)
+	Type      ColumnType  `json:"type,omitempty" yaml:"type,omitempty"`           // Optional data type of the values, written as native cells (see WithType)
+	Width     float64     `json:"width,omitempty" yaml:"width,omitempty"`         // Optional column width in character units (0 = use default)
+	Merge     *MergeRules `json:"merge,omitempty" yaml:"merge,omitempty"`         // Optional merge configuration for this column
+	Borders   *Borders    `json:"borders,omitempty" yaml:"borders,omitempty"`     // Borders configuration
+	Style     *Style      `json:"style,omitempty" yaml:"style,omitempty"`         // Optional content style
+	Columns   Columns     `json:"columns,omitempty" yaml:"columns,omitempty"`     // Sub-columns for hierarchical structures
+	Pinned    bool        `json:"pinned,omitempty" yaml:"pinned,omitempty"`       // Always kept by SelectColumns/ExcludeColumns and placed first
+	Hidden    bool        `json:"hidden,omitempty" yaml:"hidden,omitempty"`       // Processed like any column but written hidden (XLSX) or omitted (other formats)
+	Aggregate *Aggregate  `json:"aggregate,omitempty" yaml:"aggregate,omitempty"` // Optional footer aggregate for this column (see Table.WithFooter)
+	Formats   []Format    `json:"formats,omitempty" yaml:"formats,omitempty"`     // Export formats including this column (empty = all formats)
+	// TimeLocation is the optional zone of the column's time values, overriding Table.TimeLocation
+	TimeLocation *time.Location `json:"-" yaml:"-"`
+	// NilPlaceholder optionally sets the texts written for nil and missing values, overriding Table.NilPlaceholder
+	NilPlaceholder *PlaceholderOptions `json:"nilPlaceholder,omitempty" yaml:"nilPlaceholder,omitempty"`
+	// InheritStyle passes Style and Borders down to the sub-columns that set none (see WithInheritStyle)
+	InheritStyle bool `json:"inheritStyle,omitempty" yaml:"inheritStyle,omitempty"`
+	// HeaderComment is an optional comment attached to the header cell (XLSX), e.g. a column description
+	HeaderComment *Comment `json:"headerComment,omitempty" yaml:"headerComment,omitempty"`
+	// Description documents the column: header comment (XLSX), header title (HTML) and optional CSV row (see WithDescription)
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// FooterFormula is written in the footer cell instead of the aggregate in backends supporting formulas
+	FooterFormula string `json:"footerFormula,omitempty" yaml:"footerFormula,omitempty"`
+	// ParquetType is the type of the column in Parquet exports (ParquetTypeAuto infers it from the values)
+	ParquetType ParquetType `json:"parquetType,omitempty" yaml:"parquetType,omitempty"`
+	// ValueExpr computes the value of the column from the other fields of the row (see ParseExpr)
+	ValueExpr string `json:"valueExpr,omitempty" yaml:"valueExpr,omitempty"`
+	// StyleRules style the cells matching a condition, first matching rule wins (see WithStyleRule)
+	StyleRules []StyleRule `json:"styleRules,omitempty" yaml:"styleRules,omitempty"`
+	// Validations are checks on the values of the column, reported as warnings (see WithValidation)
+	Validations []Validation `json:"validations,omitempty" yaml:"validations,omitempty"`
+	// ColumnStyle is the optional style of the whole sheet column (XLSX, see WithColumnStyle)
+	ColumnStyle *Style `json:"columnStyle,omitempty" yaml:"columnStyle,omitempty"`
 }
 
 // NewColumn creates a new Column with the specified name and label.
@@ -247,6 +577,23 @@ func (c *Column) WithFormat(format string) *Column {
 	return c
 }
 
+// WithKeys sets the fallback field names for this column.
+// When a row has no value under Name, each key is tried in order and the first one present is used.
+func (c *Column) WithKeys(keys ...string) *Column {
+	c.Keys = keys
+	return c
+}
+
+// LookupKeys returns the ordered list of field names used to resolve this column's value:
+// Name (when set) followed by the fallback Keys.
+func (c *Column) LookupKeys() []string {
+	keys := make([]string, 0, len(c.Keys)+1)
+	if c.Name != "" {
+		keys = append(keys, c.Name)
+	}
+	return append(keys, c.Keys...)
+}
+
 // WithWidth sets the column width in character units for this column.
 // A value of 0 (the default) falls back to the global default width in autoFitColumns.
 func (c *Column) WithWidth(width float64) *Column {
@@ -272,6 +619,62 @@ func (c *Column) WithStyle(style *Style) *Column {
 	return c
 }
 
+// WithAggregate sets the footer aggregate for this column (e.g. AggregateSum or NewAggregate(fn)).
+func (c *Column) WithAggregate(aggregate *Aggregate) *Column {
+	c.Aggregate = aggregate
+	return c
+}
+
+// WithHeaderComment attaches a comment with the given author and text to the header cell of this column.
+func (c *Column) WithHeaderComment(author, text string) *Column {
+	c.HeaderComment = NewComment(author, text)
+	return c
+}
+
+// WithDescription sets the description of this column, so the export documents its columns without
+// a separate data dictionary: XLSX writes it as the comment of the header cell (unless
+// WithHeaderComment sets one), HTML as the title (tooltip) of the header cell, and CSV as an
+// optional row below the header (see Table.WithCSVDescriptionRow).
+func (c *Column) WithDescription(description string) *Column {
+	c.Description = description
+	return c
+}
+
+// WithPinned marks this column as pinned: column selection always keeps it and places it first.
+func (c *Column) WithPinned(pinned bool) *Column {
+	c.Pinned = pinned
+	return c
+}
+
+// WithHidden marks this column (and its sub-columns) as hidden: its values still take part in
+// data processing (grouping, merging, expressions), but XLSX writes it as a hidden sheet column and
+// the other formats omit it.
+func (c *Column) WithHidden(hidden bool) *Column {
+	c.Hidden = hidden
+	return c
+}
+
+// WithInheritStyle makes the sub-columns of this column inherit its Style and Borders: every
+// column below it that sets no Style (or no Borders) uses this column's, unless a closer group
+// also inheriting sets its own. Without it, the style and borders of a group are ignored by its
+// sub-columns.
+func (c *Column) WithInheritStyle(inherit bool) *Column {
+	c.InheritStyle = inherit
+	return c
+}
+
+// WithFormats restricts the export formats that include this column (and its sub-columns).
+// Without formats, the column is included in every format.
+func (c *Column) WithFormats(formats ...Format) *Column {
+	c.Formats = formats
+	return c
+}
+
+// IncludedIn reports whether the column is written by the given export format.
+func (c *Column) IncludedIn(format Format) bool {
+	return len(c.Formats) == 0 || slices.Contains(c.Formats, format)
+}
+
 // WithSubColumns sets the sub-columns for this column.
 func (c *Column) WithSubColumns(subColumns Columns) *Column {
 	c.Columns = subColumns
@@ -364,6 +767,107 @@ func (c Columns) GetMaxDepth() int {
 	return maxDepth
 }
 
+// SelectColumns returns the top-level columns whose Name is listed, in the order of names.
+// Pinned columns are always kept and placed first, in their original order; unknown names are ignored.
+// The receiver is not modified.
+func (c Columns) SelectColumns(names ...string) Columns {
+	selected := c.pinnedColumns()
+	for _, name := range names {
+		for _, column := range c {
+			if !column.Pinned && column.Name == name {
+				selected = append(selected, column)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// ExcludeColumns returns the top-level columns whose Name is not listed, in their original order.
+// Pinned columns are never excluded and are placed first. The receiver is not modified.
+func (c Columns) ExcludeColumns(names ...string) Columns {
+	kept := c.pinnedColumns()
+	for _, column := range c {
+		if column.Pinned || slices.Contains(names, column.Name) {
+			continue
+		}
+		kept = append(kept, column)
+	}
+	return kept
+}
+
+// ForFormat returns the columns written by the given export format: columns restricted to other
+// formats are removed, and so are groups left without sub-columns. The receiver is not modified.
+func (c Columns) ForFormat(format Format) Columns {
+	kept := make(Columns, 0, len(c))
+	for _, column := range c {
+		if !column.IncludedIn(format) {
+			continue
+		}
+		if len(column.Columns) > 0 {
+			subColumns := column.Columns.ForFormat(format)
+			if len(subColumns) == 0 {
+				continue
+			}
+			group := *column
+			group.Columns = subColumns
+			column = &group
+		}
+		kept = append(kept, column)
+	}
+	return kept
+}
+
+// inheritStyles returns the columns with the style and borders of the groups inheriting them (see
+// Column.WithInheritStyle) copied to their leaf columns, and whether any leaf column changed. Only
+// changed leaf columns and their groups are copied; the receiver is not modified.
+func (c Columns) inheritStyles(style *Style, borders *Borders) (Columns, bool) {
+	inherited := make(Columns, len(c))
+	changed := false
+	for i, column := range c {
+		inherited[i] = column
+		if len(column.Columns) > 0 {
+			groupStyle, groupBorders := style, borders
+			if column.InheritStyle {
+				if column.Style != nil {
+					groupStyle = column.Style
+				}
+				if column.Borders != nil {
+					groupBorders = column.Borders
+				}
+			}
+			if subColumns, ok := column.Columns.inheritStyles(groupStyle, groupBorders); ok {
+				group := *column
+				group.Columns = subColumns
+				inherited[i], changed = &group, true
+			}
+			continue
+		}
+		if column.Style == nil && style != nil || column.Borders == nil && borders != nil {
+			leaf := *column
+			if leaf.Style == nil {
+				leaf.Style = style
+			}
+			if leaf.Borders == nil {
+				leaf.Borders = borders
+			}
+			inherited[i], changed = &leaf, true
+		}
+	}
+	return inherited, changed
+}
+
+// pinnedColumns returns the pinned top-level columns in their original order.
+func (c Columns) pinnedColumns() Columns {
+	pinned := make(Columns, 0, len(c))
+	for _, column := range c {
+		if column.Pinned {
+			pinned = append(pinned, column)
+		}
+	}
+	return pinned
+}
+
 // RowOptionsMap maps row indices to their specific options.
 type RowOptionsMap map[int]RowOptions
 
@@ -371,11 +875,13 @@ type RowOptionsMap map[int]RowOptions
 // This allows fine-grained control over individual rows, overriding default
 // column-based settings when needed.
 type RowOptions struct {
-	RowIndex  int         // The 0-based index of the row this option applies to
-	Border    *Borders    // Optional border configuration for the entire row
-	Style     *Style      // Optional style configuration for the entire row
-	Merge     *MergeRules // Optional merge configuration that overrides column settings
-	Mergeable bool        // Whether this row cells can participate in merge operations
+	RowIndex       int         `json:"rowIndex,omitempty" yaml:"rowIndex,omitempty"`             // The 0-based index of the row this option applies to
+	Border         *Borders    `json:"border,omitempty" yaml:"border,omitempty"`                 // Optional border configuration for the entire row
+	Style          *Style      `json:"style,omitempty" yaml:"style,omitempty"`                   // Optional style configuration for the entire row
+	Merge          *MergeRules `json:"merge,omitempty" yaml:"merge,omitempty"`                   // Optional merge configuration that overrides column settings
+	Mergeable      bool        `json:"mergeable,omitempty" yaml:"mergeable,omitempty"`           // Whether this row cells can participate in merge operations
+	SpanAllColumns bool        `json:"spanAllColumns,omitempty" yaml:"spanAllColumns,omitempty"` // Whether the entire row is merged into a single full-width cell (e.g. a section title)
+	Value          interface{} `json:"value,omitempty" yaml:"value,omitempty"`                   // Value of the spanning cell (nil keeps the row's first column value)
 }
 
 // NewRowOptions creates a new RowOptions instance for the specified row index.
@@ -409,6 +915,14 @@ func (rowOptions *RowOptions) WithMergeable(mergeable bool) *RowOptions {
 	return rowOptions
 }
 
+// WithSpanAllColumns merges the entire row into a single cell holding value, spanning the full table
+// width. A nil value keeps the row's own first column value.
+func (rowOptions *RowOptions) WithSpanAllColumns(value interface{}) *RowOptions {
+	rowOptions.SpanAllColumns = true
+	rowOptions.Value = value
+	return rowOptions
+}
+
 // CellOptionsMap provides cell-level option mapping.
 // The outer map keys are column indices, inner map keys are row indices.
 type CellOptionsMap map[int]map[int]CellOptions
@@ -417,11 +931,21 @@ type CellOptionsMap map[int]map[int]CellOptions
 // This provides the finest level of control, allowing individual cells
 // to override both column and row settings.
 type CellOptions struct {
-	RowIndex  int      // The 0-based row index of this cell
-	ColIndex  int      // The 0-based column index of this cell
-	Border    *Borders // Optional border configuration for this cell
-	Style     *Style   // Optional style configuration for this cell
-	Mergeable bool     // Whether this cell can participate in merge operations
+	RowIndex  int      `json:"rowIndex,omitempty" yaml:"rowIndex,omitempty"`   // The 0-based row index of this cell
+	ColIndex  int      `json:"colIndex,omitempty" yaml:"colIndex,omitempty"`   // The 0-based column index of this cell
+	Border    *Borders `json:"border,omitempty" yaml:"border,omitempty"`       // Optional border configuration for this cell
+	Style     *Style   `json:"style,omitempty" yaml:"style,omitempty"`         // Optional style configuration for this cell
+	Mergeable bool     `json:"mergeable,omitempty" yaml:"mergeable,omitempty"` // Whether this cell can participate in merge operations
+	// Meta holds key/value metadata for downstream automation (see Table.MetaRegions):
+	// XLSX writes each key as a sheet-scoped defined name, HTML as a data-* attribute
+	Meta map[string]string `json:"meta,omitempty" yaml:"meta,omitempty"`
+	// Comment is an optional comment (note) attached to this cell (XLSX)
+	Comment *Comment `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// Locked overrides the lock state of this cell on protected sheets (XLSX, see Style.Locked)
+	Locked *bool `json:"locked,omitempty" yaml:"locked,omitempty"`
+	// Formula is written instead of the value in backends supporting formulas (XLSX, Google Sheets);
+	// placeholders such as ColumnRef("amount") refer to other cells of the table
+	Formula string `json:"formula,omitempty" yaml:"formula,omitempty"`
 }
 
 // NewCellOptions creates a new CellOptions instance for the specified row and column indices.
@@ -444,6 +968,33 @@ func (cellOptions *CellOptions) WithStyle(style *Style) *CellOptions {
 	return cellOptions
 }
 
+// WithMeta adds a metadata key/value pair to this cell (see CellOptions.Meta).
+func (cellOptions *CellOptions) WithMeta(key, value string) *CellOptions {
+	if cellOptions.Meta == nil {
+		cellOptions.Meta = make(map[string]string)
+	}
+	cellOptions.Meta[key] = value
+	return cellOptions
+}
+
+// WithComment attaches a comment with the given author and text to this cell.
+func (cellOptions *CellOptions) WithComment(author, text string) *CellOptions {
+	cellOptions.Comment = NewComment(author, text)
+	return cellOptions
+}
+
+// WithLocked sets whether this cell is locked when the sheet is protected (see Table.WithProtection).
+func (cellOptions *CellOptions) WithLocked(locked bool) *CellOptions {
+	cellOptions.Locked = &locked
+	return cellOptions
+}
+
+// WithFormula sets the formula written in this cell (see CellOptions.Formula).
+func (cellOptions *CellOptions) WithFormula(formula string) *CellOptions {
+	cellOptions.Formula = formula
+	return cellOptions
+}
+
 // WithMergeable sets whether this cell can participate in external merge operations.
 func (cellOptions *CellOptions) WithMergeable(mergeable bool) *CellOptions {
 	cellOptions.Mergeable = mergeable
@@ -463,6 +1014,8 @@ const (
 
 	// MergeConditionEmpty merges cells when both values are empty or nil
 	MergeConditionEmpty MergeCondition = "empty"
+
+	// Custom conditions evaluating a registered predicate are built with MergeConditionCustom
 )
 
 // AnyMatch checks if two sets of merge conditions share at least one common condition.
@@ -505,6 +1058,11 @@ func (mc MergeConditions) ValuesShouldMerge(value1, value2 interface{}) bool {
 			if isEmpty1 && isEmpty2 {
 				return true
 			}
+		default:
+			// Merge if the registered predicate accepts the values (see MergeConditionCustom)
+			if predicate, ok := condition.customPredicate(); ok && predicate(value1, value2) {
+				return true
+			}
 		}
 	}
 	return false // No conditions matched
@@ -514,10 +1072,30 @@ func (mc MergeConditions) ValuesShouldMerge(value1, value2 interface{}) bool {
 // It defines when and how cells should be merged based on their content.
 // Empty conditions arrays mean no merging will be applied.
 type MergeRules struct {
-	Vertical   MergeConditions `json:"vertical,omitempty"`   // Conditions for merging cells vertically (between rows)
-	Horizontal MergeConditions `json:"horizontal,omitempty"` // Conditions for merging cells horizontally (between columns)
+	Vertical   MergeConditions `json:"vertical,omitempty" yaml:"vertical,omitempty"`     // Conditions for merging cells vertically (between rows)
+	Horizontal MergeConditions `json:"horizontal,omitempty" yaml:"horizontal,omitempty"` // Conditions for merging cells horizontally (between columns)
+	Values     MergeValueMode  `json:"values,omitempty" yaml:"values,omitempty"`         // Values written in the non-anchor cells of merged ranges
+	// VerticalKeyColumn optionally names the column whose values decide vertical merges instead of
+	// the column's own values (see WithVerticalKeyColumn)
+	VerticalKeyColumn string `json:"verticalKeyColumn,omitempty" yaml:"verticalKeyColumn,omitempty"`
 }
 
+// MergeValueMode defines the values of the cells covered by a merged range, other than its
+// top-left (anchor) cell. Spreadsheets only display the anchor value, but the covered cells keep
+// their own values for consumers reading them (and show up again when the range is unmerged).
+type MergeValueMode int
+
+const (
+	// MergeValuesKeep leaves the covered cells as written (default).
+	MergeValuesKeep MergeValueMode = iota
+
+	// MergeValuesBlank clears the covered cells, so only the anchor holds the value.
+	MergeValuesBlank
+
+	// MergeValuesRepeat writes the anchor value into every covered cell.
+	MergeValuesRepeat
+)
+
 // NewMergeRules creates a new MergeRules instance with specified vertical and horizontal conditions.
 func NewMergeRules(vertical, horizontal MergeConditions) *MergeRules {
 	return &MergeRules{
@@ -526,6 +1104,21 @@ func NewMergeRules(vertical, horizontal MergeConditions) *MergeRules {
 	}
 }
 
+// WithValues sets the values of the cells covered by the merged ranges.
+func (m *MergeRules) WithValues(mode MergeValueMode) *MergeRules {
+	m.Values = mode
+	return m
+}
+
+// WithVerticalKeyColumn makes the column merge vertically when the values of another column match
+// the vertical conditions, instead of its own values (e.g. merge a "notes" column per order ID).
+// The key column is looked up by name among the table columns (hidden ones included), otherwise
+// read from the data field of that name.
+func (m *MergeRules) WithVerticalKeyColumn(name string) *MergeRules {
+	m.VerticalKeyColumn = name
+	return m
+}
+
 // BorderStyle represents the visual style of entity borders.
 // These constants correspond to common border styles available in document applications.
 type BorderStyle int
@@ -542,7 +1135,8 @@ const (
 
 // Border represents the configuration for an entity border.
 type Border struct {
-	Style BorderStyle // The visual style to apply to this border side
+	Style BorderStyle `json:"style,omitempty" yaml:"style,omitempty"` // The visual style to apply to this border side
+	Color string      `json:"color,omitempty" yaml:"color,omitempty"` // Line color (hex, e.g. "#RRGGBB"; empty = black)
 }
 
 func NewBorder(style BorderStyle) *Border {
@@ -551,13 +1145,21 @@ func NewBorder(style BorderStyle) *Border {
 	}
 }
 
+// WithColor sets the line color of the border (hex, e.g. "#RRGGBB").
+func (b *Border) WithColor(color string) *Border {
+	b.Color = color
+	return b
+}
+
 // Borders represents all borders configuration for an entity.
 type Borders struct {
-	Left   *Border  // Left border configuration
-	Right  *Border  // Right border configuration
-	Top    *Border  // Top border configuration
-	Bottom *Border  // Bottom border configuration
-	Inner  *Borders // Inner borders for ranges (used in some contexts)
+	Left         *Border  `json:"left,omitempty" yaml:"left,omitempty"`                 // Left border configuration
+	Right        *Border  `json:"right,omitempty" yaml:"right,omitempty"`               // Right border configuration
+	Top          *Border  `json:"top,omitempty" yaml:"top,omitempty"`                   // Top border configuration
+	Bottom       *Border  `json:"bottom,omitempty" yaml:"bottom,omitempty"`             // Bottom border configuration
+	Inner        *Borders `json:"inner,omitempty" yaml:"inner,omitempty"`               // Inner borders for ranges (used in some contexts)
+	DiagonalUp   *Border  `json:"diagonalUp,omitempty" yaml:"diagonalUp,omitempty"`     // Line from the bottom-left to the top-right corner of every cell
+	DiagonalDown *Border  `json:"diagonalDown,omitempty" yaml:"diagonalDown,omitempty"` // Line from the top-left to the bottom-right corner of every cell
 }
 
 // NewBorders creates a Borders with the individual style per edge.
@@ -586,7 +1188,9 @@ func (bc *Borders) HasBorders() bool {
 	return (bc.Left != nil && bc.Left.Style != BorderStyleNone) ||
 		(bc.Right != nil && bc.Right.Style != BorderStyleNone) ||
 		(bc.Top != nil && bc.Top.Style != BorderStyleNone) ||
-		(bc.Bottom != nil && bc.Bottom.Style != BorderStyleNone)
+		(bc.Bottom != nil && bc.Bottom.Style != BorderStyleNone) ||
+		(bc.DiagonalUp != nil && bc.DiagonalUp.Style != BorderStyleNone) ||
+		(bc.DiagonalDown != nil && bc.DiagonalDown.Style != BorderStyleNone)
 }
 
 // SetBoundaries sets all borders (left, right, top, bottom) to the same style.
@@ -650,6 +1254,26 @@ func (bc *Borders) SetBottom(style BorderStyle) *Borders {
 	return bc
 }
 
+// SetDiagonalUp sets the style of the line from the bottom-left to the top-right corner of cells.
+func (bc *Borders) SetDiagonalUp(style BorderStyle) *Borders {
+	if bc.DiagonalUp == nil {
+		bc.DiagonalUp = NewBorder(style)
+	} else {
+		bc.DiagonalUp.Style = style
+	}
+	return bc
+}
+
+// SetDiagonalDown sets the style of the line from the top-left to the bottom-right corner of cells.
+func (bc *Borders) SetDiagonalDown(style BorderStyle) *Borders {
+	if bc.DiagonalDown == nil {
+		bc.DiagonalDown = NewBorder(style)
+	} else {
+		bc.DiagonalDown.Style = style
+	}
+	return bc
+}
+
 // SetInner creates inner border configuration with the same style for all edges.
 // Note: When applied to a table column, this will only be applied if the column has no sub-columns.
 func (bc *Borders) SetInner(style BorderStyle) *Borders {
@@ -665,15 +1289,29 @@ func (bc *Borders) SetInner(style BorderStyle) *Borders {
 
 // Style represents comprehensive styling configuration.
 type Style struct {
-	Bold            bool      // Whether text should be bold
-	Italic          bool      // Whether text should be italic
-	Underline       string    // Underline style (format-specific values)
-	TextColor       string    // Text color (usually hex format: "#RRGGBB")
-	BackgroundColor string    // Background color (usually hex format: "#RRGGBB")
-	FontSize        float64   // Font size in points
-	FontFamily      string    // Font family name (e.g., "Arial", "Times New Roman")
-	Alignment       Alignment // Text alignment
-	NumFmt          string    // Excel number-format string (e.g. "#,##0.00 €"). Keeps values numeric while controlling display.
+	Bold            bool      `json:"bold,omitempty" yaml:"bold,omitempty"`                       // Whether text should be bold
+	Italic          bool      `json:"italic,omitempty" yaml:"italic,omitempty"`                   // Whether text should be italic
+	Underline       string    `json:"underline,omitempty" yaml:"underline,omitempty"`             // Underline style (format-specific values)
+	TextColor       string    `json:"textColor,omitempty" yaml:"textColor,omitempty"`             // Text color (usually hex format: "#RRGGBB")
+	BackgroundColor string    `json:"backgroundColor,omitempty" yaml:"backgroundColor,omitempty"` // Background color (usually hex format: "#RRGGBB")
+	FontSize        float64   `json:"fontSize,omitempty" yaml:"fontSize,omitempty"`               // Font size in points
+	FontFamily      string    `json:"fontFamily,omitempty" yaml:"fontFamily,omitempty"`           // Font family name (e.g., "Arial", "Times New Roman")
+	Alignment       Alignment `json:"alignment,omitempty" yaml:"alignment,omitempty"`             // Text alignment
+	WrapText        bool      `json:"wrapText,omitempty" yaml:"wrapText,omitempty"`               // Whether long text wraps onto several lines within the cell instead of overflowing (XLSX, HTML, Google Sheets)
+	ShrinkToFit     bool      `json:"shrinkToFit,omitempty" yaml:"shrinkToFit,omitempty"`         // Whether the font shrinks so the text fits the cell width (XLSX)
+	TextRotation    int       `json:"textRotation,omitempty" yaml:"textRotation,omitempty"`       // Text rotation in degrees, counterclockwise from -90 to 90 (XLSX, Google Sheets)
+	NumFmt          string    `json:"numFmt,omitempty" yaml:"numFmt,omitempty"`                   // Excel number-format string (e.g. "#,##0.00 €"). Keeps values numeric while controlling display.
+	Locked          *bool     `json:"locked,omitempty" yaml:"locked,omitempty"`                   // Whether the cell is locked on protected sheets (nil keeps the default: locked) (XLSX)
+	// TextDirection is the reading order of the text, e.g. TextDirectionRTL for Arabic or Hebrew text (XLSX, HTML, Google Sheets)
+	TextDirection TextDirection `json:"textDirection,omitempty" yaml:"textDirection,omitempty"`
+	// IndentLevel indents the text by this many levels, e.g. the child rows of grouped data (XLSX, HTML)
+	IndentLevel int `json:"indentLevel,omitempty" yaml:"indentLevel,omitempty"`
+	// HiddenFormula hides the formula of the cell on protected sheets, showing only its result (XLSX)
+	HiddenFormula bool `json:"hiddenFormula,omitempty" yaml:"hiddenFormula,omitempty"`
+	// Strikethrough draws a line through the text, e.g. for deprecated records (XLSX, HTML, Google Sheets)
+	Strikethrough bool `json:"strikethrough,omitempty" yaml:"strikethrough,omitempty"`
+	// VertAlign raises or lowers text cells as superscript or subscript (XLSX, HTML)
+	VertAlign VertAlign `json:"vertAlign,omitempty" yaml:"vertAlign,omitempty"`
 }
 
 // Alignment represents the alignment options for content.
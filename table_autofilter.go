@@ -0,0 +1,56 @@
+// table_autofilter.go - XLSX auto-filters.
+//
+// This file implements the optional auto-filter written over the table in XLSX exports: filter
+// buttons are placed on the bottom header row (the leaf labels of multi-level headers) and the
+// filter covers the data rows below it. Footer rows are kept out of the filter range.
+
+package spit
+
+// WithAutoFilter enables or disables an auto-filter over the header and data rows in XLSX exports.
+// Tables without a header get no filter. A sheet holds a single auto-filter, so only one table of
+// a SheetLayout should enable it.
+func (t *Table) WithAutoFilter(enabled bool) *Table {
+	t.AutoFilter = enabled
+	return t
+}
+
+// autoFilterSetter is implemented by spreadsheets supporting auto-filters.
+type autoFilterSetter interface {
+	SetAutoFilter(rangeRef string) error
+}
+
+// writeAutoFilter applies the auto-filter over the bottom header row and the data rows of the
+// written sheet. A rejected range becomes a warning, which only fails the export in ErrorStrict
+// mode (see Table.WithErrorMode).
+func (xlsx *xlsx) writeAutoFilter(result SheetResult) {
+	if !xlsx.table.AutoFilter {
+		return
+	}
+	if xlsx.table.ExcelTable != nil {
+		L().Debug("Excel tables carry their own filter, auto-filter skipped")
+		return
+	}
+	if xlsx.table.Transposed {
+		xlsx.table.warn(WarningPhaseSheet, "", "Auto-filters are not supported by transposed tables, skipped", nil, String("sheet", result.Name))
+		return
+	}
+	if result.HeaderRange.IsEmpty() {
+		xlsx.table.warn(WarningPhaseSheet, "", "Auto-filter requires a header row, skipped", nil, String("sheet", result.Name))
+		return
+	}
+	setter, ok := xlsx.spreadsheet.(autoFilterSetter)
+	if !ok {
+		xlsx.table.warn(WarningPhaseSheet, "", "Spreadsheet does not support auto-filters, skipped", nil)
+		return
+	}
+
+	// Filter buttons go on the leaf labels of multi-level headers
+	filter := result.HeaderRange
+	filter.StartRow = filter.EndRow
+	if !result.DataRange.IsEmpty() {
+		filter.EndRow = result.DataRange.EndRow
+	}
+	if err := setter.SetAutoFilter(filter.String()); err != nil {
+		xlsx.table.warn(WarningPhaseSheet, "", "Failed to apply auto-filter", err, String("range", filter.String()))
+	}
+}
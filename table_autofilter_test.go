@@ -0,0 +1,42 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_autoFilter(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"name": "Alice", "q1": 10, "q2": 20},
+		{"name": "Bob", "q1": 30, "q2": 40},
+	}, Columns{
+		NewColumn("name", "Name"),
+		NewColumn("sales", "Sales").WithSubColumns(Columns{NewColumn("q1", "Q1"), NewColumn("q2", "Q2")}),
+	}, true).
+		WithPreamble(PreambleRows{NewPreambleRow("Report")}).
+		WithStartPosition(2, 3).
+		WithFooter(nil).
+		WithAutoFilter(true)
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "filtered", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Preamble on row 3, headers on rows 4-5, data on rows 6-7, footer on row 8
+	var refersTo string
+	for _, name := range f.GetDefinedName() {
+		if name.Name == "_xlnm._FilterDatabase" {
+			refersTo = name.RefersTo
+		}
+	}
+	if want := "'Sheet1'!$B$5:$D$7"; refersTo != want {
+		t.Errorf("auto-filter range = %q, want %q", refersTo, want)
+	}
+}
@@ -0,0 +1,246 @@
+// table_chart.go - Charts in XLSX exports.
+//
+// This file implements the optional charts added to XLSX exports (see Table.WithChart): series are
+// given as column names and an optional range of data rows, and resolved against the written
+// layout, so the chart references the exported cells whatever the table position or row count.
+
+package spit
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ChartType defines the kind of a chart.
+type ChartType int
+
+const (
+	// ChartColumn draws vertical bars (default).
+	ChartColumn ChartType = iota
+
+	// ChartBar draws horizontal bars.
+	ChartBar
+
+	// ChartLine draws a line per series, e.g. a trend over time.
+	ChartLine
+
+	// ChartArea draws filled lines.
+	ChartArea
+
+	// ChartPie draws the first series as a pie.
+	ChartPie
+)
+
+// Default dimensions of charts, in pixels, and the rows between stacked default anchors.
+const (
+	DefaultChartWidth  = 480
+	DefaultChartHeight = 290
+	chartRowSpan       = 16
+)
+
+// Chart configures a chart referencing the written table (see Table.WithChart).
+type Chart struct {
+	Type     ChartType // Kind of chart (default: ChartColumn)
+	Title    string    // Optional chart title
+	Category string    // Column name of the category (X axis) labels (empty = no labels)
+	Series   []string  // Column names of the plotted values, one series per column, named after the column header
+	FirstRow int       // 1-based first data row plotted (0 = first data row)
+	LastRow  int       // 1-based last data row plotted (0 = last data row)
+	Sheet    string    // Sheet receiving the chart, created when missing (default: the data sheet)
+	Anchor   string    // Top-left cell of the chart (default: right of the table, charts stacked downwards)
+	Width    uint      // Width in pixels (default: DefaultChartWidth)
+	Height   uint      // Height in pixels (default: DefaultChartHeight)
+}
+
+// NewChart creates a chart of the given type plotting the series columns against the category column.
+func NewChart(chartType ChartType, category string, series ...string) *Chart {
+	return &Chart{Type: chartType, Category: category, Series: series}
+}
+
+// WithTitle sets the chart title.
+func (c *Chart) WithTitle(title string) *Chart {
+	c.Title = title
+	return c
+}
+
+// WithRows restricts the chart to the 1-based data rows first to last (0 keeps the first or last
+// data row), e.g. the last weeks of a report.
+func (c *Chart) WithRows(first, last int) *Chart {
+	c.FirstRow = first
+	c.LastRow = last
+	return c
+}
+
+// WithAnchor places the chart at the given cell of the given sheet (empty keeps the data sheet).
+func (c *Chart) WithAnchor(sheet, cell string) *Chart {
+	c.Sheet = sheet
+	c.Anchor = cell
+	return c
+}
+
+// WithSize sets the chart dimensions in pixels.
+func (c *Chart) WithSize(width, height uint) *Chart {
+	c.Width = width
+	c.Height = height
+	return c
+}
+
+// WithChart adds a chart referencing the written table to XLSX exports. Charts are added in order;
+// those without anchor are placed right of the table, one below the other.
+func (t *Table) WithChart(chart *Chart) *Table {
+	t.Charts = append(t.Charts, chart)
+	return t
+}
+
+// ChartSpec describes a chart to add to a workbook, with resolved references.
+type ChartSpec struct {
+	Type   ChartType
+	Title  string
+	Sheet  string            // Sheet receiving the chart
+	Cell   string            // Top-left cell of the chart
+	Width  uint              // Width in pixels
+	Height uint              // Height in pixels
+	Series []ChartSeriesSpec // Plotted series
+}
+
+// ChartSeriesSpec is a chart series as sheet-qualified absolute references (e.g. "Sheet1!$B$2:$B$9").
+type ChartSeriesSpec struct {
+	Name       string // Reference of the cell holding the series name
+	Categories string // Reference of the category labels (empty = none)
+	Values     string // Reference of the values
+}
+
+// chartAdder is implemented by spreadsheets supporting charts.
+type chartAdder interface {
+	AddChart(spec ChartSpec) error
+}
+
+// writeCharts adds the charts of the table to the workbook. A chart excelize rejects is left out
+// with a warning; the cells it would plot are written regardless.
+func (xlsx *xlsx) writeCharts(result SheetResult) {
+	t := xlsx.table
+	if len(t.Charts) == 0 {
+		return
+	}
+	if t.Transposed {
+		t.warn(WarningPhaseSheet, "", "Charts are not supported by transposed tables, charts skipped", nil, String("sheet", result.Name))
+		return
+	}
+	if result.DataRange.IsEmpty() {
+		t.warn(WarningPhaseSheet, "", "Charts require data rows, charts skipped", nil, String("sheet", result.Name))
+		return
+	}
+	adder, ok := xlsx.spreadsheet.(chartAdder)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support charts, charts skipped", nil)
+		return
+	}
+
+	placed := 0
+	for i, chart := range t.Charts {
+		if chart == nil {
+			continue
+		}
+		spec, err := xlsx.chartSpec(chart, result, placed)
+		if err != nil {
+			t.warn(WarningPhaseSheet, "", "Invalid chart, chart skipped", err, Int("chart", i))
+			continue
+		}
+		if chart.Anchor == "" {
+			placed++
+		}
+		if err := adder.AddChart(spec); err != nil {
+			t.warn(WarningPhaseSheet, "", "Failed to add chart", err, Int("chart", i), String("cell", spec.Cell))
+		}
+	}
+}
+
+// chartSpec resolves the references of a chart against the written layout; placed is the number
+// of charts already placed at a default anchor.
+func (xlsx *xlsx) chartSpec(chart *Chart, result SheetResult, placed int) (ChartSpec, error) {
+	if len(chart.Series) == 0 {
+		return ChartSpec{}, fmt.Errorf("chart has no series")
+	}
+
+	// Locate the leaf columns and the header row holding their labels
+	type leaf struct{ col, headerRow int }
+	leaves := make(map[string]leaf)
+	var locate func(columns Columns, level int)
+	locate = func(columns Columns, level int) {
+		for _, column := range columns {
+			if column.HasSubColumns() {
+				locate(column.Columns, level+1)
+				continue
+			}
+			leaves[column.Name] = leaf{col: result.DataRange.StartCol + len(leaves), headerRow: result.HeaderRange.StartRow + level}
+		}
+	}
+	locate(xlsx.table.Columns, 0)
+
+	first, last := result.DataRange.StartRow, result.DataRange.EndRow
+	if chart.FirstRow > 0 {
+		first = result.DataRange.StartRow + chart.FirstRow - 1
+	}
+	if chart.LastRow > 0 {
+		last = result.DataRange.StartRow + chart.LastRow - 1
+	}
+	if first > last || first < result.DataRange.StartRow || last > result.DataRange.EndRow {
+		return ChartSpec{}, fmt.Errorf("rows %d to %d are outside the %d data rows", chart.FirstRow, chart.LastRow, len(xlsx.table.Data))
+	}
+
+	var categories string
+	if chart.Category != "" {
+		category, ok := leaves[chart.Category]
+		if !ok {
+			return ChartSpec{}, fmt.Errorf("unknown category column %q", chart.Category)
+		}
+		categories = chartRef(result.Name, category.col, first, last)
+	}
+
+	spec := ChartSpec{
+		Type:   chart.Type,
+		Title:  chart.Title,
+		Sheet:  chart.Sheet,
+		Cell:   chart.Anchor,
+		Width:  chart.Width,
+		Height: chart.Height,
+	}
+	for _, name := range chart.Series {
+		series, ok := leaves[name]
+		if !ok {
+			return ChartSpec{}, fmt.Errorf("unknown series column %q", name)
+		}
+		seriesSpec := ChartSeriesSpec{Categories: categories, Values: chartRef(result.Name, series.col, first, last)}
+		if !result.HeaderRange.IsEmpty() {
+			seriesSpec.Name = chartRef(result.Name, series.col, series.headerRow, series.headerRow)
+		}
+		spec.Series = append(spec.Series, seriesSpec)
+	}
+
+	if spec.Sheet == "" {
+		spec.Sheet = result.Name
+	}
+	if spec.Cell == "" {
+		// Right of the table, below the charts already placed there
+		spec.Cell, _ = excelize.CoordinatesToCellName(result.TableRange.EndCol+2, result.TableRange.StartRow+placed*chartRowSpan)
+	}
+	if spec.Width == 0 {
+		spec.Width = DefaultChartWidth
+	}
+	if spec.Height == 0 {
+		spec.Height = DefaultChartHeight
+	}
+	return spec, nil
+}
+
+// chartRef returns the sheet-qualified absolute reference of rows first to last of a column
+// (e.g. "Sheet1!$B$2:$B$9", or "Sheet1!$B$1" for a single cell).
+func chartRef(sheet string, col, first, last int) string {
+	start, _ := excelize.CoordinatesToCellName(col, first, true)
+	if first == last {
+		return quoteSheetName(sheet) + "!" + start
+	}
+	end, _ := excelize.CoordinatesToCellName(col, last, true)
+	return quoteSheetName(sheet) + "!" + start + ":" + end
+}
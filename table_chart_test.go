@@ -0,0 +1,96 @@
+package spit
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readZipEntry returns the content of a file of an XLSX package.
+func readZipEntry(t *testing.T, path, name string) string {
+	t.Helper()
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", name, err)
+		}
+		defer rc.Close()
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("ReadAll(%s) error = %v", name, err)
+		}
+		return string(content)
+	}
+	return ""
+}
+
+func TestExportXLSX_charts(t *testing.T) {
+	dir := t.TempDir()
+	table := fixtureTable("name", "sales", "score").
+		WithChart(NewChart(ChartLine, "name", "sales", "score").WithTitle("Sales by seller")).
+		WithChart(NewChart(ChartPie, "name", "sales").WithRows(3, 0).WithAnchor("Charts", "B2"))
+	table.StartRow, table.StartCol = 2, 2
+
+	result, err := ExportXLSX(NewSpreadsheetExcelize("Sales", table), FileWriteParams{Filename: "charts", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	if len(result.Warnings) > 0 {
+		t.Errorf("unexpected warnings %v", result.Warnings)
+	}
+
+	path := filepath.Join(dir, "charts.xlsx")
+	line := readZipEntry(t, path, "xl/charts/chart1.xml")
+	for _, want := range []string{"Sales by seller", "Sales!$B$3:$B$7", "Sales!$C$3:$C$7", "Sales!$D$3:$D$7", "Sales!$C$2", "<lineChart>"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("line chart should contain %q", want)
+		}
+	}
+	pie := readZipEntry(t, path, "xl/charts/chart2.xml")
+	for _, want := range []string{"Sales!$B$5:$B$7", "Sales!$C$5:$C$7", "<pieChart>"} {
+		if !strings.Contains(pie, want) {
+			t.Errorf("pie chart should contain %q", want)
+		}
+	}
+	// The line chart sits right of the table, the pie chart on its own sheet
+	if drawing := readZipEntry(t, path, "xl/drawings/drawing1.xml"); !strings.Contains(drawing, "<xdr:col>5</xdr:col>") {
+		t.Errorf("line chart should be anchored two columns right of the table, got %s", drawing)
+	}
+	if readZipEntry(t, path, "xl/worksheets/sheet2.xml") == "" {
+		t.Error("the pie chart sheet should be created")
+	}
+}
+
+func TestExportXLSX_chartWarnings(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart *Chart
+	}{
+		{"unknown series", NewChart(ChartColumn, "name", "missing")},
+		{"unknown category", NewChart(ChartColumn, "missing", "sales")},
+		{"rows out of range", NewChart(ChartColumn, "name", "sales").WithRows(2, 9)},
+		{"no series", NewChart(ChartColumn, "name")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := fixtureTable("name", "sales", "score").WithChart(tt.chart)
+			result, err := ExportXLSX(NewSpreadsheetExcelize("Sales", table), FileWriteParams{Filename: "charts", Filepath: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ExportXLSX() error = %v", err)
+			}
+			if len(result.Warnings) != 1 {
+				t.Errorf("expected one warning, got %v", result.Warnings)
+			}
+		})
+	}
+}
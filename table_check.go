@@ -0,0 +1,197 @@
+// table_check.go - Configuration checks.
+//
+// This file implements the dry run of a table configuration (see Table.Validate): the columns,
+// row and cell options, merge rules, colors, expressions and styles are checked against each other
+// and against the data, without writing anything, so report definitions can be rejected when they
+// are loaded rather than producing a surprising file. Data validations are checked by
+// Table.ValidateData.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigIssueKind identifies the kind of problem found in a table configuration.
+type ConfigIssueKind string
+
+const (
+	ConfigIssueMissingKey      ConfigIssueKind = "missing-key"      // Column whose keys no data row holds
+	ConfigIssueDuplicateColumn ConfigIssueKind = "duplicate-column" // Leaf columns sharing a name
+	ConfigIssueRowIndex        ConfigIssueKind = "row-index"        // Row or cell options beyond the data rows
+	ConfigIssueColumnIndex     ConfigIssueKind = "column-index"     // Cell options beyond the leaf columns
+	ConfigIssueMerge           ConfigIssueKind = "merge"            // Merge rules that can never apply as configured
+	ConfigIssueColor           ConfigIssueKind = "color"            // Colors that are not "#RRGGBB" or "#RGB" hex colors
+	ConfigIssueExpr            ConfigIssueKind = "expression"       // Expressions that do not parse (see CheckExprs)
+	ConfigIssueStyle           ConfigIssueKind = "style"            // Style lint warnings (see Table.LintStyles)
+)
+
+// ConfigIssue is a problem found in a table configuration (see Table.Validate).
+type ConfigIssue struct {
+	Kind    ConfigIssueKind // Kind of problem
+	Path    string          // Configured element, e.g. `column "amount"` or "row options 12"; the lint code of style issues
+	Message string          // Description of the problem
+}
+
+// Error returns the issue as a single line, e.g. `column "amount": no data row holds the key`.
+func (i ConfigIssue) Error() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// Validate checks the configuration of the table without exporting it and returns the problems
+// found, in configuration order: columns whose keys no data row holds, leaf columns sharing a
+// name, row and cell options beyond the data rows or columns, merge rules referencing unknown
+// conditions or columns, invalid colors and expressions that do not parse, then the style lint
+// warnings (see LintStyles). Indices refer to the data rows of the table before filters and
+// sorting. Data validations are checked by ValidateData.
+func (t *Table) Validate() []ConfigIssue {
+	var issues []ConfigIssue
+	add := func(kind ConfigIssueKind, path, message string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Kind: kind, Path: path, Message: fmt.Sprintf(message, args...)})
+	}
+
+	flatColumns := t.Columns.GetFlattenedColumns()
+	names := make(map[string]bool, len(flatColumns))
+	for _, column := range flatColumns {
+		path := fmt.Sprintf("column %q", column.Name)
+		if names[column.Name] {
+			add(ConfigIssueDuplicateColumn, path, "another column has the same name")
+		}
+		names[column.Name] = true
+		if len(t.Data) > 0 && column.ValueExpr == "" && !t.holdsColumn(column) {
+			add(ConfigIssueMissingKey, path, "no data row holds %s", strings.Join(column.LookupKeys(), " or "))
+		}
+		if column.Merge != nil {
+			t.checkMerge(column.Merge, path+" merge", add)
+		}
+		checkStyleColors(column.Style, path+" style", add)
+		checkBorderColors(column.Borders, path+" borders", add)
+		for _, rule := range column.StyleRules {
+			checkStyleColors(rule.Style, fmt.Sprintf("%s style rule %q", path, rule.When), add)
+		}
+		for _, err := range columnExprErrors(column) {
+			add(ConfigIssueExpr, path, "%v", err)
+		}
+	}
+
+	if t.HeaderOptions != nil {
+		checkStyleColors(t.HeaderOptions.Style, "header style", add)
+		checkBorderColors(t.HeaderOptions.Borders, "header borders", add)
+	}
+	if t.FooterOptions != nil {
+		checkStyleColors(t.FooterOptions.Style, "footer style", add)
+	}
+	if t.Zebra != nil {
+		checkStyleColors(t.Zebra.Even, "zebra even style", add)
+		checkStyleColors(t.Zebra.Odd, "zebra odd style", add)
+	}
+
+	for _, row := range sortedKeys(t.RowOptionsMap) {
+		path := fmt.Sprintf("row options %d", row)
+		if row < 0 || row >= len(t.Data) {
+			add(ConfigIssueRowIndex, path, "the table has %d data rows", len(t.Data))
+		}
+		options := t.RowOptionsMap[row]
+		if options.Merge != nil {
+			t.checkMerge(options.Merge, path+" merge", add)
+		}
+		checkStyleColors(options.Style, path+" style", add)
+		checkBorderColors(options.Border, path+" borders", add)
+	}
+
+	for _, col := range sortedKeys(t.CellOptionsMap) {
+		for _, row := range sortedKeys(t.CellOptionsMap[col]) {
+			path := fmt.Sprintf("cell options (%d, %d)", col, row)
+			if col < 1 || col > len(flatColumns) {
+				add(ConfigIssueColumnIndex, path, "the table has %d columns (cell columns are 1-based)", len(flatColumns))
+			}
+			if row < 0 || row >= len(t.Data) {
+				add(ConfigIssueRowIndex, path, "the table has %d data rows", len(t.Data))
+			}
+			checkStyleColors(t.CellOptionsMap[col][row].Style, path+" style", add)
+			checkBorderColors(t.CellOptionsMap[col][row].Border, path+" borders", add)
+		}
+	}
+
+	for _, warning := range t.LintStyles() {
+		add(ConfigIssueStyle, string(warning.Code), "%s", warning.Message)
+	}
+	return issues
+}
+
+// holdsColumn reports whether a data row of the table holds a key of the column.
+func (t *Table) holdsColumn(column *Column) bool {
+	for _, item := range t.Data {
+		if _, err, found := item.LookupColumn(column); err == nil && found {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMerge reports the merge conditions and key column of merge rules that can never apply.
+func (t *Table) checkMerge(rules *MergeRules, path string, add func(ConfigIssueKind, string, string, ...interface{})) {
+	for _, conditions := range []MergeConditions{rules.Vertical, rules.Horizontal} {
+		for _, condition := range conditions {
+			name, custom := strings.CutPrefix(string(condition), customMergePrefix)
+			switch {
+			case condition == MergeConditionIdentical || condition == MergeConditionEmpty:
+			case !custom:
+				add(ConfigIssueMerge, path, "unknown merge condition %q", condition)
+			default:
+				if _, ok := LookupMergePredicate(name); !ok {
+					add(ConfigIssueMerge, path, "merge predicate %q is not registered", name)
+				}
+			}
+		}
+	}
+	// Key columns may name a data field the table does not export
+	if key := rules.VerticalKeyColumn; key != "" && len(t.Data) > 0 && !t.holdsColumn(t.columnByName(key)) {
+		add(ConfigIssueMerge, path, "key column %q is neither a column nor a data field", key)
+	}
+}
+
+// checkStyleColors reports the colors of a style that are not hex colors.
+func checkStyleColors(style *Style, path string, add func(ConfigIssueKind, string, string, ...interface{})) {
+	if style == nil {
+		return
+	}
+	for _, color := range []struct{ field, value string }{
+		{"text color", style.TextColor},
+		{"background color", style.BackgroundColor},
+	} {
+		if color.value == "" {
+			continue
+		}
+		if _, _, _, ok := parseHexColor(color.value); !ok {
+			add(ConfigIssueColor, path, "invalid %s %q", color.field, color.value)
+		}
+	}
+}
+
+// checkBorderColors reports the line colors of borders that are not hex colors.
+func checkBorderColors(borders *Borders, path string, add func(ConfigIssueKind, string, string, ...interface{})) {
+	if borders == nil {
+		return
+	}
+	for _, side := range []struct {
+		name   string
+		border *Border
+	}{
+		{"left", borders.Left},
+		{"right", borders.Right},
+		{"top", borders.Top},
+		{"bottom", borders.Bottom},
+		{"diagonal up", borders.DiagonalUp},
+		{"diagonal down", borders.DiagonalDown},
+	} {
+		if side.border == nil || side.border.Color == "" {
+			continue
+		}
+		if _, _, _, ok := parseHexColor(side.border.Color); !ok {
+			add(ConfigIssueColor, path, "invalid %s border color %q", side.name, side.border.Color)
+		}
+	}
+	checkBorderColors(borders.Inner, path+" inner", add)
+}
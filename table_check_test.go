@@ -0,0 +1,52 @@
+package spit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Validate(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"name": "alice", "amount": 10},
+		{"name": "bob", "amount": 5},
+	}, Columns{
+		NewColumn("name", "Name").WithStyle(&Style{TextColor: "red"}),
+		NewColumn("amount", "Amount").WithMerge(&MergeRules{
+			Vertical:          MergeConditions{MergeConditionIdentical, "same", MergeConditionCustom("unregistered")},
+			VerticalKeyColumn: "team",
+		}),
+		NewColumn("amount", "Amount again").WithBorders(&Borders{Bottom: &Border{Style: BorderStyleThin, Color: "blue"}}),
+		NewColumn("price", "Price").WithKeys("cost").WithStyle(&Style{FontSize: 6}),
+		NewColumn("total", "Total").WithValueExpr("amount *"),
+	}, true).
+		WithHeaderOptions(&HeaderOptions{Style: &Style{BackgroundColor: "#12345G"}}).
+		WithRowOptions(RowOptionsMap{1: {Style: &Style{BackgroundColor: "#EEE"}}, 2: {}}).
+		WithCellOptions(CellOptionsMap{6: {0: {}}, 1: {-1: {}}})
+
+	var got []string
+	for _, issue := range table.Validate() {
+		got = append(got, string(issue.Kind)+" "+issue.Error())
+	}
+	want := []string{
+		`color column "name" style: invalid text color "red"`,
+		`merge column "amount" merge: unknown merge condition "same"`,
+		`merge column "amount" merge: merge predicate "unregistered" is not registered`,
+		`merge column "amount" merge: key column "team" is neither a column nor a data field`,
+		`duplicate-column column "amount": another column has the same name`,
+		`color column "amount" borders: invalid bottom border color "blue"`,
+		`missing-key column "price": no data row holds price or cost`,
+		`expression column "total": expression "amount *": unexpected end of expression at offset 8`,
+		`color header style: invalid background color "#12345G"`,
+		`row-index row options 2: the table has 2 data rows`,
+		`row-index cell options (1, -1): the table has 2 data rows`,
+		`column-index cell options (6, 0): the table has 5 columns (cell columns are 1-based)`,
+		`style tiny-font: column "price" uses font size 6 (minimum readable size is 8)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() =\n%q\nwant\n%q", got, want)
+	}
+
+	if issues := fixtureTable("name", "team", "score").Validate(); len(issues) != 0 {
+		t.Errorf("Validate() = %v, want no issues", issues)
+	}
+}
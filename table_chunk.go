@@ -0,0 +1,192 @@
+// table_chunk.go - Chunked exports.
+//
+// This file implements the chunk size of a table (see Table.WithChunkSize). Exports of tables
+// holding more data rows than the chunk size are split into parts of consecutive rows, taken after
+// filters, sorting and the row limit. Each part repeats the headers and computes its footer over
+// its own rows. Parts are written to separate files named after the export (report_part1.xlsx,
+// report_part2.xlsx, ...) or, for XLSX exports in ChunkSheets mode, to separate sheets of the same
+// workbook. XLSX exports of tables exceeding the sheet size fail instead of being cut short.
+
+package spit
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ChunkMode defines where the parts of a chunked XLSX export are written.
+type ChunkMode int
+
+const (
+	// ChunkFiles writes each part to its own file, named after the export with a "_part<n>" suffix (default).
+	ChunkFiles ChunkMode = iota
+
+	// ChunkSheets writes each part to its own sheet of the workbook, named after the sheet with a
+	// " (<n>)" suffix. Other formats write separate files.
+	ChunkSheets
+)
+
+// WithChunkSize sets the maximum number of data rows per exported part (0 = no chunking). Tables
+// holding more rows are exported as several parts, each repeating the headers (see ChunkMode).
+// Preview exports are never chunked.
+func (t *Table) WithChunkSize(size int) *Table {
+	t.ChunkSize = size
+	return t
+}
+
+// WithChunkMode sets whether the parts of XLSX exports are written to separate files or sheets.
+func (t *Table) WithChunkMode(mode ChunkMode) *Table {
+	t.ChunkMode = mode
+	return t
+}
+
+// chunks returns the parts of the table, each holding at most ChunkSize consecutive data rows, or
+// nil when the table is exported in one piece. Rows are selected once (see selectRows); parts
+// keep the row and cell options of their rows, and only the last part reports the rows left out by
+// Limit.
+func (t *Table) chunks() []*Table {
+	if t.ChunkSize <= 0 || t.Preview != nil {
+		return nil
+	}
+	selected := t.selectRows()
+	if len(selected.Data) <= t.ChunkSize {
+		return nil
+	}
+
+	var parts []*Table
+	for start := 0; start < len(selected.Data); start += t.ChunkSize {
+		rows := make([]int, min(t.ChunkSize, len(selected.Data)-start))
+		for i := range rows {
+			rows[i] = start + i
+		}
+		part := selected.withRows(rows)
+		part.Filters, part.Sort, part.Limit, part.ChunkSize = nil, nil, 0, 0
+		part.prepared = nil
+		part.truncated = 0
+		parts = append(parts, part)
+	}
+	parts[len(parts)-1].truncated = selected.truncated
+	return parts
+}
+
+// partFilename returns the filename of the given 1-based part of an export (e.g. "report_part2").
+func partFilename(filename string, part int) string {
+	return filename + "_part" + strconv.Itoa(part)
+}
+
+// partSheetName returns the sheet name of the given 1-based part of a table (e.g. "Orders (2)"),
+// shortening the base name so the suffix fits Excel's 31-character limit.
+func partSheetName(sheetName string, part int) string {
+	suffix := " (" + strconv.Itoa(part) + ")"
+	base := []rune(sheetName)
+	if limit := maxSheetNameLength - len(suffix); len(base) > limit {
+		base = base[:limit]
+	}
+	return string(base) + suffix
+}
+
+// exportParts exports each part of a chunked table to its own file through export, and returns
+// the result of the first part listing every part. Parts share the seed, limits, progress and
+// timings of the run.
+func exportParts(parts []*Table, params FileWriteParams, export func(part *Table, params FileWriteParams) (*FileWriteResult, error)) (*FileWriteResult, error) {
+	if params.Writer != nil || params.entry {
+		return nil, fmt.Errorf("chunked exports write one file per part and cannot be written to a single writer")
+	}
+
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+
+	results := make([]FileWriteResult, 0, len(parts))
+	for i, part := range parts {
+		partParams := params
+		partParams.Filename = partFilename(params.Filename, i+1)
+		res, err := export(part, partParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export part %d: %w", i+1, err)
+		}
+		results = append(results, *res)
+	}
+
+	result := results[0]
+	result.Sheets, result.Warnings, result.SparseColumns = nil, nil, nil
+	for _, res := range results {
+		result.Sheets = append(result.Sheets, res.Sheets...)
+		result.Warnings = append(result.Warnings, res.Warnings...)
+		result.SparseColumns = append(result.SparseColumns, res.SparseColumns...)
+	}
+	result.Parts = results
+	result.Usage = results[len(results)-1].Usage
+	result.Timings = params.timings.snapshot()
+	return &result, nil
+}
+
+// chunkSheets replaces the sheets whose table is chunked in ChunkSheets mode by one sheet per
+// part. The returned parts are set when the single sheet of the export is chunked in ChunkFiles
+// mode, in which case the sheets are left as they are. Only Excelize sheets are chunked.
+func chunkSheets(sheets []Spreadsheet) ([]Spreadsheet, []*Table, error) {
+	var expanded []Spreadsheet
+	for _, sheet := range sheets {
+		e, ok := sheet.(*SpreadsheetExcelize)
+		if !ok || e.Table == nil || e.GetTable() == nil {
+			expanded = append(expanded, sheet)
+			continue
+		}
+		t := e.GetTable()
+		parts := t.chunks()
+		if parts == nil {
+			expanded = append(expanded, sheet)
+			continue
+		}
+		if t.ChunkMode == ChunkFiles {
+			if len(sheets) > 1 {
+				return nil, nil, fmt.Errorf("sheet %s: chunking into files requires a single sheet per export, use ChunkSheets", e.SheetName)
+			}
+			if e.File != nil {
+				return nil, nil, fmt.Errorf("sheet %s: chunking into files creates one workbook per part and cannot reuse an existing file, use ChunkSheets", e.SheetName)
+			}
+			return sheets, parts, nil
+		}
+		for i, part := range parts {
+			expanded = append(expanded, e.forPart(partSheetName(e.sheetName(), i+1), part))
+		}
+	}
+	return expanded, nil, nil
+}
+
+// forPart returns a copy of the spreadsheet writing the given part of its table to another sheet.
+func (e *SpreadsheetExcelize) forPart(sheetName string, part *Table) *SpreadsheetExcelize {
+	c := *e
+	c.SheetName = sheetName
+	c.Table = NewTableExcelize(sheetName, part)
+	if e.File != nil {
+		c.Table.WithFile(e.File)
+	}
+	return &c
+}
+
+// sheetName returns the sheet name, defaulting to "Sheet1" as written by ExportXLSXSheets.
+func (e *SpreadsheetExcelize) sheetName() string {
+	if e.SheetName == "" {
+		return "Sheet1"
+	}
+	return e.SheetName
+}
+
+// checkSheetBounds fails when the prepared table does not fit in a sheet (excelize.TotalRows rows
+// and excelize.MaxColumns columns), which would otherwise produce a truncated or corrupt workbook.
+func (t *Table) checkSheetBounds() error {
+	cols, rows := t.sheetSize()
+	lastCol, lastRow := t.sheetCoords(1, 1)
+	lastCol, lastRow = lastCol+cols-1, lastRow+rows-1
+	if lastRow > excelize.TotalRows {
+		return fmt.Errorf("table ends on sheet row %d, beyond the limit of %d rows: split it with Table.WithChunkSize", lastRow, excelize.TotalRows)
+	}
+	if lastCol > excelize.MaxColumns {
+		return fmt.Errorf("table ends on sheet column %d, beyond the limit of %d columns", lastCol, excelize.MaxColumns)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package spit
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_chunks(t *testing.T) {
+	table := fixtureTable("name", "team", "score").WithSort(SortKey{Field: "name"}).WithLimit(4).WithChunkSize(3)
+
+	parts := table.chunks()
+	if len(parts) != 2 {
+		t.Fatalf("chunks() returned %d parts, want 2", len(parts))
+	}
+	if got, want := sortedNames(parts[0].Prepare()), []string{"alice", "bob", "carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("part 1 rows = %v, want %v", got, want)
+	}
+	last := parts[1].Prepare()
+	if got := sortedNames(last); !reflect.DeepEqual(got, []string{"dave"}) {
+		t.Errorf("part 2 rows = %v, want [dave]", got)
+	}
+	if last.SourceRowIndex(0) != 3 || last.Truncated() != 1 || parts[0].Truncated() != 0 {
+		t.Errorf("SourceRowIndex(0) = %d, truncated = %d/%d; want 3 and the truncation on the last part only",
+			last.SourceRowIndex(0), parts[0].Truncated(), last.Truncated())
+	}
+
+	for _, table := range []*Table{fixtureTable("name", "team", "score"), fixtureTable("name", "team", "score").WithChunkSize(5), fixtureTable("name", "team", "score").WithChunkSize(2).WithPreview(NewPreviewOptions())} {
+		if parts := table.chunks(); parts != nil {
+			t.Errorf("chunks() = %d parts, want none", len(parts))
+		}
+	}
+}
+
+func TestExport_chunked(t *testing.T) {
+	table := func(mode ChunkMode) *Table {
+		table := fixtureTable("name", "team", "score").WithChunkSize(2).WithChunkMode(mode).WithFooter(nil)
+		table.Columns[2].WithAggregate(AggregateSum)
+		return table
+	}
+
+	t.Run("csv files", func(t *testing.T) {
+		dir := t.TempDir()
+		res, err := ExportCSV(",", table(ChunkFiles), FileWriteParams{Filename: "report", Filepath: dir})
+		if err != nil {
+			t.Fatalf("ExportCSV() error = %v", err)
+		}
+		if res.Filename != "report_part1.csv" || len(res.Parts) != 3 {
+			t.Fatalf("result = %s with %d parts, want report_part1.csv with 3 parts", res.Filename, len(res.Parts))
+		}
+		want := []string{
+			"Name,Team,Score\ncarol,b,7\nalice,a,10\nTotal,,17\n",
+			"Name,Team,Score\nbob,b,10\ndave,a\nTotal,,10\n",
+			"Name,Team,Score\nerin,a,3\nTotal,,3\n",
+		}
+		for i, part := range res.Parts {
+			content, err := os.ReadFile(part.Filepath)
+			if err != nil {
+				t.Fatalf("part %d was not written: %v", i+1, err)
+			}
+			if string(content) != want[i] {
+				t.Errorf("part %d = %q, want %q", i+1, content, want[i])
+			}
+		}
+	})
+
+	t.Run("xlsx files", func(t *testing.T) {
+		res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table(ChunkFiles)), FileWriteParams{Filename: "report", Filepath: t.TempDir()})
+		if err != nil {
+			t.Fatalf("ExportXLSX() error = %v", err)
+		}
+		if len(res.Parts) != 3 || res.Parts[2].Filename != "report_part3.xlsx" || len(res.Sheets) != 3 {
+			t.Fatalf("unexpected result: %+v", res)
+		}
+		f, err := excelize.OpenFile(res.Parts[1].Filepath)
+		if err != nil {
+			t.Fatalf("OpenFile failed: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		rows, _ := f.GetRows("Data")
+		if len(rows) != 4 || rows[0][0] != "Name" || rows[1][0] != "bob" {
+			t.Errorf("part 2 rows = %v, want the headers, bob, dave and the footer", rows)
+		}
+	})
+
+	t.Run("xlsx sheets", func(t *testing.T) {
+		var buf bytes.Buffer
+		res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table(ChunkSheets)), FileWriteParams{Filename: "report", Writer: &buf})
+		if err != nil {
+			t.Fatalf("ExportXLSX() error = %v", err)
+		}
+		if len(res.Parts) != 0 || len(res.Sheets) != 3 || res.Sheets[2].Name != "Data (3)" {
+			t.Fatalf("unexpected result: %+v", res)
+		}
+		f, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatalf("OpenReader failed: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		if got, want := f.GetSheetList(), []string{"Data (1)", "Data (2)", "Data (3)"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("sheets = %v, want %v", got, want)
+		}
+		if got, _ := f.GetCellValue("Data (3)", "A2"); got != "erin" {
+			t.Errorf("Data (3)!A2 = %q, want erin", got)
+		}
+	})
+
+	t.Run("invalid destinations", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := ExportCSV(",", table(ChunkFiles), FileWriteParams{Filename: "report", Writer: &buf}); err == nil {
+			t.Error("chunking into files should fail with a single writer")
+		}
+		sheets := []Spreadsheet{NewSpreadsheetExcelize("Data", table(ChunkFiles)), NewSpreadsheetExcelize("Other", fixtureTable("name", "team", "score"))}
+		if _, err := ExportXLSXSheets(sheets, FileWriteParams{Filename: "report", Filepath: t.TempDir()}); err == nil {
+			t.Error("chunking into files should fail with several sheets")
+		}
+	})
+}
+
+func TestExportXLSX_sheetBounds(t *testing.T) {
+	var buf bytes.Buffer
+	table := fixtureTable("name", "team", "score").WithStartPosition(1, excelize.TotalRows-3)
+	_, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "report", Writer: &buf})
+	if err == nil || !strings.Contains(err.Error(), "WithChunkSize") {
+		t.Errorf("ExportXLSX() error = %v, want the sheet row limit", err)
+	}
+}
+
+func Test_partSheetName(t *testing.T) {
+	if got := partSheetName("Orders", 2); got != "Orders (2)" {
+		t.Errorf("partSheetName() = %q, want Orders (2)", got)
+	}
+	long := strings.Repeat("x", 40)
+	if got := partSheetName(long, 12); got != strings.Repeat("x", 26)+" (12)" {
+		t.Errorf("partSheetName() = %q, want a 31-character name keeping the suffix", got)
+	}
+}
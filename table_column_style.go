@@ -0,0 +1,87 @@
+// table_column_style.go - Whole-column XLSX styles.
+//
+// This file implements column styles (see Column.WithColumnStyle): a style set on the sheet column
+// itself rather than on each cell. Excel applies it to every cell of the column, including the
+// empty cells below the table a user fills in later, and stores it once however many rows the
+// table holds. Column styles are set before any cell is written, so the cells of the table start
+// from the column style and the header, column, row and cell styles are laid over it.
+
+package spit
+
+import "github.com/xuri/excelize/v2"
+
+// WithColumnStyle sets the style of the whole sheet column of this leaf column (XLSX), e.g. a date
+// format applying to the cells added below the table. Unlike WithStyle, the style is stored once
+// for the column, and the header cell starts from it too. Other formats ignore it.
+func (c *Column) WithColumnStyle(style *Style) *Column {
+	c.ColumnStyle = style
+	return c
+}
+
+// columnStyler is implemented by spreadsheets supporting whole-column styles.
+type columnStyler interface {
+	SetColumnStyle(colLetter string, style Style) error
+}
+
+// SetColumnStyle sets the style of a whole column by its letter (e.g. "C"). Existing cells of the
+// column are restyled, and cells written afterwards start from the style.
+func (e *SpreadsheetExcelize) SetColumnStyle(colLetter string, style Style) error {
+	styleID, err := e.Table.newStyle(convertStyleToExcelizeStyle(style))
+	if err != nil {
+		return err
+	}
+	return e.File.SetColStyle(e.SheetName, colLetter, styleID)
+}
+
+// SetColumnStyle sets the style of a whole column by its letter (e.g. "C"). The cells of the
+// column recorded afterwards start from the style, as they would in a sheet written cell by cell.
+func (s *streamSheet) SetColumnStyle(colLetter string, style Style) error {
+	if err := s.SpreadsheetExcelize.SetColumnStyle(colLetter, style); err != nil {
+		return err
+	}
+	col, err := excelize.ColumnNameToNumber(colLetter)
+	if err != nil {
+		return err
+	}
+	styleID, err := s.File.GetColStyle(s.SheetName, colLetter)
+	if err != nil {
+		return err
+	}
+	if s.colStyles == nil {
+		s.colStyles = make(map[int]int32)
+	}
+	s.colStyles[col] = int32(styleID)
+	return nil
+}
+
+// writeColumnStyles sets the column styles of the written sheet. It runs before any cell is
+// written, as setting a column style restyles the cells already in the column. A column whose
+// style cannot be set stays unstyled and is reported as a warning.
+func (xlsx *xlsx) writeColumnStyles() {
+	flatColumns := xlsx.table.Columns.GetFlattenedColumns()
+	styled := false
+	for _, column := range flatColumns {
+		styled = styled || column.ColumnStyle != nil
+	}
+	if !styled {
+		return
+	}
+	if xlsx.table.Transposed {
+		xlsx.table.warn(WarningPhaseSheet, "", "Column styles are not supported by transposed tables, ignored", nil)
+		return
+	}
+	styler, ok := xlsx.spreadsheet.(columnStyler)
+	if !ok {
+		xlsx.table.warn(WarningPhaseSheet, "", "Spreadsheet does not support column styles, ignored", nil)
+		return
+	}
+	for i, column := range flatColumns {
+		if column.ColumnStyle == nil {
+			continue
+		}
+		colLetter := xlsx.cells().GetColumnLetter(i + 1)
+		if err := styler.SetColumnStyle(colLetter, *column.ColumnStyle); err != nil {
+			xlsx.table.warn(WarningPhaseSheet, "", "Failed to set column style", err, String("column", colLetter))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_columnStyle(t *testing.T) {
+	for name, streaming := range map[string]StreamingMode{"cell by cell": StreamingNever, "streamed": StreamingAlways} {
+		t.Run(name, func(t *testing.T) {
+			day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+			table := NewTable(DataSlice{{"name": "a", "day": day}, {"name": "b", "day": day}}, Columns{
+				NewColumn("name", "Name"),
+				NewColumn("day", "Day").WithType(ColumnTypeDate).
+					WithColumnStyle(&Style{NumFmt: "dd/mm/yyyy", BackgroundColor: "#FFF2CC"}).
+					WithStyle(&Style{Bold: true}),
+			}, true)
+			var buf bytes.Buffer
+			params := FileWriteParams{Filename: "columns", Writer: &buf, Streaming: streaming}
+			if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), params); err != nil {
+				t.Fatalf("ExportXLSX() error = %v", err)
+			}
+			f, err := excelize.OpenReader(&buf)
+			if err != nil {
+				t.Fatalf("OpenReader failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			columnStyleID, _ := f.GetColStyle("Data", "B")
+			if style, _ := f.GetStyle(columnStyleID); style == nil || style.CustomNumFmt == nil || *style.CustomNumFmt != "dd/mm/yyyy" {
+				t.Fatalf("column B style = %+v, want the column style", style)
+			}
+			if styleID, _ := f.GetColStyle("Data", "A"); styleID != 0 {
+				t.Errorf("column A style = %d, want none", styleID)
+			}
+			// Data cells start from the column style and keep their own
+			styleID, _ := f.GetCellStyle("Data", "B3")
+			style, _ := f.GetStyle(styleID)
+			if style == nil || len(style.Fill.Color) == 0 || style.Fill.Color[0] != "FFF2CC" || style.Font == nil || !style.Font.Bold {
+				t.Errorf("B3 style = %+v, want the column fill and the bold font", style)
+			}
+			if value, _ := f.GetCellValue("Data", "B3"); value != "01/03/2024" {
+				t.Errorf("B3 = %q, want the column date format", value)
+			}
+		})
+	}
+}
+
+func TestExportXLSX_columnStyleTransposed(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("a", "A").WithColumnStyle(&Style{Bold: true})}, true)
+	table.Transposed = true
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "columns", Writer: &bytes.Buffer{}})
+	if err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	if len(res.Warnings) != 1 || res.Warnings[0].Message != "Column styles are not supported by transposed tables, ignored" {
+		t.Errorf("warnings = %+v, want the transposed table", res.Warnings)
+	}
+}
@@ -0,0 +1,98 @@
+// table_comments.go - Cell comments.
+//
+// This file implements the comments (notes) attached to header cells (Column.HeaderComment, or
+// Column.Description when the column sets no comment) and data cells (CellOptions.Comment). XLSX exports write them as native comments, which makes them
+// a convenient place for column descriptions and data caveats in generated reports.
+
+package spit
+
+// Comment is a comment (note) attached to a cell.
+type Comment struct {
+	Author string `json:"author,omitempty" yaml:"author,omitempty"` // Comment author shown by spreadsheet applications
+	Text   string `json:"text,omitempty" yaml:"text,omitempty"`     // Comment text
+}
+
+// NewComment creates a new Comment with the given author and text.
+func NewComment(author, text string) *Comment {
+	return &Comment{Author: author, Text: text}
+}
+
+// CellComment is a comment located at a 1-based, table-relative cell.
+type CellComment struct {
+	Col, Row int
+	Comment  Comment
+}
+
+// Comments returns the header and data cell comments of a prepared table, in row then column
+// order. Header comments are located on the header cell of their column (the top cell of a
+// merged header cell).
+func (t *Table) Comments() []CellComment {
+	var comments []CellComment
+	if t.WriteHeader && len(t.Columns) > 0 {
+		comments = appendHeaderComments(comments, t.Columns, t.GetHeaderStartRow(), 1)
+	}
+
+	dataStartRow := t.GetDataStartRow()
+	for rowIndex := range t.Data {
+		for col := 1; col <= t.Columns.GetTotalColumnCount(); col++ {
+			if options, ok := t.CellOptionsMap[col][rowIndex]; ok && options.Comment != nil {
+				comments = append(comments, CellComment{Col: col, Row: dataStartRow + rowIndex, Comment: *options.Comment})
+			}
+		}
+	}
+	return comments
+}
+
+// appendHeaderComments appends the header comments of columns placed at row, starting at col,
+// following the layout of the header writers.
+func appendHeaderComments(comments []CellComment, columns Columns, row, col int) []CellComment {
+	for _, column := range columns {
+		if comment := column.headerComment(); comment != nil {
+			comments = append(comments, CellComment{Col: col, Row: row, Comment: *comment})
+		}
+		if column.HasSubColumns() {
+			comments = appendHeaderComments(comments, column.Columns, row+1, col)
+			col += column.CountSubColumns()
+		} else {
+			col++
+		}
+	}
+	return comments
+}
+
+// headerComment returns the comment of the header cell of the column: its HeaderComment, else a
+// comment holding its Description, or nil.
+func (c *Column) headerComment() *Comment {
+	if c.HeaderComment == nil && c.Description != "" {
+		return &Comment{Text: c.Description}
+	}
+	return c.HeaderComment
+}
+
+// commentAdder is implemented by spreadsheets supporting cell comments.
+type commentAdder interface {
+	AddComment(col, row int, comment Comment) error
+}
+
+// writeComments attaches the table comments to the written sheet.
+// A comment that cannot be added is reported as a warning for its cell and the others are still
+// attached.
+func (xlsx *xlsx) writeComments() {
+	t := xlsx.table
+	comments := t.Comments()
+	if len(comments) == 0 {
+		return
+	}
+	adder, ok := xlsx.spreadsheet.(commentAdder)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support comments, comments skipped", nil)
+		return
+	}
+
+	for _, c := range comments {
+		col, row := t.sheetCoords(c.Col, c.Row)
+		if err := adder.AddComment(col, row, c.Comment); err != nil {
+			t.warn(WarningPhaseSheet, t.cellRef(c.Col, c.Row), "Failed to add comment", err)
+		}
+	}
+}
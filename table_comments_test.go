@@ -0,0 +1,75 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_comments(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}}, Columns{
+		NewColumn("a", "A").WithHeaderComment("spit", "Identifier"),
+		NewColumn("g", "Group").WithSubColumns(Columns{
+			NewColumn("b", "B").WithHeaderComment("spit", "Amount in EUR"),
+		}),
+	}, true).
+		WithStartPosition(2, 1).
+		WithCellOptions(CellOptionsMap{2: {1: *NewCellOptions(1, 1).WithComment("ops", "Estimated")}})
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "comments", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	comments, err := f.GetComments("Sheet1")
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	// Header rows 1-2 (A spans both), data rows 3-4, table starting at column B
+	want := map[string]string{"B1": "Identifier", "C2": "Amount in EUR", "C4": "Estimated"}
+	if len(comments) != len(want) {
+		t.Fatalf("got %d comments, want %d: %+v", len(comments), len(want), comments)
+	}
+	for _, c := range comments {
+		if text := c.Text; want[c.Cell] == "" || !contains(text, want[c.Cell]) {
+			t.Errorf("comment at %s = %q, want %q", c.Cell, text, want[c.Cell])
+		}
+	}
+}
+
+func TestExportXLSX_descriptionComments(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1, "b": 2}}, Columns{
+		NewColumn("a", "A").WithDescription("Identifier"),
+		NewColumn("b", "B").WithDescription("Amount").WithHeaderComment("spit", "Amount in EUR"),
+	}, true)
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "descriptions", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	comments, err := f.GetComments("Sheet1")
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	// The header comment takes precedence over the description
+	want := map[string]string{"A1": "Identifier", "B1": "Amount in EUR"}
+	if len(comments) != len(want) {
+		t.Fatalf("got %d comments, want %d: %+v", len(comments), len(want), comments)
+	}
+	for _, c := range comments {
+		if text := c.Text; want[c.Cell] == "" || !contains(text, want[c.Cell]) {
+			t.Errorf("comment at %s = %q, want %q", c.Cell, text, want[c.Cell])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// table_concat.go - Tables concatenated from several datasets.
+//
+// This file builds a single table from several data slices appended vertically, e.g. the same
+// report run against several environments or regions. Each row is tagged with its source label in
+// an added column, and full-width section header rows can separate the sources.
+
+package spit
+
+import "fmt"
+
+// ConcatOptions configures how ConcatTables labels and separates its sources.
+type ConcatOptions struct {
+	Labels         []string // Source labels, in source order (default: "Source 1", "Source 2", ...)
+	SourceHeader   string   // Header label of the source column (default: the source label key)
+	SectionHeaders bool     // Insert a full-width header row before the rows of each source
+	SectionStyle   *Style   // Optional style for section header rows (default: bold)
+}
+
+// NewConcatOptions creates a new ConcatOptions instance with the given source labels.
+func NewConcatOptions(labels ...string) *ConcatOptions {
+	return &ConcatOptions{Labels: labels}
+}
+
+// WithSourceHeader sets the header label of the source column.
+func (c *ConcatOptions) WithSourceHeader(label string) *ConcatOptions {
+	c.SourceHeader = label
+	return c
+}
+
+// WithSectionHeaders sets whether a full-width header row is inserted before the rows of each source.
+func (c *ConcatOptions) WithSectionHeaders(enabled bool) *ConcatOptions {
+	c.SectionHeaders = enabled
+	return c
+}
+
+// WithSectionStyle sets the style of section header rows.
+func (c *ConcatOptions) WithSectionStyle(style *Style) *ConcatOptions {
+	c.SectionStyle = style
+	return c
+}
+
+// label returns the label of the source at index i.
+func (c *ConcatOptions) label(i int) string {
+	if c != nil && i < len(c.Labels) && c.Labels[i] != "" {
+		return c.Labels[i]
+	}
+	return fmt.Sprintf("Source %d", i+1)
+}
+
+// ConcatTables builds a table from several data slices appended vertically, in order.
+// Every row is copied with its source label stored under sourceLabelKey, and a source column
+// reading that key is prepended to columns (unless columns already define it). An empty
+// sourceLabelKey adds no column, which is useful with section headers only. Options may be nil.
+// The source rows are never modified. The header is written.
+func ConcatTables(sources []DataSlice, sourceLabelKey string, columns Columns, options *ConcatOptions) *Table {
+	defined := false
+	for _, column := range columns.GetFlattenedColumns() {
+		defined = defined || column.Name == sourceLabelKey
+	}
+	if sourceLabelKey != "" && !defined {
+		header := sourceLabelKey
+		if options != nil && options.SourceHeader != "" {
+			header = options.SourceHeader
+		}
+		columns = append(Columns{NewColumn(sourceLabelKey, header)}, columns...)
+	}
+
+	style := &Style{Bold: true}
+	if options != nil && options.SectionStyle != nil {
+		style = options.SectionStyle
+	}
+
+	total := 0
+	for _, source := range sources {
+		total += len(source)
+	}
+	table := NewTable(make(DataSlice, 0, total+len(sources)), columns, true)
+	table.RowOptionsMap = make(RowOptionsMap)
+	for i, source := range sources {
+		label := options.label(i)
+		if options != nil && options.SectionHeaders {
+			index := len(table.Data)
+			table.Data = append(table.Data, Data{})
+			table.RowOptionsMap[index] = RowOptions{RowIndex: index, Style: style, SpanAllColumns: true, Value: label}
+		}
+		for _, item := range source {
+			row := make(Data, len(item)+1)
+			for k, v := range item {
+				row[k] = v
+			}
+			if sourceLabelKey != "" {
+				row[sourceLabelKey] = label
+			}
+			table.Data = append(table.Data, row)
+		}
+	}
+	return table
+}
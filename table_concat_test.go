@@ -0,0 +1,47 @@
+package spit
+
+import (
+	"os"
+	"testing"
+)
+
+func TestConcatTables(t *testing.T) {
+	prod := DataSlice{{"host": "a", "cpu": 10}, {"host": "b", "cpu": 20}}
+	staging := DataSlice{{"host": "c", "cpu": 5}}
+	columns := Columns{NewColumn("host", "Host"), NewColumn("cpu", "CPU")}
+
+	tests := []struct {
+		name    string
+		key     string
+		options *ConcatOptions
+		want    string
+	}{
+		{"source column", "env", NewConcatOptions("prod", "staging").WithSourceHeader("Environment"),
+			"Environment,Host,CPU\nprod,a,10\nprod,b,20\nstaging,c,5\n"},
+		{"default labels", "env", nil,
+			"env,Host,CPU\nSource 1,a,10\nSource 1,b,20\nSource 2,c,5\n"},
+		{"section headers only", "", NewConcatOptions("prod", "staging").WithSectionHeaders(true),
+			"Host,CPU\nprod,\na,10\nb,20\nstaging,\nc,5\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := ConcatTables([]DataSlice{prod, staging}, tt.key, columns, tt.options)
+			res, err := ExportCSV(",", table, FileWriteParams{Filename: "concat", Filepath: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ExportCSV failed: %v", err)
+			}
+			content, err := os.ReadFile(res.Filepath)
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("CSV = %q, want %q", content, tt.want)
+			}
+		})
+	}
+
+	if _, ok := prod[0]["env"]; ok {
+		t.Error("ConcatTables must not modify the source rows")
+	}
+}
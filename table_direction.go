@@ -0,0 +1,86 @@
+// table_direction.go - Right-to-left sheets and text direction.
+//
+// This file implements the options of right-to-left reports (Arabic, Hebrew, Persian...): the
+// reading order of cell text (Style.TextDirection) and right-to-left tables (Table.RightToLeft),
+// whose sheet runs from right to left, the first column on the right. XLSX and Google Sheets map
+// them to their sheet view and alignment settings; HTML falls back to the CSS and dir attribute of
+// the table.
+
+package spit
+
+import "github.com/xuri/excelize/v2"
+
+// TextDirection defines the reading order of the text of a cell.
+type TextDirection int
+
+const (
+	// TextDirectionContext derives the reading order from the first strong character of the text
+	// (default).
+	TextDirectionContext TextDirection = iota
+
+	// TextDirectionLTR reads the text from left to right.
+	TextDirectionLTR
+
+	// TextDirectionRTL reads the text from right to left.
+	TextDirectionRTL
+)
+
+// WithRightToLeft sets whether the table is displayed right to left: XLSX and Google Sheets
+// display its sheet with the first column on the right, and HTML writes the table with
+// dir="rtl". Text alignment and the reading order of cells follow the sheet unless their styles
+// set them (see Style.TextDirection).
+func (t *Table) WithRightToLeft(rightToLeft bool) *Table {
+	t.RightToLeft = rightToLeft
+	return t
+}
+
+// excelReadingOrder returns the Excel reading order of the direction: 0 (context), 1 (left to
+// right) or 2 (right to left).
+func (d TextDirection) excelReadingOrder() uint64 {
+	switch d {
+	case TextDirectionLTR:
+		return 1
+	case TextDirectionRTL:
+		return 2
+	}
+	return 0
+}
+
+// cssDirection returns the CSS direction of the text direction, or "" for the context direction.
+func (d TextDirection) cssDirection() string {
+	switch d {
+	case TextDirectionLTR:
+		return "ltr"
+	case TextDirectionRTL:
+		return "rtl"
+	}
+	return ""
+}
+
+// sheetDirectionSetter is implemented by spreadsheets supporting right-to-left sheets.
+type sheetDirectionSetter interface {
+	SetRightToLeft(rightToLeft bool) error
+}
+
+// SetRightToLeft sets whether the sheet is displayed right to left, the first column on the right.
+func (e *SpreadsheetExcelize) SetRightToLeft(rightToLeft bool) error {
+	return e.File.SetSheetView(e.SheetName, -1, &excelize.ViewOptions{RightToLeft: &rightToLeft})
+}
+
+// writeDirection displays the written sheet right to left when the table is (see
+// Table.WithRightToLeft). If the sheet view cannot be updated, the sheet keeps its left-to-right
+// layout and a warning is reported.
+func (xlsx *xlsx) writeDirection() {
+	t := xlsx.table
+	if !t.RightToLeft {
+		return
+	}
+	setter, ok := xlsx.spreadsheet.(sheetDirectionSetter)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support right-to-left sheets, written left to right", nil)
+		return
+	}
+	if err := setter.SetRightToLeft(true); err != nil {
+		t.warn(WarningPhaseSheet, "", "Failed to set the sheet direction", err)
+	}
+}
@@ -0,0 +1,58 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_rightToLeft(t *testing.T) {
+	for name, streaming := range map[string]StreamingMode{"cell by cell": StreamingNever, "streamed": StreamingAlways} {
+		t.Run(name, func(t *testing.T) {
+			table := NewTable(DataSlice{{"name": "سارة", "code": "A-1"}}, Columns{
+				NewColumn("name", "الاسم").WithStyle(&Style{TextDirection: TextDirectionRTL}),
+				NewColumn("code", "Code").WithStyle(&Style{TextDirection: TextDirectionLTR, Bold: true}),
+			}, true).WithRightToLeft(true)
+			var buf bytes.Buffer
+			params := FileWriteParams{Filename: "rtl", Writer: &buf, Streaming: streaming}
+			if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), params); err != nil {
+				t.Fatalf("ExportXLSX() error = %v", err)
+			}
+			f, err := excelize.OpenReader(&buf)
+			if err != nil {
+				t.Fatalf("OpenReader failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			view, err := f.GetSheetView("Data", -1)
+			if err != nil || view.RightToLeft == nil || !*view.RightToLeft {
+				t.Errorf("sheet view = %+v, %v, want right to left", view, err)
+			}
+			for cell, want := range map[string]uint64{"A2": 2, "B2": 1} {
+				styleID, _ := f.GetCellStyle("Data", cell)
+				if style, _ := f.GetStyle(styleID); style == nil || style.Alignment == nil || style.Alignment.ReadingOrder != want {
+					t.Errorf("%s style = %+v, want reading order %d", cell, style, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTMLRightToLeft(t *testing.T) {
+	table := NewTable(DataSlice{{"name": "سارة", "amount": 3}}, Columns{
+		NewColumn("name", "الاسم").WithStyle(&Style{TextDirection: TextDirectionRTL}),
+		NewColumn("amount", "المبلغ"),
+	}, true).WithRightToLeft(true)
+	out := buildHTML(t, table, HTMLOptions{})
+	if !strings.Contains(out, `<table dir="rtl" style=`) {
+		t.Errorf("expected a right-to-left table, got:\n%s", out)
+	}
+	if !strings.Contains(out, "direction:rtl") {
+		t.Errorf("expected the text direction of the name cells, got:\n%s", out)
+	}
+	if !strings.Contains(out, "text-align:left") || strings.Contains(out, "text-align:right") {
+		t.Errorf("expected numbers aligned to the left of right-to-left cells, got:\n%s", out)
+	}
+}
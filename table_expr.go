@@ -0,0 +1,236 @@
+// table_expr.go - Expressions in table definitions.
+//
+// This file wires the expression language (see ParseExpr) into tables: computed columns
+// (Column.ValueExpr), style rules applied to the cells matching a condition and validations of
+// column values. Together they let report definitions loaded from YAML or JSON carry logic
+// without Go code. Invalid expressions never abort styling or validation: they are reported as
+// warnings, and CheckExprs finds them before exporting.
+
+package spit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StyleRule styles the data cells of a column whose row matches a condition.
+type StyleRule struct {
+	When  string `json:"when,omitempty" yaml:"when,omitempty"`   // Condition (see ParseExpr); value is the cell value
+	Style *Style `json:"style,omitempty" yaml:"style,omitempty"` // Style applied to the matching cells
+}
+
+// Validation is a check on the values of a column.
+type Validation struct {
+	Expr    string `json:"expr,omitempty" yaml:"expr,omitempty"`       // Condition every row must satisfy (see ParseExpr); value is the cell value
+	Message string `json:"message,omitempty" yaml:"message,omitempty"` // Optional message reported when the condition is not met
+}
+
+// ValidationIssue is a data cell failing a validation (see Table.ValidateData).
+type ValidationIssue struct {
+	Column  string      // Name of the column
+	Row     int         // 0-based source data row
+	Value   interface{} // Value of the cell
+	Message string      // Validation message, or a default one naming the expression
+	Err     error       // Evaluation error, if the expression could not be evaluated
+
+	dataRow int // 0-based data row of the checked table (differs from Row in grouped tables)
+	col     int // 1-based leaf column index
+}
+
+// Error returns the issue as a single line, e.g. `column "amount", row 3: must be positive`.
+func (i ValidationIssue) Error() string {
+	message := i.Message
+	if i.Err != nil {
+		message = fmt.Sprintf("%s: %v", message, i.Err)
+	}
+	return fmt.Sprintf("column %q, row %d: %s", i.Column, i.Row, message)
+}
+
+// Unwrap returns the evaluation error, if any.
+func (i ValidationIssue) Unwrap() error {
+	return i.Err
+}
+
+// WithValueExpr makes the column computed: its value is the result of the expression over the
+// fields of the row, e.g. "amount * qty" (see ParseExpr). Rows holding a value under the column
+// name keep it.
+func (c *Column) WithValueExpr(expr string) *Column {
+	c.ValueExpr = expr
+	return c
+}
+
+// WithStyleRule adds a style applied to the data cells whose row matches the condition, e.g.
+// "status == 'late'" or "value < 0". Rules are tried in order and the first match wins; rule
+// styles take precedence over row and column styles, cell styles over rule styles.
+func (c *Column) WithStyleRule(when string, style *Style) *Column {
+	c.StyleRules = append(c.StyleRules, StyleRule{When: when, Style: style})
+	return c
+}
+
+// WithValidation adds a condition every value of the column must satisfy, e.g. "value >= 0".
+// Failing cells are reported as warnings by exports (see WarningPhaseValidation) and returned by
+// Table.ValidateData; message describes the problem (optional).
+func (c *Column) WithValidation(expr, message string) *Column {
+	c.Validations = append(c.Validations, Validation{Expr: expr, Message: message})
+	return c
+}
+
+// computeColumn evaluates the value expression of a computed column over the row.
+func (d Data) computeColumn(column *Column) (interface{}, error, bool) {
+	e, err := compileExpr(column.ValueExpr)
+	if err != nil {
+		return nil, err, false
+	}
+	value, err := e.Eval(d)
+	if err != nil {
+		return nil, err, false
+	}
+	return value, nil, true
+}
+
+// cellValue returns the normalized value of a cell for style rules and validations.
+func cellValue(item Data, column *Column) (interface{}, error) {
+	value, err, found := item.LookupColumn(column)
+	if err != nil || !found {
+		return nil, err
+	}
+	return NormalizeValue(value), nil
+}
+
+// matchStyleRule returns the first style rule of the column matching the row, or nil.
+func matchStyleRule(column *Column, item Data) (*StyleRule, error) {
+	if len(column.StyleRules) == 0 {
+		return nil, nil
+	}
+	value, err := cellValue(item, column)
+	if err != nil {
+		return nil, err
+	}
+	for i, rule := range column.StyleRules {
+		e, err := compileExpr(rule.When)
+		if err != nil {
+			return nil, err
+		}
+		matched, err := e.evalCell(item, value)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(matched) {
+			return &column.StyleRules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ValidateData checks the data rows against the validations of the columns (see
+// Column.WithValidation) and returns the failing cells, by row then column. The table is checked
+// as it will be exported (see Table.Prepare); group header and subtotal rows are not checked. The
+// configuration itself is checked by Validate.
+func (t *Table) ValidateData() []ValidationIssue {
+	return t.Prepare().validate()
+}
+
+// validate checks the data rows of the table as is.
+func (t *Table) validate() []ValidationIssue {
+	flatColumns := t.Columns.GetFlattenedColumns()
+	var validated []int
+	for i, column := range flatColumns {
+		if len(column.Validations) > 0 {
+			validated = append(validated, i)
+		}
+	}
+	if len(validated) == 0 {
+		return nil
+	}
+
+	var issues []ValidationIssue
+	for dataRow, item := range t.Data {
+		source := t.SourceRowIndex(dataRow)
+		if source < 0 {
+			continue
+		}
+		for _, i := range validated {
+			column := flatColumns[i]
+			issue := ValidationIssue{Column: column.Name, Row: source, dataRow: dataRow, col: i + 1}
+			value, err := cellValue(item, column)
+			if err != nil {
+				issue.Message, issue.Err = "invalid value", err
+				issues = append(issues, issue)
+				continue
+			}
+			issue.Value = value
+			for _, validation := range column.Validations {
+				if ok, err := validation.check(item, value); !ok {
+					issue.Message, issue.Err = validation.message(), err
+					issues = append(issues, issue)
+					break
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// check evaluates the validation for a cell.
+func (v Validation) check(item Data, value interface{}) (bool, error) {
+	e, err := compileExpr(v.Expr)
+	if err != nil {
+		return false, err
+	}
+	result, err := e.evalCell(item, value)
+	if err != nil {
+		return false, err
+	}
+	return truthy(result), nil
+}
+
+// message returns the message of the validation, defaulting to one naming the expression.
+func (v Validation) message() string {
+	if v.Message != "" {
+		return v.Message
+	}
+	return fmt.Sprintf("validation %q failed", v.Expr)
+}
+
+// reportValidations reports the failing cells of the table as warnings.
+func (t *Table) reportValidations() {
+	dataStartRow := t.GetDataStartRow()
+	for _, issue := range t.validate() {
+		t.warn(WarningPhaseValidation, t.cellRef(issue.col, dataStartRow+issue.dataRow), issue.Message, issue.Err,
+			String("column", issue.Column),
+			Int("row", issue.Row))
+	}
+}
+
+// CheckExprs parses every expression of the columns (value expressions, style rules and
+// validations) and returns the syntax errors joined, or nil. Useful to reject a report definition
+// before exporting it.
+func (t *Table) CheckExprs() error {
+	var errs []error
+	for _, column := range t.Columns.GetFlattenedColumns() {
+		for _, err := range columnExprErrors(column) {
+			errs = append(errs, fmt.Errorf("column %q: %w", column.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// columnExprErrors returns the syntax errors of the expressions of a column, in CheckExprs order.
+func columnExprErrors(column *Column) []error {
+	var errs []error
+	check := func(source string) {
+		if _, err := compileExpr(source); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if column.ValueExpr != "" {
+		check(column.ValueExpr)
+	}
+	for _, rule := range column.StyleRules {
+		check(rule.When)
+	}
+	for _, validation := range column.Validations {
+		check(validation.Expr)
+	}
+	return errs
+}
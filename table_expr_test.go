@@ -0,0 +1,152 @@
+package spit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestColumn_WithValueExpr(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"item": "Pen", "amount": 1.5, "qty": 4},
+		{"item": "Box", "amount": 10, "qty": 2, "total": "n/a"},
+	}, Columns{
+		NewColumn("item", "Item"),
+		NewColumn("total", "Total").WithValueExpr("amount * qty"),
+	}, true)
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "orders", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	// Rows holding a value under the column name keep it
+	if want := "Item,Total\nPen,6\nBox,n/a\n"; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+
+	table.Columns[1].WithValueExpr("amount *")
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "invalid", Filepath: t.TempDir()}); err == nil {
+		t.Error("expected an error for an invalid value expression")
+	}
+}
+
+func TestColumn_WithStyleRule(t *testing.T) {
+	late := &Style{TextColor: "#C00000"}
+	negative := &Style{Bold: true}
+	rowStyle := &Style{Italic: true}
+	table := NewTable(DataSlice{
+		{"status": "late", "amount": 10},
+		{"status": "ok", "amount": -5},
+		{"status": "ok", "amount": 3},
+	}, Columns{
+		NewColumn("status", "Status"),
+		NewColumn("amount", "Amount").
+			WithStyleRule("status == 'late'", late).
+			WithStyleRule("value < 0", negative),
+	}, true).WithRowOptions(RowOptionsMap{0: RowOptions{Style: rowStyle}, 2: RowOptions{Style: rowStyle}})
+
+	tests := []struct {
+		name     string
+		row      int
+		applied  StyleSourceKind
+		resolved *Style
+	}{
+		{"rule over row", 2, StyleSourceRule, late},
+		{"value rule", 3, StyleSourceRule, negative},
+		{"no matching rule", 4, StyleSourceRow, rowStyle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ExplainCellStyle(table, 2, tt.row)
+			var applied StyleSourceKind
+			for _, source := range e.Sources {
+				if source.Applied {
+					applied = source.Kind
+				}
+			}
+			if applied != tt.applied || e.Resolved == nil || *e.Resolved != *tt.resolved {
+				t.Errorf("applied %q, resolved %+v; want %q, %+v\n%s", applied, e.Resolved, tt.applied, tt.resolved, e)
+			}
+		})
+	}
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Orders", table), FileWriteParams{Filename: "orders", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	styleID, err := f.GetCellStyle("Orders", "B3")
+	if err != nil {
+		t.Fatalf("GetCellStyle failed: %v", err)
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil || style.Font == nil || !style.Font.Bold {
+		t.Errorf("B3 style = %+v (%v), want bold", style, err)
+	}
+}
+
+func TestTable_ValidateData(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"id": 1, "amount": 10},
+		{"id": 2, "amount": -5},
+		{"id": 3},
+	}, Columns{
+		NewColumn("id", "ID"),
+		NewColumn("amount", "Amount").
+			WithValidation("value != null", "amount is required").
+			WithValidation("value >= 0", ""),
+	}, true)
+
+	issues := table.ValidateData()
+	if len(issues) != 2 {
+		t.Fatalf("ValidateData() = %v, want 2 issues", issues)
+	}
+	if got := issues[0].Error(); got != `column "amount", row 1: validation "value >= 0" failed` {
+		t.Errorf("issues[0] = %q", got)
+	}
+	if issues[1].Row != 2 || issues[1].Message != "amount is required" {
+		t.Errorf("issues[1] = %+v", issues[1])
+	}
+
+	var hooked []ExportWarning
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{
+		Filename:  "validated",
+		Filepath:  t.TempDir(),
+		OnWarning: func(w ExportWarning) { hooked = append(hooked, w) },
+	})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	if len(res.Warnings) != 2 || len(hooked) != 2 {
+		t.Fatalf("warnings = %v, hooked = %v", res.Warnings, hooked)
+	}
+	if w := res.Warnings[0]; w.Phase != WarningPhaseValidation || w.Cell != "B3" {
+		t.Errorf("warning = %+v, want validation warning on B3", w)
+	}
+}
+
+func TestTable_CheckExprs(t *testing.T) {
+	table := NewTable(nil, Columns{
+		NewColumn("total", "Total").WithValueExpr("amount * qty").WithStyleRule("value >", nil),
+		NewColumn("status", "Status").WithValidation("len(value) > 0", ""),
+	}, true)
+	err := table.CheckExprs()
+	if err == nil || !strings.Contains(err.Error(), `column "total": expression "value >"`) {
+		t.Errorf("CheckExprs() = %v", err)
+	}
+
+	table.Columns[0].StyleRules = nil
+	if err := table.CheckExprs(); err != nil {
+		t.Errorf("CheckExprs() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,42 @@
+// table_filter.go - Data row filtering.
+//
+// This file implements row filters (see Table.WithFilter). Filters are evaluated by Table.Prepare
+// at export time, so one table definition can export several filtered views without copying its
+// data: the prepared table references the kept rows of the original slice.
+
+package spit
+
+// WithFilter adds a filter keeping the data rows for which keep returns true. Filters are
+// cumulative: a row is exported when every filter keeps it. Filters are evaluated at export time,
+// before sorting and every other transformation; row and cell options follow the kept rows, and
+// SourceRowIndex returns the original index of each kept row.
+func (t *Table) WithFilter(keep func(row Data) bool) *Table {
+	t.Filters = append(t.Filters, keep)
+	return t
+}
+
+// Filtered returns a shallow copy of the table with the given filters added to its own, leaving t
+// untouched. It exports a filtered view of a shared table definition:
+//
+//	ExportCSV(",", table.Filtered(func(row Data) bool { return row["region"] == "EU" }), params)
+func (t *Table) Filtered(filters ...func(row Data) bool) *Table {
+	f := *t
+	f.prepared = nil
+	f.Filters = append(append([]func(row Data) bool(nil), t.Filters...), filters...)
+	return &f
+}
+
+// applyFilters returns a shallow copy of t holding the data rows kept by every filter.
+func (t *Table) applyFilters() *Table {
+	kept := make([]int, 0, len(t.Data))
+rows:
+	for i, item := range t.Data {
+		for _, keep := range t.Filters {
+			if keep != nil && !keep(item) {
+				continue rows
+			}
+		}
+		kept = append(kept, i)
+	}
+	return t.withRows(kept)
+}
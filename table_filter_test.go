@@ -0,0 +1,60 @@
+package spit
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTable_WithFilter(t *testing.T) {
+	byTeam := func(team string) func(Data) bool {
+		return func(row Data) bool { return row["team"] == team }
+	}
+	scored := func(row Data) bool { return row["score"] != nil }
+
+	table := fixtureTable("name", "team", "score").
+		WithRowOptions(RowOptionsMap{4: RowOptions{RowIndex: 4, Style: &Style{Bold: true}}}).
+		WithFilter(scored)
+
+	if got, want := sortedNames(table.Prepare()), []string{"carol", "alice", "bob", "erin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filtered rows = %v, want %v", got, want)
+	}
+
+	view := table.Filtered(byTeam("a"))
+	p := view.Prepare()
+	if got, want := sortedNames(p), []string{"alice", "erin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("view rows = %v, want %v", got, want)
+	}
+	if p.SourceRowIndex(1) != 4 {
+		t.Errorf("SourceRowIndex(1) = %d, want 4", p.SourceRowIndex(1))
+	}
+	if ro, ok := p.RowOptionsMap[1]; !ok || ro.RowIndex != 1 || !ro.Style.Bold {
+		t.Errorf("row options should follow their row, got %v", p.RowOptionsMap)
+	}
+	if len(table.Filters) != 1 || len(table.Prepare().Data) != 4 {
+		t.Error("Filtered must not modify the original table")
+	}
+	if len(table.Data) != 5 {
+		t.Error("filtering must not modify the original data")
+	}
+
+	// Filters run before sorting
+	sorted := table.Filtered(byTeam("b")).WithSort(SortKey{Field: "name"})
+	if got, want := sortedNames(sorted.Prepare()), []string{"bob", "carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted view rows = %v, want %v", got, want)
+	}
+}
+
+func TestExportCSV_filtered(t *testing.T) {
+	table := fixtureTable("name", "team", "score").WithFooter(nil)
+	table.Columns[2].WithAggregate(AggregateSum)
+
+	var buf bytes.Buffer
+	view := table.Filtered(func(row Data) bool { return row["team"] == "b" })
+	if _, err := ExportCSV(",", view, FileWriteParams{Filename: "filtered", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	if want := "Name,Team,Score\ncarol,b,7\nbob,b,10\nTotal,,17\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,323 @@
+// table_footer.go - Totals / aggregate footer row.
+//
+// This file implements the footer row written after the data rows: each leaf column may carry an
+// Aggregate (SUM, AVERAGE, COUNT, MIN, MAX or a custom function) computed over the exported values.
+// RenderFooter writes the row through TableOperations so every backend shares the same logic;
+// backends supporting formulas may write built-in aggregates as real formulas (e.g. =SUM(B3:B10)).
+
+package spit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultFooterLabel is the label written in the first footer cell when it has no aggregate.
+const DefaultFooterLabel = "Total"
+
+// Aggregate computes the footer value of a column from its values.
+type Aggregate struct {
+	Function string                                 // Spreadsheet function name used for formulas (e.g. "SUM"); empty for custom aggregates
+	Compute  func(values []interface{}) interface{} // Computes the footer value from the column values (nil values excluded)
+}
+
+var (
+	// AggregateSum sums the numeric values of the column.
+	AggregateSum = &Aggregate{Function: "SUM", Compute: func(values []interface{}) interface{} {
+		sum, _ := aggregateNumbers(values)
+		return sum
+	}}
+
+	// AggregateAvg averages the numeric values of the column (nil when there are none).
+	AggregateAvg = &Aggregate{Function: "AVERAGE", Compute: func(values []interface{}) interface{} {
+		sum, count := aggregateNumbers(values)
+		if count == 0 {
+			return nil
+		}
+		return sum / float64(count)
+	}}
+
+	// AggregateCount counts the non-empty values of the column (COUNTA in formulas).
+	AggregateCount = &Aggregate{Function: "COUNTA", Compute: func(values []interface{}) interface{} {
+		count := 0
+		for _, v := range values {
+			if fmt.Sprintf("%v", v) != "" {
+				count++
+			}
+		}
+		return count
+	}}
+
+	// AggregateMin returns the smallest numeric value of the column (nil when there are none).
+	AggregateMin = &Aggregate{Function: "MIN", Compute: func(values []interface{}) interface{} {
+		return extremum(values, func(a, b float64) bool { return a < b })
+	}}
+
+	// AggregateMax returns the largest numeric value of the column (nil when there are none).
+	AggregateMax = &Aggregate{Function: "MAX", Compute: func(values []interface{}) interface{} {
+		return extremum(values, func(a, b float64) bool { return a > b })
+	}}
+)
+
+// NewAggregate creates a custom Aggregate computed by the given function.
+// Custom aggregates are always written as values, never as formulas.
+func NewAggregate(compute func(values []interface{}) interface{}) *Aggregate {
+	return &Aggregate{Compute: compute}
+}
+
+// aggregateNames maps the names of built-in aggregates in configuration files to the aggregates.
+var aggregateNames = map[string]*Aggregate{
+	"sum":   AggregateSum,
+	"avg":   AggregateAvg,
+	"count": AggregateCount,
+	"min":   AggregateMin,
+	"max":   AggregateMax,
+}
+
+// MarshalText returns the name of a built-in aggregate (e.g. "sum"). Custom aggregates have no
+// name and cannot be marshalled.
+func (a *Aggregate) MarshalText() ([]byte, error) {
+	for name, aggregate := range aggregateNames {
+		if a.Function != "" && a.Function == aggregate.Function {
+			return []byte(name), nil
+		}
+	}
+	return nil, fmt.Errorf("custom aggregates cannot be marshalled")
+}
+
+// UnmarshalText sets the aggregate to the built-in aggregate of the given name ("sum", "avg",
+// "count", "min" or "max"), or of the given spreadsheet function (e.g. "AVERAGE").
+func (a *Aggregate) UnmarshalText(text []byte) error {
+	name := strings.TrimSpace(string(text))
+	for key, aggregate := range aggregateNames {
+		if strings.EqualFold(name, key) || strings.EqualFold(name, aggregate.Function) {
+			*a = *aggregate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown aggregate %q", name)
+}
+
+// FooterOptions configures the footer row of a table.
+type FooterOptions struct {
+	Label    string `json:"label,omitempty" yaml:"label,omitempty"`       // Label written in the first column when it has no aggregate (default: DefaultFooterLabel)
+	Style    *Style `json:"style,omitempty" yaml:"style,omitempty"`       // Optional style for the footer row (default: bold)
+	Formulas bool   `json:"formulas,omitempty" yaml:"formulas,omitempty"` // Write built-in aggregates as formulas in backends that support them (XLSX, Google Sheets)
+}
+
+// NewFooterOptions creates a new FooterOptions instance with default settings.
+func NewFooterOptions() *FooterOptions {
+	return &FooterOptions{}
+}
+
+// WithLabel sets the label written in the first footer cell.
+func (f *FooterOptions) WithLabel(label string) *FooterOptions {
+	f.Label = label
+	return f
+}
+
+// WithStyle sets the style of the footer row.
+func (f *FooterOptions) WithStyle(style *Style) *FooterOptions {
+	f.Style = style
+	return f
+}
+
+// WithFormulas sets whether built-in aggregates are written as formulas.
+func (f *FooterOptions) WithFormulas(formulas bool) *FooterOptions {
+	f.Formulas = formulas
+	return f
+}
+
+// GetLabel returns the footer label, falling back to DefaultFooterLabel.
+func (f *FooterOptions) GetLabel() string {
+	if f != nil && f.Label != "" {
+		return f.Label
+	}
+	return DefaultFooterLabel
+}
+
+// GetFooterRow returns the 1-based, table-relative row of the footer (right after the data rows).
+func (t *Table) GetFooterRow() int {
+	return t.GetDataStartRow() + len(t.Data)
+}
+
+// hasFooter reports whether a footer row is written.
+func (t *Table) hasFooter() bool {
+	return t.WriteFooter && len(t.Columns) > 0
+}
+
+// FooterValues computes the footer value of every leaf column, in column order.
+// Columns without an aggregate get nil, except the first one which gets the footer label.
+func (t *Table) FooterValues() []interface{} {
+	flatColumns := t.Columns.GetFlattenedColumns()
+	values := make([]interface{}, len(flatColumns))
+	for i, column := range flatColumns {
+		if column.Aggregate == nil || column.Aggregate.Compute == nil {
+			if i == 0 {
+				values[i] = t.FooterOptions.GetLabel()
+			}
+			continue
+		}
+		values[i] = t.inLocation(column, column.Aggregate.Compute(t.columnValues(column)))
+	}
+	return values
+}
+
+// columnValues returns the non-nil values of a column across all data rows, subtotal rows excluded.
+func (t *Table) columnValues(column *Column) []interface{} {
+	rows := t.Data
+	if len(t.subtotals) > 0 {
+		rows = make(DataSlice, 0, len(t.Data))
+		for i, item := range t.Data {
+			if !t.isSubtotalRow(i) {
+				rows = append(rows, item)
+			}
+		}
+	}
+	return valuesOf(rows, column)
+}
+
+// valuesOf returns the non-nil values of a column across the given rows.
+func valuesOf(rows DataSlice, column *Column) []interface{} {
+	values := make([]interface{}, 0, len(rows))
+	for _, item := range rows {
+		if v, err, found := item.LookupColumn(column); err == nil && found && v != nil {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// RenderFooter writes the footer row after the data rows when Table.WriteFooter is set.
+// When allowFormulas is true and FooterOptions.Formulas is set, built-in aggregates are written as
+// formulas over the data range; otherwise computed values are written. Like ProcessMerging and
+// RenderStyles, ops receives table-relative coordinates translated by the start position.
+func (t *Table) RenderFooter(ops TableOperations, allowFormulas bool) error {
+	if !t.hasFooter() {
+		return nil
+	}
+	ops = t.Offset(ops)
+
+	row := t.GetFooterRow()
+	useFormulas := allowFormulas && t.FooterOptions != nil && t.FooterOptions.Formulas && len(t.Data) > 0
+	// Subtotal values written as plain values would be counted twice by a formula over the data range
+	if len(t.subtotals) > 0 && !t.GroupOptions.SubtotalFormulas {
+		useFormulas = false
+	}
+	flatColumns := t.Columns.GetFlattenedColumns()
+
+	for i, value := range t.FooterValues() {
+		col := i + 1
+		if formula := flatColumns[i].FooterFormula; allowFormulas && formula != "" {
+			resolved, err := t.resolveFormula(ops, formula, row)
+			if err == nil {
+				if err := ops.SetCellFormula(col, row, resolved); err != nil {
+					return fmt.Errorf("failed to set footer formula at column %d: %w", col, err)
+				}
+				continue
+			}
+			t.warn(WarningPhaseData, t.cellRef(col, row), "Failed to resolve footer formula, aggregate kept", err, String("formula", formula))
+		}
+		if agg := flatColumns[i].Aggregate; useFormulas && agg != nil && agg.Function != "" {
+			if err := ops.SetCellFormula(col, row, t.footerFormula(ops, agg, col)); err != nil {
+				return fmt.Errorf("failed to set footer formula at column %d: %w", col, err)
+			}
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if err := ops.SetCellValue(col, row, value); err != nil {
+			return fmt.Errorf("failed to set footer value at column %d: %w", col, err)
+		}
+	}
+	return nil
+}
+
+// footerFormula builds the formula of a built-in aggregate over the data rows of a column,
+// e.g. "SUM(B3:B10)", addressed by the backend (see CellAddresser). ops takes table-relative
+// coordinates (see Table.Offset).
+// Tables with subtotal rows use SUBTOTAL, which skips the nested subtotals, e.g. "SUBTOTAL(9,B3:B10)".
+func (t *Table) footerFormula(ops TableOperations, agg *Aggregate, col int) string {
+	first := t.GetDataStartRow()
+	ref := AddresserFor(ops).RangeRef(col, first, col, first+len(t.Data)-1)
+	if number, ok := subtotalFunctions[agg.Function]; ok && len(t.subtotals) > 0 {
+		return fmt.Sprintf("SUBTOTAL(%d,%s)", number, ref)
+	}
+	return fmt.Sprintf("%s(%s)", agg.Function, ref)
+}
+
+// applyFooterStyles applies the footer style (default: bold) across the footer row.
+func (t *Table) applyFooterStyles(ops TableOperations) error {
+	style := Style{Bold: true}
+	if t.FooterOptions != nil && t.FooterOptions.Style != nil {
+		style = t.contrastStyle(*t.FooterOptions.Style)
+	}
+	row := t.GetFooterRow()
+	if err := ops.ApplyStyleToRange(1, row, t.Columns.GetTotalColumnCount(), row, style); err != nil {
+		return fmt.Errorf("failed to apply footer style: %w", err)
+	}
+	return nil
+}
+
+// aggregateNumbers sums the numeric values and returns the sum and the number of numeric values.
+func aggregateNumbers(values []interface{}) (sum float64, count int) {
+	for _, v := range values {
+		if f, ok := toFloat(NormalizeValue(v)); ok {
+			sum += f
+			count++
+		}
+	}
+	return sum, count
+}
+
+// extremum returns the numeric value for which better(value, current) holds against all others.
+func extremum(values []interface{}, better func(a, b float64) bool) interface{} {
+	var result interface{}
+	var current float64
+	for _, v := range values {
+		f, ok := toFloat(NormalizeValue(v))
+		if !ok {
+			continue
+		}
+		if result == nil || better(f, current) {
+			result, current = v, f
+		}
+	}
+	return result
+}
+
+// toFloat converts numeric values (and numeric strings) to float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
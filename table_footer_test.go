@@ -0,0 +1,125 @@
+package spit
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_FooterValues(t *testing.T) {
+	values := []interface{}{"A", 3, "", 4.5, nil}
+	tests := []struct {
+		name      string
+		aggregate *Aggregate
+		expected  interface{}
+	}{
+		{name: "Sum", aggregate: AggregateSum, expected: 7.5},
+		{name: "Average", aggregate: AggregateAvg, expected: 3.75},
+		{name: "Count", aggregate: AggregateCount, expected: 3},
+		{name: "Min", aggregate: AggregateMin, expected: 3},
+		{name: "Max", aggregate: AggregateMax, expected: 4.5},
+		{name: "Custom", aggregate: NewAggregate(func(v []interface{}) interface{} { return len(v) }), expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := make(DataSlice, len(values))
+			for i, v := range values {
+				data[i] = Data{"v": v}
+			}
+			table := NewTable(data, Columns{NewColumn("v", "V").WithAggregate(tt.aggregate)}, true)
+			if got := table.FooterValues(); !reflect.DeepEqual(got, []interface{}{tt.expected}) {
+				t.Errorf("FooterValues() = %v, want [%v]", got, tt.expected)
+			}
+		})
+	}
+
+	table := fixtureTable("region", "sales", "score").WithFooter(nil)
+	table.Columns[1].WithAggregate(AggregateSum)
+	table.Columns[2].WithAggregate(AggregateMax)
+	got := table.FooterValues()
+	if !reflect.DeepEqual(got, []interface{}{DefaultFooterLabel, float64(115), 10}) {
+		t.Errorf("FooterValues() = %v", got)
+	}
+}
+
+func TestExportCSV_footer(t *testing.T) {
+	table := fixtureTable("region", "sales", "score").WithFooter(NewFooterOptions().WithLabel("Sum"))
+	table.Columns[1].WithAggregate(AggregateSum)
+	table.Columns[2].WithAggregate(AggregateMax)
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "footer", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if last := lines[len(lines)-1]; last != "Sum,115,10" {
+		t.Errorf("footer line = %q, want %q", last, "Sum,115,10")
+	}
+}
+
+func TestExportXLSX_footer(t *testing.T) {
+	for _, formulas := range []bool{false, true} {
+		table := fixtureTable("region", "sales", "score").
+			WithFooter(NewFooterOptions().WithFormulas(formulas)).
+			WithStartPosition(2, 2)
+		table.Columns[1].WithAggregate(AggregateSum)
+		table.Columns[2].WithAggregate(AggregateMax)
+		s := NewSpreadsheetExcelize("Sheet1", table)
+
+		res, err := ExportXLSX(s, FileWriteParams{Filename: "footer", Filepath: t.TempDir(), OverwriteFile: true})
+		if err != nil {
+			t.Fatalf("ExportXLSX failed: %v", err)
+		}
+		f, err := excelize.OpenFile(res.Filepath)
+		if err != nil {
+			t.Fatalf("OpenFile failed: %v", err)
+		}
+
+		// Header at row 2, data at rows 3-7, footer at row 8 (columns B-D)
+		if got, _ := f.GetCellValue("Sheet1", "B8"); got != DefaultFooterLabel {
+			t.Errorf("formulas=%v: B8 = %q, want %q", formulas, got, DefaultFooterLabel)
+		}
+		formula, _ := f.GetCellFormula("Sheet1", "C8")
+		if formulas && formula != "SUM(C3:C7)" {
+			t.Errorf("C8 formula = %q, want SUM(C3:C7)", formula)
+		}
+		if !formulas {
+			if got, _ := f.GetCellValue("Sheet1", "C8"); got != "115" || formula != "" {
+				t.Errorf("C8 = %q (formula %q), want value 115", got, formula)
+			}
+		}
+		styleID, _ := f.GetCellStyle("Sheet1", "D8")
+		if style, err := f.GetStyle(styleID); err != nil || style.Font == nil || !style.Font.Bold {
+			t.Errorf("formulas=%v: expected bold footer", formulas)
+		}
+		_ = f.Close()
+	}
+}
+
+func TestExportHTML_footer(t *testing.T) {
+	table := fixtureTable("region", "sales", "score").WithFooter(nil)
+	table.Columns[1].WithAggregate(AggregateSum)
+	table.Columns[2].WithAggregate(AggregateMax)
+
+	res, err := ExportHTML(table, HTMLOptions{FragmentOnly: true}, FileWriteParams{Filename: "footer", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportHTML failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	markup := string(content)
+	foot := markup[strings.Index(markup, "<tfoot>"):]
+	if !strings.Contains(foot, ">Total<") || !strings.Contains(foot, ">115<") {
+		t.Errorf("expected footer in <tfoot>, got:\n%s", markup)
+	}
+}
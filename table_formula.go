@@ -0,0 +1,155 @@
+// table_formula.go - Cell-level formulas.
+//
+// This file implements formulas attached to individual cells (CellOptions.Formula) and to footer
+// cells (Column.FooterFormula), e.g. a ratio next to the data or in the totals row. Formulas refer
+// to table cells through placeholders naming columns, resolved at write time against the final
+// layout (start position, grouping, orientation) in the backend's addressing scheme, so they stay
+// correct whatever the number of rows. Backends without formulas (CSV, HTML) write cell values.
+
+package spit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// formulaPlaceholder matches the column references of a formula, e.g. {{amount}}, {{$amount[]}}
+// or {{amount[footer]}}.
+var formulaPlaceholder = regexp.MustCompile(`\{\{(\$?)([^{}\[\]$]+)(\[\]|\[footer\])?\}\}`)
+
+// a1Reference matches a single A1 cell reference, possibly already absolute.
+var a1Reference = regexp.MustCompile(`^\$?([A-Z]+)\$?([0-9]+)$`)
+
+// ColumnRef returns a formula placeholder referencing the cell of the named column in the row of
+// the formula, e.g. "{{amount}}" resolved to "C4".
+func ColumnRef(name string) string {
+	return "{{" + name + "}}"
+}
+
+// ColumnRangeRef returns a formula placeholder referencing the data cells of the named column,
+// e.g. "{{amount[]}}" resolved to "C3:C20".
+func ColumnRangeRef(name string) string {
+	return "{{" + name + "[]}}"
+}
+
+// FooterRef returns a formula placeholder referencing the footer cell of the named column,
+// e.g. "{{amount[footer]}}" resolved to "C21".
+func FooterRef(name string) string {
+	return "{{" + name + "[footer]}}"
+}
+
+// AbsoluteRef marks a placeholder built by ColumnRef, ColumnRangeRef or FooterRef as absolute,
+// e.g. "{{$amount[footer]}}" resolved to "$C$21" in A1 addressing. Other strings are returned
+// unchanged.
+func AbsoluteRef(ref string) string {
+	if strings.HasPrefix(ref, "{{") && !strings.HasPrefix(ref, "{{$") {
+		return "{{$" + ref[2:]
+	}
+	return ref
+}
+
+// WithFooterFormula sets a formula written in the footer cell of this column instead of its
+// aggregate, in backends supporting formulas (see FooterRef for the placeholders).
+func (c *Column) WithFooterFormula(formula string) *Column {
+	c.FooterFormula = formula
+	return c
+}
+
+// RenderCellFormulas writes the formulas of the data cells (see CellOptions.Formula) over their
+// values. Formulas whose placeholders cannot be resolved are reported as warnings and the cell
+// keeps its value. Like RenderFooter, ops receives table-relative coordinates translated by the
+// start position.
+func (t *Table) RenderCellFormulas(ops TableOperations) error {
+	return t.renderCellFormulaRows(0, len(t.Data), t.Offset(ops))
+}
+
+// renderCellFormulaRows writes the formulas of the cells of the data rows from (included) to
+// (excluded); ops is already translated by the start position.
+func (t *Table) renderCellFormulaRows(from, to int, ops TableOperations) error {
+	dataStartRow := t.GetDataStartRow()
+	for _, col := range sortedKeys(t.CellOptionsMap) {
+		cells := t.CellOptionsMap[col]
+		for rowIndex := from; rowIndex < to; rowIndex++ {
+			formula := cells[rowIndex].Formula
+			if formula == "" {
+				continue
+			}
+			row := rowIndex + dataStartRow
+			resolved, err := t.resolveFormula(ops, formula, row)
+			if err != nil {
+				t.warn(WarningPhaseData, t.cellRef(col, row), "Failed to resolve cell formula, value kept", err, String("formula", formula))
+				continue
+			}
+			if err := ops.SetCellFormula(col, row, resolved); err != nil {
+				return fmt.Errorf("failed to set cell formula at (%d, %d): %w", col, row, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveFormula replaces the placeholders of a formula written in the given table-relative row
+// by references in the addressing scheme of ops. The leading "=" is optional and removed.
+func (t *Table) resolveFormula(ops TableOperations, formula string, row int) (string, error) {
+	flatColumns := t.Columns.GetFlattenedColumns()
+	addresser := AddresserFor(ops)
+	dataStartRow := t.GetDataStartRow()
+
+	var resolveErr error
+	resolved := formulaPlaceholder.ReplaceAllStringFunc(formula, func(match string) string {
+		parts := formulaPlaceholder.FindStringSubmatch(match)
+		absolute, name, target := parts[1] == "$", strings.TrimSpace(parts[2]), parts[3]
+
+		col := 0
+		for i, column := range flatColumns {
+			if column.Name == name {
+				col = i + 1
+				break
+			}
+		}
+		if col == 0 {
+			resolveErr = fmt.Errorf("unknown column %q in %s", name, match)
+			return match
+		}
+
+		var ref string
+		switch target {
+		case "[]":
+			if len(t.Data) == 0 {
+				resolveErr = fmt.Errorf("no data rows for %s", match)
+				return match
+			}
+			ref = addresser.RangeRef(col, dataStartRow, col, dataStartRow+len(t.Data)-1)
+		case "[footer]":
+			if !t.hasFooter() {
+				resolveErr = fmt.Errorf("no footer row for %s", match)
+				return match
+			}
+			ref = addresser.CellRef(col, t.GetFooterRow())
+		default:
+			ref = addresser.CellRef(col, row)
+		}
+		if absolute {
+			ref = absoluteReference(ref)
+		}
+		return ref
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return strings.TrimPrefix(resolved, "="), nil
+}
+
+// absoluteReference turns the A1 cells of a reference or range absolute ("C3:C20" becomes
+// "$C$3:$C$20"). References in other addressing schemes are returned unchanged.
+func absoluteReference(ref string) string {
+	cells := strings.Split(ref, ":")
+	for i, cell := range cells {
+		if !a1Reference.MatchString(cell) {
+			return ref
+		}
+		cells[i] = a1Reference.ReplaceAllString(cell, `$$$1$$$2`)
+	}
+	return strings.Join(cells, ":")
+}
@@ -0,0 +1,59 @@
+// table_formula_escape.go - Formula injection escaping.
+//
+// This file implements the escaping of text values that spreadsheet applications would evaluate
+// as formulas (see Table.WithFormulaEscape), a known injection vector when exports hold user
+// input: a cell such as "=HYPERLINK(...)" or "@SUM(...)" runs when the CSV file is opened in Excel.
+// Escaped values get a leading character making them plain text. CSV exports escape data, footer
+// and full-width row cells; columns formatted as formulas or hyperlinks are written as usual.
+// Sheet backends (XLSX, Google Sheets) store text as string cells, which are never evaluated, so
+// they write it unescaped.
+
+package spit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormulaEscapeMode defines how text values starting like a formula are escaped.
+type FormulaEscapeMode int
+
+const (
+	// FormulaEscapeNone writes values as is (default).
+	FormulaEscapeNone FormulaEscapeMode = iota
+
+	// FormulaEscapeQuote prefixes values with a single quote, e.g. "'=1+2".
+	FormulaEscapeQuote
+
+	// FormulaEscapeTab prefixes values with a tab character, which spreadsheets do not display.
+	FormulaEscapeTab
+)
+
+// formulaTriggers lists the leading characters that make spreadsheet applications evaluate a value.
+const formulaTriggers = "=+-@\t\r"
+
+// WithFormulaEscape sets how CSV exports escape text values starting with a formula character (=,
+// +, -, @, tab or carriage return). Numbers such as "-12" are never escaped. XLSX and Google
+// Sheets exports write text as string cells, which are never evaluated, and ignore the mode.
+func (t *Table) WithFormulaEscape(mode FormulaEscapeMode) *Table {
+	t.FormulaEscape = mode
+	return t
+}
+
+// escapeFormula returns the text of a value of the column, escaped when the table escapes formulas
+// and the text starts like a formula.
+func (t *Table) escapeFormula(column *Column, text string) string {
+	if t == nil || t.FormulaEscape == FormulaEscapeNone || text == "" || !strings.ContainsRune(formulaTriggers, rune(text[0])) {
+		return text
+	}
+	if column.Format == ExcelizeFormatFormula || column.Format == ExcelizeFormatHyperlink {
+		return text
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return text
+	}
+	if t.FormulaEscape == FormulaEscapeTab {
+		return "\t" + text
+	}
+	return "'" + text
+}
@@ -0,0 +1,79 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_escapeFormula(t *testing.T) {
+	column := NewColumn("note", "Note")
+	tests := []struct {
+		mode FormulaEscapeMode
+		text string
+		want string
+	}{
+		{FormulaEscapeQuote, "=1+2", "'=1+2"},
+		{FormulaEscapeQuote, "+33 6 12", "'+33 6 12"},
+		{FormulaEscapeQuote, "@SUM(A1)", "'@SUM(A1)"},
+		{FormulaEscapeQuote, "-cmd", "'-cmd"},
+		{FormulaEscapeTab, "=1+2", "\t=1+2"},
+		{FormulaEscapeQuote, "-12.5", "-12.5"},
+		{FormulaEscapeQuote, "a=b", "a=b"},
+		{FormulaEscapeQuote, "", ""},
+		{FormulaEscapeNone, "=1+2", "=1+2"},
+	}
+	for _, tt := range tests {
+		table := NewTable(nil, nil, true).WithFormulaEscape(tt.mode)
+		if got := table.escapeFormula(column, tt.text); got != tt.want {
+			t.Errorf("%s escapeFormula(%q) = %q, want %q", tt.mode, tt.text, got, tt.want)
+		}
+	}
+
+	table := NewTable(nil, nil, true).WithFormulaEscape(FormulaEscapeQuote)
+	if got := table.escapeFormula(NewColumn("f", "F").WithFormat(ExcelizeFormatFormula), "=1+2"); got != "=1+2" {
+		t.Errorf("escapeFormula() on a formula column = %q, want the formula", got)
+	}
+}
+
+func TestExport_formulaEscape(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"name": "=HYPERLINK(\"http://evil\")", "amount": -3, "total": "SUM(1,2)"},
+		{"name": "-ignored"},
+	}, Columns{
+		NewColumn("name", "Name"),
+		NewColumn("amount", "Amount"),
+		NewColumn("total", "Total").WithFormat(ExcelizeFormatFormula),
+	}, true).WithFormulaEscape(FormulaEscapeQuote).
+		WithRowOptions(RowOptionsMap{1: {SpanAllColumns: true, Value: "@note"}}).
+		WithFooter(&FooterOptions{Label: "=total"})
+
+	var buf bytes.Buffer
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "escaped", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	want := "Name,Amount,Total\n\"'=HYPERLINK(\"\"http://evil\"\")\",-3,\"SUM(1,2)\"\n'@note,,\n'=total,,\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "escaped", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if got, _ := f.GetCellValue("Data", "A2"); got != "=HYPERLINK(\"http://evil\")" {
+		t.Errorf("A2 = %q, want the text unescaped", got)
+	}
+	if cellType, _ := f.GetCellType("Data", "A2"); cellType != excelize.CellTypeSharedString && cellType != excelize.CellTypeInlineString {
+		t.Errorf("A2 type = %v, want a string cell", cellType)
+	}
+	if formula, _ := f.GetCellFormula("Data", "C2"); formula != "SUM(1,2)" {
+		t.Errorf("C2 formula = %q, want the formula column written as usual", formula)
+	}
+}
@@ -0,0 +1,78 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_cellFormulas(t *testing.T) {
+	share := "=" + ColumnRef("revenue") + "/" + AbsoluteRef(FooterRef("revenue"))
+	table := NewTable(DataSlice{
+		{"name": "Alice", "revenue": 100, "profit": 20},
+		{"name": "Bob", "revenue": 300, "profit": 90},
+	}, Columns{
+		NewColumn("name", "Name"),
+		NewColumn("revenue", "Revenue").WithAggregate(AggregateSum),
+		NewColumn("profit", "Profit").WithAggregate(AggregateSum),
+		NewColumn("share", "Share").WithFooterFormula("SUM(" + ColumnRangeRef("share") + ")"),
+	}, true).
+		WithStartPosition(2, 2).
+		WithFooter(nil).
+		WithCellOptions(CellOptionsMap{
+			4: {
+				0: *NewCellOptions(0, 3).WithFormula(share),
+				1: *NewCellOptions(1, 3).WithFormula(share),
+			},
+			3: {1: *NewCellOptions(1, 2).WithFormula(ColumnRef("missing") + "*2")},
+		})
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "formulas", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Header on row 2, data on rows 3-4, footer on row 5, columns B-E
+	tests := map[string]string{
+		"E3": "C3/$C$5",
+		"E4": "C4/$C$5",
+		"E5": "SUM(E3:E4)",
+		"D4": "",
+	}
+	for cell, want := range tests {
+		if got, _ := f.GetCellFormula("Sheet1", cell); got != want {
+			t.Errorf("%s formula = %q, want %q", cell, got, want)
+		}
+	}
+	if got, _ := f.GetCellValue("Sheet1", "D4"); got != "90" {
+		t.Errorf("D4 = %q, want the value kept for an unresolved formula", got)
+	}
+	if len(res.Warnings) != 1 || res.Warnings[0].Cell != "D4" {
+		t.Errorf("warnings = %v, want one for D4", res.Warnings)
+	}
+}
+
+func TestAbsoluteReference(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"B3", "$B$3"},
+		{"B3:C10", "$B$3:$C$10"},
+		{"$B$3", "$B$3"},
+		{"R3C2", "R3C2"},
+	}
+	for _, tt := range tests {
+		if got := absoluteReference(tt.ref); got != tt.want {
+			t.Errorf("absoluteReference(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+	if got := AbsoluteRef(ColumnRef("amount")); got != "{{$amount}}" {
+		t.Errorf("AbsoluteRef = %q", got)
+	}
+}
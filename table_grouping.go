@@ -0,0 +1,322 @@
+// table_grouping.go - Row grouping.
+//
+// This file implements row grouping: data rows are sorted and segmented by one or more group
+// columns, a full-width group header row is inserted at the start of each group, and every row is
+// given an outline level so backends supporting it (XLSX) let readers collapse groups.
+// Grouping is applied by Table.Prepare, so every backend writes the same rows.
+
+package spit
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// GroupOptions configures the group header and subtotal rows of a grouped table.
+type GroupOptions struct {
+	Style     *Style                                         `json:"style,omitempty" yaml:"style,omitempty"`         // Optional style for group header rows (default: bold)
+	Collapsed bool                                           `json:"collapsed,omitempty" yaml:"collapsed,omitempty"` // Open XLSX workbooks with groups collapsed
+	Label     func(column *Column, value interface{}) string `json:"-" yaml:"-"`                                     // Optional header label (default: the group value)
+
+	Subtotals        bool                                           `json:"subtotals,omitempty" yaml:"subtotals,omitempty"`               // Write a subtotal row after each group (see Column.Aggregate)
+	SubtotalStyle    *Style                                         `json:"subtotalStyle,omitempty" yaml:"subtotalStyle,omitempty"`       // Optional style for subtotal rows (default: bold, italic)
+	SubtotalFormulas bool                                           `json:"subtotalFormulas,omitempty" yaml:"subtotalFormulas,omitempty"` // Write built-in aggregates as SUBTOTAL formulas (XLSX, Google Sheets)
+	SubtotalLabel    func(column *Column, value interface{}) string `json:"-" yaml:"-"`                                                   // Optional subtotal label (default: "<value> Total")
+}
+
+// NewGroupOptions creates a new GroupOptions instance with default settings.
+func NewGroupOptions() *GroupOptions {
+	return &GroupOptions{}
+}
+
+// WithStyle sets the style of group header rows.
+func (g *GroupOptions) WithStyle(style *Style) *GroupOptions {
+	g.Style = style
+	return g
+}
+
+// WithCollapsed sets whether XLSX workbooks open with groups collapsed.
+func (g *GroupOptions) WithCollapsed(collapsed bool) *GroupOptions {
+	g.Collapsed = collapsed
+	return g
+}
+
+// WithLabel sets the function building the label of group header rows.
+func (g *GroupOptions) WithLabel(label func(column *Column, value interface{}) string) *GroupOptions {
+	g.Label = label
+	return g
+}
+
+// WithSubtotals sets whether a subtotal row is written after each group. Subtotals aggregate the
+// group rows of every column with an Aggregate (see Column.WithAggregate).
+func (g *GroupOptions) WithSubtotals(enabled bool) *GroupOptions {
+	g.Subtotals = enabled
+	return g
+}
+
+// WithSubtotalStyle sets the style of subtotal rows.
+func (g *GroupOptions) WithSubtotalStyle(style *Style) *GroupOptions {
+	g.SubtotalStyle = style
+	return g
+}
+
+// WithSubtotalFormulas sets whether built-in aggregates of subtotal rows are written as SUBTOTAL formulas.
+func (g *GroupOptions) WithSubtotalFormulas(formulas bool) *GroupOptions {
+	g.SubtotalFormulas = formulas
+	return g
+}
+
+// WithSubtotalLabel sets the function building the label of subtotal rows.
+func (g *GroupOptions) WithSubtotalLabel(label func(column *Column, value interface{}) string) *GroupOptions {
+	g.SubtotalLabel = label
+	return g
+}
+
+// WithGroupBy groups the data rows by the given column names, outermost group first.
+// Rows are sorted by the group values (stable, so the original order is kept within a group) and a
+// full-width header row is inserted at the start of each group (see GroupOptions).
+func (t *Table) WithGroupBy(columns ...string) *Table {
+	t.GroupBy = columns
+	return t
+}
+
+// WithGroupOptions sets the group header options of a grouped table.
+func (t *Table) WithGroupOptions(options *GroupOptions) *Table {
+	t.GroupOptions = options
+	return t
+}
+
+// RowOutlineLevel returns the outline level of a 0-based data row of a prepared table: 0 for the
+// outermost group headers, increasing with each group level, and len(GroupBy) for data rows.
+// Returns 0 for tables without grouping.
+func (t *Table) RowOutlineLevel(rowIndex int) int {
+	if rowIndex < 0 || rowIndex >= len(t.outlineLevels) {
+		return 0
+	}
+	return t.outlineLevels[rowIndex]
+}
+
+// SourceRowIndex returns the index in the original Data of a 0-based data row of a prepared table,
+// or -1 for the header and subtotal rows inserted by grouping.
+func (t *Table) SourceRowIndex(rowIndex int) int {
+	if t.sourceRows == nil {
+		return rowIndex
+	}
+	if rowIndex < 0 || rowIndex >= len(t.sourceRows) {
+		return -1
+	}
+	return t.sourceRows[rowIndex]
+}
+
+// applyGrouping returns a shallow copy of t with its rows sorted, segmented and interleaved with
+// group header rows (and subtotal rows when enabled). Row and cell options follow their rows to
+// their new indices.
+func (t *Table) applyGrouping() *Table {
+	groupColumns := make([]*Column, len(t.GroupBy))
+	flatColumns := t.Columns.GetFlattenedColumns()
+	for i, name := range t.GroupBy {
+		groupColumns[i] = NewColumn(name, name)
+		for _, column := range flatColumns {
+			if column.Name == name {
+				groupColumns[i] = column
+				break
+			}
+		}
+	}
+
+	groupValues := func(item Data) []interface{} {
+		values := make([]interface{}, len(groupColumns))
+		for i, column := range groupColumns {
+			if v, err, found := item.LookupColumn(column); err == nil && found {
+				values[i] = NormalizeValue(v)
+			}
+		}
+		return values
+	}
+
+	// Sort the original row indices by group values
+	values := make([][]interface{}, len(t.Data))
+	order := make([]int, len(t.Data))
+	for i, item := range t.Data {
+		values[i] = groupValues(item)
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		for level := range groupColumns {
+			if c := compareValues(values[a][level], values[b][level]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	style := &Style{Bold: true}
+	if t.GroupOptions != nil && t.GroupOptions.Style != nil {
+		style = t.GroupOptions.Style
+	}
+
+	g := *t
+	g.Data = make(DataSlice, 0, len(t.Data))
+	g.RowOptionsMap = make(RowOptionsMap, len(t.RowOptionsMap))
+	g.CellOptionsMap = make(CellOptionsMap, len(t.CellOptionsMap))
+	g.outlineLevels = make([]int, 0, len(t.Data))
+	g.sourceRows = make([]int, 0, len(t.Data))
+
+	// Groups currently open, outermost first; closing a group writes its subtotal row
+	var open []openGroup
+	closeGroups := func(from int) {
+		for level := len(open) - 1; level >= from; level-- {
+			if t.GroupOptions != nil && t.GroupOptions.Subtotals {
+				g.appendSubtotal(groupColumns[level], level, open[level])
+			}
+		}
+		open = open[:from]
+	}
+
+	var previous []string
+	for _, src := range order {
+		keys := make([]string, len(groupColumns))
+		for level, v := range values[src] {
+			keys[level] = fmt.Sprintf("%v", v)
+		}
+
+		// Open a header row for every group level starting at the first changed value
+		changed := 0
+		for changed < len(keys) && previous != nil && keys[changed] == previous[changed] {
+			changed++
+		}
+		closeGroups(changed)
+		for level := changed; level < len(keys); level++ {
+			index := len(g.Data)
+			g.Data = append(g.Data, Data{})
+			g.RowOptionsMap[index] = RowOptions{
+				RowIndex:       index,
+				Style:          style,
+				SpanAllColumns: true,
+				Value:          t.groupLabel(groupColumns[level], values[src][level]),
+			}
+			g.outlineLevels = append(g.outlineLevels, level)
+			g.sourceRows = append(g.sourceRows, -1)
+			open = append(open, openGroup{first: index + 1, value: values[src][level]})
+		}
+		previous = keys
+
+		index := len(g.Data)
+		g.Data = append(g.Data, t.Data[src])
+		for level := range open {
+			open[level].rows = append(open[level].rows, t.Data[src])
+		}
+		g.outlineLevels = append(g.outlineLevels, len(groupColumns))
+		g.sourceRows = append(g.sourceRows, t.SourceRowIndex(src))
+		if rowOptions, ok := t.RowOptionsMap[src]; ok {
+			rowOptions.RowIndex = index
+			g.RowOptionsMap[index] = rowOptions
+		}
+		for col, rows := range t.CellOptionsMap {
+			if cellOptions, ok := rows[src]; ok {
+				if g.CellOptionsMap[col] == nil {
+					g.CellOptionsMap[col] = make(map[int]CellOptions)
+				}
+				cellOptions.RowIndex = index
+				g.CellOptionsMap[col][index] = cellOptions
+			}
+		}
+	}
+	closeGroups(0)
+	return &g
+}
+
+// groupLabel returns the label of a group header row.
+func (t *Table) groupLabel(column *Column, value interface{}) string {
+	if t.GroupOptions != nil && t.GroupOptions.Label != nil {
+		return t.GroupOptions.Label(column, value)
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// compareValues orders two normalized values: numbers numerically, times chronologically and
+// anything else by its string representation. Nil values sort first.
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	}
+	if _, isString := a.(string); !isString {
+		if fa, ok := toFloat(a); ok {
+			if fb, ok := toFloat(b); ok {
+				return cmp.Compare(fa, fb)
+			}
+		}
+	}
+	return cmp.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// outlineSetter is implemented by spreadsheets supporting row outlines.
+type outlineSetter interface {
+	SetRowOutlineLevel(row, level int) error
+	SetRowVisible(row int, visible bool) error
+	SetOutlineSummaryAbove() error
+}
+
+// startOutline places group headers above their rows and returns the spreadsheet setting the row
+// outlines, or nil when the table has none or they cannot be written.
+func (xlsx *xlsx) startOutline() outlineSetter {
+	t := xlsx.table
+	if len(t.outlineLevels) == 0 {
+		return nil
+	}
+	if t.Transposed {
+		t.warn(WarningPhaseSheet, "", "Row outlines are not supported by transposed tables, grouping levels skipped", nil)
+		return nil
+	}
+	setter, ok := xlsx.spreadsheet.(outlineSetter)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support row outlines, grouping levels skipped", nil)
+		return nil
+	}
+
+	// Group headers sit above their rows
+	if err := setter.SetOutlineSummaryAbove(); err != nil {
+		t.warn(WarningPhaseSheet, "", "Failed to place outline summary rows above details", err)
+	}
+	return setter
+}
+
+// writeOutlineRows sets the outline level of the grouped data rows from (included) to (excluded)
+// so readers can collapse groups. Rows whose level cannot be set are reported as warnings (see
+// ErrorMode).
+func (xlsx *xlsx) writeOutlineRows(setter outlineSetter, from, to int) {
+	t := xlsx.table
+	collapsed := t.GroupOptions != nil && t.GroupOptions.Collapsed
+	rowOffset := t.GetDataStartRow() + t.GetStartRow() - 1
+	for rowIndex := from; rowIndex < min(to, len(t.outlineLevels)); rowIndex++ {
+		level := t.outlineLevels[rowIndex]
+		if level == 0 {
+			continue
+		}
+		row := rowIndex + rowOffset
+		if err := setter.SetRowOutlineLevel(row, level); err != nil {
+			t.warn(WarningPhaseSheet, t.cellRef(1, rowIndex+t.GetDataStartRow()), "Failed to set row outline level", err, Int("row", row))
+			continue
+		}
+		if collapsed {
+			if err := setter.SetRowVisible(row, false); err != nil {
+				t.warn(WarningPhaseSheet, t.cellRef(1, rowIndex+t.GetDataStartRow()), "Failed to collapse row", err, Int("row", row))
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package spit
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_WithGroupBy(t *testing.T) {
+	table := fixtureTable("region", "country", "sales").
+		WithGroupBy("region", "country").
+		WithCellOptions(CellOptionsMap{3: {0: CellOptions{RowIndex: 0, Style: &Style{Italic: true}}}})
+
+	p := table.Prepare()
+	var rows []string
+	var levels []int
+	for i, item := range p.Data {
+		if ro, ok := p.RowOptionsMap[i]; ok && ro.SpanAllColumns {
+			rows = append(rows, "# "+ro.Value.(string))
+		} else {
+			rows = append(rows, item["country"].(string))
+		}
+		levels = append(levels, p.RowOutlineLevel(i))
+	}
+
+	wantRows := []string{"# EU", "# DE", "DE", "# FR", "FR", "FR", "# US", "# CA", "CA", "# US", "US"}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("rows = %v, want %v", rows, wantRows)
+	}
+	wantLevels := []int{0, 1, 2, 1, 2, 2, 0, 1, 2, 1, 2}
+	if !reflect.DeepEqual(levels, wantLevels) {
+		t.Errorf("levels = %v, want %v", levels, wantLevels)
+	}
+
+	// The cell option of the original first row (US/CA) follows it to index 8
+	if co, ok := p.CellOptionsMap[3][8]; !ok || co.RowIndex != 8 || !co.Style.Italic {
+		t.Errorf("cell options should follow their row, got %v", p.CellOptionsMap)
+	}
+	if len(table.Data) != 5 || table.Data[0]["region"] != "US" {
+		t.Error("grouping must not modify the original table")
+	}
+}
+
+func TestExport_grouping(t *testing.T) {
+	dir := t.TempDir()
+	table := fixtureTable("region", "country", "sales").WithGroupBy("region").
+		WithGroupOptions(NewGroupOptions().WithCollapsed(true).WithLabel(func(c *Column, v interface{}) string {
+			return c.Label + ": " + v.(string)
+		}))
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "grouped", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if got, _ := f.GetCellValue("Sheet1", "A2"); got != "Region: EU" {
+		t.Errorf("A2 = %q, want the EU group header", got)
+	}
+	merges, _ := f.GetMergeCells("Sheet1")
+	if len(merges) == 0 || merges[0].GetStartAxis() != "A2" || merges[0].GetEndAxis() != "C2" {
+		t.Errorf("expected the group header to span A2:C2, got %v", merges)
+	}
+	for row, want := range map[int]uint8{2: 0, 3: 1, 6: 0, 7: 1} {
+		if level, _ := f.GetRowOutlineLevel("Sheet1", row); level != want {
+			t.Errorf("row %d outline level = %d, want %d", row, level, want)
+		}
+	}
+	if visible, _ := f.GetRowVisible("Sheet1", 3); visible {
+		t.Error("expected detail rows to be collapsed")
+	}
+
+	csvRes, err := ExportCSV(",", table, FileWriteParams{Filename: "grouped", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(csvRes.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	want := "Region,Country,Sales\nRegion: EU,,\nEU,FR,10\nEU,DE,20\nEU,FR,15\nRegion: US,,\nUS,CA,30\nUS,US,40\n"
+	if string(content) != want {
+		t.Errorf("CSV = %q, want %q", content, want)
+	}
+}
+
+func TestTable_rowStyler_grouping(t *testing.T) {
+	data := DataSlice{
+		{"team": "B", "name": "Alan"},
+		{"team": "A", "name": "Ada"},
+	}
+	var indices []int
+	table := NewTable(data, Columns{NewColumn("team", "Team"), NewColumn("name", "Name")}, true).
+		WithGroupBy("team").
+		WithRowStyler(func(rowIndex int, row Data) *Style {
+			indices = append(indices, rowIndex)
+			if row["name"] == "Ada" {
+				return &Style{Bold: true}
+			}
+			return nil
+		}).
+		Prepare()
+
+	// Group header rows are not styled by the callback, data rows keep their index in Data
+	var styled []int
+	for i := range table.Data {
+		if table.rowStyle(i) != nil && table.SourceRowIndex(i) >= 0 {
+			styled = append(styled, table.SourceRowIndex(i))
+		}
+	}
+	if !reflect.DeepEqual(indices, []int{1, 0}) {
+		t.Errorf("styler indices = %v, want [1 0]", indices)
+	}
+	if !reflect.DeepEqual(styled, []int{1}) {
+		t.Errorf("styled source rows = %v, want [1]", styled)
+	}
+}
@@ -0,0 +1,122 @@
+// table_keyvalue.go - Key/value tables built from maps.
+//
+// This file builds a two-column (Key, Value) table from a map, the usual shape of configuration
+// dumps and settings exports: keys are sorted, nested maps are flattened into dotted keys and the
+// value column carries an optional format like any other column.
+
+package spit
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// KeyValueOptions configures NewKeyValueTable.
+type KeyValueOptions struct {
+	KeyLabel    string // Header label of the key column (default: "Key")
+	ValueLabel  string // Header label of the value column (default: "Value")
+	ValueFormat string // Optional format of the value column (see Column.Format)
+	// Flatten replaces nested maps by their entries, with keys joined by Separator (e.g.
+	// "db.host"); otherwise nested maps are written as values
+	Flatten   bool
+	Separator string // Separator of flattened keys (default: KeyPathSeparator)
+	// Less orders the keys (default: ascending order); Descending reverses the order
+	Less       func(a, b string) bool
+	Descending bool
+}
+
+// Names of the columns of key/value tables.
+const (
+	KeyValueKeyColumn   = "key"
+	KeyValueValueColumn = "value"
+)
+
+// NewKeyValueTable builds a table with one row per entry of data, holding the key in the "key"
+// column and the value in the "value" column (see KeyValueKeyColumn and KeyValueValueColumn).
+// Nested maps are any map with string keys (Data, map[string]interface{}, map[string]string, ...);
+// an empty nested map is written as an entry with an empty value when flattened. The header is
+// written.
+func NewKeyValueTable(data map[string]interface{}, opts KeyValueOptions) *Table {
+	separator := opts.Separator
+	if separator == "" {
+		separator = KeyPathSeparator
+	}
+
+	entries := map[string]interface{}{}
+	var collect func(prefix string, m map[string]interface{})
+	collect = func(prefix string, m map[string]interface{}) {
+		for k, v := range m {
+			key := k
+			if prefix != "" {
+				key = prefix + separator + k
+			}
+			if nested, ok := asStringMap(v); ok && opts.Flatten {
+				if len(nested) == 0 {
+					entries[key] = nil
+					continue
+				}
+				collect(key, nested)
+				continue
+			}
+			entries[key] = v
+		}
+	}
+	collect("", data)
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	less := opts.Less
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+	slices.SortStableFunc(keys, func(a, b string) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	if opts.Descending {
+		slices.Reverse(keys)
+	}
+
+	rows := make(DataSlice, len(keys))
+	for i, key := range keys {
+		rows[i] = Data{KeyValueKeyColumn: key, KeyValueValueColumn: entries[key]}
+	}
+
+	keyLabel, valueLabel := opts.KeyLabel, opts.ValueLabel
+	if keyLabel == "" {
+		keyLabel = "Key"
+	}
+	if valueLabel == "" {
+		valueLabel = "Value"
+	}
+	columns := Columns{
+		NewColumn(KeyValueKeyColumn, keyLabel),
+		NewColumn(KeyValueValueColumn, valueLabel).WithFormat(opts.ValueFormat),
+	}
+	return NewTable(rows, columns, true)
+}
+
+// asStringMap returns a map with string keys as a map[string]interface{}.
+func asStringMap(value interface{}) (map[string]interface{}, bool) {
+	if d, ok := asData(value); ok {
+		return d, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
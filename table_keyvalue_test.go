@@ -0,0 +1,62 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewKeyValueTable(t *testing.T) {
+	config := map[string]interface{}{
+		"name": "api",
+		"db": map[string]interface{}{
+			"host": "localhost",
+			"port": 5432,
+			"pool": map[string]string{"max": "10"},
+		},
+		"tags":  Data{},
+		"debug": true,
+	}
+
+	tests := []struct {
+		name     string
+		opts     KeyValueOptions
+		wantKeys []string
+	}{
+		{"nested maps as values", KeyValueOptions{}, []string{"db", "debug", "name", "tags"}},
+		{"flattened", KeyValueOptions{Flatten: true}, []string{"db.host", "db.pool.max", "db.port", "debug", "name", "tags"}},
+		{"custom separator, descending", KeyValueOptions{Flatten: true, Separator: "/", Descending: true}, []string{"tags", "name", "debug", "db/port", "db/pool/max", "db/host"}},
+		{"custom order", KeyValueOptions{Less: func(a, b string) bool { return len(a) < len(b) }}, []string{"db", "name", "tags", "debug"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewKeyValueTable(config, tt.opts)
+			var keys []string
+			for _, row := range table.Data {
+				keys = append(keys, row[KeyValueKeyColumn].(string))
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+
+	t.Run("values and labels", func(t *testing.T) {
+		table := NewKeyValueTable(config, KeyValueOptions{Flatten: true, KeyLabel: "Setting", ValueLabel: "Current"})
+		values := map[string]interface{}{}
+		for _, row := range table.Data {
+			values[row[KeyValueKeyColumn].(string)] = row[KeyValueValueColumn]
+		}
+		if values["db.port"] != 5432 || values["db.pool.max"] != "10" || values["tags"] != nil {
+			t.Errorf("values = %v", values)
+		}
+
+		var buf bytes.Buffer
+		if _, err := ExportCSV(",", table, FileWriteParams{Filename: "config", Writer: &buf}); err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "Setting,Current\ndb.host,localhost\n") {
+			t.Errorf("content = %q", buf.String())
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// table_limit.go - Row limit and truncation notice.
+//
+// This file implements the row limit of a table (see Table.WithLimit). The limit is applied by
+// Table.Prepare after filtering and sorting, so every format exports the same first rows. Tables
+// may append a notice row after the table (and its footer) telling readers how many rows were
+// left out; RenderTruncationNotice writes it through TableOperations so sheet backends share it,
+// while CSV and Markdown write it as a trailing record and paragraph.
+
+package spit
+
+import "fmt"
+
+// DefaultTruncationNoticeText is the format of the truncation notice, given the number of rows
+// left out.
+const DefaultTruncationNoticeText = "%d additional rows not shown"
+
+// TruncationNoticeOptions configures the notice written when Table.Limit truncates the data.
+type TruncationNoticeOptions struct {
+	Text  string `json:"text,omitempty" yaml:"text,omitempty"`   // Format of the notice, given the number of rows left out (default: DefaultTruncationNoticeText)
+	Style *Style `json:"style,omitempty" yaml:"style,omitempty"` // Optional style of the notice cell (default: italic, gray text)
+}
+
+// NewTruncationNoticeOptions creates a new TruncationNoticeOptions instance with default settings.
+func NewTruncationNoticeOptions() *TruncationNoticeOptions {
+	return &TruncationNoticeOptions{}
+}
+
+// WithText sets the format of the notice, e.g. "…and %d more orders".
+func (n *TruncationNoticeOptions) WithText(text string) *TruncationNoticeOptions {
+	n.Text = text
+	return n
+}
+
+// WithStyle sets the style of the notice cell.
+func (n *TruncationNoticeOptions) WithStyle(style *Style) *TruncationNoticeOptions {
+	n.Style = style
+	return n
+}
+
+// GetText returns the notice for the given number of rows left out.
+func (n *TruncationNoticeOptions) GetText(omitted int) string {
+	text := DefaultTruncationNoticeText
+	if n != nil && n.Text != "" {
+		text = n.Text
+	}
+	return fmt.Sprintf(text, omitted)
+}
+
+// WithLimit sets the maximum number of data rows exported (0 = no limit). The limit applies after
+// filters and sorting, before preview mode and grouping.
+func (t *Table) WithLimit(limit int64) *Table {
+	t.Limit = limit
+	return t
+}
+
+// WithTruncationNotice sets the notice row written after the table when Limit truncates the data.
+func (t *Table) WithTruncationNotice(options *TruncationNoticeOptions) *Table {
+	t.TruncationNotice = options
+	return t
+}
+
+// Truncated returns the number of data rows left out by Limit. It is only known once the table is
+// prepared (see Prepare).
+func (t *Table) Truncated() int {
+	return t.truncated
+}
+
+// applyLimit returns a shallow copy of t holding its first Limit data rows.
+func (t *Table) applyLimit() *Table {
+	kept := make([]int, t.Limit)
+	for i := range kept {
+		kept[i] = i
+	}
+	limited := t.withRows(kept)
+	limited.truncated = len(t.Data) - len(kept)
+	return limited
+}
+
+// hasTruncationNotice reports whether a truncation notice row is written.
+func (t *Table) hasTruncationNotice() bool {
+	return t.TruncationNotice != nil && t.truncated > 0
+}
+
+// GetTruncationNoticeRow returns the 1-based, table-relative row of the truncation notice (right
+// after the footer, or after the data rows without footer).
+func (t *Table) GetTruncationNoticeRow() int {
+	if t.hasFooter() {
+		return t.GetFooterRow() + 1
+	}
+	return t.GetDataStartRow() + len(t.Data)
+}
+
+// RenderTruncationNotice writes the truncation notice in the first column of its row when Limit
+// truncated the data and a notice is configured. Like RenderFooter, ops receives table-relative
+// coordinates translated to the start position.
+func (t *Table) RenderTruncationNotice(ops TableOperations) error {
+	if !t.hasTruncationNotice() {
+		return nil
+	}
+	ops = t.Offset(ops)
+	if err := ops.SetCellValue(1, t.GetTruncationNoticeRow(), t.TruncationNotice.GetText(t.truncated)); err != nil {
+		return fmt.Errorf("failed to write truncation notice: %w", err)
+	}
+	return nil
+}
+
+// applyTruncationNoticeStyle applies the notice style (default: italic, gray text) to the notice cell.
+func (t *Table) applyTruncationNoticeStyle(ops TableOperations) error {
+	style := Style{Italic: true, TextColor: "#808080"}
+	if t.TruncationNotice.Style != nil {
+		style = t.contrastStyle(*t.TruncationNotice.Style)
+	}
+	row := t.GetTruncationNoticeRow()
+	if err := ops.ApplyStyleToCell(1, row, style); err != nil {
+		return fmt.Errorf("failed to apply truncation notice style: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package spit
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_WithLimit(t *testing.T) {
+	table := fixtureTable("name", "team", "score").
+		WithFilter(func(row Data) bool { return row["score"] != nil }).
+		WithSort(SortKey{Field: "name"}).
+		WithLimit(2)
+
+	p := table.Prepare()
+	if got, want := sortedNames(p), []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("limited rows = %v, want %v", got, want)
+	}
+	if p.Truncated() != 2 || p.SourceRowIndex(1) != 2 {
+		t.Errorf("Truncated() = %d, SourceRowIndex(1) = %d; want 2 and 2", p.Truncated(), p.SourceRowIndex(1))
+	}
+	if len(table.Data) != 5 || table.Truncated() != 0 {
+		t.Error("limiting must not modify the original table")
+	}
+
+	if p := fixtureTable("name", "team", "score").WithLimit(5).Prepare(); len(p.Data) != 5 || p.Truncated() != 0 {
+		t.Errorf("limit above the row count kept %d rows, truncated %d", len(p.Data), p.Truncated())
+	}
+}
+
+func TestExport_truncationNotice(t *testing.T) {
+	table := func(notice *TruncationNoticeOptions) *Table {
+		table := fixtureTable("name", "team", "score").WithLimit(2).WithFooter(nil).WithTruncationNotice(notice)
+		table.Columns[2].WithAggregate(AggregateSum)
+		return table
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			notice *TruncationNoticeOptions
+			want   string
+		}{
+			{name: "without notice", want: "Name,Team,Score\ncarol,b,7\nalice,a,10\nTotal,,17\n"},
+			{name: "default notice", notice: NewTruncationNoticeOptions(),
+				want: "Name,Team,Score\ncarol,b,7\nalice,a,10\nTotal,,17\n3 additional rows not shown,,\n"},
+			{name: "custom text", notice: NewTruncationNoticeOptions().WithText("…and %d more"),
+				want: "Name,Team,Score\ncarol,b,7\nalice,a,10\nTotal,,17\n…and 3 more,,\n"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				if _, err := ExportCSV(",", table(tt.notice), FileWriteParams{Filename: "limited", Writer: &buf}); err != nil {
+					t.Fatalf("ExportCSV() error = %v", err)
+				}
+				if buf.String() != tt.want {
+					t.Errorf("expected %q, got %q", tt.want, buf.String())
+				}
+			})
+		}
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		var buf bytes.Buffer
+		notice := NewTruncationNoticeOptions().WithStyle(&Style{Bold: true})
+		res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table(notice).WithStartPosition(2, 2)), FileWriteParams{Filename: "limited", Writer: &buf})
+		if err != nil {
+			t.Fatalf("ExportXLSX() error = %v", err)
+		}
+		if got := res.Sheets[0].TableRange.String(); got != "B2:D6" {
+			t.Errorf("TableRange = %s, want B2:D6 with the notice", got)
+		}
+		f, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatalf("OpenReader failed: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		if got, _ := f.GetCellValue("Data", "B6"); got != "3 additional rows not shown" {
+			t.Errorf("B6 = %q, want the notice", got)
+		}
+		id, _ := f.GetCellStyle("Data", "B6")
+		if style, _ := f.GetStyle(id); style == nil || style.Font == nil || !style.Font.Bold {
+			t.Errorf("notice style = %+v, want bold", style)
+		}
+	})
+
+	t.Run("markdown and html", func(t *testing.T) {
+		markup, err := RenderMarkdown(table(NewTruncationNoticeOptions()))
+		if err != nil || !strings.HasSuffix(markup, "\n_3 additional rows not shown_\n") {
+			t.Errorf("RenderMarkdown() = %q, %v; want the notice paragraph", markup, err)
+		}
+
+		var buf bytes.Buffer
+		if _, err := ExportHTML(table(NewTruncationNoticeOptions()), HTMLOptions{}, FileWriteParams{Filename: "limited", Writer: &buf}); err != nil {
+			t.Fatalf("ExportHTML() error = %v", err)
+		}
+		tfoot := buf.String()[strings.Index(buf.String(), "<tfoot>"):]
+		if !strings.Contains(tfoot, "3 additional rows not shown") {
+			t.Errorf("tfoot = %q, want the notice", tfoot)
+		}
+	})
+
+	t.Run("style explanation", func(t *testing.T) {
+		e := ExplainCellStyle(table(NewTruncationNoticeOptions()), 1, 5)
+		if e.Region != "notice" || len(e.Sources) != 1 || e.Sources[0].Kind != StyleSourceNotice || !e.Resolved.Italic {
+			t.Errorf("ExplainCellStyle() = %+v, want the default notice style", e)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+// table_lint.go - Style linting.
+//
+// This file implements an advisory lint pass over the styling configuration of a table. It reports
+// styles that can never be seen (fully shadowed by more specific styles or hidden by merged
+// ranges), tiny font sizes and text colors too close to their background. Lint warnings never
+// prevent an export; they are meant to improve report quality before shipping.
+
+package spit
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// MinReadableFontSize is the smallest font size (in points) not reported as tiny.
+	MinReadableFontSize = 8
+
+	// minColorDistance is the RGB distance under which two colors are considered nearly identical.
+	minColorDistance = 48
+)
+
+// LintCode identifies the kind of problem reported by a LintWarning.
+type LintCode string
+
+const (
+	// LintShadowedStyle reports a column or row style that is overridden on every cell it covers.
+	LintShadowedStyle LintCode = "shadowed-style"
+
+	// LintTinyFont reports a font size below MinReadableFontSize.
+	LintTinyFont LintCode = "tiny-font"
+
+	// LintLowContrast reports a text color nearly identical to its background color.
+	LintLowContrast LintCode = "low-contrast"
+
+	// LintHiddenStyle reports a styled cell hidden by a merged range.
+	LintHiddenStyle LintCode = "hidden-style"
+)
+
+// LintWarning is an advisory warning produced by Table.LintStyles.
+type LintWarning struct {
+	Code    LintCode // Kind of problem
+	Message string   // Human-readable description including the location
+}
+
+// String returns the warning as "code: message".
+func (w LintWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Code, w.Message)
+}
+
+// LintStyles checks the styling configuration of the table and returns advisory warnings.
+// The table is analysed as it will be exported (see Table.Prepare); nothing is written.
+func (t *Table) LintStyles() []LintWarning {
+	p := t.Prepare()
+	var warnings []LintWarning
+
+	// Individual style checks, in rendering order
+	for _, ls := range p.collectStyles() {
+		warnings = append(warnings, lintStyle(ls.location, ls.style)...)
+	}
+
+	warnings = append(warnings, p.lintShadowedStyles()...)
+	warnings = append(warnings, p.lintHiddenStyles()...)
+	return warnings
+}
+
+// locatedStyle pairs a configured style with a human-readable location.
+type locatedStyle struct {
+	location string
+	style    *Style
+}
+
+// collectStyles returns every configured style of the table with its location.
+func (t *Table) collectStyles() []locatedStyle {
+	var styles []locatedStyle
+	for i, row := range t.Preamble {
+		if row.Style != nil {
+			styles = append(styles, locatedStyle{fmt.Sprintf("preamble row %d", i), row.Style})
+		}
+	}
+	if t.HeaderOptions != nil && t.HeaderOptions.Style != nil {
+		styles = append(styles, locatedStyle{"header", t.HeaderOptions.Style})
+	}
+	for _, column := range t.Columns.GetFlattenedColumns() {
+		if column.Style != nil {
+			styles = append(styles, locatedStyle{fmt.Sprintf("column %q", column.Name), column.Style})
+		}
+		for _, rule := range column.StyleRules {
+			if rule.Style != nil {
+				styles = append(styles, locatedStyle{fmt.Sprintf("column %q rule %q", column.Name, rule.When), rule.Style})
+			}
+		}
+	}
+	for _, rowIndex := range sortedKeys(t.RowOptionsMap) {
+		if style := t.RowOptionsMap[rowIndex].Style; style != nil {
+			styles = append(styles, locatedStyle{fmt.Sprintf("row %d", rowIndex), style})
+		}
+	}
+	for _, colIndex := range sortedKeys(t.CellOptionsMap) {
+		rows := t.CellOptionsMap[colIndex]
+		for _, rowIndex := range sortedKeys(rows) {
+			if style := rows[rowIndex].Style; style != nil {
+				styles = append(styles, locatedStyle{fmt.Sprintf("cell (column %d, row %d)", colIndex, rowIndex), style})
+			}
+		}
+	}
+	return styles
+}
+
+// lintStyle checks a single style for tiny fonts and low contrast.
+func lintStyle(location string, style *Style) []LintWarning {
+	var warnings []LintWarning
+	if style.FontSize > 0 && style.FontSize < MinReadableFontSize {
+		warnings = append(warnings, LintWarning{
+			Code:    LintTinyFont,
+			Message: fmt.Sprintf("%s uses font size %g (minimum readable size is %d)", location, style.FontSize, MinReadableFontSize),
+		})
+	}
+	if style.TextColor != "" && style.BackgroundColor != "" && colorsNearlyIdentical(style.TextColor, style.BackgroundColor) {
+		warnings = append(warnings, LintWarning{
+			Code:    LintLowContrast,
+			Message: fmt.Sprintf("%s text color %s is nearly identical to its background %s", location, style.TextColor, style.BackgroundColor),
+		})
+	}
+	return warnings
+}
+
+// lintShadowedStyles reports column and row styles overridden on every data cell they cover.
+// Style priority is cell > row > column (see applyCellStyles).
+func (t *Table) lintShadowedStyles() []LintWarning {
+	if len(t.Data) == 0 {
+		return nil
+	}
+	var warnings []LintWarning
+	flatColumns := t.Columns.GetFlattenedColumns()
+
+	hasCellStyle := func(colIndex, rowIndex int) bool {
+		cell, ok := t.CellOptionsMap[colIndex][rowIndex]
+		return ok && cell.Style != nil
+	}
+	hasRowStyle := func(rowIndex int) bool {
+		row, ok := t.RowOptionsMap[rowIndex]
+		return ok && row.Style != nil
+	}
+
+	for i, column := range flatColumns {
+		if column.Style == nil {
+			continue
+		}
+		shadowed := true
+		for rowIndex := range t.Data {
+			if !hasRowStyle(rowIndex) && !hasCellStyle(i+1, rowIndex) {
+				shadowed = false
+				break
+			}
+		}
+		if shadowed {
+			warnings = append(warnings, LintWarning{
+				Code:    LintShadowedStyle,
+				Message: fmt.Sprintf("style of column %q is overridden by row or cell styles on every row", column.Name),
+			})
+		}
+	}
+
+	for _, rowIndex := range sortedKeys(t.RowOptionsMap) {
+		if !hasRowStyle(rowIndex) || rowIndex >= len(t.Data) || len(flatColumns) == 0 {
+			continue
+		}
+		shadowed := true
+		for i := range flatColumns {
+			if !hasCellStyle(i+1, rowIndex) {
+				shadowed = false
+				break
+			}
+		}
+		if shadowed {
+			warnings = append(warnings, LintWarning{
+				Code:    LintShadowedStyle,
+				Message: fmt.Sprintf("style of row %d is overridden by cell styles on every column", rowIndex),
+			})
+		}
+	}
+	return warnings
+}
+
+// lintHiddenStyles renders the table into an in-memory grid and reports cell styles that end up
+// covered by a merged range, where they can never be seen.
+func (t *Table) lintHiddenStyles() []LintWarning {
+	if len(t.CellOptionsMap) == 0 {
+		return nil
+	}
+	grid := &htmlExport{table: t.withoutOffset(), grid: make(map[int]map[int]*htmlCell)}
+	if err := grid.build(); err != nil {
+		t.warn(WarningPhaseStyle, "", "Failed to render table for style linting", err)
+		return nil
+	}
+
+	var warnings []LintWarning
+	dataStartRow := t.GetDataStartRow()
+	for _, colIndex := range sortedKeys(t.CellOptionsMap) {
+		rows := t.CellOptionsMap[colIndex]
+		for _, rowIndex := range sortedKeys(rows) {
+			if rows[rowIndex].Style == nil {
+				continue
+			}
+			if c := grid.peek(colIndex, dataStartRow+rowIndex); c != nil && c.covered {
+				warnings = append(warnings, LintWarning{
+					Code:    LintHiddenStyle,
+					Message: fmt.Sprintf("style of cell (column %d, row %d) is hidden by a merged range", colIndex, rowIndex),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// colorsNearlyIdentical reports whether two hex colors are within minColorDistance of each other.
+// Unparseable colors are never reported.
+func colorsNearlyIdentical(a, b string) bool {
+	r1, g1, b1, ok1 := parseHexColor(a)
+	r2, g2, b2, ok2 := parseHexColor(b)
+	if !ok1 || !ok2 {
+		return false
+	}
+	dr, dg, db := float64(r1)-float64(r2), float64(g1)-float64(g2), float64(b1)-float64(b2)
+	return math.Sqrt(dr*dr+dg*dg+db*db) < minColorDistance
+}
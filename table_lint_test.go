@@ -0,0 +1,97 @@
+package spit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_LintStyles(t *testing.T) {
+	data := DataSlice{
+		{"region": "EU", "sales": 10},
+		{"region": "EU", "sales": 20},
+	}
+	newColumns := func() Columns {
+		return Columns{
+			NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+			NewColumn("sales", "Sales"),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		table    func() *Table
+		expected []LintCode
+	}{
+		{
+			name:     "Clean table",
+			table:    func() *Table { return NewTable(data, newColumns(), true) },
+			expected: nil,
+		},
+		{
+			name: "Tiny font and low contrast",
+			table: func() *Table {
+				return NewTable(data, newColumns(), true).
+					WithHeaderOptions(NewHeaderOptions().WithStyle(&Style{FontSize: 6, TextColor: "#333333", BackgroundColor: "#303030"}))
+			},
+			expected: []LintCode{LintTinyFont, LintLowContrast},
+		},
+		{
+			name: "Column style shadowed by row styles",
+			table: func() *Table {
+				columns := newColumns()
+				columns[1].WithStyle(&Style{Bold: true})
+				return NewTable(data, columns, true).WithRowOptions(RowOptionsMap{
+					0: {Style: &Style{Italic: true}},
+					1: {Style: &Style{Italic: true}},
+				})
+			},
+			expected: []LintCode{LintShadowedStyle},
+		},
+		{
+			name: "Row style shadowed by cell styles",
+			table: func() *Table {
+				return NewTable(data, newColumns(), true).
+					WithRowOptions(RowOptionsMap{0: {Style: &Style{Italic: true}}}).
+					WithCellOptions(CellOptionsMap{
+						1: {0: {Style: &Style{Bold: true}}},
+						2: {0: {Style: &Style{Bold: true}}},
+					})
+			},
+			expected: []LintCode{LintShadowedStyle},
+		},
+		{
+			name: "Cell style hidden by vertical merge",
+			table: func() *Table {
+				return NewTable(data, newColumns(), true).
+					WithCellOptions(CellOptionsMap{1: {1: {Style: &Style{Bold: true}, Mergeable: true}}})
+			},
+			expected: []LintCode{LintHiddenStyle},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []LintCode
+			for _, w := range tt.table().LintStyles() {
+				codes = append(codes, w.Code)
+			}
+			if !reflect.DeepEqual(codes, tt.expected) {
+				t.Errorf("LintStyles() codes = %v, want %v", codes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTable_LintStyles_renderFailure(t *testing.T) {
+	var warnings []ExportWarning
+	table := NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("total", "Total").WithValueExpr("a *")}, true).
+		WithCellOptions(CellOptionsMap{1: {0: {Style: &Style{Bold: true}}}}).
+		withWarnings("", func(w ExportWarning) { warnings = append(warnings, w) }, nil)
+
+	if got := table.LintStyles(); len(got) != 0 {
+		t.Errorf("LintStyles() = %v, want no warnings", got)
+	}
+	if len(warnings) != 1 || warnings[0].Phase != WarningPhaseStyle || warnings[0].Err == nil {
+		t.Errorf("run warnings = %+v, want the render failure reported", warnings)
+	}
+}
@@ -0,0 +1,128 @@
+// table_listobject.go - Native Excel tables.
+//
+// This file implements the optional Excel table (ListObject) output of XLSX exports: the header
+// and data rows are registered as a named table with a built-in table style, so consumers get
+// structured references (e.g. Sales[Amount]), filter buttons and banded styling out of the box.
+
+package spit
+
+// DefaultExcelTableStyle is the built-in table style used when ExcelTableOptions.Style is unset.
+const DefaultExcelTableStyle = "TableStyleMedium2"
+
+// ExcelTableOptions configures the native Excel table written over an XLSX export.
+type ExcelTableOptions struct {
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`                   // Table name used in structured references (default: the sheet name followed by "Table")
+	Style         string `json:"style,omitempty" yaml:"style,omitempty"`                 // Built-in table style, e.g. "TableStyleLight9" (default: DefaultExcelTableStyle)
+	BandedRows    bool   `json:"bandedRows,omitempty" yaml:"bandedRows,omitempty"`       // Alternate row shading
+	BandedColumns bool   `json:"bandedColumns,omitempty" yaml:"bandedColumns,omitempty"` // Alternate column shading
+	FirstColumn   bool   `json:"firstColumn,omitempty" yaml:"firstColumn,omitempty"`     // Emphasize the first column
+	LastColumn    bool   `json:"lastColumn,omitempty" yaml:"lastColumn,omitempty"`       // Emphasize the last column
+}
+
+// NewExcelTableOptions creates a new ExcelTableOptions instance with banded rows.
+func NewExcelTableOptions() *ExcelTableOptions {
+	return &ExcelTableOptions{BandedRows: true}
+}
+
+// WithName sets the table name used in structured references.
+func (o *ExcelTableOptions) WithName(name string) *ExcelTableOptions {
+	o.Name = name
+	return o
+}
+
+// WithStyle sets the built-in table style (e.g. "TableStyleLight9", "TableStyleDark1").
+func (o *ExcelTableOptions) WithStyle(style string) *ExcelTableOptions {
+	o.Style = style
+	return o
+}
+
+// WithBanding sets whether rows and columns get alternate shading.
+func (o *ExcelTableOptions) WithBanding(rows, columns bool) *ExcelTableOptions {
+	o.BandedRows = rows
+	o.BandedColumns = columns
+	return o
+}
+
+// WithEmphasis sets whether the first and last columns are emphasized.
+func (o *ExcelTableOptions) WithEmphasis(firstColumn, lastColumn bool) *ExcelTableOptions {
+	o.FirstColumn = firstColumn
+	o.LastColumn = lastColumn
+	return o
+}
+
+// WithExcelTable writes the header and data rows of XLSX exports as a native Excel table with the
+// given options (nil uses NewExcelTableOptions). The default header style and borders are left
+// out so the table style shows; HeaderOptions still apply when set. Requires a single header row.
+func (t *Table) WithExcelTable(options *ExcelTableOptions) *Table {
+	if options == nil {
+		options = NewExcelTableOptions()
+	}
+	t.ExcelTable = options
+	return t
+}
+
+// ExcelTableSpec describes a native Excel table to add to a sheet.
+type ExcelTableSpec struct {
+	Range string // Cell range covering the header and data rows, e.g. "A1:D20"
+	ExcelTableOptions
+}
+
+// excelTableAdder is implemented by spreadsheets supporting native tables.
+type excelTableAdder interface {
+	AddExcelTable(spec ExcelTableSpec) error
+}
+
+// excelTableHeaderOptions returns header options leaving the header unstyled, so the table style
+// shows through, unless the table has header options of its own.
+func (t *Table) excelTableHeaderOptions() *HeaderOptions {
+	if t.ExcelTable == nil || t.HeaderOptions != nil {
+		return t.HeaderOptions
+	}
+	return &HeaderOptions{Style: &Style{}, Borders: &Borders{}}
+}
+
+// writeExcelTable registers the header and data rows of the written sheet as a native table.
+// The cells are written either way: if excelize rejects the table, a warning is reported instead
+// and ErrorMode decides whether the export fails.
+func (xlsx *xlsx) writeExcelTable(result SheetResult) {
+	t := xlsx.table
+	options := t.ExcelTable
+	if options == nil {
+		return
+	}
+	if t.Transposed {
+		t.warn(WarningPhaseSheet, "", "Excel tables are not supported by transposed tables, table skipped", nil, String("sheet", result.Name))
+		return
+	}
+	if result.HeaderRange.IsEmpty() || result.HeaderRange.StartRow != result.HeaderRange.EndRow {
+		t.warn(WarningPhaseSheet, "", "Excel tables require a single header row, table skipped", nil, String("sheet", result.Name))
+		return
+	}
+	for _, rowOptions := range t.RowOptionsMap {
+		if rowOptions.SpanAllColumns {
+			t.warn(WarningPhaseSheet, "", "Excel tables cannot hold full-width rows, table skipped", nil, String("sheet", result.Name))
+			return
+		}
+	}
+	adder, ok := xlsx.spreadsheet.(excelTableAdder)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support Excel tables, table skipped", nil)
+		return
+	}
+
+	spec := ExcelTableSpec{Range: result.HeaderRange.String(), ExcelTableOptions: *options}
+	if !result.DataRange.IsEmpty() {
+		rng := result.HeaderRange
+		rng.EndRow = result.DataRange.EndRow
+		spec.Range = rng.String()
+	}
+	if spec.Name == "" {
+		spec.Name = SanitizeDefinedName(result.Name + "Table")
+	}
+	if spec.Style == "" {
+		spec.Style = DefaultExcelTableStyle
+	}
+	if err := adder.AddExcelTable(spec); err != nil {
+		t.warn(WarningPhaseSheet, "", "Failed to add Excel table", err, String("name", spec.Name), String("range", spec.Range))
+	}
+}
@@ -0,0 +1,71 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_excelTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     *Table
+		wantName  string
+		wantRange string
+	}{
+		{
+			name: "defaults",
+			table: NewTable(DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}},
+				Columns{NewColumn("a", "A"), NewColumn("b", "B")}, true).
+				WithStartPosition(2, 2).WithFooter(nil).WithAutoFilter(true).WithExcelTable(nil),
+			wantName:  "Q1_SalesTable",
+			wantRange: "B2:C4",
+		},
+		{
+			name: "named",
+			table: NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("a", "A")}, true).
+				WithExcelTable(NewExcelTableOptions().WithName("Sales").WithStyle("TableStyleLight9")),
+			wantName:  "Sales",
+			wantRange: "A1:A2",
+		},
+		{
+			name: "multi-level header skipped",
+			table: NewTable(DataSlice{{"a": 1}}, Columns{
+				NewColumn("g", "G").WithSubColumns(Columns{NewColumn("a", "A")}),
+			}, true).WithExcelTable(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ExportXLSX(NewSpreadsheetExcelize("Q1 Sales", tt.table), FileWriteParams{Filename: "tables", Filepath: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ExportXLSX failed: %v", err)
+			}
+			f, err := excelize.OpenFile(res.Filepath)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			tables, err := f.GetTables("Q1 Sales")
+			if err != nil {
+				t.Fatalf("GetTables failed: %v", err)
+			}
+			if tt.wantName == "" {
+				if len(tables) != 0 {
+					t.Errorf("expected no table, got %+v", tables)
+				}
+				return
+			}
+			if len(tables) != 1 || tables[0].Name != tt.wantName || tables[0].Range != tt.wantRange {
+				t.Fatalf("tables = %+v, want %s over %s", tables, tt.wantName, tt.wantRange)
+			}
+			for _, name := range f.GetDefinedName() {
+				if name.Name == "_xlnm._FilterDatabase" {
+					t.Errorf("the sheet auto-filter must be skipped for Excel tables, got %s", name.RefersTo)
+				}
+			}
+		})
+	}
+}
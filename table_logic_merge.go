@@ -13,11 +13,14 @@ import (
 // ProcessMerging applies all cell merging operations to the table.
 // Handles header, vertical, and horizontal merging in order. Errors are logged and processing continues for best-effort merging.
 func (t *Table) ProcessMerging(ops TableOperations) error {
+	defer t.timings.measure(timingMerge)()
+
+	// Layout is computed in table-relative coordinates; translate them to the start position
+	ops = t.Offset(ops)
+
 	// Process header merging first
-	if t.WriteHeader && len(t.Columns) > 0 {
-		if err := t.executeHeaderMerging(ops); err != nil {
-			return fmt.Errorf("failed to process header merging: %w", err)
-		}
+	if err := t.mergeHeaders(ops); err != nil {
+		return err
 	}
 
 	// Calculate where data rows start (after headers, if present)
@@ -28,18 +31,57 @@ func (t *Table) ProcessMerging(ops TableOperations) error {
 		// Column indices are 1-based, so we add 1 to the 0-based slice index
 		if err := t.executeVerticalMerging(column, actualColIndex+1, dataStartRow, ops); err != nil {
 			// Log the error but continue processing other columns
-			L().Warn("Failed to process column for vertical merging", Error(err))
+			t.warn(WarningPhaseMerge, "", "Failed to process column for vertical merging", err)
+		}
+		if err := t.strictErr(); err != nil {
+			return err
 		}
 	}
 
 	// Process horizontal merging for each data row
-	for rowIndex, item := range t.Data {
+	if err := t.mergeDataRows(0, len(t.Data), dataStartRow, ops); err != nil {
+		return err
+	}
+
+	// Strict tables fail with the first merge that could not be applied
+	return t.strictErr()
+}
+
+// mergeHeaders applies the merging of the header cells.
+func (t *Table) mergeHeaders(ops TableOperations) error {
+	if t.WriteHeader && len(t.Columns) > 0 {
+		if err := t.executeHeaderMerging(ops); err != nil {
+			return fmt.Errorf("failed to process header merging: %w", err)
+		}
+	}
+	return t.strictErr()
+}
+
+// mergeDataRows applies the horizontal merging of the data rows from (included) to (excluded).
+func (t *Table) mergeDataRows(from, to, dataStartRow int, ops TableOperations) error {
+	for rowIndex := from; rowIndex < to; rowIndex++ {
+		item := t.Data[rowIndex]
+		if err := t.advance(1); err != nil {
+			return err
+		}
+		if err := t.strictErr(); err != nil {
+			return err
+		}
+
 		// Convert data row index to actual sheet row number
 		rowNum := rowIndex + dataStartRow
 
 		// Retrieve any custom row options for this row
 		rc, exists := t.RowOptionsMap[rowIndex]
 
+		// Full-width rows are merged into a single cell and never take part in other merges
+		if exists && rc.SpanAllColumns {
+			if err := t.executeSpanMerging(&rc, rowNum, ops); err != nil {
+				t.warn(WarningPhaseMerge, t.cellRef(1, rowNum), "Failed to merge full-width row", t.mergeError(1, rowNum, t.Columns.GetTotalColumnCount(), rowNum, err), Int("row", rowNum))
+			}
+			continue
+		}
+
 		// If row has custom horizontal merge settings, process with those settings
 		if exists && rc.Merge != nil && len(rc.Merge.Horizontal) > 0 {
 			if err := t.executeHorizontalMerging(item, t.Columns, rowNum, 1, &rc, ops); err != nil {
@@ -55,13 +97,31 @@ func (t *Table) ProcessMerging(ops TableOperations) error {
 
 		// Standard horizontal merging processing
 		if err := t.executeHorizontalMerging(item, t.Columns, rowNum, 1, nil, ops); err != nil {
-			L().Warn("Failed to execute horizontal merging for row", Int("row", rowNum), Error(err))
+			t.warn(WarningPhaseMerge, t.cellRef(1, rowNum), "Failed to execute horizontal merging for row", err, Int("row", rowNum))
 		}
 	}
 
 	return nil
 }
 
+// executeSpanMerging merges a data row across all leaf columns, writing the row options value
+// (when set) into the spanning cell.
+func (t *Table) executeSpanMerging(rc *RowOptions, rowNum int, ops TableOperations) error {
+	if rc.Value != nil {
+		if err := ops.SetCellValue(1, rowNum, rc.Value); err != nil {
+			return fmt.Errorf("failed to set spanning cell value: %w", err)
+		}
+	}
+	totalColumns := t.Columns.GetTotalColumnCount()
+	if totalColumns <= 1 {
+		return nil
+	}
+	if err := ops.MergeCells(1, rowNum, totalColumns, rowNum); err != nil {
+		return fmt.Errorf("failed to merge row %d: %w", rowNum, err)
+	}
+	return nil
+}
+
 // executeHeaderMerging applies merging operations to header cells.
 // Handles hierarchical header merging for multi-level headers.
 func (t *Table) executeHeaderMerging(ops TableOperations) error {
@@ -93,11 +153,10 @@ func (t *Table) processHeaderMergingRecursive(columns Columns, currentRow, maxRo
 			endCol := currentCol + columnSpan - 1
 			if endCol > currentCol {
 				if err := ops.MergeCells(currentCol, currentRow, endCol, currentRow); err != nil {
-					L().Warn("Failed to merge header cells horizontally",
+					t.warn(WarningPhaseMerge, t.cellRef(currentCol, currentRow), "Failed to merge header cells horizontally", t.mergeError(currentCol, currentRow, endCol, currentRow, err),
 						Int("startCol", currentCol),
 						Int("endCol", endCol),
-						Int("row", currentRow),
-						Error(err))
+						Int("row", currentRow))
 				}
 			}
 
@@ -112,11 +171,10 @@ func (t *Table) processHeaderMergingRecursive(columns Columns, currentRow, maxRo
 			// Merge vertically for leaf columns that span multiple header rows
 			if currentRow < maxRow {
 				if err := ops.MergeCells(currentCol, currentRow, currentCol, maxRow); err != nil {
-					L().Warn("Failed to merge header cells vertically",
+					t.warn(WarningPhaseMerge, t.cellRef(currentCol, currentRow), "Failed to merge header cells vertically", t.mergeError(currentCol, currentRow, currentCol, maxRow, err),
 						Int("col", currentCol),
 						Int("startRow", currentRow),
-						Int("endRow", maxRow),
-						Error(err))
+						Int("endRow", maxRow))
 				}
 			}
 			currentCol++
@@ -134,8 +192,14 @@ func (t *Table) executeVerticalMerging(column *Column, actualColIndex int, dataS
 	}
 
 	// Analyze the column data and identify merge ranges
-	mergeRanges := t.findVerticalMergeRanges(actualColIndex, column.Name, column.Format, column.Merge.Vertical, ops)
+	mergeRanges := t.findVerticalMergeRanges(actualColIndex, column, column.Merge.Vertical, ops)
+	t.applyVerticalMerges(column, actualColIndex, dataStartRow, mergeRanges, ops)
+	return nil
+}
 
+// applyVerticalMerges merges the ranges of data rows found in a column by findVerticalMergeRanges.
+// Failures are reported as warnings.
+func (t *Table) applyVerticalMerges(column *Column, actualColIndex, dataStartRow int, mergeRanges [][]int, ops TableOperations) {
 	// Execute merge operations for each identified range
 	for _, mr := range mergeRanges {
 		if len(mr) < 2 {
@@ -148,26 +212,48 @@ func (t *Table) executeVerticalMerging(column *Column, actualColIndex int, dataS
 
 		// Execute the vertical merge operation
 		if err := ops.MergeCells(actualColIndex, startRow, actualColIndex, endRow); err != nil {
-			L().Warn("Failed to merge cells vertically",
+			t.warn(WarningPhaseMerge, t.cellRef(actualColIndex, startRow), "Failed to merge cells vertically", t.mergeError(actualColIndex, startRow, actualColIndex, endRow, err),
 				Int("col", actualColIndex),
 				Int("startRow", startRow),
-				Int("endRow", endRow),
-				Error(err))
+				Int("endRow", endRow))
+			continue
 		}
-	}
 
-	return nil
+		// Write the values of the covered cells
+		if mode := column.Merge.Values; mode != MergeValuesKeep {
+			anchor := t.mergeAnchorValue(t.Data[mr[0]], column, ops)
+			t.fillMergedRange(mode, anchor, actualColIndex, startRow, actualColIndex, endRow, ops)
+		}
+	}
 }
 
 // findVerticalMergeRanges identifies ranges of consecutive rows that should be merged vertically.
 // Returns a slice of ranges (each range is a slice of row indices).
-func (t *Table) findVerticalMergeRanges(colIndex int, fieldName string, format string, conditions MergeConditions, ops TableOperations) [][]int {
+func (t *Table) findVerticalMergeRanges(colIndex int, column *Column, conditions MergeConditions, ops TableOperations) [][]int {
+	mergeRanges, _ := t.scanVerticalMerges(colIndex, column, conditions, 0, len(t.Data), ops)
+	return mergeRanges
+}
+
+// scanVerticalMerges identifies the vertical merge ranges of the data rows from (included) to
+// (excluded), like findVerticalMergeRanges. It also returns the row from which a new scan finds the
+// same ranges as a scan of the whole column: the first row of the range still open at the last row,
+// which may extend over the following rows, otherwise the last row ending the ranges.
+func (t *Table) scanVerticalMerges(colIndex int, column *Column, conditions MergeConditions, from, to int, ops TableOperations) ([][]int, int) {
 	var mergeRanges [][]int   // Collection of merge ranges to return
 	var currentRange []int    // Current range being built
 	var lastValue interface{} // Previous row's processed value for comparison
+	resetRow := from          // Last row ending the ranges, where a new scan starts in the same state
+
+	// Compare the values of the key column when the column merges on another one
+	compared := column
+	if column.Merge != nil && column.Merge.VerticalKeyColumn != "" {
+		compared = t.columnByName(column.Merge.VerticalKeyColumn)
+	}
 
 	// Iterate through each data row to analyze values and build ranges
-	for rowIndex, item := range t.Data {
+	for rowIndex := from; rowIndex < to; rowIndex++ {
+		item := t.Data[rowIndex]
+
 		// Skip rows that have disabled merging or have custom vertical merge configurations
 		// Custom configurations are handled separately to avoid conflicts
 		if rc, exists := t.RowOptionsMap[rowIndex]; exists && (!rc.Mergeable || rc.Merge != nil) {
@@ -177,6 +263,7 @@ func (t *Table) findVerticalMergeRanges(colIndex int, fieldName string, format s
 			}
 			currentRange = nil
 			lastValue = nil
+			resetRow = rowIndex
 			continue
 		}
 
@@ -189,11 +276,12 @@ func (t *Table) findVerticalMergeRanges(colIndex int, fieldName string, format s
 			}
 			currentRange = nil
 			lastValue = nil
+			resetRow = rowIndex
 			continue
 		}
 
 		// Extract the raw value from the data item for this column
-		value, err, found := item.Lookup(fieldName)
+		value, err, found := item.LookupColumn(compared)
 		if err != nil || !found {
 			// Can't get value for this row - end current range if it exists
 			if len(currentRange) > 1 {
@@ -201,17 +289,18 @@ func (t *Table) findVerticalMergeRanges(colIndex int, fieldName string, format s
 			}
 			currentRange = nil
 			lastValue = nil
+			resetRow = rowIndex
 			continue
 		}
 
 		// Process the value according to the column's format specification
 		// This ensures consistent formatting for merge comparison
-		processedValue, err := ops.ProcessValue(value, format)
+		processedValue, err := t.ProcessCellValue(ops, item, compared, value)
 		if err != nil {
 			continue // Skip this row if value processing fails
 		}
 
-		if rowIndex == 0 {
+		if rowIndex == from {
 			// First row - initialize the range tracking
 			currentRange = []int{rowIndex}
 			lastValue = processedValue
@@ -244,10 +333,25 @@ func (t *Table) findVerticalMergeRanges(colIndex int, fieldName string, format s
 	}
 
 	// Don't forget to add the final range if it contains multiple rows
+	open := resetRow
+	if len(currentRange) > 0 {
+		open = currentRange[0]
+	}
 	if len(currentRange) > 1 {
 		mergeRanges = append(mergeRanges, currentRange)
 	}
-	return mergeRanges
+	return mergeRanges, open
+}
+
+// columnByName returns the leaf column of the given name, or a column reading the data field of
+// that name when the table has none.
+func (t *Table) columnByName(name string) *Column {
+	for _, column := range t.Columns.GetFlattenedColumns() {
+		if column.Name == name {
+			return column
+		}
+	}
+	return NewColumn(name, name)
 }
 
 // executeHorizontalMerging processes horizontal cell merging for a single row.
@@ -262,7 +366,8 @@ func (t *Table) executeHorizontalMerging(item Data, columns Columns, rowNum int,
 	if rowOptions != nil && rowOptions.Merge != nil && len(rowOptions.Merge.Horizontal) > 0 {
 		// Use row-level merge conditions for all columns in this row
 		mergeRanges := t.findHorizontalMergeRanges(item, columns, rowOptions.Merge.Horizontal, ops)
-		t.applyHorizontalMerges(mergeRanges, rowNum, startColIndex, ops)
+		merged := t.applyHorizontalMerges(mergeRanges, rowNum, startColIndex, ops)
+		t.fillHorizontalMerges(item, columns, merged, rowNum, startColIndex, rowOptions.Merge, ops)
 		return nil
 	}
 
@@ -296,7 +401,8 @@ func (t *Table) executeHorizontalMerging(item Data, columns Columns, rowNum int,
 				// Process the completed group only if it has merge conditions and multiple columns
 				groupColumns := Columns(currentGroup)
 				mergeRanges := t.findHorizontalMergeRanges(item, groupColumns, currentConditions, ops)
-				t.applyHorizontalMerges(mergeRanges, rowNum, startColIndex+currentGroupStartIndex, ops)
+				merged := t.applyHorizontalMerges(mergeRanges, rowNum, startColIndex+currentGroupStartIndex, ops)
+				t.fillHorizontalMerges(item, groupColumns, merged, rowNum, startColIndex+currentGroupStartIndex, nil, ops)
 			}
 
 			// Start a new group with the current column
@@ -310,14 +416,18 @@ func (t *Table) executeHorizontalMerging(item Data, columns Columns, rowNum int,
 	if len(currentGroup) > 1 && len(currentConditions) > 0 {
 		groupColumns := Columns(currentGroup)
 		mergeRanges := t.findHorizontalMergeRanges(item, groupColumns, currentConditions, ops)
-		t.applyHorizontalMerges(mergeRanges, rowNum, startColIndex+currentGroupStartIndex, ops)
+		merged := t.applyHorizontalMerges(mergeRanges, rowNum, startColIndex+currentGroupStartIndex, ops)
+		t.fillHorizontalMerges(item, groupColumns, merged, rowNum, startColIndex+currentGroupStartIndex, nil, ops)
 	}
 
 	return nil
 }
 
-// applyHorizontalMerges executes horizontal merge operations for identified merge ranges.
-func (t *Table) applyHorizontalMerges(mergeRanges [][]int, rowNum, baseColIndex int, ops TableOperations) {
+// applyHorizontalMerges executes horizontal merge operations for identified merge ranges and
+// returns the ranges merged successfully.
+func (t *Table) applyHorizontalMerges(mergeRanges [][]int, rowNum, baseColIndex int, ops TableOperations) [][]int {
+	var merged [][]int
+
 	// Process each identified merge range
 	for _, mergeRange := range mergeRanges {
 		if len(mergeRange) < 2 {
@@ -331,11 +441,72 @@ func (t *Table) applyHorizontalMerges(mergeRanges [][]int, rowNum, baseColIndex
 		// Execute the horizontal merge operation across the column range
 		if err := ops.MergeCells(startCol, rowNum, endCol, rowNum); err != nil {
 			// Log detailed error information for debugging and continue processing
-			L().Warn("Failed to merge cells horizontally",
+			t.warn(WarningPhaseMerge, t.cellRef(startCol, rowNum), "Failed to merge cells horizontally", t.mergeError(startCol, rowNum, endCol, rowNum, err),
 				Int("row", rowNum),
 				Int("startCol", startCol),
-				Int("endCol", endCol),
-				Error(err))
+				Int("endCol", endCol))
+			continue
+		}
+		merged = append(merged, mergeRange)
+	}
+	return merged
+}
+
+// fillHorizontalMerges writes the values of the cells covered by horizontally merged ranges of a
+// row, following the row merge rules when given, otherwise those of the first column of each range.
+func (t *Table) fillHorizontalMerges(item Data, columns Columns, mergeRanges [][]int, rowNum, baseColIndex int, rowRules *MergeRules, ops TableOperations) {
+	flatColumns := columns.GetFlattenedColumns()
+	for _, mergeRange := range mergeRanges {
+		anchorColumn := flatColumns[mergeRange[0]]
+		rules := rowRules
+		if rules == nil {
+			rules = anchorColumn.Merge
+		}
+		if rules == nil || rules.Values == MergeValuesKeep {
+			continue
+		}
+		anchor := t.mergeAnchorValue(item, anchorColumn, ops)
+		startCol := mergeRange[0] + baseColIndex
+		endCol := mergeRange[len(mergeRange)-1] + baseColIndex
+		t.fillMergedRange(rules.Values, anchor, startCol, rowNum, endCol, rowNum, ops)
+	}
+}
+
+// mergeAnchorValue returns the processed value of the anchor cell of a merged range, or nil when
+// it has none.
+func (t *Table) mergeAnchorValue(item Data, column *Column, ops TableOperations) interface{} {
+	value, err, found := item.LookupColumn(column)
+	if err != nil || !found {
+		return nil
+	}
+	processed, err := t.ProcessCellValue(ops, item, column, value)
+	if err != nil {
+		return nil
+	}
+	return processed
+}
+
+// fillMergedRange writes the cells of a merged range other than its top-left anchor: nothing with
+// MergeValuesKeep, nil with MergeValuesBlank and the anchor value with MergeValuesRepeat. Failures
+// are reported as warnings.
+func (t *Table) fillMergedRange(mode MergeValueMode, anchor interface{}, startCol, startRow, endCol, endRow int, ops TableOperations) {
+	if mode == MergeValuesKeep {
+		return
+	}
+	value := anchor
+	if mode == MergeValuesBlank {
+		value = nil
+	}
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			if col == startCol && row == startRow {
+				continue
+			}
+			if err := ops.SetCellValue(col, row, value); err != nil {
+				t.warn(WarningPhaseMerge, t.cellRef(col, row), "Failed to write merged cell value", t.mergeError(col, row, col, row, err),
+					Int("col", col),
+					Int("row", row))
+			}
 		}
 	}
 }
@@ -368,7 +539,7 @@ func (t *Table) findHorizontalMergeRanges(item Data, columns Columns, conditions
 		}
 
 		// Extract the raw value from the data item for this column
-		value, err, found := item.Lookup(column.Name)
+		value, err, found := item.LookupColumn(column)
 		if err != nil || !found {
 			// Can't get value for this column - end current range if it exists
 			if len(currentRange) > 1 {
@@ -381,7 +552,7 @@ func (t *Table) findHorizontalMergeRanges(item Data, columns Columns, conditions
 
 		// Process the value according to the column's format specification
 		// This ensures consistent formatting for merge comparison
-		processedValue, err := ops.ProcessValue(value, column.Format)
+		processedValue, err := t.ProcessCellValue(ops, item, column, value)
 		if err != nil {
 			// Use raw value if processing fails
 			processedValue = value
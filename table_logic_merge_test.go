@@ -2,6 +2,7 @@ package spit
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -693,7 +694,7 @@ func TestTable_findVerticalMergeRanges(t *testing.T) {
 			tt.setupMock(mockOps)
 
 			table := tt.setupTable()
-			ranges := table.findVerticalMergeRanges(tt.colIndex, tt.fieldName, tt.format, tt.conditions, mockOps)
+			ranges := table.findVerticalMergeRanges(tt.colIndex, &Column{Name: tt.fieldName, Format: tt.format}, tt.conditions, mockOps)
 
 			if len(ranges) != len(tt.expectedRanges) {
 				t.Errorf("Expected %d ranges, got %d", len(tt.expectedRanges), len(ranges))
@@ -1131,3 +1132,117 @@ func containsSubstring(s, substr string) bool {
 				return false
 			}()))
 }
+
+func TestTable_ProcessMerging_spanAllColumns(t *testing.T) {
+	data := DataSlice{
+		{"name": "ignored", "qty": 1},
+		{"name": "Widget", "qty": 2},
+		{"name": "Widget", "qty": 3},
+	}
+	columns := Columns{
+		NewColumn("name", "Name").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+		NewColumn("qty", "Qty"),
+		NewColumn("note", "Note"),
+	}
+	table := NewTable(data, columns, true).WithRowOptions(RowOptionsMap{
+		0: *NewRowOptions(0).WithSpanAllColumns("Section A"),
+	})
+
+	grid := &htmlExport{table: table, grid: make(map[int]map[int]*htmlCell)}
+	if err := grid.build(); err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	section := grid.peek(1, 2)
+	if section == nil || section.value != "Section A" || section.colspan != 3 || section.rowspan != 1 {
+		t.Fatalf("expected a full-width 'Section A' cell, got %+v", section)
+	}
+	// Data rows below still merge vertically, without absorbing the section row
+	if widget := grid.peek(1, 3); widget == nil || widget.rowspan != 2 {
+		t.Errorf("expected Widget rows to merge vertically, got %+v", widget)
+	}
+}
+
+func TestTable_mergeValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     MergeValueMode
+		wantCity []string
+		wantRow  []string
+	}{
+		{"keep", MergeValuesKeep, []string{"Oslo", "Oslo", "Rome"}, []string{"x", "x"}},
+		{"blank", MergeValuesBlank, []string{"Oslo", "", "Rome"}, []string{"x", ""}},
+		{"repeat", MergeValuesRepeat, []string{"Oslo", "Oslo", "Rome"}, []string{"x", "x"}},
+	}
+	// Blank cells continue the value above them, as in indented reports
+	if err := RegisterMergePredicate("continued", func(prev, curr interface{}) bool {
+		return curr == nil || curr == ""
+	}); err != nil {
+		t.Fatalf("RegisterMergePredicate failed: %v", err)
+	}
+	defer UnregisterMergePredicate("continued")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vertical := NewMergeRules(MergeConditions{MergeConditionIdentical, MergeConditionCustom("continued")}, nil).WithValues(tt.mode)
+			horizontal := NewMergeRules(nil, MergeConditions{MergeConditionIdentical}).WithValues(tt.mode)
+			data := DataSlice{
+				{"city": "Oslo", "a": "x", "b": "x"},
+				{"city": "Oslo", "a": "1", "b": "2"},
+				{"city": "Rome", "a": "3", "b": "4"},
+			}
+			if tt.mode == MergeValuesRepeat {
+				data[1]["city"] = ""
+			}
+			table := NewTable(data, Columns{
+				NewColumn("city", "City").WithMerge(vertical),
+				NewColumn("a", "A").WithMerge(horizontal),
+				NewColumn("b", "B").WithMerge(horizontal),
+			}, false)
+
+			grid := &htmlExport{table: table, grid: make(map[int]map[int]*htmlCell)}
+			if err := grid.build(); err != nil {
+				t.Fatalf("build failed: %v", err)
+			}
+			for i, want := range tt.wantCity {
+				if got := grid.peek(1, i+1).value; got != want {
+					t.Errorf("city row %d = %q, want %q", i+1, got, want)
+				}
+			}
+			for i, want := range tt.wantRow {
+				if got := grid.peek(i+2, 1).value; got != want {
+					t.Errorf("row 1 column %d = %q, want %q", i+2, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTable_findVerticalMergeRanges_keyColumn(t *testing.T) {
+	data := DataSlice{
+		{"order": 1, "notes": "fragile"},
+		{"order": 1, "notes": ""},
+		{"order": 2, "notes": "fragile"},
+		{"order": 2, "notes": "fragile"},
+		{"order": 2, "notes": "express"},
+	}
+	rules := NewMergeRules(MergeConditions{MergeConditionIdentical}, nil).WithVerticalKeyColumn("order")
+	notes := NewColumn("notes", "Notes").WithMerge(rules)
+
+	tests := []struct {
+		name    string
+		columns Columns
+	}{
+		{"key column", Columns{NewColumn("order", "Order").WithHidden(true), notes}},
+		{"key data field", Columns{notes}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(data, tt.columns, true)
+			got := table.findVerticalMergeRanges(len(tt.columns), notes, rules.Vertical, &htmlExport{table: table})
+			if want := [][]int{{0, 1}, {2, 3, 4}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("ranges = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -14,10 +14,33 @@ import (
 // It processes preamble styles, header styles, data cell styles, column borders, row borders, and cell-specific borders in order.
 // Errors are wrapped and returned, but processing continues for best-effort styling.
 func (t *Table) RenderStyles(ops TableOperations) error {
-	dataStartRow := t.GetDataStartRow()
-	totalColumns := t.Columns.GetTotalColumnCount()
-	dataEndRow := dataStartRow + len(t.Data) - 1
+	defer t.timings.measure(timingStyle)()
+
+	// Layout is computed in table-relative coordinates; translate them to the start position
+	ops = t.Offset(ops)
+
+	if err := t.styleHeaders(ops); err != nil {
+		return err
+	}
+
+	var bands []int
+	if t.Zebra != nil {
+		bands = t.zebraBands(ops)
+	}
+	if err := t.styleDataRows(0, len(t.Data), bands, ops); err != nil {
+		return err
+	}
 
+	if err := t.styleTrailer(ops); err != nil {
+		return err
+	}
+
+	// Strict tables fail with the first style that could not be applied
+	return t.strictErr()
+}
+
+// styleHeaders applies the styles of the preamble and header rows.
+func (t *Table) styleHeaders(ops TableOperations) error {
 	// Apply preamble styles
 	if len(t.Preamble) > 0 {
 		if err := t.applyPreambleStyles(ops); err != nil {
@@ -31,19 +54,29 @@ func (t *Table) RenderStyles(ops TableOperations) error {
 			return fmt.Errorf("failed to apply header styles: %w", err)
 		}
 	}
+	return t.strictErr()
+}
+
+// styleDataRows applies the styles and borders of the data rows from (included) to (excluded),
+// banded by zebraBands when bands is not nil.
+func (t *Table) styleDataRows(from, to int, bands []int, ops TableOperations) error {
+	dataStartRow := t.GetDataStartRow()
+	totalColumns := t.Columns.GetTotalColumnCount()
+	dataEndRow := dataStartRow + len(t.Data) - 1
+	firstRow, lastRow := dataStartRow+from, dataStartRow+to-1
 
 	// Apply data cell styles
-	if err := t.applyCellStyles(dataStartRow, dataEndRow, ops); err != nil {
+	if err := t.applyBandedCellStyles(firstRow, lastRow, bands, ops); err != nil {
 		return fmt.Errorf("failed to apply cell styles: %w", err)
 	}
 
 	// Apply column borders
-	if err := t.applyColumnBorders(dataStartRow, dataEndRow, ops); err != nil {
+	if err := t.applyColumnBordersTo(firstRow, lastRow, dataStartRow, dataEndRow, ops); err != nil {
 		return fmt.Errorf("failed to apply column borders: %w", err)
 	}
 
 	// Apply row borders for each data row
-	for rowIndex := range t.Data {
+	for rowIndex := from; rowIndex < to; rowIndex++ {
 		actualRowNum := rowIndex + dataStartRow
 		err := t.applyRowBorders(rowIndex, actualRowNum, totalColumns, ops)
 		if err != nil {
@@ -51,11 +84,35 @@ func (t *Table) RenderStyles(ops TableOperations) error {
 		}
 	}
 
-	// Apply cell-specific borders last to override other border settings
-	if err := t.applyCellSpecificBorders(dataStartRow, ops); err != nil {
+	// Apply cell-specific borders last to override other border settings; a window of rows only
+	// applies those of its rows
+	var err error
+	if from == 0 && to == len(t.Data) {
+		err = t.applyCellSpecificBorders(dataStartRow, ops)
+	} else {
+		err = t.applyCellSpecificBordersTo(from, to, dataStartRow, ops)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to apply cell-specific borders: %w", err)
 	}
+	return nil
+}
+
+// styleTrailer applies the styles of the footer and truncation notice rows.
+func (t *Table) styleTrailer(ops TableOperations) error {
+	// Apply footer style
+	if t.hasFooter() {
+		if err := t.applyFooterStyles(ops); err != nil {
+			return fmt.Errorf("failed to apply footer styles: %w", err)
+		}
+	}
 
+	// Apply truncation notice style
+	if t.hasTruncationNotice() {
+		if err := t.applyTruncationNoticeStyle(ops); err != nil {
+			return fmt.Errorf("failed to apply truncation notice style: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -75,10 +132,9 @@ func (t *Table) applyHeaderStyles(ops TableOperations) error {
 	for row := headerStartRow; row < headerStartRow+maxDepth; row++ {
 		for col := 1; col <= totalColumns; col++ {
 			if err := t.applyBordersToCell(col, row, borders, ops); err != nil {
-				L().Warn("Failed to apply header cell-specific border",
+				t.warn(WarningPhaseStyle, t.cellRef(col, row), "Failed to apply header cell-specific border", t.styleError(col, row, err),
 					Int("column", col),
-					Int("row", row),
-					Error(err))
+					Int("row", row))
 			}
 		}
 	}
@@ -107,24 +163,44 @@ func (t *Table) applyHeaderCellStyles(ops TableOperations) error {
 
 	// Use user-provided style if configured
 	if t.HeaderOptions != nil && t.HeaderOptions.Style != nil {
-		headerStyle = *t.HeaderOptions.Style
+		headerStyle = t.contrastStyle(*t.HeaderOptions.Style)
 	}
 
 	// Apply header styling to all header rows
 	if err := ops.ApplyStyleToRange(1, headerStartRow, totalColumns, headerStartRow+maxDepth-1, headerStyle); err != nil {
-		L().Warn("Failed to apply header range style", Error(err))
+		t.warn(WarningPhaseStyle, "", "Failed to apply header range style", err)
 		return err
 	}
 
 	return nil
 }
 
-// applyCellStyles applies styling to all data cells based on priority: cell > row > column.
-// For each cell, determines the most specific style to apply and applies it.
+// applyCellStyles applies styling to all data cells based on priority: cell > style rule > row > column > band.
+// For each cell, determines the most specific style to apply; contiguous rectangles of cells
+// sharing the same style are then styled with a single range operation.
 func (t *Table) applyCellStyles(dataStartRow, dataEndRow int, ops TableOperations) error {
+	var bands []int
+	if t.Zebra != nil {
+		bands = t.zebraBands(ops)
+	}
+	return t.applyBandedCellStyles(dataStartRow, dataEndRow, bands, ops)
+}
+
+// applyBandedCellStyles applies the data cell styles like applyCellStyles, with the zebra bands of
+// the data rows computed once by the caller (nil without banding).
+func (t *Table) applyBandedCellStyles(dataStartRow, dataEndRow int, bands []int, ops TableOperations) error {
 	flatColumns := t.Columns.GetFlattenedColumns()
 
-	// Apply styles to each data row
+	// Rectangles still growing downwards, by start column; the others are applied as soon as they end
+	open := make(map[int]*styleRect)
+	defer func() {
+		for _, rect := range open {
+			t.applyStyleRect(rect, ops)
+		}
+	}()
+	styles := make([]*Style, len(flatColumns))
+
+	// Resolve the style of each data row
 	for rowIndex := dataStartRow; rowIndex <= dataEndRow; rowIndex++ {
 		dataRowIndex := t.GetDataIndexFromRowIndex(rowIndex)
 
@@ -132,12 +208,15 @@ func (t *Table) applyCellStyles(dataStartRow, dataEndRow int, ops TableOperation
 		if dataRowIndex >= len(t.Data) {
 			break
 		}
+		if err := t.advance(1); err != nil {
+			return err
+		}
+		if err := t.strictErr(); err != nil {
+			return err
+		}
 
 		// Get row-level style if configured
-		var rowStyle *Style
-		if rc, exists := t.RowOptionsMap[dataRowIndex]; exists && rc.Style != nil {
-			rowStyle = rc.Style
-		}
+		rowStyle := t.rowStyle(dataRowIndex)
 
 		// Process each column in this row
 		for colIndex, column := range flatColumns {
@@ -151,6 +230,19 @@ func (t *Table) applyCellStyles(dataStartRow, dataEndRow int, ops TableOperation
 				}
 			}
 
+			// Style rules only apply to data rows, not to group header and subtotal rows
+			if styleToApply == nil && len(column.StyleRules) > 0 && t.SourceRowIndex(dataRowIndex) >= 0 {
+				rule, err := matchStyleRule(column, t.Data[dataRowIndex])
+				if err != nil {
+					t.warn(WarningPhaseStyle, t.cellRef(actualColIndex, rowIndex), "Failed to evaluate style rule", t.styleError(actualColIndex, rowIndex, err),
+						Int("column", actualColIndex),
+						Int("row", rowIndex))
+				}
+				if rule != nil {
+					styleToApply = rule.Style
+				}
+			}
+
 			if styleToApply == nil && rowStyle != nil {
 				styleToApply = rowStyle
 			}
@@ -158,22 +250,108 @@ func (t *Table) applyCellStyles(dataStartRow, dataEndRow int, ops TableOperation
 			if styleToApply == nil && column.Style != nil {
 				styleToApply = column.Style
 			}
+			styleToApply = t.cellNumFmtStyle(column, t.Data[dataRowIndex], styleToApply)
 
-			// Apply the determined style
-			if err := t.applyCellStyle(styleToApply, actualColIndex, rowIndex, ops); err != nil {
-				L().Warn("Failed to apply cell style",
-					Int("column", actualColIndex),
-					Int("row", rowIndex),
-					Error(err))
-				// Continue processing other cells even if one fails
-				continue
+			if bands != nil {
+				styleToApply = withBand(styleToApply, t.Zebra.bandStyle(bands[dataRowIndex]))
 			}
+
+			// The protection is resolved on its own so that unlocking a column survives cell and row styles
+			styleToApply = withLockState(styleToApply, t.cellLocked(actualColIndex, dataRowIndex, rowStyle, column.Style))
+			styles[colIndex] = withHiddenFormula(styleToApply, t.cellHiddenFormula(actualColIndex, dataRowIndex, rowStyle, column.Style))
+		}
+
+		// Extend the rectangles whose columns hold the same style on this row, start the others
+		for startCol := 1; startCol <= len(styles); {
+			style := styles[startCol-1]
+			endCol := startCol
+			for endCol < len(styles) && sameStyle(styles[endCol], style) {
+				endCol++
+			}
+			rect, growing := open[startCol]
+			if growing && (rect.endCol != endCol || !sameStyle(rect.style, style)) {
+				t.applyStyleRect(rect, ops)
+				growing = false
+			}
+			delete(open, startCol)
+			if growing {
+				rect.endRow = rowIndex
+				open[startCol] = rect
+			} else if style != nil {
+				open[startCol] = &styleRect{style: style, startCol: startCol, startRow: rowIndex, endCol: endCol, endRow: rowIndex}
+			}
+			// Rectangles starting inside this run end on the previous row
+			for col := startCol + 1; col <= endCol; col++ {
+				if inner, ok := open[col]; ok {
+					t.applyStyleRect(inner, ops)
+					delete(open, col)
+				}
+			}
+			startCol = endCol + 1
 		}
 	}
 
 	return nil
 }
 
+// styleRect is a rectangle of data cells sharing the same style (bounds included).
+type styleRect struct {
+	style              *Style
+	startCol, startRow int
+	endCol, endRow     int
+}
+
+// applyStyleRect styles a rectangle of cells with a single operation. When the range cannot be
+// styled, each cell is styled on its own so that failures are reported per cell.
+func (t *Table) applyStyleRect(rect *styleRect, ops TableOperations) {
+	if rect.startCol != rect.endCol || rect.startRow != rect.endRow {
+		if ops.ApplyStyleToRange(rect.startCol, rect.startRow, rect.endCol, rect.endRow, t.contrastStyle(*rect.style)) == nil {
+			return
+		}
+	}
+	for row := rect.startRow; row <= rect.endRow; row++ {
+		for col := rect.startCol; col <= rect.endCol; col++ {
+			if err := t.applyCellStyle(rect.style, col, row, ops); err != nil {
+				t.warn(WarningPhaseStyle, t.cellRef(col, row), "Failed to apply cell style", t.styleError(col, row, err),
+					Int("column", col),
+					Int("row", row))
+			}
+		}
+	}
+}
+
+// sameStyle reports whether two styles are identical, comparing the lock states by value.
+func sameStyle(a, b *Style) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	if (a.Locked == nil) != (b.Locked == nil) || (a.Locked != nil && *a.Locked != *b.Locked) {
+		return false
+	}
+	x, y := *a, *b
+	x.Locked, y.Locked = nil, nil
+	return x == y
+}
+
+// rowStyle returns the style of a data row: its RowOptions style, otherwise the style computed by
+// the RowStyler for rows of the original data, or nil.
+func (t *Table) rowStyle(dataRowIndex int) *Style {
+	if rc, exists := t.RowOptionsMap[dataRowIndex]; exists && rc.Style != nil {
+		return rc.Style
+	}
+	if t.RowStyler == nil {
+		return nil
+	}
+	source := t.SourceRowIndex(dataRowIndex)
+	if source < 0 || dataRowIndex >= len(t.Data) {
+		return nil
+	}
+	return t.RowStyler(source, t.Data[dataRowIndex])
+}
+
 // applyCellStyle applies a style configuration to a specific cell.
 // If style is nil, no operation is performed.
 func (t *Table) applyCellStyle(style *Style, colIndex, rowIndex int, ops TableOperations) error {
@@ -182,7 +360,7 @@ func (t *Table) applyCellStyle(style *Style, colIndex, rowIndex int, ops TableOp
 	}
 
 	// Apply the style using the operations interface
-	if err := ops.ApplyStyleToCell(colIndex, rowIndex, *style); err != nil {
+	if err := ops.ApplyStyleToCell(colIndex, rowIndex, t.contrastStyle(*style)); err != nil {
 		return fmt.Errorf("failed to apply style to cell (%d,%d): %w", colIndex, rowIndex, err)
 	}
 
@@ -192,6 +370,12 @@ func (t *Table) applyCellStyle(style *Style, colIndex, rowIndex int, ops TableOp
 // applyColumnBorders applies borders to columns for all data rows.
 // Handles both inner and boundary borders for each column.
 func (t *Table) applyColumnBorders(dataStartRow, dataEndRow int, ops TableOperations) error {
+	return t.applyColumnBordersTo(dataStartRow, dataEndRow, dataStartRow, dataEndRow, ops)
+}
+
+// applyColumnBordersTo applies the column borders to the data rows firstRow to lastRow of a table
+// whose data spans dataStartRow to dataEndRow, which receive the top and bottom borders.
+func (t *Table) applyColumnBordersTo(firstRow, lastRow, dataStartRow, dataEndRow int, ops TableOperations) error {
 	flatColumns := t.Columns.GetFlattenedColumns()
 
 	for colIndex, column := range flatColumns {
@@ -204,21 +388,22 @@ func (t *Table) applyColumnBorders(dataStartRow, dataEndRow int, ops TableOperat
 
 		// If inner borders are configured, apply to all cells in column
 		if column.Borders.Inner != nil {
-			for row := dataStartRow; row <= dataEndRow; row++ {
+			for row := firstRow; row <= lastRow; row++ {
 				if err := t.applyBordersToCell(actualColIndex, row, column.Borders.Inner, ops); err != nil {
-					L().Warn("Failed to apply column border",
+					t.warn(WarningPhaseStyle, t.cellRef(actualColIndex, row), "Failed to apply column border", t.styleError(actualColIndex, row, err),
 						Int("column", actualColIndex),
-						Int("row", row),
-						Error(err))
+						Int("row", row))
 					continue
 				}
 			}
 		} else {
 			// Otherwise, apply left/right borders to all cells, top/bottom only to boundary cells
-			for row := dataStartRow; row <= dataEndRow; row++ {
+			for row := firstRow; row <= lastRow; row++ {
 				cellBorder := &Borders{
-					Left:  column.Borders.Left,
-					Right: column.Borders.Right,
+					Left:         column.Borders.Left,
+					Right:        column.Borders.Right,
+					DiagonalUp:   column.Borders.DiagonalUp,
+					DiagonalDown: column.Borders.DiagonalDown,
 				}
 
 				if row == dataStartRow {
@@ -229,10 +414,9 @@ func (t *Table) applyColumnBorders(dataStartRow, dataEndRow int, ops TableOperat
 				}
 
 				if err := t.applyBordersToCell(actualColIndex, row, cellBorder, ops); err != nil {
-					L().Warn("Failed to apply column border",
+					t.warn(WarningPhaseStyle, t.cellRef(actualColIndex, row), "Failed to apply column border", t.styleError(actualColIndex, row, err),
 						Int("column", actualColIndex),
-						Int("row", row),
-						Error(err))
+						Int("row", row))
 					continue
 				}
 			}
@@ -255,10 +439,9 @@ func (t *Table) applyRowBorders(dataRowIndex, actualRowNum, totalColumns int, op
 		if rowOptions.Border.Inner != nil {
 			for col := 1; col <= totalColumns; col++ {
 				if err := t.applyBordersToCell(col, actualRowNum, rowOptions.Border.Inner, ops); err != nil {
-					L().Warn("Failed to apply row border",
+					t.warn(WarningPhaseStyle, t.cellRef(col, actualRowNum), "Failed to apply row border", t.styleError(col, actualRowNum, err),
 						Int("column", col),
-						Int("row", actualRowNum),
-						Error(err))
+						Int("row", actualRowNum))
 					continue
 				}
 			}
@@ -266,8 +449,10 @@ func (t *Table) applyRowBorders(dataRowIndex, actualRowNum, totalColumns int, op
 			// Otherwise, apply top/bottom borders to all cells, left/right only to boundary cells
 			for col := 1; col <= totalColumns; col++ {
 				cellBorders := &Borders{
-					Top:    rowOptions.Border.Top,
-					Bottom: rowOptions.Border.Bottom,
+					Top:          rowOptions.Border.Top,
+					Bottom:       rowOptions.Border.Bottom,
+					DiagonalUp:   rowOptions.Border.DiagonalUp,
+					DiagonalDown: rowOptions.Border.DiagonalDown,
 				}
 
 				// Apply left border only to first column
@@ -279,10 +464,9 @@ func (t *Table) applyRowBorders(dataRowIndex, actualRowNum, totalColumns int, op
 				}
 
 				if err := t.applyBordersToCell(col, actualRowNum, cellBorders, ops); err != nil {
-					L().Warn("Failed to apply row border",
+					t.warn(WarningPhaseStyle, t.cellRef(col, actualRowNum), "Failed to apply row border", t.styleError(col, actualRowNum, err),
 						Int("column", col),
-						Int("row", actualRowNum),
-						Error(err))
+						Int("row", actualRowNum))
 					continue
 				}
 			}
@@ -297,22 +481,38 @@ func (t *Table) applyCellSpecificBorders(dataStartRow int, ops TableOperations)
 	for colIndex, rowOptionsMap := range t.CellOptionsMap {
 		for rowIndex, cellOptions := range rowOptionsMap {
 			if cellOptions.Border != nil {
-				actualRowNum := rowIndex + dataStartRow
-				if err := t.applyBordersToCell(colIndex, actualRowNum, cellOptions.Border, ops); err != nil {
-					L().Warn("Failed to apply cell-specific border",
-						Int("column", colIndex),
-						Int("row", actualRowNum),
-						Error(err))
-					continue
-				}
+				t.applyCellSpecificBorder(colIndex, rowIndex+dataStartRow, cellOptions.Border, ops)
 			}
 		}
 	}
 	return nil
 }
 
+// applyCellSpecificBordersTo applies the cell-specific borders of the data rows from (included) to
+// (excluded), like applyCellSpecificBorders.
+func (t *Table) applyCellSpecificBordersTo(from, to, dataStartRow int, ops TableOperations) error {
+	for _, colIndex := range sortedKeys(t.CellOptionsMap) {
+		rowOptionsMap := t.CellOptionsMap[colIndex]
+		for rowIndex := from; rowIndex < to; rowIndex++ {
+			if cellOptions, ok := rowOptionsMap[rowIndex]; ok && cellOptions.Border != nil {
+				t.applyCellSpecificBorder(colIndex, rowIndex+dataStartRow, cellOptions.Border, ops)
+			}
+		}
+	}
+	return nil
+}
+
+// applyCellSpecificBorder applies the borders of a cell, reporting failures as warnings.
+func (t *Table) applyCellSpecificBorder(colIndex, actualRowNum int, borders *Borders, ops TableOperations) {
+	if err := t.applyBordersToCell(colIndex, actualRowNum, borders, ops); err != nil {
+		t.warn(WarningPhaseStyle, t.cellRef(colIndex, actualRowNum), "Failed to apply cell-specific border", t.styleError(colIndex, actualRowNum, err),
+			Int("column", colIndex),
+			Int("row", actualRowNum))
+	}
+}
+
 // applyBordersToCell applies all configured borders to a specific cell.
-// Each border (left, right, top, bottom) is applied if present.
+// Each border (left, right, top, bottom and diagonals) is applied if present.
 func (t *Table) applyBordersToCell(col, row int, borders *Borders, ops TableOperations) error {
 	if borders.Left != nil {
 		if err := ops.ApplyBorderToCell(col, row, "left", borders.Left); err != nil {
@@ -334,6 +534,16 @@ func (t *Table) applyBordersToCell(col, row int, borders *Borders, ops TableOper
 			return fmt.Errorf("failed to apply bottom border: %w", err)
 		}
 	}
+	if borders.DiagonalUp != nil {
+		if err := ops.ApplyBorderToCell(col, row, "diagonalUp", borders.DiagonalUp); err != nil {
+			return fmt.Errorf("failed to apply diagonal up border: %w", err)
+		}
+	}
+	if borders.DiagonalDown != nil {
+		if err := ops.ApplyBorderToCell(col, row, "diagonalDown", borders.DiagonalDown); err != nil {
+			return fmt.Errorf("failed to apply diagonal down border: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -346,11 +556,10 @@ func (t *Table) applyPreambleStyles(ops TableOperations) error {
 		}
 		actualRow := i + 1
 		for col := range row.Values {
-			if err := ops.ApplyStyleToCell(col+1, actualRow, *row.Style); err != nil {
-				L().Warn("Failed to apply preamble cell style",
+			if err := ops.ApplyStyleToCell(col+1, actualRow, t.contrastStyle(*row.Style)); err != nil {
+				t.warn(WarningPhaseStyle, t.cellRef(col+1, actualRow), "Failed to apply preamble cell style", t.styleError(col+1, actualRow, err),
 					Int("column", col+1),
-					Int("row", actualRow),
-					Error(err))
+					Int("row", actualRow))
 			}
 		}
 	}
@@ -401,6 +401,7 @@ func TestTable_applyHeaderCellStyles(t *testing.T) {
 				expectedStyle := Style{
 					Bold:            false,
 					Italic:          true,
+					TextColor:       ContrastTextDark, // Derived from the background
 					BackgroundColor: "#FF0000",
 					Alignment:       AlignmentLeft,
 				}
@@ -523,9 +524,79 @@ func TestTable_applyCellStyles(t *testing.T) {
 			startRow: 2,
 			endRow:   2,
 			setupMock: func(mockOps *MockTableOperations) {
+				// Both cells share the row style: styled as one range
 				rowStyle := Style{Italic: true}
-				mockOps.EXPECT().ApplyStyleToCell(1, 2, rowStyle).Return(nil)
-				mockOps.EXPECT().ApplyStyleToCell(2, 2, rowStyle).Return(nil)
+				mockOps.EXPECT().ApplyStyleToRange(1, 2, 2, 2, rowStyle).Return(nil)
+			},
+		},
+		{
+			name: "row_styler_between_cell_and_column",
+			table: &Table{
+				Data: DataSlice{
+					{"name": "John", "status": "FAILED"},
+					{"name": "Jane", "status": "OK"},
+					{"name": "Jim", "status": "FAILED"},
+				},
+				Columns: Columns{
+					{Name: "name", Label: "Name", Style: &Style{Bold: true}},
+					{Name: "status", Label: "Status"},
+				},
+				RowOptionsMap: RowOptionsMap{
+					2: RowOptions{Style: &Style{Italic: true}}, // Explicit row style wins over the styler
+				},
+				CellOptionsMap: CellOptionsMap{
+					2: map[int]CellOptions{0: {Style: &Style{Underline: "single"}}},
+				},
+				RowStyler: func(rowIndex int, row Data) *Style {
+					if row["status"] == "FAILED" {
+						return &Style{TextColor: "#FF0000"}
+					}
+					return nil
+				},
+				WriteHeader: true,
+			},
+			startRow: 2,
+			endRow:   4,
+			setupMock: func(mockOps *MockTableOperations) {
+				mockOps.EXPECT().ApplyStyleToCell(1, 2, Style{TextColor: "#FF0000"}).Return(nil)
+				mockOps.EXPECT().ApplyStyleToCell(2, 2, Style{Underline: "single"}).Return(nil)
+				mockOps.EXPECT().ApplyStyleToCell(1, 3, Style{Bold: true}).Return(nil)
+				mockOps.EXPECT().ApplyStyleToRange(1, 4, 2, 4, Style{Italic: true}).Return(nil)
+			},
+		},
+		{
+			name: "identical_styles_batched_into_rectangles",
+			table: &Table{
+				Data: DataSlice{{"a": 1}, {"a": 2}, {"a": 3}},
+				Columns: Columns{
+					{Name: "a", Label: "A", Style: &Style{Bold: true}},
+					{Name: "b", Label: "B", Style: &Style{Bold: true}},
+					{Name: "c", Label: "C", Style: &Style{FontSize: 9}},
+				},
+				RowOptionsMap: RowOptionsMap{2: RowOptions{Style: &Style{Italic: true}}},
+				WriteHeader:   true,
+			},
+			startRow: 2,
+			endRow:   4,
+			setupMock: func(mockOps *MockTableOperations) {
+				mockOps.EXPECT().ApplyStyleToRange(1, 2, 2, 3, Style{Bold: true}).Return(nil)
+				mockOps.EXPECT().ApplyStyleToRange(3, 2, 3, 3, Style{FontSize: 9}).Return(nil)
+				mockOps.EXPECT().ApplyStyleToRange(1, 4, 3, 4, Style{Italic: true}).Return(nil)
+			},
+		},
+		{
+			name: "failed_range_styled_per_cell",
+			table: &Table{
+				Data:        DataSlice{{"a": 1}, {"a": 2}},
+				Columns:     Columns{{Name: "a", Label: "A", Style: &Style{Bold: true}}},
+				WriteHeader: true,
+			},
+			startRow: 2,
+			endRow:   3,
+			setupMock: func(mockOps *MockTableOperations) {
+				mockOps.EXPECT().ApplyStyleToRange(1, 2, 1, 3, Style{Bold: true}).Return(errors.New("range failed"))
+				mockOps.EXPECT().ApplyStyleToCell(1, 2, Style{Bold: true}).Return(nil)
+				mockOps.EXPECT().ApplyStyleToCell(1, 3, Style{Bold: true}).Return(nil)
 			},
 		},
 		{
@@ -966,6 +1037,20 @@ func TestTable_applyBordersToCell(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "apply_diagonal_borders",
+			col:  1,
+			row:  2,
+			borders: &Borders{
+				DiagonalUp:   &Border{Style: BorderStyleThin, Color: "#FF0000"},
+				DiagonalDown: &Border{Style: BorderStyleThin},
+			},
+			setupMock: func(mockOps *MockTableOperations) {
+				mockOps.EXPECT().ApplyBorderToCell(1, 2, "diagonalUp", &Border{Style: BorderStyleThin, Color: "#FF0000"}).Return(nil)
+				mockOps.EXPECT().ApplyBorderToCell(1, 2, "diagonalDown", &Border{Style: BorderStyleThin}).Return(nil)
+			},
+			expectedError: false,
+		},
 		{
 			name: "apply_partial_borders",
 			col:  1,
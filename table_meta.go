@@ -0,0 +1,106 @@
+// table_meta.go - Cell metadata.
+//
+// This file groups the metadata attached to cells (CellOptions.Meta) into named regions so
+// downstream automation (macros, Power Automate, scrapers) can locate semantic parts of an export
+// programmatically. XLSX writes each region as a sheet-scoped defined name whose comment holds the
+// value; HTML writes data-* attributes on the cells.
+
+package spit
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MetaRegion is the set of cells sharing a metadata key.
+type MetaRegion struct {
+	Key    string   // Metadata key
+	Cells  [][2]int // Absolute 1-based (column, row) sheet coordinates, in column then row order
+	Values []string // Distinct values attached to the cells, in order of appearance
+}
+
+// MetaRegions returns the metadata regions of the table, sorted by key.
+// Coordinates are absolute sheet coordinates (start position included).
+func (t *Table) MetaRegions() []MetaRegion {
+	regions := make(map[string]*MetaRegion)
+	dataStartRow := t.GetDataStartRow()
+
+	for _, colIndex := range sortedKeys(t.CellOptionsMap) {
+		rows := t.CellOptionsMap[colIndex]
+		for _, rowIndex := range sortedKeys(rows) {
+			meta := rows[rowIndex].Meta
+			for _, key := range sortedKeys(meta) {
+				region, ok := regions[key]
+				if !ok {
+					region = &MetaRegion{Key: key}
+					regions[key] = region
+				}
+				col, row := t.sheetCoords(colIndex, rowIndex+dataStartRow)
+				region.Cells = append(region.Cells, [2]int{col, row})
+				if value := meta[key]; !slices.Contains(region.Values, value) {
+					region.Values = append(region.Values, value)
+				}
+			}
+		}
+	}
+
+	result := make([]MetaRegion, 0, len(regions))
+	for _, key := range sortedKeys(regions) {
+		result = append(result, *regions[key])
+	}
+	return result
+}
+
+// definedNameSetter is implemented by spreadsheets supporting defined names.
+type definedNameSetter interface {
+	SetDefinedName(name, refersTo, comment string) error
+}
+
+// writeMeta writes the metadata regions of the table as defined names scoped to sheetName.
+// Names excelize rejects are skipped with a warning each (see ErrorMode).
+func (xlsx *xlsx) writeMeta(sheetName string) {
+	regions := xlsx.table.MetaRegions()
+	if len(regions) == 0 {
+		return
+	}
+	setter, ok := xlsx.spreadsheet.(definedNameSetter)
+	if !ok {
+		xlsx.table.warn(WarningPhaseSheet, "", "Spreadsheet does not support defined names, cell metadata skipped", nil, String("sheet", sheetName))
+		return
+	}
+
+	sheet := quoteSheetName(sheetName)
+	for _, region := range regions {
+		refs := make([]string, len(region.Cells))
+		for i, cell := range region.Cells {
+			ref, _ := excelize.CoordinatesToCellName(cell[0], cell[1], true)
+			refs[i] = sheet + "!" + ref
+		}
+		name := SanitizeDefinedName(region.Key)
+		if err := setter.SetDefinedName(name, strings.Join(refs, ","), strings.Join(region.Values, "; ")); err != nil {
+			xlsx.table.warn(WarningPhaseSheet, "", "Failed to write cell metadata", err, String("name", name))
+		}
+	}
+}
+
+// SanitizeDefinedName converts a metadata key to a valid spreadsheet defined name: characters other
+// than letters, digits, underscores and dots are replaced by underscores, and names starting with
+// a digit or looking like an A1 or R1C1 cell reference (e.g. "A1", "R1C1" or "C") are prefixed
+// with an underscore.
+func SanitizeDefinedName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "" {
+		return "_"
+	}
+	if isCellReference(name) || name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
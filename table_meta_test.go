@@ -0,0 +1,89 @@
+package spit
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_MetaRegions(t *testing.T) {
+	regions := fixtureTable("region", "sales").WithStartPosition(2, 3).WithCellOptions(CellOptionsMap{
+		2: {
+			0: *NewCellOptions(0, 1).WithMeta("kpi.sales", "us"),
+			1: *NewCellOptions(1, 1).WithMeta("kpi.sales", "eu").WithMeta("review", "pending"),
+		},
+	}).MetaRegions()
+	want := []MetaRegion{
+		{Key: "kpi.sales", Cells: [][2]int{{3, 4}, {3, 5}}, Values: []string{"us", "eu"}},
+		{Key: "review", Cells: [][2]int{{3, 5}}, Values: []string{"pending"}},
+	}
+	if !reflect.DeepEqual(regions, want) {
+		t.Errorf("MetaRegions() = %v, want %v", regions, want)
+	}
+}
+
+func TestSanitizeDefinedName(t *testing.T) {
+	for key, want := range map[string]string{
+		"kpi.sales":   "kpi.sales",
+		"total sales": "total_sales",
+		"A1":          "_A1",
+		"2024":        "_2024",
+		"R":           "_R",
+		"C":           "_C",
+		"R1C1":        "_R1C1",
+		"r2c3":        "_r2c3",
+		"RC":          "_RC",
+		"Rate":        "Rate",
+		"Sales1":      "Sales1",
+		"":            "_",
+	} {
+		if got := SanitizeDefinedName(key); got != want {
+			t.Errorf("SanitizeDefinedName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestExport_cellMeta(t *testing.T) {
+	dir := t.TempDir()
+	table := fixtureTable("region", "sales").WithCellOptions(CellOptionsMap{
+		2: {
+			0: *NewCellOptions(0, 1).WithMeta("kpi.sales", "us"),
+			1: *NewCellOptions(1, 1).WithMeta("kpi.sales", "eu").WithMeta("review", "pending"),
+		},
+	})
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Q1 Sales", table), FileWriteParams{Filename: "meta", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	names := make(map[string]excelize.DefinedName)
+	for _, dn := range f.GetDefinedName() {
+		names[dn.Name] = dn
+	}
+	if dn := names["kpi.sales"]; dn.RefersTo != "'Q1 Sales'!$B$2,'Q1 Sales'!$B$3" || dn.Comment != "us; eu" || dn.Scope != "Q1 Sales" {
+		t.Errorf("unexpected kpi.sales defined name: %+v", dn)
+	}
+	if dn := names["review"]; dn.RefersTo != "'Q1 Sales'!$B$3" {
+		t.Errorf("unexpected review defined name: %+v", dn)
+	}
+
+	htmlRes, err := ExportHTML(table, HTMLOptions{}, FileWriteParams{Filename: "meta", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportHTML failed: %v", err)
+	}
+	content, err := os.ReadFile(htmlRes.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(content), `data-kpi-sales="eu" data-review="pending"`) {
+		t.Errorf("expected data attributes on the EU sales cell:\n%s", content)
+	}
+}
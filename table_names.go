@@ -0,0 +1,153 @@
+// table_names.go - Named ranges.
+//
+// This file implements the optional defined names written over the regions of XLSX exports: the
+// header rows, data rows and footer row, and the data cells of each column, scoped to their sheet,
+// and a workbook-scoped name over the data rows. Downstream formulas, validation lists, Power Query
+// and macros can then reference the exported regions by name (e.g. =SUM(col_amount)) whatever the
+// row count or start position.
+
+package spit
+
+// DefaultColumnNamePrefix is the prefix of per-column names when NamedRangeOptions.ColumnPrefix is unset.
+const DefaultColumnNamePrefix = "col_"
+
+// Region names written by WithNamedRanges, before NamedRangeOptions.Prefix is applied.
+const (
+	NamedRangeHeaders = "Headers" // Header rows
+	NamedRangeData    = "Data"    // Data rows
+	NamedRangeTotals  = "Totals"  // Footer row
+)
+
+// NamedRangeOptions configures the defined names written over the regions of an XLSX export.
+type NamedRangeOptions struct {
+	Prefix       string `json:"prefix,omitempty" yaml:"prefix,omitempty"`             // Prepended to every name, e.g. "Sales_" gives Sales_Data (default: none)
+	Columns      bool   `json:"columns,omitempty" yaml:"columns,omitempty"`           // Whether the data cells of each column get a name (see ColumnPrefix)
+	ColumnPrefix string `json:"columnPrefix,omitempty" yaml:"columnPrefix,omitempty"` // Prefix of column names, followed by the column name (default: DefaultColumnNamePrefix)
+}
+
+// NewNamedRangeOptions creates a new NamedRangeOptions instance with per-column names.
+func NewNamedRangeOptions() *NamedRangeOptions {
+	return &NamedRangeOptions{Columns: true}
+}
+
+// WithPrefix sets the prefix prepended to every name, so several tables of a sheet or workbook
+// get distinct names.
+func (o *NamedRangeOptions) WithPrefix(prefix string) *NamedRangeOptions {
+	o.Prefix = prefix
+	return o
+}
+
+// WithColumns sets whether the data cells of each column get a name, starting with columnPrefix
+// (empty uses DefaultColumnNamePrefix).
+func (o *NamedRangeOptions) WithColumns(enabled bool, columnPrefix string) *NamedRangeOptions {
+	o.Columns = enabled
+	o.ColumnPrefix = columnPrefix
+	return o
+}
+
+// WithNamedRanges writes sheet-scoped defined names over the header rows (Headers), data rows
+// (Data), footer row (Totals) and the data cells of each column (col_<name>) of XLSX exports,
+// with the given options (nil uses NewNamedRangeOptions). Regions that were not written get no name.
+func (t *Table) WithNamedRanges(options *NamedRangeOptions) *Table {
+	if options == nil {
+		options = NewNamedRangeOptions()
+	}
+	t.NamedRanges = options
+	return t
+}
+
+// WithDataName writes a workbook-scoped defined name over the data rows of XLSX exports (e.g.
+// "SalesData"), referenced without a sheet name by formulas of any sheet, Power Query and macros.
+// The name is sanitized like the other names (see SanitizeDefinedName) and must be unique in the
+// workbook; an empty name writes none.
+func (t *Table) WithDataName(name string) *Table {
+	t.DataName = name
+	return t
+}
+
+// namedRanges returns the defined names of the written table mapped to their sheet ranges, in
+// write order: the regions first, then the columns.
+func (t *Table) namedRanges(result SheetResult) ([]string, map[string]CellRange) {
+	options := t.NamedRanges
+	var names []string
+	ranges := make(map[string]CellRange)
+	add := func(name string, r CellRange) {
+		if r.IsEmpty() {
+			return
+		}
+		name = SanitizeDefinedName(options.Prefix + name)
+		if _, ok := ranges[name]; !ok {
+			names = append(names, name)
+		}
+		ranges[name] = r
+	}
+
+	add(NamedRangeHeaders, result.HeaderRange)
+	add(NamedRangeData, result.DataRange)
+	add(NamedRangeTotals, result.FooterRange)
+
+	if !options.Columns || len(t.Data) == 0 {
+		return names, ranges
+	}
+	columnPrefix := options.ColumnPrefix
+	if columnPrefix == "" {
+		columnPrefix = DefaultColumnNamePrefix
+	}
+	dataStartRow := t.GetDataStartRow()
+	for i, column := range t.Columns.GetFlattenedColumns() {
+		if column.Name == "" {
+			continue
+		}
+		col := i + 1
+		add(columnPrefix+column.Name, t.sheetRange(CellRange{StartCol: col, StartRow: dataStartRow, EndCol: col, EndRow: dataStartRow + len(t.Data) - 1}))
+	}
+	return names, ranges
+}
+
+// writeNamedRanges writes the defined names of the written sheet. Each rejected name is reported
+// as a warning without preventing the others.
+func (xlsx *xlsx) writeNamedRanges(result SheetResult) {
+	t := xlsx.table
+	if t.NamedRanges == nil {
+		return
+	}
+	setter, ok := xlsx.spreadsheet.(definedNameSetter)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support defined names, named ranges skipped", nil, String("sheet", result.Name))
+		return
+	}
+
+	names, ranges := t.namedRanges(result)
+	for _, name := range names {
+		refersTo := quoteSheetName(result.Name) + "!" + absoluteReference(ranges[name].String())
+		if err := setter.SetDefinedName(name, refersTo, ""); err != nil {
+			t.warn(WarningPhaseSheet, "", "Failed to write named range", err, String("name", name), String("range", refersTo))
+		}
+	}
+}
+
+// workbookNameSetter is implemented by spreadsheets supporting workbook-scoped defined names.
+type workbookNameSetter interface {
+	SetWorkbookDefinedName(name, refersTo string) error
+}
+
+// writeDataName writes the workbook-scoped name over the data rows of the written sheet (see
+// Table.WithDataName). Tables without data rows get no name. A rejected name is reported as a
+// warning, which ErrorStrict turns into an export error.
+func (xlsx *xlsx) writeDataName(result SheetResult) {
+	t := xlsx.table
+	if t.DataName == "" || result.DataRange.IsEmpty() {
+		return
+	}
+	setter, ok := xlsx.spreadsheet.(workbookNameSetter)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support defined names, data name skipped", nil, String("sheet", result.Name))
+		return
+	}
+
+	name := SanitizeDefinedName(t.DataName)
+	refersTo := quoteSheetName(result.Name) + "!" + absoluteReference(result.DataRange.String())
+	if err := setter.SetWorkbookDefinedName(name, refersTo); err != nil {
+		t.warn(WarningPhaseSheet, "", "Failed to write data name", err, String("name", name), String("range", refersTo))
+	}
+}
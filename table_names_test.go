@@ -0,0 +1,143 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_namedRanges(t *testing.T) {
+	newTable := func() *Table {
+		return NewTable(DataSlice{
+			{"name": "Alice", "amount": 10},
+			{"name": "Bob", "amount": 30},
+		}, Columns{
+			NewColumn("name", "Name"),
+			NewColumn("amount", "Amount").WithAggregate(AggregateSum),
+		}, true).
+			WithStartPosition(2, 3).
+			WithFooter(nil)
+	}
+
+	tests := []struct {
+		name    string
+		table   *Table
+		sheet   string
+		want    map[string]string
+		missing []string
+	}{
+		{
+			name:  "Default names",
+			table: newTable().WithNamedRanges(nil),
+			sheet: "Sheet1",
+			want: map[string]string{
+				"Headers":    "Sheet1!$B$3:$C$3",
+				"Data":       "Sheet1!$B$4:$C$5",
+				"Totals":     "Sheet1!$B$6:$C$6",
+				"col_name":   "Sheet1!$B$4:$B$5",
+				"col_amount": "Sheet1!$C$4:$C$5",
+			},
+		},
+		{
+			name:  "Prefixed names without columns",
+			table: newTable().WithNamedRanges(NewNamedRangeOptions().WithPrefix("Q1 ").WithColumns(false, "")),
+			sheet: "Q1 Sales",
+			want: map[string]string{
+				"Q1_Headers": "'Q1 Sales'!$B$3:$C$3",
+				"Q1_Data":    "'Q1 Sales'!$B$4:$C$5",
+				"Q1_Totals":  "'Q1 Sales'!$B$6:$C$6",
+			},
+			missing: []string{"Q1_col_amount"},
+		},
+		{
+			name:  "Transposed table",
+			table: newTable().WithTransposed(true).WithNamedRanges(NewNamedRangeOptions().WithColumns(true, "c_")),
+			sheet: "Sheet1",
+			want: map[string]string{
+				"Headers":  "Sheet1!$B$3:$B$4",
+				"Data":     "Sheet1!$C$3:$D$4",
+				"c_amount": "Sheet1!$C$4:$D$4",
+			},
+		},
+		{
+			name:    "Disabled",
+			table:   newTable(),
+			sheet:   "Sheet1",
+			missing: []string{"Data", "col_amount"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ExportXLSX(NewSpreadsheetExcelize(tt.sheet, tt.table), FileWriteParams{Filename: "named", Filepath: t.TempDir()})
+			if err != nil {
+				t.Fatalf("ExportXLSX failed: %v", err)
+			}
+			if len(res.Warnings) > 0 {
+				t.Errorf("unexpected warnings: %v", res.Warnings)
+			}
+			f, err := excelize.OpenFile(res.Filepath)
+			if err != nil {
+				t.Fatalf("OpenFile failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			names := make(map[string]excelize.DefinedName)
+			for _, dn := range f.GetDefinedName() {
+				names[dn.Name] = dn
+			}
+			for name, want := range tt.want {
+				dn, ok := names[name]
+				if !ok {
+					t.Errorf("missing defined name %q", name)
+					continue
+				}
+				if dn.RefersTo != want {
+					t.Errorf("%s refers to %q, want %q", name, dn.RefersTo, want)
+				}
+				if dn.Scope != tt.sheet {
+					t.Errorf("%s scope = %q, want %q", name, dn.Scope, tt.sheet)
+				}
+			}
+			for _, name := range tt.missing {
+				if _, ok := names[name]; ok {
+					t.Errorf("unexpected defined name %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExportXLSX_dataName(t *testing.T) {
+	sales := NewTable(DataSlice{{"amount": 10}, {"amount": 30}}, Columns{NewColumn("amount", "Amount")}, true).
+		WithStartPosition(2, 3).
+		WithDataName("Sales Data")
+	returns := NewTable(DataSlice{{"amount": 5}}, Columns{NewColumn("amount", "Amount")}, true).WithDataName("Sales_Data")
+	empty := NewTable(nil, Columns{NewColumn("amount", "Amount")}, true).WithDataName("EmptyData")
+
+	res, err := ExportXLSXSheets([]Spreadsheet{
+		NewSpreadsheetExcelize("Q1 Sales", sales),
+		NewSpreadsheetExcelize("Returns", returns),
+		NewSpreadsheetExcelize("Empty", empty),
+	}, FileWriteParams{Filename: "named", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+	// The second table reuses the name of the first one
+	if len(res.Warnings) != 1 || res.Warnings[0].Message != "Failed to write data name" {
+		t.Errorf("warnings = %+v, want the duplicate name", res.Warnings)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	names := f.GetDefinedName()
+	if len(names) != 1 {
+		t.Fatalf("defined names = %+v, want a single one", names)
+	}
+	if dn := names[0]; dn.Name != "Sales_Data" || dn.RefersTo != "'Q1 Sales'!$B$4:$B$5" || dn.Scope != "Workbook" {
+		t.Errorf("defined name = %+v, want the workbook-scoped data rows of Q1 Sales", dn)
+	}
+}
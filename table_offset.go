@@ -0,0 +1,181 @@
+// table_offset.go - Table placement within a sheet.
+//
+// This file implements the start position of a table (Table.StartRow / Table.StartCol).
+// Layout calculations (header rows, data rows, merge ranges, styled ranges) are expressed in
+// table-relative coordinates where (1, 1) is the table origin. The offsetOperations wrapper
+// translates those coordinates to absolute sheet coordinates (swapping columns and rows for
+// transposed tables), so sheet-based backends (XLSX, Google Sheets) and the shared pipelines
+// (merging, styling) honor the placement without duplicating the math.
+
+package spit
+
+// GetStartRow returns the 1-based sheet row of the table origin (defaults to 1).
+func (t *Table) GetStartRow() int {
+	if t.StartRow < 1 {
+		return 1
+	}
+	return t.StartRow
+}
+
+// GetStartCol returns the 1-based sheet column of the table origin (defaults to 1).
+func (t *Table) GetStartCol() int {
+	if t.StartCol < 1 {
+		return 1
+	}
+	return t.StartCol
+}
+
+// Offset returns ops wrapped so that table-relative coordinates are translated to sheet
+// coordinates according to the table start position and orientation (see WithTransposed). When
+// the table starts at (1, 1) and is not transposed, or ops is already offset for this table, ops
+// is returned unchanged.
+func (t *Table) Offset(ops TableOperations) TableOperations {
+	colOffset, rowOffset := t.GetStartCol()-1, t.GetStartRow()-1
+	if colOffset == 0 && rowOffset == 0 && !t.Transposed {
+		return ops
+	}
+	if o, ok := ops.(*offsetOperations); ok && o.table == t {
+		return ops
+	}
+	return &offsetOperations{TableOperations: ops, table: t, colOffset: colOffset, rowOffset: rowOffset, transposed: t.Transposed}
+}
+
+// offsetOperations decorates a TableOperations implementation, shifting every coordinate by a
+// fixed number of columns and rows, after swapping columns and rows for transposed tables.
+type offsetOperations struct {
+	TableOperations
+	table      *Table // Table whose start position defines the offset
+	colOffset  int    // Number of sheet columns before the table origin
+	rowOffset  int    // Number of sheet rows before the table origin
+	transposed bool   // Whether table columns are written as sheet rows (see Table.WithTransposed)
+}
+
+// at returns the sheet coordinates of a table-relative cell.
+func (o *offsetOperations) at(col, row int) (int, int) {
+	if o.transposed {
+		col, row = row, col
+	}
+	return col + o.colOffset, row + o.rowOffset
+}
+
+// side returns the sheet border side of a table-relative border side.
+func (o *offsetOperations) side(side string) string {
+	if o.transposed {
+		return transposedSide(side)
+	}
+	return side
+}
+
+// GetCellValue returns the value of the offset cell.
+func (o *offsetOperations) GetCellValue(col, row int) (string, error) {
+	return o.TableOperations.GetCellValue(o.at(col, row))
+}
+
+// SetCellValue sets the value of the offset cell.
+func (o *offsetOperations) SetCellValue(col, row int, value interface{}) error {
+	col, row = o.at(col, row)
+	return o.TableOperations.SetCellValue(col, row, value)
+}
+
+// MergeCells merges the offset range.
+func (o *offsetOperations) MergeCells(startCol, startRow, endCol, endRow int) error {
+	startCol, startRow = o.at(startCol, startRow)
+	endCol, endRow = o.at(endCol, endRow)
+	return o.TableOperations.MergeCells(startCol, startRow, endCol, endRow)
+}
+
+// IsCellMerged checks whether the offset cell is part of a merged range.
+func (o *offsetOperations) IsCellMerged(col, row int) bool {
+	return o.TableOperations.IsCellMerged(o.at(col, row))
+}
+
+// IsCellMergedHorizontally checks whether the offset cell is merged horizontally, that is across
+// sheet rows for transposed tables.
+func (o *offsetOperations) IsCellMergedHorizontally(col, row int) bool {
+	col, row = o.at(col, row)
+	if o.transposed {
+		return o.TableOperations.IsCellMerged(col, row) && !o.TableOperations.IsCellMergedHorizontally(col, row)
+	}
+	return o.TableOperations.IsCellMergedHorizontally(col, row)
+}
+
+// ApplyBorderToCell applies a border to the offset cell.
+func (o *offsetOperations) ApplyBorderToCell(col, row int, side string, border *Border) error {
+	col, row = o.at(col, row)
+	return o.TableOperations.ApplyBorderToCell(col, row, o.side(side), border)
+}
+
+// ApplyBordersToRange applies borders to the offset range.
+func (o *offsetOperations) ApplyBordersToRange(startCol, startRow, endCol, endRow int, borders Borders) error {
+	startCol, startRow = o.at(startCol, startRow)
+	endCol, endRow = o.at(endCol, endRow)
+	if o.transposed {
+		borders = transposedBorders(borders)
+	}
+	return o.TableOperations.ApplyBordersToRange(startCol, startRow, endCol, endRow, borders)
+}
+
+// HasExistingBorder checks whether the offset cell has a border on the given side.
+func (o *offsetOperations) HasExistingBorder(col, row int, side string) bool {
+	col, row = o.at(col, row)
+	return o.TableOperations.HasExistingBorder(col, row, o.side(side))
+}
+
+// ApplyStyleToCell applies a style to the offset cell.
+func (o *offsetOperations) ApplyStyleToCell(col, row int, style Style) error {
+	col, row = o.at(col, row)
+	return o.TableOperations.ApplyStyleToCell(col, row, style)
+}
+
+// ApplyStyleToRange applies a style to the offset range.
+func (o *offsetOperations) ApplyStyleToRange(startCol, startRow, endCol, endRow int, style Style) error {
+	startCol, startRow = o.at(startCol, startRow)
+	endCol, endRow = o.at(endCol, endRow)
+	return o.TableOperations.ApplyStyleToRange(startCol, startRow, endCol, endRow, style)
+}
+
+// CellRef returns the reference of the offset cell in the addressing scheme of the wrapped operations.
+func (o *offsetOperations) CellRef(col, row int) string {
+	return AddresserFor(o.TableOperations).CellRef(o.at(col, row))
+}
+
+// RangeRef returns the reference of the offset range in the addressing scheme of the wrapped operations.
+func (o *offsetOperations) RangeRef(startCol, startRow, endCol, endRow int) string {
+	startCol, startRow = o.at(startCol, startRow)
+	endCol, endRow = o.at(endCol, endRow)
+	return AddresserFor(o.TableOperations).RangeRef(startCol, startRow, endCol, endRow)
+}
+
+// GetColumnLetter returns the column letter of the offset column.
+func (o *offsetOperations) GetColumnLetter(col int) string {
+	return o.TableOperations.GetColumnLetter(col + o.colOffset)
+}
+
+// SetCellFormula sets the formula of the offset cell.
+func (o *offsetOperations) SetCellFormula(col, row int, formula string) error {
+	col, row = o.at(col, row)
+	return o.TableOperations.SetCellFormula(col, row, formula)
+}
+
+// SetCellHyperLink sets a hyperlink on the offset cell.
+func (o *offsetOperations) SetCellHyperLink(col, row int, link string) error {
+	col, row = o.at(col, row)
+	return o.TableOperations.SetCellHyperLink(col, row, link)
+}
+
+// SetCellImage places an image at the offset cell.
+func (o *offsetOperations) SetCellImage(col, row int, img Image) error {
+	col, row = o.at(col, row)
+	return o.TableOperations.SetCellImage(col, row, img)
+}
+
+// withoutOffset returns t placed at the sheet origin. Backends without a notion of sheet position
+// (such as HTML) use it so the shared pipelines address the same cells they write.
+func (t *Table) withoutOffset() *Table {
+	if t.StartRow <= 1 && t.StartCol <= 1 {
+		return t
+	}
+	placed := *t
+	placed.StartRow, placed.StartCol = 0, 0
+	return &placed
+}
@@ -0,0 +1,82 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_Offset_identity(t *testing.T) {
+	table := NewTable(nil, nil, true)
+	ops := &htmlExport{table: table, grid: make(map[int]map[int]*htmlCell)}
+	if table.Offset(ops) != TableOperations(ops) {
+		t.Error("Offset() should return ops unchanged when the table starts at (1, 1)")
+	}
+
+	table.WithStartPosition(3, 2)
+	wrapped := table.Offset(ops)
+	if wrapped == TableOperations(ops) {
+		t.Fatal("Offset() should wrap ops when a start position is set")
+	}
+	if table.Offset(wrapped) != wrapped {
+		t.Error("Offset() should not wrap ops twice")
+	}
+
+	if err := wrapped.SetCellValue(1, 1, "x"); err != nil {
+		t.Fatalf("SetCellValue failed: %v", err)
+	}
+	if v, _ := ops.GetCellValue(3, 2); v != "x" {
+		t.Errorf("expected value at (3, 2), got %q", v)
+	}
+	if got := wrapped.GetColumnLetter(1); got != "C" {
+		t.Errorf("GetColumnLetter(1) = %q, want C", got)
+	}
+}
+
+func TestExportXLSX_startPosition(t *testing.T) {
+	data := DataSlice{
+		{"region": "EU", "sales": 10},
+		{"region": "EU", "sales": 20},
+		{"region": "US", "sales": 30},
+	}
+	columns := Columns{
+		NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+		NewColumn("sales", "Sales"),
+	}
+	table := NewTable(data, columns, true).
+		WithPreamble(PreambleRows{NewPreambleRow("Report")}).
+		WithHeaderOptions(NewHeaderOptions().WithStyle(&Style{Bold: true})).
+		WithStartPosition(2, 3)
+	s := NewSpreadsheetExcelize("Sheet1", table)
+
+	res, err := ExportXLSX(s, FileWriteParams{Filename: "offset", Filepath: t.TempDir(), OverwriteFile: true})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	for cell, want := range map[string]string{"B3": "Report", "B4": "Region", "C4": "Sales", "B5": "EU", "C7": "30", "A1": ""} {
+		if got, _ := f.GetCellValue("Sheet1", cell); got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+
+	merges, err := f.GetMergeCells("Sheet1")
+	if err != nil {
+		t.Fatalf("GetMergeCells failed: %v", err)
+	}
+	if len(merges) != 1 || merges[0].GetStartAxis() != "B5" || merges[0].GetEndAxis() != "B6" {
+		t.Errorf("expected a single B5:B6 merge, got %v", merges)
+	}
+
+	styleID, _ := f.GetCellStyle("Sheet1", "B4")
+	style, err := f.GetStyle(styleID)
+	if err != nil || style.Font == nil || !style.Font.Bold {
+		t.Error("expected the offset header cell to be bold")
+	}
+}
@@ -0,0 +1,102 @@
+// table_placeholder.go - Placeholders of nil and missing values.
+//
+// This file implements the placeholders written instead of empty cells (see
+// Table.WithNilPlaceholder): a text for nil values (nil, nil pointers, SQL NULL) and another for
+// rows holding none of the keys of a column, so readers can tell both apart from empty strings.
+// Columns may override the placeholders of their table. Placeholders are written as plain text,
+// whatever the format or type of the column, and take no part in merges.
+
+package spit
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// PlaceholderOptions configures the text written instead of nil and missing values. An empty text
+// leaves the cells as without placeholders.
+type PlaceholderOptions struct {
+	Nil     string `json:"nil,omitempty" yaml:"nil,omitempty"`         // Text written for nil values, e.g. "N/A"
+	Missing string `json:"missing,omitempty" yaml:"missing,omitempty"` // Text written when the row holds none of the keys of the column, e.g. "–"
+}
+
+// NewPlaceholderOptions creates a new PlaceholderOptions instance with default settings.
+func NewPlaceholderOptions() *PlaceholderOptions {
+	return &PlaceholderOptions{}
+}
+
+// WithNil sets the text written for nil values.
+func (p *PlaceholderOptions) WithNil(text string) *PlaceholderOptions {
+	p.Nil = text
+	return p
+}
+
+// WithMissing sets the text written when the row holds none of the keys of the column.
+func (p *PlaceholderOptions) WithMissing(text string) *PlaceholderOptions {
+	p.Missing = text
+	return p
+}
+
+// WithNilPlaceholder sets the text written for nil and missing values of every column.
+func (t *Table) WithNilPlaceholder(text string) *Table {
+	return t.WithNilPlaceholderOptions(&PlaceholderOptions{Nil: text, Missing: text})
+}
+
+// WithNilPlaceholderOptions sets the texts written for nil and missing values of every column.
+func (t *Table) WithNilPlaceholderOptions(options *PlaceholderOptions) *Table {
+	t.NilPlaceholder = options
+	return t
+}
+
+// WithNilPlaceholder sets the text written for nil and missing values of the column, overriding
+// the placeholders of the table.
+func (c *Column) WithNilPlaceholder(text string) *Column {
+	return c.WithNilPlaceholderOptions(&PlaceholderOptions{Nil: text, Missing: text})
+}
+
+// WithNilPlaceholderOptions sets the texts written for nil and missing values of the column,
+// overriding the placeholders of the table.
+func (c *Column) WithNilPlaceholderOptions(options *PlaceholderOptions) *Column {
+	c.NilPlaceholder = options
+	return c
+}
+
+// CellPlaceholder returns the text written instead of the value of a column looked up in a row
+// (see Data.LookupColumn), or false when the value is written as usual. Backends call it before
+// processing values.
+func (t *Table) CellPlaceholder(column *Column, value interface{}, found bool) (string, bool) {
+	options := column.NilPlaceholder
+	if options == nil && t != nil {
+		options = t.NilPlaceholder
+	}
+	if options == nil {
+		return "", false
+	}
+	text := options.Nil
+	if !found {
+		text = options.Missing
+	} else if !isNilValue(value) {
+		return "", false
+	}
+	return text, text != ""
+}
+
+// isNilValue reports whether a value is nil: the nil interface, a nil pointer, map, slice or
+// function, or a driver.Valuer holding SQL NULL.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr || v.Kind() == reflect.Map ||
+		v.Kind() == reflect.Slice || v.Kind() == reflect.Func || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil && dv == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package spit
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_CellPlaceholder(t *testing.T) {
+	var nilName *string
+	table := NewTable(nil, nil, true).WithNilPlaceholderOptions(NewPlaceholderOptions().WithNil("N/A").WithMissing("–"))
+	column := NewColumn("name", "Name")
+	tests := []struct {
+		name   string
+		column *Column
+		value  interface{}
+		found  bool
+		want   string
+		wantOK bool
+	}{
+		{"nil", column, nil, true, "N/A", true},
+		{"nil pointer", column, nilName, true, "N/A", true},
+		{"sql null", column, sql.NullString{}, true, "N/A", true},
+		{"missing", column, nil, false, "–", true},
+		{"value", column, "bob", true, "", false},
+		{"empty string", column, "", true, "", false},
+		{"column override", NewColumn("name", "Name").WithNilPlaceholder("?"), nil, false, "?", true},
+		{"column without missing", NewColumn("name", "Name").WithNilPlaceholderOptions(NewPlaceholderOptions().WithNil("-")), nil, false, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := table.CellPlaceholder(tt.column, tt.value, tt.found)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("CellPlaceholder() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if _, ok := NewTable(nil, nil, true).CellPlaceholder(column, nil, false); ok {
+		t.Error("CellPlaceholder() without placeholders should write the value as usual")
+	}
+}
+
+func TestExport_nilPlaceholder(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"name": "alice", "team": nil, "score": 10},
+		{"name": "bob", "score": nil},
+	}, Columns{
+		NewColumn("name", "Name"),
+		NewColumn("team", "Team"),
+		NewColumn("score", "Score").WithFormat(ExcelizeFormatNumber).WithNilPlaceholder("0?"),
+	}, true).WithNilPlaceholderOptions(NewPlaceholderOptions().WithNil("N/A").WithMissing("–"))
+
+	var buf bytes.Buffer
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "placeholders", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	if want := "Name,Team,Score\nalice,N/A,10\nbob,–,0?\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	markup, err := RenderMarkdown(table)
+	if err != nil || !strings.Contains(markup, "| bob | – | 0? |") {
+		t.Errorf("RenderMarkdown() = %q, %v; want the placeholders", markup, err)
+	}
+
+	buf.Reset()
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "placeholders", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	for cell, want := range map[string]string{"B2": "N/A", "B3": "–", "C3": "0?"} {
+		if got, _ := f.GetCellValue("Data", cell); got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+}
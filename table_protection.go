@@ -0,0 +1,142 @@
+// table_protection.go - XLSX sheet protection.
+//
+// This file implements the optional protection of the sheet written by XLSX exports, along with the
+// resolution of per-cell lock states. Spreadsheet cells are locked by default, so protecting a sheet
+// makes it read-only except for the cells explicitly unlocked through Style.Locked or
+// CellOptions.Locked: exported templates can restrict edits to their intended input areas. Cells
+// can also hide their formulas on protected sheets through Style.HiddenFormula.
+
+package spit
+
+// SheetProtection configures the protection of a sheet and the actions still allowed to users.
+type SheetProtection struct {
+	Password          string `json:"password,omitempty" yaml:"password,omitempty"`                   // Optional password required to unprotect the sheet (empty = no password)
+	SelectLockedCells bool   `json:"selectLockedCells,omitempty" yaml:"selectLockedCells,omitempty"` // Whether locked cells can be selected (unlocked cells always can)
+	FormatCells       bool   `json:"formatCells,omitempty" yaml:"formatCells,omitempty"`             // Whether cells can be formatted
+	FormatColumns     bool   `json:"formatColumns,omitempty" yaml:"formatColumns,omitempty"`         // Whether columns can be formatted (e.g. resized)
+	FormatRows        bool   `json:"formatRows,omitempty" yaml:"formatRows,omitempty"`               // Whether rows can be formatted (e.g. resized)
+	InsertRows        bool   `json:"insertRows,omitempty" yaml:"insertRows,omitempty"`               // Whether rows can be inserted
+	DeleteRows        bool   `json:"deleteRows,omitempty" yaml:"deleteRows,omitempty"`               // Whether rows can be deleted
+	Sort              bool   `json:"sort,omitempty" yaml:"sort,omitempty"`                           // Whether ranges can be sorted
+	AutoFilter        bool   `json:"autoFilter,omitempty" yaml:"autoFilter,omitempty"`               // Whether auto-filters can be used
+}
+
+// NewSheetProtection creates a SheetProtection with an optional password; locked cells stay
+// selectable and every other action is disallowed.
+func NewSheetProtection(password string) *SheetProtection {
+	return &SheetProtection{
+		Password:          password,
+		SelectLockedCells: true,
+	}
+}
+
+// WithFormatting sets whether users can format cells, columns and rows of the protected sheet.
+func (p *SheetProtection) WithFormatting(allowed bool) *SheetProtection {
+	p.FormatCells = allowed
+	p.FormatColumns = allowed
+	p.FormatRows = allowed
+	return p
+}
+
+// WithRowEdits sets whether users can insert and delete rows of the protected sheet.
+func (p *SheetProtection) WithRowEdits(allowed bool) *SheetProtection {
+	p.InsertRows = allowed
+	p.DeleteRows = allowed
+	return p
+}
+
+// WithSorting sets whether users can sort and filter the protected sheet (e.g. through an
+// auto-filter, see Table.WithAutoFilter).
+func (p *SheetProtection) WithSorting(allowed bool) *SheetProtection {
+	p.Sort = allowed
+	p.AutoFilter = allowed
+	return p
+}
+
+// WithProtection protects the sheet written by XLSX exports (nil disables protection). Cells are
+// locked unless unlocked through Style.Locked (e.g. on a column style) or CellOptions.Locked.
+func (t *Table) WithProtection(protection *SheetProtection) *Table {
+	t.Protection = protection
+	return t
+}
+
+// cellLocked resolves the lock state of a data cell: cell options first, then the cell, row and
+// column styles. Nil means the cell keeps the spreadsheet default (locked).
+func (t *Table) cellLocked(col, dataRow int, rowStyle, columnStyle *Style) *bool {
+	if cc, exists := t.CellOptionsMap[col]; exists {
+		if cellOptions, cellExists := cc[dataRow]; cellExists {
+			if cellOptions.Locked != nil {
+				return cellOptions.Locked
+			}
+			if cellOptions.Style != nil && cellOptions.Style.Locked != nil {
+				return cellOptions.Style.Locked
+			}
+		}
+	}
+	for _, style := range []*Style{rowStyle, columnStyle} {
+		if style != nil && style.Locked != nil {
+			return style.Locked
+		}
+	}
+	return nil
+}
+
+// withLockState returns the style with the given lock state, copying it rather than modifying
+// the caller's style. Styles are returned unchanged when there is no lock state to apply.
+func withLockState(style *Style, locked *bool) *Style {
+	if locked == nil || (style != nil && style.Locked == locked) {
+		return style
+	}
+	resolved := Style{}
+	if style != nil {
+		resolved = *style
+	}
+	resolved.Locked = locked
+	return &resolved
+}
+
+// cellHiddenFormula reports whether a data cell hides its formula: the style of its cell options,
+// row or column hides it.
+func (t *Table) cellHiddenFormula(col, dataRow int, rowStyle, columnStyle *Style) bool {
+	if cc, exists := t.CellOptionsMap[col]; exists {
+		if cellOptions, cellExists := cc[dataRow]; cellExists && cellOptions.Style != nil && cellOptions.Style.HiddenFormula {
+			return true
+		}
+	}
+	return rowStyle != nil && rowStyle.HiddenFormula || columnStyle != nil && columnStyle.HiddenFormula
+}
+
+// withHiddenFormula returns the style hiding its formula when hidden is set, copying it rather
+// than modifying the caller's style.
+func withHiddenFormula(style *Style, hidden bool) *Style {
+	if !hidden || (style != nil && style.HiddenFormula) {
+		return style
+	}
+	resolved := Style{}
+	if style != nil {
+		resolved = *style
+	}
+	resolved.HiddenFormula = true
+	return &resolved
+}
+
+// sheetProtector is implemented by spreadsheets supporting sheet protection.
+type sheetProtector interface {
+	ProtectSheet(protection SheetProtection) error
+}
+
+// writeProtection protects the written sheet. When protection fails, the sheet stays editable and a
+// warning is reported; ErrorStrict turns it into an export error.
+func (xlsx *xlsx) writeProtection() {
+	if xlsx.table.Protection == nil {
+		return
+	}
+	protector, ok := xlsx.spreadsheet.(sheetProtector)
+	if !ok {
+		xlsx.table.warn(WarningPhaseSheet, "", "Spreadsheet does not support sheet protection, skipped", nil)
+		return
+	}
+	if err := protector.ProtectSheet(*xlsx.table.Protection); err != nil {
+		xlsx.table.warn(WarningPhaseSheet, "", "Failed to protect sheet", err)
+	}
+}
@@ -0,0 +1,138 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_protection(t *testing.T) {
+	unlocked := false
+	table := NewTable(DataSlice{
+		{"name": "Alice", "target": 10},
+		{"name": "Bob", "target": 20},
+	}, Columns{
+		NewColumn("name", "Name"),
+		NewColumn("target", "Target").WithStyle(&Style{Italic: true, Locked: &unlocked}),
+	}, true).
+		WithCellOptions(CellOptionsMap{2: {1: *NewCellOptions(1, 1).WithStyle(&Style{Bold: true}).WithLocked(true)}}).
+		WithProtection(NewSheetProtection("secret").WithSorting(true))
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "protected", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	if len(res.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", res.Warnings)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Cells keep the default lock unless unlocked: the Target column is, except for Bob's cell
+	tests := []struct {
+		cell   string
+		locked bool
+		bold   bool
+	}{
+		{"A2", true, false},
+		{"B2", false, false},
+		{"B3", true, true},
+	}
+	for _, tt := range tests {
+		styleID, err := f.GetCellStyle("Sheet1", tt.cell)
+		if err != nil {
+			t.Fatalf("GetCellStyle(%s) failed: %v", tt.cell, err)
+		}
+		style, err := f.GetStyle(styleID)
+		if err != nil {
+			t.Fatalf("GetStyle(%s) failed: %v", tt.cell, err)
+		}
+		locked := style.Protection == nil || style.Protection.Locked
+		if locked != tt.locked {
+			t.Errorf("%s locked = %v, want %v", tt.cell, locked, tt.locked)
+		}
+		if bold := style.Font != nil && style.Font.Bold; bold != tt.bold {
+			t.Errorf("%s bold = %v, want %v", tt.cell, bold, tt.bold)
+		}
+	}
+
+	if err := f.UnprotectSheet("Sheet1", "wrong"); err == nil {
+		t.Error("UnprotectSheet accepted a wrong password")
+	}
+	if err := f.UnprotectSheet("Sheet1", "secret"); err != nil {
+		t.Errorf("UnprotectSheet failed: %v", err)
+	}
+}
+
+func TestExportXLSX_hiddenFormula(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"price": 10, "quantity": 2},
+		{"price": 20, "quantity": 3},
+	}, Columns{
+		NewColumn("price", "Price"),
+		NewColumn("quantity", "Quantity"),
+		NewColumn("total", "Total").WithStyle(&Style{HiddenFormula: true}),
+	}, true).
+		WithCellOptions(CellOptionsMap{3: {
+			0: *NewCellOptions(0, 2).WithFormula(ColumnRef("price") + "*" + ColumnRef("quantity")),
+			1: *NewCellOptions(1, 2).WithFormula(ColumnRef("price") + "*" + ColumnRef("quantity")).WithStyle(&Style{Bold: true}),
+		}}).
+		WithProtection(NewSheetProtection(""))
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "hidden", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// The column hides its formulas, including the cell with its own style, and stays locked
+	for _, cell := range []string{"C2", "C3"} {
+		styleID, _ := f.GetCellStyle("Sheet1", cell)
+		style, _ := f.GetStyle(styleID)
+		if style == nil || style.Protection == nil || !style.Protection.Hidden || !style.Protection.Locked {
+			t.Errorf("%s protection = %+v, want a locked cell hiding its formula", cell, style)
+		}
+	}
+	if formula, _ := f.GetCellFormula("Sheet1", "C3"); formula != "A3*B3" {
+		t.Errorf("C3 formula = %q, want A3*B3", formula)
+	}
+}
+
+func TestWithHiddenFormula(t *testing.T) {
+	style := &Style{Bold: true}
+	hidden := withHiddenFormula(style, true)
+	if hidden == style || !hidden.HiddenFormula || !hidden.Bold {
+		t.Errorf("withHiddenFormula = %+v, want a bold copy hiding its formula", hidden)
+	}
+	if style.HiddenFormula {
+		t.Error("withHiddenFormula must not modify the given style")
+	}
+	if got := withHiddenFormula(style, false); got != style {
+		t.Error("withHiddenFormula without hidden formula must return the style unchanged")
+	}
+}
+
+func TestWithLockState(t *testing.T) {
+	no := false
+	style := &Style{Bold: true}
+	unlocked := withLockState(style, &no)
+	if unlocked == style || unlocked.Locked == nil || *unlocked.Locked || !unlocked.Bold {
+		t.Errorf("withLockState = %+v, want a bold unlocked copy", unlocked)
+	}
+	if style.Locked != nil {
+		t.Error("withLockState must not modify the given style")
+	}
+	if got := withLockState(style, nil); got != style {
+		t.Error("withLockState without lock state must return the style unchanged")
+	}
+	if got := withLockState(nil, &no); got == nil || *got.Locked {
+		t.Errorf("withLockState(nil) = %+v, want an unlocked style", got)
+	}
+}
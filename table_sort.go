@@ -0,0 +1,187 @@
+// table_sort.go - Data row sorting.
+//
+// This file implements multi-key sorting of data rows (see Table.WithSort). Rows are sorted by
+// Table.Prepare right after filtering, before any other transformation, so previews show the first sorted rows and
+// grouping keeps the sorted order within each group. Row and cell options follow their rows.
+
+package spit
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortDirection defines the order of a sort key.
+type SortDirection int
+
+const (
+	// SortAscending sorts values from lowest to highest (default).
+	SortAscending SortDirection = iota
+
+	// SortDescending sorts values from highest to lowest.
+	SortDescending
+)
+
+// SortKey is a key of a multi-key sort (see Table.WithSort).
+type SortKey struct {
+	Field     string        `json:"field,omitempty" yaml:"field,omitempty"`         // Column name, or data field name when no column has this name
+	Direction SortDirection `json:"direction,omitempty" yaml:"direction,omitempty"` // Order of the key (default: SortAscending)
+	// Comparator optionally orders two non-nil values of the key, returning a negative number,
+	// zero or a positive number (e.g. CompareStrings, CompareNumbers, CompareTimes). By default
+	// numbers sort numerically, times chronologically and anything else by its string representation.
+	Comparator func(a, b interface{}) int `json:"-" yaml:"-"`
+}
+
+// WithSort sorts the data rows by the given keys, first key first. The sort is stable, so rows
+// with equal keys keep their original order. Missing and nil values sort last in both directions.
+// A key field is looked up among the table columns (so computed and nested columns sort by their
+// values), otherwise read from the data field of that name. Row and cell options follow their rows,
+// and SourceRowIndex returns the original index of each sorted row.
+func (t *Table) WithSort(keys ...SortKey) *Table {
+	t.Sort = keys
+	return t
+}
+
+// applySort returns a shallow copy of t with its rows sorted by the sort keys.
+func (t *Table) applySort() *Table {
+	columns := make([]*Column, len(t.Sort))
+	for i, key := range t.Sort {
+		columns[i] = t.columnByName(key.Field)
+	}
+
+	values := make([][]interface{}, len(t.Data))
+	order := make([]int, len(t.Data))
+	for i, item := range t.Data {
+		values[i] = make([]interface{}, len(columns))
+		for k, column := range columns {
+			if v, err, found := item.LookupColumn(column); err == nil && found {
+				values[i][k] = NormalizeValue(v)
+			}
+		}
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		for k, key := range t.Sort {
+			if c := key.compare(values[a][k], values[b][k]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return t.withRows(order)
+}
+
+// withRows returns a shallow copy of t whose data rows are the rows of t at the given indices, in
+// order. Row and cell options follow their rows, and source row indices are kept.
+func (t *Table) withRows(order []int) *Table {
+	s := *t
+	s.Data = make(DataSlice, len(order))
+	s.RowOptionsMap = make(RowOptionsMap, len(t.RowOptionsMap))
+	s.CellOptionsMap = make(CellOptionsMap, len(t.CellOptionsMap))
+	s.sourceRows = make([]int, len(order))
+	for index, src := range order {
+		s.Data[index] = t.Data[src]
+		s.sourceRows[index] = t.SourceRowIndex(src)
+		if rowOptions, ok := t.RowOptionsMap[src]; ok {
+			rowOptions.RowIndex = index
+			s.RowOptionsMap[index] = rowOptions
+		}
+		for col, rows := range t.CellOptionsMap {
+			if cellOptions, ok := rows[src]; ok {
+				if s.CellOptionsMap[col] == nil {
+					s.CellOptionsMap[col] = make(map[int]CellOptions)
+				}
+				cellOptions.RowIndex = index
+				s.CellOptionsMap[col][index] = cellOptions
+			}
+		}
+	}
+	return &s
+}
+
+// compare orders two values of the key, nil values last whatever the direction.
+func (k SortKey) compare(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	compare := compareValues
+	if k.Comparator != nil {
+		compare = k.Comparator
+	}
+	if k.Direction == SortDescending {
+		return compare(b, a)
+	}
+	return compare(a, b)
+}
+
+// CompareStrings orders two values by their string representation.
+func CompareStrings(a, b interface{}) int {
+	return cmp.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// CompareNumbers orders two values numerically, parsing numeric strings. Values that are not
+// numbers sort after numbers, by their string representation.
+func CompareNumbers(a, b interface{}) int {
+	fa, okA := sortNumber(a)
+	fb, okB := sortNumber(b)
+	switch {
+	case okA && okB:
+		return cmp.Compare(fa, fb)
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return CompareStrings(a, b)
+}
+
+// CompareTimes orders two values chronologically, parsing date strings (see ParseDate). Values
+// that are not times sort after times, by their string representation.
+func CompareTimes(a, b interface{}) int {
+	ta, okA := sortTime(a)
+	tb, okB := sortTime(b)
+	switch {
+	case okA && okB:
+		return ta.Compare(tb)
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return CompareStrings(a, b)
+}
+
+// sortNumber returns a value as a float, parsing numeric strings.
+func sortNumber(v interface{}) (float64, bool) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		return f, err == nil
+	}
+	return toFloat(v)
+}
+
+// sortTime returns a value as a time, parsing date strings.
+func sortTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t != nil {
+			return *t, true
+		}
+	case string:
+		if parsed, err := ParseDate(strings.TrimSpace(t)); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
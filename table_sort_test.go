@@ -0,0 +1,128 @@
+package spit
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sortedNames(t *Table) []string {
+	var names []string
+	for _, item := range t.Data {
+		names = append(names, item["name"].(string))
+	}
+	return names
+}
+
+func TestTable_WithSort(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []SortKey
+		want []string
+	}{
+		{"ascending numbers, missing last", []SortKey{{Field: "score"}},
+			[]string{"erin", "carol", "alice", "bob", "dave"}},
+		{"descending numbers, missing last", []SortKey{{Field: "score", Direction: SortDescending}},
+			[]string{"alice", "bob", "carol", "erin", "dave"}},
+		{"multiple keys", []SortKey{{Field: "team"}, {Field: "score", Direction: SortDescending}},
+			[]string{"alice", "erin", "dave", "bob", "carol"}},
+		{"data field without column", []SortKey{{Field: "joined", Comparator: CompareTimes}},
+			[]string{"erin", "alice", "bob", "carol", "dave"}},
+		{"custom comparator", []SortKey{{Field: "name", Comparator: func(a, b interface{}) int {
+			return len(a.(string)) - len(b.(string))
+		}}}, []string{"bob", "dave", "erin", "carol", "alice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := fixtureTable("name", "team", "score").WithSort(tt.keys...)
+			if got := sortedNames(table.Prepare()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sorted rows = %v, want %v", got, tt.want)
+			}
+			if table.Data[0]["name"] != "carol" {
+				t.Error("sorting must not modify the original table")
+			}
+		})
+	}
+}
+
+func TestTable_WithSort_options(t *testing.T) {
+	table := fixtureTable("name", "team", "score").
+		WithSort(SortKey{Field: "name"}).
+		WithRowOptions(RowOptionsMap{0: RowOptions{RowIndex: 0, Style: &Style{Bold: true}}}).
+		WithCellOptions(CellOptionsMap{3: {4: CellOptions{RowIndex: 4, Style: &Style{Italic: true}}}})
+
+	p := table.Prepare()
+	// carol (0) sorts to index 2, erin (4) stays last
+	if ro, ok := p.RowOptionsMap[2]; !ok || ro.RowIndex != 2 || !ro.Style.Bold {
+		t.Errorf("row options should follow their row, got %v", p.RowOptionsMap)
+	}
+	if co, ok := p.CellOptionsMap[3][4]; !ok || !co.Style.Italic {
+		t.Errorf("cell options should follow their row, got %v", p.CellOptionsMap)
+	}
+	var sources []int
+	for i := range p.Data {
+		sources = append(sources, p.SourceRowIndex(i))
+	}
+	if want := []int{1, 2, 0, 3, 4}; !reflect.DeepEqual(sources, want) {
+		t.Errorf("source rows = %v, want %v", sources, want)
+	}
+
+	// Sampled previews keep the original index of the sorted rows
+	original := fixtureTable("name", "team", "score")
+	sampled := original.WithSort(SortKey{Field: "name"}).WithPreview(NewPreviewOptions().WithMaxRows(3).WithSample(true)).Prepare()
+	for i, item := range sampled.Data {
+		if source := sampled.SourceRowIndex(i); original.Data[source]["name"] != item["name"] {
+			t.Errorf("row %d (%v) reports source row %d", i, item["name"], source)
+		}
+	}
+
+	// Grouping keeps the sorted order within each group
+	grouped := fixtureTable("name", "team", "score").WithSort(SortKey{Field: "score", Direction: SortDescending}).WithGroupBy("team").Prepare()
+	var rows []string
+	for _, item := range grouped.Data {
+		if name, ok := item["name"].(string); ok {
+			rows = append(rows, name)
+		}
+	}
+	if want := []string{"alice", "erin", "dave", "bob", "carol"}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("grouped rows = %v, want %v", rows, want)
+	}
+}
+
+func TestSortComparators(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		compare func(a, b interface{}) int
+		a, b    interface{}
+		want    int
+	}{
+		{"strings", CompareStrings, 10, 9, -1},
+		{"numbers", CompareNumbers, 10, 9, 1},
+		{"numeric strings", CompareNumbers, "10", " 9.5", 1},
+		{"numbers before text", CompareNumbers, "n/a", 1, 1},
+		{"times", CompareTimes, day, day.Add(time.Hour), -1},
+		{"date strings", CompareTimes, "2024-05-02", day, 1},
+		{"times before text", CompareTimes, day, "soon", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("compare(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportCSV_sorted(t *testing.T) {
+	var buf bytes.Buffer
+	table := fixtureTable("name", "team", "score").WithSort(SortKey{Field: "name", Direction: SortDescending})
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "sorted", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "Name,Team,Score\nerin,a,3\ndave,a\ncarol,b,7\n") {
+		t.Errorf("unexpected content %q", buf.String())
+	}
+}
@@ -0,0 +1,283 @@
+// table_sparse.go - Hidden columns and compaction of always-empty columns.
+//
+// This file implements hidden columns (see Column.WithHidden), written as hidden sheet columns by
+// XLSX and omitted by the other formats, and the optional compaction of sparse columns: leaf
+// columns whose value is empty (missing, nil or blank) in every exported row are dropped, hidden
+// or moved under a trailing "Other" column group. Large generated schemas often carry dozens of
+// such fields. The decision is taken when the table is prepared (see Table.Prepare), so previews
+// only consider the sampled rows, and it is reported in FileWriteResult.SparseColumns.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SparseColumnAction defines what happens to leaf columns that are empty in every exported row.
+type SparseColumnAction int
+
+const (
+	// SparseColumnsKeep writes sparse columns as any other column (default).
+	SparseColumnsKeep SparseColumnAction = iota
+
+	// SparseColumnsDrop removes sparse columns from the output.
+	SparseColumnsDrop
+
+	// SparseColumnsHide writes sparse columns as hidden sheet columns (XLSX); formats without
+	// hidden columns drop them.
+	SparseColumnsHide
+
+	// SparseColumnsGroup moves sparse columns under a column group appended after the other
+	// columns (see SparseColumnOptions.OtherLabel).
+	SparseColumnsGroup
+)
+
+// SparseColumnOptions configures the compaction of sparse columns (see Table.WithSparseColumns).
+type SparseColumnOptions struct {
+	Action     SparseColumnAction `json:"action,omitempty" yaml:"action,omitempty"`         // What happens to sparse columns
+	OtherLabel string             `json:"otherLabel,omitempty" yaml:"otherLabel,omitempty"` // Label of the group holding sparse columns with SparseColumnsGroup (default: "Other")
+}
+
+// SparseColumn reports a compacted column in FileWriteResult.SparseColumns.
+type SparseColumn struct {
+	Sheet  string             // Sheet name (XLSX only)
+	Name   string             // Column name
+	Label  string             // Column label
+	Action SparseColumnAction // Action applied to the column
+}
+
+// NewSparseColumnOptions creates a new SparseColumnOptions instance applying the given action.
+func NewSparseColumnOptions(action SparseColumnAction) *SparseColumnOptions {
+	return &SparseColumnOptions{Action: action}
+}
+
+// WithOtherLabel sets the label of the group holding sparse columns with SparseColumnsGroup.
+func (o *SparseColumnOptions) WithOtherLabel(label string) *SparseColumnOptions {
+	o.OtherLabel = label
+	return o
+}
+
+// WithSparseColumns compacts the leaf columns that are empty in every exported row with the given
+// action. Pinned columns and the columns grouping the rows (see WithGroupBy) are never compacted.
+func (t *Table) WithSparseColumns(action SparseColumnAction) *Table {
+	t.SparseColumns = NewSparseColumnOptions(action)
+	return t
+}
+
+// WithSparseColumnOptions sets the compaction of sparse columns.
+func (t *Table) WithSparseColumnOptions(options *SparseColumnOptions) *Table {
+	t.SparseColumns = options
+	return t
+}
+
+// otherLabel returns the label of the group holding sparse columns.
+func (o *SparseColumnOptions) otherLabel() string {
+	if o.OtherLabel == "" {
+		return "Other"
+	}
+	return o.OtherLabel
+}
+
+// compactSparseColumns returns a shallow copy of the table with its sparse columns compacted, or t
+// itself when no column is sparse.
+func (t *Table) compactSparseColumns() *Table {
+	options := t.SparseColumns
+	grouping := make(map[string]bool, len(t.GroupBy))
+	for _, name := range t.GroupBy {
+		grouping[name] = true
+	}
+
+	sparse := make(map[*Column]bool)
+	var report []SparseColumn
+	for _, column := range t.Columns.GetFlattenedColumns() {
+		if column.Pinned || grouping[column.Name] || !t.emptyColumn(column) {
+			continue
+		}
+		sparse[column] = true
+		report = append(report, SparseColumn{Name: column.Name, Label: column.Label, Action: options.Action})
+	}
+	if len(sparse) == 0 {
+		return t
+	}
+	L().Debug("Compacting sparse columns", Int("columns", len(sparse)), String("action", options.Action.String()))
+
+	var c *Table
+	switch options.Action {
+	case SparseColumnsDrop:
+		c = t.withColumns(t.Columns.without(sparse))
+	case SparseColumnsHide:
+		c = t.hideColumns(sparse)
+	case SparseColumnsGroup:
+		other := NewColumn(otherColumnName(t.Columns), options.otherLabel())
+		for _, column := range t.Columns.GetFlattenedColumns() {
+			if sparse[column] {
+				other.Columns = append(other.Columns, column)
+			}
+		}
+		c = t.withColumns(append(t.Columns.without(sparse), other))
+	default:
+		return t
+	}
+	c.prepared = nil
+	c.sparse = append(append([]SparseColumn(nil), t.sparse...), report...)
+	return c
+}
+
+// emptyColumn reports whether the column is empty in every data row.
+func (t *Table) emptyColumn(column *Column) bool {
+	for _, item := range t.Data {
+		value, err, found := item.LookupColumn(column)
+		if err != nil || !found {
+			continue
+		}
+		if !isEmptyValue(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyValue reports whether a cell value renders as an empty cell: nil, a blank string or an
+// empty list.
+func isEmptyValue(value interface{}) bool {
+	switch v := NormalizeValue(value).(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// otherColumnName returns a column name for the "Other" group not used by the columns.
+func otherColumnName(columns Columns) string {
+	used := make(map[string]bool)
+	var collect func(Columns)
+	collect = func(cs Columns) {
+		for _, column := range cs {
+			used[column.Name] = true
+			collect(column.Columns)
+		}
+	}
+	collect(columns)
+	name := "other"
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("other_%d", i)
+	}
+	return name
+}
+
+// without returns the columns without the given leaf columns; groups left without leaf columns
+// are removed too. Groups are copied, leaf columns are kept as-is.
+func (c Columns) without(removed map[*Column]bool) Columns {
+	kept := make(Columns, 0, len(c))
+	for _, column := range c {
+		if removed[column] {
+			continue
+		}
+		if len(column.Columns) > 0 {
+			subColumns := column.Columns.without(removed)
+			if len(subColumns) == 0 {
+				continue
+			}
+			group := *column
+			group.Columns = subColumns
+			column = &group
+		}
+		kept = append(kept, column)
+	}
+	return kept
+}
+
+// withoutHidden returns the table without its hidden columns, for formats that cannot hide them.
+func (t *Table) withoutHidden() *Table {
+	if len(t.hidden) == 0 {
+		return t
+	}
+	c := t.withColumns(t.Columns.without(t.hidden))
+	c.hidden = nil
+	return c
+}
+
+// withHiddenColumns returns a shallow copy of the table with the leaf columns marked hidden (see
+// Column.WithHidden) added to its hidden columns, or t itself when no column is marked hidden.
+func (t *Table) withHiddenColumns() *Table {
+	marked := make(map[*Column]bool)
+	var collect func(columns Columns, hidden bool)
+	collect = func(columns Columns, hidden bool) {
+		for _, column := range columns {
+			if len(column.Columns) > 0 {
+				collect(column.Columns, hidden || column.Hidden)
+			} else if hidden || column.Hidden {
+				marked[column] = true
+			}
+		}
+	}
+	collect(t.Columns, false)
+	if len(marked) == 0 {
+		return t
+	}
+	return t.hideColumns(marked)
+}
+
+// hideColumns returns a shallow copy of the table with the given leaf columns added to its hidden
+// columns.
+func (t *Table) hideColumns(columns map[*Column]bool) *Table {
+	c := *t
+	c.prepared = nil
+	c.hidden = make(map[*Column]bool, len(t.hidden)+len(columns))
+	for column := range t.hidden {
+		c.hidden[column] = true
+	}
+	for column := range columns {
+		c.hidden[column] = true
+	}
+	return &c
+}
+
+// sparseColumns returns the compacted columns of the table, attributed to the given sheet.
+func (t *Table) sparseColumns(sheet string) []SparseColumn {
+	if len(t.sparse) == 0 {
+		return nil
+	}
+	report := make([]SparseColumn, len(t.sparse))
+	for i, column := range t.sparse {
+		column.Sheet = sheet
+		report[i] = column
+	}
+	return report
+}
+
+// columnHider is implemented by spreadsheets supporting hidden columns.
+type columnHider interface {
+	SetColumnHidden(colLetter string) error
+}
+
+// writeHiddenColumns hides the hidden columns of the written sheet. A column that cannot be
+// hidden stays visible and yields a warning (see ErrorMode).
+func (xlsx *xlsx) writeHiddenColumns() {
+	if len(xlsx.table.hidden) == 0 {
+		return
+	}
+	if xlsx.table.Transposed {
+		xlsx.table.warn(WarningPhaseSheet, "", "Hidden columns are not supported by transposed tables, written visible", nil)
+		return
+	}
+	hider, ok := xlsx.spreadsheet.(columnHider)
+	if !ok {
+		xlsx.table.warn(WarningPhaseSheet, "", "Spreadsheet does not support hidden columns, written visible", nil)
+		return
+	}
+	for i, column := range xlsx.table.Columns.GetFlattenedColumns() {
+		if !xlsx.table.hidden[column] {
+			continue
+		}
+		colLetter := xlsx.cells().GetColumnLetter(i + 1)
+		if err := hider.SetColumnHidden(colLetter); err != nil {
+			xlsx.table.warn(WarningPhaseSheet, "", "Failed to hide column", err, String("column", colLetter))
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// sparseTable returns a table whose "fax" and "notes.internal" columns are empty in every row.
+func sparseTable() *Table {
+	return NewTable(DataSlice{
+		{"name": "Ada", "fax": "", "city": "London"},
+		{"name": "Alan", "fax": nil, "city": "Wilmslow", "internal": []interface{}{}},
+	}, Columns{
+		NewColumn("name", "Name"),
+		NewColumn("fax", "Fax"),
+		NewColumn("notes", "Notes").WithSubColumns(Columns{NewColumn("internal", "Internal")}),
+		NewColumn("city", "City"),
+	}, true).WithCellOptions(CellOptionsMap{4: {0: *NewCellOptions(0, 3).WithMeta("k", "v")}})
+}
+
+func TestTable_compactSparseColumns(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     SparseColumnAction
+		wantLabels []string // Top-level labels
+		wantLeaves []string // Leaf names
+		wantHidden []string
+	}{
+		{"keep", SparseColumnsKeep, []string{"Name", "Fax", "Notes", "City"}, []string{"name", "fax", "internal", "city"}, nil},
+		{"drop", SparseColumnsDrop, []string{"Name", "City"}, []string{"name", "city"}, nil},
+		{"hide", SparseColumnsHide, []string{"Name", "Fax", "Notes", "City"}, []string{"name", "fax", "internal", "city"}, []string{"fax", "internal"}},
+		{"group", SparseColumnsGroup, []string{"Name", "City", "Other"}, []string{"name", "city", "fax", "internal"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := sparseTable().WithSparseColumns(tt.action)
+			prepared := table.Prepare()
+
+			var labels, leaves, hidden []string
+			for _, column := range prepared.Columns {
+				labels = append(labels, column.Label)
+			}
+			for _, column := range prepared.Columns.GetFlattenedColumns() {
+				leaves = append(leaves, column.Name)
+				if prepared.hidden[column] {
+					hidden = append(hidden, column.Name)
+				}
+			}
+			if strings.Join(labels, ",") != strings.Join(tt.wantLabels, ",") {
+				t.Errorf("labels = %v, want %v", labels, tt.wantLabels)
+			}
+			if strings.Join(leaves, ",") != strings.Join(tt.wantLeaves, ",") {
+				t.Errorf("leaves = %v, want %v", leaves, tt.wantLeaves)
+			}
+			if strings.Join(hidden, ",") != strings.Join(tt.wantHidden, ",") {
+				t.Errorf("hidden = %v, want %v", hidden, tt.wantHidden)
+			}
+
+			// Cell options follow their column
+			for col, cells := range prepared.CellOptionsMap {
+				if _, ok := cells[0]; ok && leaves[col-1] != "city" {
+					t.Errorf("cell options moved to column %d (%s), want the city column", col, leaves[col-1])
+				}
+			}
+
+			wantReport := 2
+			if tt.action == SparseColumnsKeep {
+				wantReport = 0
+			}
+			if report := prepared.sparseColumns(""); len(report) != wantReport {
+				t.Errorf("report = %v, want %d columns", report, wantReport)
+			}
+			if len(table.Columns) != 4 {
+				t.Errorf("source table modified: %d columns", len(table.Columns))
+			}
+		})
+	}
+
+	t.Run("pinned and grouping columns are kept", func(t *testing.T) {
+		table := sparseTable().WithSparseColumns(SparseColumnsDrop)
+		table.Columns[1].WithPinned(true)
+		table.Columns = append(table.Columns, NewColumn("region", "Region"))
+		table.WithGroupBy("region")
+		leaves := table.Prepare().Columns.GetFlattenedColumns()
+		var names []string
+		for _, column := range leaves {
+			names = append(names, column.Name)
+		}
+		if got := strings.Join(names, ","); !strings.Contains(got, "fax") || !strings.Contains(got, "region") || strings.Contains(got, "internal") {
+			t.Errorf("columns = %v, want fax and region kept, internal dropped", names)
+		}
+	})
+}
+
+func TestExportSparseColumns(t *testing.T) {
+	t.Run("xlsx hides columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		res, err := ExportXLSX(NewSpreadsheetExcelize("People", sparseTable().WithSparseColumns(SparseColumnsHide)), FileWriteParams{Filename: "report", Writer: &buf})
+		if err != nil {
+			t.Fatalf("ExportXLSX failed: %v", err)
+		}
+		if len(res.SparseColumns) != 2 || res.SparseColumns[0].Sheet != "People" || res.SparseColumns[0].Action != SparseColumnsHide {
+			t.Errorf("SparseColumns = %+v, want 2 hidden columns of People", res.SparseColumns)
+		}
+		f, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatalf("OpenReader failed: %v", err)
+		}
+		defer f.Close()
+		for col, want := range map[string]bool{"A": true, "B": false, "C": false, "D": true} {
+			if visible, err := f.GetColVisible("People", col); err != nil || visible != want {
+				t.Errorf("column %s visible = %v (%v), want %v", col, visible, err, want)
+			}
+		}
+	})
+
+	t.Run("csv drops hidden columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		res, err := ExportCSV(",", sparseTable().WithSparseColumns(SparseColumnsHide), FileWriteParams{Filename: "report", Writer: &buf})
+		if err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+		if want := "Name,City\nAda,London\nAlan,Wilmslow\n"; buf.String() != want {
+			t.Errorf("content = %q, want %q", buf.String(), want)
+		}
+		if len(res.SparseColumns) != 2 || res.SparseColumns[1].Name != "internal" {
+			t.Errorf("SparseColumns = %+v, want fax and internal", res.SparseColumns)
+		}
+	})
+}
+
+func TestColumnHidden(t *testing.T) {
+	hiddenTable := func() *Table {
+		columns := Columns{
+			NewColumn("team", "Team").WithHidden(true),
+			NewColumn("name", "Name"),
+			{Name: "audit", Label: "Audit", Columns: Columns{NewColumn("by", "By")}, Hidden: true},
+		}
+		data := DataSlice{
+			{"team": "B", "name": "Alan", "by": "x"},
+			{"team": "A", "name": "Ada", "by": "y"},
+		}
+		return NewTable(data, columns, true).WithGroupBy("team")
+	}
+
+	t.Run("xlsx hides columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := ExportXLSX(NewSpreadsheetExcelize("People", hiddenTable()), FileWriteParams{Filename: "report", Writer: &buf}); err != nil {
+			t.Fatalf("ExportXLSX failed: %v", err)
+		}
+		f, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatalf("OpenReader failed: %v", err)
+		}
+		defer f.Close()
+		for col, want := range map[string]bool{"A": false, "B": true, "C": false} {
+			if visible, err := f.GetColVisible("People", col); err != nil || visible != want {
+				t.Errorf("column %s visible = %v (%v), want %v", col, visible, err, want)
+			}
+		}
+	})
+
+	t.Run("csv omits columns but still groups by them", func(t *testing.T) {
+		var buf bytes.Buffer
+		res, err := ExportCSV(",", hiddenTable(), FileWriteParams{Filename: "report", Writer: &buf})
+		if err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+		if want := "Name\nA\nAda\nB\nAlan\n"; buf.String() != want {
+			t.Errorf("content = %q, want %q", buf.String(), want)
+		}
+		if len(res.SparseColumns) != 0 {
+			t.Errorf("SparseColumns = %+v, want none", res.SparseColumns)
+		}
+	})
+}
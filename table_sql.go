@@ -0,0 +1,166 @@
+// table_sql.go - Tables built from database/sql result sets.
+//
+// This file builds a Table from *sql.Rows: columns are derived from the result set metadata and
+// rows are scanned into Data, making query-to-file exports a few lines of code. Result sets too
+// large for memory are streamed to CSV instead (see ExportCSVFromSQLRows), one row at a time.
+
+package spit
+
+import (
+	"database/sql"
+	stdcsv "encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NewTableFromSQLRows builds a table (header included) from a query result set.
+// Columns are named and labeled after the result columns, in order; duplicate names (e.g. from
+// joins) get a numeric suffix ("id", "id_2"). DECIMAL and NUMERIC columns use
+// ExcelizeFormatNumber so XLSX stores real numbers. SQL NULL values become nil and []byte values
+// strings.
+//
+// All rows are read into memory, as merging and footers need the whole data set; stream large
+// results to CSV with ExportCSVFromSQLRows. rows is consumed but not closed: the caller still
+// owns it.
+func NewTableFromSQLRows(rows *sql.Rows) (*Table, error) {
+	columns, scan, err := sqlColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var data DataSlice
+	for rows.Next() {
+		row, err := scan()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row %d: %w", len(data), err)
+		}
+		data = append(data, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return NewTable(data, columns, true), nil
+}
+
+// ExportCSVFromSQLRows streams a query result set to a CSV file, header included, without
+// reading it into memory: each row is written as soon as it is scanned. Columns are derived as by
+// NewTableFromSQLRows. Table options needing the whole data set (merging, footers, sorting...)
+// are not available; build a table with NewTableFromSQLRows for those. Limits are checked row by
+// row. rows is consumed but not closed: the caller still owns it.
+func ExportCSVFromSQLRows(separator string, rows *sql.Rows, params FileWriteParams) (*FileWriteResult, error) {
+	columns, scan, err := sqlColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+	if params.Extension == "" {
+		params.Extension = FormatCSV.String()
+	}
+	params.quota = params.resolveQuota()
+	csvConfig := &csv{
+		separator: separator,
+		table:     NewTable(nil, columns, true).Prepare().ForFormat(FormatCSV).withWarnings("", params.OnWarning, params.Logger),
+		params:    params,
+	}
+	flatColumns := csvConfig.table.Columns.GetFlattenedColumns()
+
+	params.logger().Info("Starting CSV export of SQL rows to file", String("filename", params.Filename))
+
+	writeFunc := func(writer io.Writer) error {
+		encoded, flush, err := params.Encoding.writer(writer)
+		if err != nil {
+			return fmt.Errorf("error writing CSV byte order mark: %w", err)
+		}
+		csvConfig.writer = stdcsv.NewWriter(encoded)
+		csvConfig.setDelimiter()
+		if err := csvConfig.writeHeaders(); err != nil {
+			return fmt.Errorf("error writing CSV headers: %w", err)
+		}
+
+		rowIdx := 0
+		for ; rows.Next(); rowIdx++ {
+			row, err := scan()
+			if err != nil {
+				return fmt.Errorf("failed to scan row %d: %w", rowIdx, err)
+			}
+			if err := params.quota.addRows(1, len(flatColumns)); err != nil {
+				return err
+			}
+			record, err := csvConfig.dataRecord(rowIdx, row, flatColumns, nil)
+			if err != nil {
+				return err
+			}
+			if err := csvConfig.writer.Write(record); err != nil {
+				return fmt.Errorf("error writing CSV record for row %d: %w", rowIdx, err)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate rows: %w", err)
+		}
+
+		csvConfig.writer.Flush()
+		if err := csvConfig.writer.Error(); err != nil {
+			return fmt.Errorf("error flushing CSV writer: %w", err)
+		}
+		if err := csvConfig.table.strictErr(); err != nil {
+			return err
+		}
+		return flush()
+	}
+
+	result, err := params.WriteToFile(writeFunc)
+	if err != nil {
+		params.logger().Error("Failed to write CSV to file", Error(err))
+		return nil, err
+	}
+	result.Warnings = csvConfig.table.exportWarnings()
+
+	params.logger().Info("CSV export of SQL rows completed", String("filename", params.Filename))
+	return result, nil
+}
+
+// sqlColumns returns the columns of a result set and a function scanning its current row into
+// Data keyed by column name.
+func sqlColumns(rows *sql.Rows) (Columns, func() (Data, error), error) {
+	if rows == nil {
+		return nil, nil, fmt.Errorf("no rows provided")
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read result columns: %w", err)
+	}
+
+	names := make([]string, len(columnTypes))
+	columns := make(Columns, len(columnTypes))
+	used := make(map[string]bool, len(columnTypes))
+	for i, ct := range columnTypes {
+		names[i] = uniqueName(ct.Name(), used)
+		columns[i] = NewColumn(names[i], ct.Name())
+		switch strings.ToUpper(ct.DatabaseTypeName()) {
+		case "DECIMAL", "NUMERIC":
+			columns[i].WithFormat(ExcelizeFormatNumber)
+		}
+	}
+
+	values := make([]interface{}, len(columnTypes))
+	dest := make([]interface{}, len(columnTypes))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	scan := func() (Data, error) {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make(Data, len(names))
+		for i, name := range names {
+			if b, ok := values[i].([]byte); ok {
+				row[name] = string(b)
+			} else {
+				row[name] = values[i]
+			}
+		}
+		return row, nil
+	}
+	return columns, scan, nil
+}
@@ -0,0 +1,132 @@
+package spit
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// testSQLDriver serves a fixed result set for any query.
+type testSQLDriver struct{}
+
+func (testSQLDriver) Open(string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+type testSQLConn struct{}
+
+func (testSQLConn) Prepare(string) (driver.Stmt, error) { return testSQLStmt{}, nil }
+func (testSQLConn) Close() error                        { return nil }
+func (testSQLConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type testSQLStmt struct{}
+
+func (testSQLStmt) Close() error                               { return nil }
+func (testSQLStmt) NumInput() int                              { return -1 }
+func (testSQLStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (testSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &testSQLRows{values: [][]driver.Value{
+		{int64(1), []byte("Alice"), []byte("12.50"), int64(10), int64(100)},
+		{int64(2), nil, []byte("3"), int64(20), int64(200)},
+	}}, nil
+}
+
+type testSQLRows struct {
+	values [][]driver.Value
+	next   int
+}
+
+func (r *testSQLRows) Columns() []string { return []string{"id", "name", "amount", "id", "id_2"} }
+func (r *testSQLRows) Close() error      { return nil }
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
+func (r *testSQLRows) ColumnTypeDatabaseTypeName(index int) string {
+	return []string{"INT", "TEXT", "decimal", "INT", "INT"}[index]
+}
+
+func init() {
+	sql.Register("spit-test", testSQLDriver{})
+}
+
+func TestNewTableFromSQLRows(t *testing.T) {
+	db, err := sql.Open("spit-test", "")
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query("SELECT id, name, amount, id, id_2 FROM orders")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	table, err := NewTableFromSQLRows(rows)
+	if err != nil {
+		t.Fatalf("NewTableFromSQLRows failed: %v", err)
+	}
+
+	var got [][3]string
+	for _, c := range table.Columns {
+		got = append(got, [3]string{c.Name, c.Label, c.Format})
+	}
+	want := [][3]string{{"id", "id", ""}, {"name", "name", ""}, {"amount", "amount", ExcelizeFormatNumber}, {"id_2", "id", ""}, {"id_2_2", "id_2", ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+
+	wantData := DataSlice{
+		{"id": int64(1), "name": "Alice", "amount": "12.50", "id_2": int64(10), "id_2_2": int64(100)},
+		{"id": int64(2), "name": nil, "amount": "3", "id_2": int64(20), "id_2_2": int64(200)},
+	}
+	if !reflect.DeepEqual(table.Data, wantData) {
+		t.Errorf("data = %v, want %v", table.Data, wantData)
+	}
+
+	if _, err := NewTableFromSQLRows(nil); err == nil {
+		t.Error("expected an error for nil rows")
+	}
+}
+
+func TestExportCSVFromSQLRows(t *testing.T) {
+	db, err := sql.Open("spit-test", "")
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query("SELECT id, name, amount, id, id_2 FROM orders")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var buf bytes.Buffer
+	if _, err := ExportCSVFromSQLRows(",", rows, FileWriteParams{Filename: "orders", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSVFromSQLRows failed: %v", err)
+	}
+	want := "id,name,amount,id,id_2\n1,Alice,12.50,10,100\n2,,3,20,200\n"
+	if got := buf.String(); got != want {
+		t.Errorf("csv = %q, want %q", got, want)
+	}
+
+	rows, err = db.Query("SELECT id, name, amount, id, id_2 FROM orders")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	buf.Reset()
+	_, err = ExportCSVFromSQLRows(",", rows, FileWriteParams{Filename: "orders", Writer: &buf, Limits: &Limits{MaxRows: 1}})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Limit != LimitRows {
+		t.Errorf("expected a rows quota error past the row limit, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+// table_structs.go - Tables built from typed structs.
+//
+// This file builds a Table from a slice of structs using reflection: each exported field becomes
+// a leaf column and each struct a data row. Fields are configured with `spit:"label,format"` tags.
+
+package spit
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StructTag is the struct tag key read by NewTableFromStructs.
+const StructTag = "spit"
+
+// NewTableFromStructs builds a table from a slice (or array) of structs or struct pointers.
+// Every exported field becomes a column named after the field, in declaration order; fields of
+// embedded structs without a tag are promoted. The `spit` tag sets the header label and the column format:
+//
+//	type Order struct {
+//		ID      int       `spit:"Order ID"`
+//		Placed  time.Time `spit:"Placed on,2006-01-02"`
+//		Total   float64   `spit:",number"` // label defaults to the field name
+//		Secret  string    `spit:"-"`       // skipped
+//	}
+//
+// The format is everything after the first comma, so layouts containing commas are supported.
+// Nil pointer fields produce empty cells and nil struct pointers empty rows. The header is written.
+func NewTableFromStructs(slice interface{}) (*Table, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", slice)
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", slice)
+	}
+
+	fields := structFields(elemType)
+	columns := make(Columns, len(fields))
+	for i, f := range fields {
+		columns[i] = NewColumn(f.name, f.label).WithFormat(f.format)
+	}
+
+	data := make(DataSlice, v.Len())
+	for i := range data {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				data[i] = Data{}
+				continue
+			}
+			elem = elem.Elem()
+		}
+		row := make(Data, len(fields))
+		for _, f := range fields {
+			if value, ok := fieldValue(elem, f.index); ok {
+				row[f.name] = value
+			}
+		}
+		data[i] = row
+	}
+
+	return NewTable(data, columns, true), nil
+}
+
+// structField describes an exported struct field mapped to a column.
+type structField struct {
+	name   string // Column name and data key (the Go field name)
+	label  string // Header label
+	format string // Column format
+	index  []int  // Field index path (see reflect.Value.FieldByIndex)
+	tagged bool   // Whether the field has a spit tag
+}
+
+// structFields returns the columns of a struct type in declaration order, promoting the fields of
+// untagged embedded structs.
+// Conflicting names are resolved like encoding/json: the shallowest field wins, a tagged field
+// wins among fields at the same depth, and the remaining ambiguous fields are all dropped.
+func structFields(t reflect.Type) []structField {
+	all := collectStructFields(t, nil, map[reflect.Type]bool{})
+
+	byName := make(map[string][]structField, len(all))
+	for _, f := range all {
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	var fields []structField
+	for _, f := range all {
+		if dominant, ok := dominantField(byName[f.name]); ok && slices.Equal(dominant.index, f.index) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// collectStructFields returns every exported field of t and of its untagged embedded structs, in
+// declaration order. visiting holds the embedded types on the current path to stop on cycles.
+func collectStructFields(t reflect.Type, index []int, visiting map[reflect.Type]bool) []structField {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup(StructTag)
+		if tag == "-" {
+			continue
+		}
+		path := append(append([]int(nil), index...), i)
+
+		if sf.Anonymous && !tagged {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if !visiting[embedded] {
+					fields = append(fields, collectStructFields(embedded, path, visiting)...)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		label, format, _ := strings.Cut(tag, ",")
+		if label == "" {
+			label = sf.Name
+		}
+		fields = append(fields, structField{name: sf.Name, label: label, format: format, index: path, tagged: tagged})
+	}
+	return fields
+}
+
+// dominantField returns the field hiding the other candidates sharing its name, if any: the
+// shallowest one, or the only tagged one among the shallowest.
+func dominantField(candidates []structField) (structField, bool) {
+	depth := len(candidates[0].index)
+	for _, f := range candidates[1:] {
+		depth = min(depth, len(f.index))
+	}
+
+	var shallowest []structField
+	for _, f := range candidates {
+		if len(f.index) == depth {
+			shallowest = append(shallowest, f)
+		}
+	}
+	if len(shallowest) == 1 {
+		return shallowest[0], true
+	}
+
+	var tagged []structField
+	for _, f := range shallowest {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+// fieldValue returns the value of the field at index, dereferencing pointers.
+// A nil pointer field yields nil, which is written as an empty cell; ok is false when an embedded
+// struct pointer on the path is nil.
+func fieldValue(v reflect.Value, index []int) (interface{}, bool) {
+	for i, x := range index {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return nil, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), true
+}
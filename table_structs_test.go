@@ -0,0 +1,128 @@
+package spit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testAudit struct {
+	CreatedBy string `spit:"Created by"`
+}
+
+type testOrder struct {
+	testAudit
+	ID     int        `spit:"Order ID"`
+	Placed time.Time  `spit:"Placed on,Jan 2, 2006"`
+	Total  float64    `spit:",number"`
+	Note   *string    `spit:"Note"`
+	Secret string     `spit:"-"`
+	Closed *time.Time // untagged: label defaults to the field name
+	hidden string
+}
+
+func TestNewTableFromStructs(t *testing.T) {
+	note := "rush"
+	placed := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	orders := []*testOrder{
+		{testAudit: testAudit{CreatedBy: "ann"}, ID: 1, Placed: placed, Total: 9.5, Note: &note, Secret: "s", hidden: "h"},
+		nil,
+		{ID: 2},
+	}
+
+	table, err := NewTableFromStructs(orders)
+	if err != nil {
+		t.Fatalf("NewTableFromStructs failed: %v", err)
+	}
+	if !table.WriteHeader {
+		t.Error("expected the header to be written")
+	}
+
+	var got [][3]string
+	for _, c := range table.Columns {
+		got = append(got, [3]string{c.Name, c.Label, c.Format})
+	}
+	want := [][3]string{
+		{"CreatedBy", "Created by", ""},
+		{"ID", "Order ID", ""},
+		{"Placed", "Placed on", "Jan 2, 2006"},
+		{"Total", "Total", "number"},
+		{"Note", "Note", ""},
+		{"Closed", "Closed", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+
+	wantData := DataSlice{
+		{"CreatedBy": "ann", "ID": 1, "Placed": placed, "Total": 9.5, "Note": "rush", "Closed": nil},
+		{},
+		{"CreatedBy": "", "ID": 2, "Placed": time.Time{}, "Total": 0.0, "Note": nil, "Closed": nil},
+	}
+	if !reflect.DeepEqual(table.Data, wantData) {
+		t.Errorf("data = %v, want %v", table.Data, wantData)
+	}
+}
+
+func TestNewTableFromStructs_errors(t *testing.T) {
+	for name, input := range map[string]interface{}{
+		"nil":            nil,
+		"struct":         testOrder{},
+		"slice of ints":  []int{1, 2},
+		"slice of *ints": []*int{nil},
+		"map of structs": map[string]testOrder{},
+	} {
+		if _, err := NewTableFromStructs(input); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	table, err := NewTableFromStructs([]testAudit{})
+	if err != nil || len(table.Columns) != 1 || len(table.Data) != 0 {
+		t.Errorf("empty slice: got %v, %v", table, err)
+	}
+}
+
+type testNamed struct {
+	Name string
+	Kind string
+	Code string `spit:"Code"`
+}
+
+type testLabeled struct {
+	Name string
+	Kind string
+	Code string
+}
+
+type testConflicts struct {
+	testNamed
+	*testLabeled
+	Name string `spit:"Own name"`
+}
+
+func TestNewTableFromStructs_conflicts(t *testing.T) {
+	rows := []testConflicts{{
+		testNamed:   testNamed{Name: "inner", Kind: "a", Code: "tagged"},
+		testLabeled: &testLabeled{Name: "other", Kind: "b", Code: "untagged"},
+		Name:        "outer",
+	}}
+
+	table, err := NewTableFromStructs(rows)
+	if err != nil {
+		t.Fatalf("NewTableFromStructs failed: %v", err)
+	}
+
+	var got [][2]string
+	for _, c := range table.Columns {
+		got = append(got, [2]string{c.Name, c.Label})
+	}
+	// The shallower Name hides both embedded ones, the tagged Code wins and Kind is ambiguous
+	want := [][2]string{{"Code", "Code"}, {"Name", "Own name"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+	if wantData := (Data{"Code": "tagged", "Name": "outer"}); !reflect.DeepEqual(table.Data[0], wantData) {
+		t.Errorf("data = %v, want %v", table.Data[0], wantData)
+	}
+}
@@ -0,0 +1,132 @@
+// table_subtotal.go - Group subtotal rows.
+//
+// This file implements the subtotal rows written after each group of a grouped table (see
+// GroupOptions.Subtotals). Subtotals reuse the column aggregates of the footer row, which then
+// serves as the grand total: subtotal rows are excluded from footer values, and footer formulas
+// switch to SUBTOTAL so nested subtotals are never counted twice.
+
+package spit
+
+import "fmt"
+
+// subtotalFunctions maps built-in aggregate functions to their SUBTOTAL function numbers.
+var subtotalFunctions = map[string]int{
+	"AVERAGE": 1,
+	"COUNTA":  3,
+	"MAX":     4,
+	"MIN":     5,
+	"SUM":     9,
+}
+
+// openGroup tracks a group while its rows are being appended.
+type openGroup struct {
+	first int         // Data row index of the first row after the group header
+	value interface{} // Group value
+	rows  DataSlice   // Original data rows of the group
+}
+
+// subtotalRange is the data row range aggregated by a subtotal row (bounds included).
+type subtotalRange struct {
+	first int
+	last  int
+}
+
+// appendSubtotal appends the subtotal row of a closed group, with the outline level of the
+// group contents so it collapses with them. Values are stored under the first lookup key of each
+// column, so columns resolved through Keys alone find them.
+func (t *Table) appendSubtotal(groupColumn *Column, level int, group openGroup) {
+	index := len(t.Data)
+	flatColumns := t.Columns.GetFlattenedColumns()
+
+	row := make(Data, len(flatColumns))
+	labeled := false
+	for _, column := range flatColumns {
+		value := interface{}("")
+		if column.Aggregate != nil && column.Aggregate.Compute != nil {
+			if v := column.Aggregate.Compute(valuesOf(group.rows, column)); v != nil {
+				value = v
+			}
+		} else if !labeled {
+			value = t.subtotalLabel(groupColumn, group.value)
+			labeled = true
+		}
+		key := column.Name
+		if keys := column.LookupKeys(); len(keys) > 0 {
+			key = keys[0]
+		}
+		row[key] = value
+	}
+
+	style := &Style{Bold: true, Italic: true}
+	if t.GroupOptions.SubtotalStyle != nil {
+		style = t.GroupOptions.SubtotalStyle
+	}
+
+	t.Data = append(t.Data, row)
+	t.RowOptionsMap[index] = RowOptions{RowIndex: index, Style: style}
+	t.outlineLevels = append(t.outlineLevels, level+1)
+	t.sourceRows = append(t.sourceRows, -1)
+	if t.subtotals == nil {
+		t.subtotals = make(map[int]subtotalRange)
+	}
+	t.subtotals[index] = subtotalRange{first: group.first, last: index - 1}
+}
+
+// subtotalLabel returns the label of a subtotal row.
+func (t *Table) subtotalLabel(column *Column, value interface{}) string {
+	if t.GroupOptions.SubtotalLabel != nil {
+		return t.GroupOptions.SubtotalLabel(column, value)
+	}
+	return fmt.Sprintf("%v %s", value, DefaultFooterLabel)
+}
+
+// isSubtotalRow reports whether a 0-based data row is a subtotal row.
+func (t *Table) isSubtotalRow(rowIndex int) bool {
+	_, ok := t.subtotals[rowIndex]
+	return ok
+}
+
+// subtotalFormulas reports whether subtotal rows get SUBTOTAL formulas (see RenderSubtotals).
+func (t *Table) subtotalFormulas(allowFormulas bool) bool {
+	return allowFormulas && len(t.subtotals) > 0 && t.GroupOptions != nil && t.GroupOptions.SubtotalFormulas
+}
+
+// RenderSubtotals writes the built-in aggregates of subtotal rows as SUBTOTAL formulas over their
+// group rows when allowFormulas is true and GroupOptions.SubtotalFormulas is set; the computed
+// values written with the data rows are kept otherwise. Like RenderFooter, ops receives
+// table-relative coordinates translated by the start position.
+func (t *Table) RenderSubtotals(ops TableOperations, allowFormulas bool) error {
+	if !t.subtotalFormulas(allowFormulas) {
+		return nil
+	}
+	return t.renderSubtotalRows(0, len(t.Data), t.Offset(ops))
+}
+
+// renderSubtotalRows writes the SUBTOTAL formulas of the subtotal rows among the data rows from
+// (included) to (excluded); ops is already translated by the start position.
+func (t *Table) renderSubtotalRows(from, to int, ops TableOperations) error {
+	dataStartRow := t.GetDataStartRow()
+	addresser := AddresserFor(ops)
+	for rowIndex := from; rowIndex < to; rowIndex++ {
+		r, ok := t.subtotals[rowIndex]
+		if !ok {
+			continue
+		}
+		for i, column := range t.Columns.GetFlattenedColumns() {
+			if column.Aggregate == nil || r.last < r.first {
+				continue
+			}
+			number, ok := subtotalFunctions[column.Aggregate.Function]
+			if !ok {
+				continue
+			}
+			col := i + 1
+			ref := addresser.RangeRef(col, r.first+dataStartRow, col, r.last+dataStartRow)
+			formula := fmt.Sprintf("SUBTOTAL(%d,%s)", number, ref)
+			if err := ops.SetCellFormula(col, rowIndex+dataStartRow, formula); err != nil {
+				return fmt.Errorf("failed to set subtotal formula at column %d: %w", col, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package spit
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportCSV_subtotals(t *testing.T) {
+	table := fixtureTable("region", "country", "sales").WithGroupBy("region").
+		WithGroupOptions(NewGroupOptions().WithSubtotals(true)).WithFooter(NewFooterOptions())
+	table.Columns[2].WithAggregate(AggregateSum)
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "subtotals", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	want := "Region,Country,Sales\n" +
+		"EU,,\nEU,FR,10\nEU,DE,20\nEU,FR,15\nEU Total,,45\n" +
+		"US,,\nUS,CA,30\nUS,US,40\nUS Total,,70\n" +
+		"Total,,115\n"
+	if string(content) != want {
+		t.Errorf("CSV = %q, want %q", content, want)
+	}
+}
+
+func TestTable_subtotalsKeys(t *testing.T) {
+	table := fixtureTable("region", "country", "sales").WithGroupBy("region").
+		WithGroupOptions(NewGroupOptions().WithSubtotals(true)).WithFooter(NewFooterOptions())
+	table.Columns[0] = NewColumn("", "Region").WithKeys("region")
+	table.Columns[2] = NewColumn("", "Sales").WithKeys("sales").WithAggregate(AggregateSum)
+	p := table.Prepare()
+
+	var totals []string
+	for i, row := range p.Data {
+		if !p.isSubtotalRow(i) {
+			continue
+		}
+		label, _, _ := row.LookupColumn(p.Columns[0])
+		value, _, _ := row.LookupColumn(p.Columns[2])
+		totals = append(totals, fmt.Sprintf("%v=%v", label, value))
+	}
+	if want := []string{"EU Total=45", "US Total=70"}; !reflect.DeepEqual(totals, want) {
+		t.Errorf("subtotals = %v, want %v", totals, want)
+	}
+}
+
+func TestTable_subtotalsNested(t *testing.T) {
+	table := fixtureTable("region", "country", "sales").WithGroupBy("region", "country").
+		WithGroupOptions(NewGroupOptions().WithSubtotals(true)).WithFooter(NewFooterOptions())
+	table.Columns[2].WithAggregate(AggregateSum)
+	p := table.Prepare()
+
+	var totals []string
+	for i, item := range p.Data {
+		if p.isSubtotalRow(i) {
+			totals = append(totals, item["region"].(string))
+			if p.RowOutlineLevel(i) == 0 {
+				t.Errorf("subtotal row %d should be outlined with its group", i)
+			}
+		}
+	}
+	want := []string{"DE Total", "FR Total", "EU Total", "CA Total", "US Total", "US Total"}
+	if len(totals) != len(want) {
+		t.Fatalf("subtotal labels = %v, want %v", totals, want)
+	}
+	for i := range want {
+		if totals[i] != want[i] {
+			t.Errorf("subtotal labels = %v, want %v", totals, want)
+			break
+		}
+	}
+	if got := p.FooterValues()[2]; got != 115.0 {
+		t.Errorf("grand total = %v, want 115 (subtotals excluded)", got)
+	}
+}
+
+func TestExportXLSX_subtotalFormulas(t *testing.T) {
+	table := fixtureTable("region", "country", "sales").WithGroupBy("region").
+		WithGroupOptions(NewGroupOptions().WithSubtotals(true).WithSubtotalFormulas(true)).
+		WithFooter(NewFooterOptions().WithFormulas(true))
+	table.Columns[2].WithAggregate(AggregateSum)
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table),
+		FileWriteParams{Filename: "subtotals", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Header on row 1, EU group on rows 2-6, US group on rows 7-10, grand total on row 11
+	for cell, want := range map[string]string{
+		"C6":  "SUBTOTAL(9,C3:C5)",
+		"C10": "SUBTOTAL(9,C8:C9)",
+		"C11": "SUBTOTAL(9,C2:C10)",
+	} {
+		if got, _ := f.GetCellFormula("Sheet1", cell); got != want {
+			t.Errorf("%s formula = %q, want %q", cell, got, want)
+		}
+	}
+	if got, _ := f.GetCellValue("Sheet1", "A6"); got != "EU Total" {
+		t.Errorf("A6 = %q, want the subtotal label", got)
+	}
+}
@@ -0,0 +1,96 @@
+// table_summary.go - Summary sheets.
+//
+// This file builds summary tables from a declarative list of metrics: each metric aggregates a
+// named range of another sheet (see WithNamedRanges), written as a cross-sheet formula such as
+// =SUM(Sales!col_amount). The formulas follow the source tables whatever their row counts, so a
+// workbook can carry a summary sheet without hand-written formula strings.
+
+package spit
+
+import "fmt"
+
+// Column names and labels of the tables built by NewSummaryTable.
+const (
+	SummaryLabelColumn = "metric"
+	SummaryValueColumn = "value"
+)
+
+// SummaryMetric is a row of a summary table: an aggregate over a named range of another sheet.
+type SummaryMetric struct {
+	Label     string     // Label written in the first column
+	Sheet     string     // Name of the sheet holding the range
+	Name      string     // Defined name of the range on that sheet (e.g. "col_amount", see WithNamedRanges)
+	Aggregate *Aggregate // Built-in aggregate applied to the range (default: AggregateSum)
+}
+
+// NewSummaryMetric creates a metric summing the column named range of the given column on sheet
+// (e.g. column "amount" refers to col_amount).
+func NewSummaryMetric(label, sheet, column string) *SummaryMetric {
+	return &SummaryMetric{
+		Label:     label,
+		Sheet:     sheet,
+		Name:      DefaultColumnNamePrefix + column,
+		Aggregate: AggregateSum,
+	}
+}
+
+// WithName sets the defined name of the range, e.g. a prefixed name ("Sales_col_amount") or a
+// region ("Data").
+func (m *SummaryMetric) WithName(name string) *SummaryMetric {
+	m.Name = name
+	return m
+}
+
+// WithAggregate sets the aggregate applied to the range. Only built-in aggregates (AggregateSum,
+// AggregateAvg, AggregateCount, AggregateMin, AggregateMax) can be written as formulas.
+func (m *SummaryMetric) WithAggregate(aggregate *Aggregate) *SummaryMetric {
+	m.Aggregate = aggregate
+	return m
+}
+
+// Formula returns the formula of the metric, e.g. "SUM('Q1 Sales'!col_amount)".
+func (m *SummaryMetric) Formula() (string, error) {
+	aggregate := m.Aggregate
+	if aggregate == nil {
+		aggregate = AggregateSum
+	}
+	if aggregate.Function == "" {
+		return "", fmt.Errorf("metric %q: custom aggregates cannot be written as formulas", m.Label)
+	}
+	if m.Sheet == "" || m.Name == "" {
+		return "", fmt.Errorf("metric %q: sheet and range name are required", m.Label)
+	}
+	return aggregate.Function + "(" + NamedRangeRef(m.Sheet, m.Name) + ")", nil
+}
+
+// NamedRangeRef returns a reference to a sheet-scoped defined name, quoting the sheet name when
+// needed (e.g. "Sales!col_amount" or "'Q1 Sales'!Data"). The name is sanitized like the names
+// written by WithNamedRanges.
+func NamedRangeRef(sheet, name string) string {
+	return quoteSheetName(sheet) + "!" + SanitizeDefinedName(name)
+}
+
+// NewSummaryTable creates a table with one row per metric: the metric label, then its formula
+// (see CellOptions.Formula). The source tables must write the referenced names (see
+// WithNamedRanges); export the summary with them through ExportXLSXSheets. Backends without
+// formulas (CSV, HTML) leave the values empty.
+func NewSummaryTable(metrics ...*SummaryMetric) (*Table, error) {
+	data := make(DataSlice, len(metrics))
+	cells := make(map[int]CellOptions, len(metrics))
+	for i, metric := range metrics {
+		if metric == nil {
+			return nil, fmt.Errorf("summary metric %d is nil", i)
+		}
+		formula, err := metric.Formula()
+		if err != nil {
+			return nil, err
+		}
+		data[i] = Data{SummaryLabelColumn: metric.Label, SummaryValueColumn: nil}
+		cells[i] = *NewCellOptions(i, 2).WithFormula(formula)
+	}
+
+	return NewTable(data, Columns{
+		NewColumn(SummaryLabelColumn, "Metric"),
+		NewColumn(SummaryValueColumn, "Value"),
+	}, true).WithCellOptions(CellOptionsMap{2: cells}), nil
+}
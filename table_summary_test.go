@@ -0,0 +1,97 @@
+package spit
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSummaryMetric_Formula(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  *SummaryMetric
+		want    string
+		wantErr bool
+	}{
+		{"Column sum", NewSummaryMetric("Revenue", "Sales", "amount"), "SUM(Sales!col_amount)", false},
+		{"Quoted sheet", NewSummaryMetric("Orders", "Q1 Sales", "id").WithAggregate(AggregateCount), "COUNTA('Q1 Sales'!col_id)", false},
+		{"Custom name", NewSummaryMetric("Best", "Sales", "").WithName("Sales_col_amount").WithAggregate(AggregateMax), "MAX(Sales!Sales_col_amount)", false},
+		{"Custom aggregate", NewSummaryMetric("Custom", "Sales", "amount").WithAggregate(NewAggregate(nil)), "", true},
+		{"Missing sheet", NewSummaryMetric("Revenue", "", "amount"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.metric.Formula()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Formula() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Formula() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportXLSXSheets_summary(t *testing.T) {
+	sales := NewTable(DataSlice{
+		{"region": "North", "amount": 100},
+		{"region": "South", "amount": 250},
+		{"region": "East", "amount": 50},
+	}, Columns{
+		NewColumn("region", "Region"),
+		NewColumn("amount", "Amount"),
+	}, true).WithStartPosition(3, 2).WithNamedRanges(nil)
+
+	summary, err := NewSummaryTable(
+		NewSummaryMetric("Revenue", "Sales", "amount"),
+		NewSummaryMetric("Best sale", "Sales", "amount").WithAggregate(AggregateMax),
+		NewSummaryMetric("Regions", "Sales", "region").WithAggregate(AggregateCount),
+	)
+	if err != nil {
+		t.Fatalf("NewSummaryTable failed: %v", err)
+	}
+
+	res, err := ExportXLSXSheets([]Spreadsheet{
+		NewSpreadsheetExcelize("Summary", summary),
+		NewSpreadsheetExcelize("Sales", sales),
+	}, FileWriteParams{Filename: "summary", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+	if len(res.Warnings) > 0 {
+		t.Errorf("unexpected warnings: %v", res.Warnings)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	want := []struct {
+		cell, label, formula string
+	}{
+		{"B2", "Revenue", "SUM(Sales!col_amount)"},
+		{"B3", "Best sale", "MAX(Sales!col_amount)"},
+		{"B4", "Regions", "COUNTA(Sales!col_region)"},
+	}
+	for i, w := range want {
+		if label, _ := f.GetCellValue("Summary", "A"+w.cell[1:]); label != w.label {
+			t.Errorf("row %d label = %q, want %q", i, label, w.label)
+		}
+		formula, err := f.GetCellFormula("Summary", w.cell)
+		if err != nil || formula != w.formula {
+			t.Errorf("%s formula = %q (%v), want %q", w.cell, formula, err, w.formula)
+		}
+	}
+
+	// The referenced names exist on the source sheet
+	names := make(map[string]string)
+	for _, dn := range f.GetDefinedName() {
+		if dn.Scope == "Sales" {
+			names[dn.Name] = dn.RefersTo
+		}
+	}
+	if names["col_amount"] != "Sales!$D$3:$D$5" || names["col_region"] != "Sales!$C$3:$C$5" {
+		t.Errorf("unexpected named ranges: %v", names)
+	}
+}
@@ -1,7 +1,9 @@
 package spit
 
 import (
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -754,6 +756,13 @@ func TestBorders_hasBorders(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Diagonal border only",
+			borders: &Borders{
+				DiagonalDown: &Border{Style: BorderStyleThin},
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1259,6 +1268,27 @@ func TestNewBorder(t *testing.T) {
 	}
 }
 
+func TestBorder_WithColor(t *testing.T) {
+	border := NewBorder(BorderStyleThin).WithColor("#FF0000")
+	if border.Style != BorderStyleThin || border.Color != "#FF0000" {
+		t.Errorf("WithColor() = %+v, want a thin red border", border)
+	}
+}
+
+func TestBorders_SetDiagonals(t *testing.T) {
+	borders := (&Borders{}).SetDiagonalUp(BorderStyleThin).SetDiagonalDown(BorderStyleDashed)
+	if borders.DiagonalUp == nil || borders.DiagonalUp.Style != BorderStyleThin {
+		t.Errorf("SetDiagonalUp() DiagonalUp = %+v, want thin", borders.DiagonalUp)
+	}
+	if borders.DiagonalDown == nil || borders.DiagonalDown.Style != BorderStyleDashed {
+		t.Errorf("SetDiagonalDown() DiagonalDown = %+v, want dashed", borders.DiagonalDown)
+	}
+	borders.SetDiagonalUp(BorderStyleNone)
+	if borders.DiagonalUp.Style != BorderStyleNone {
+		t.Errorf("SetDiagonalUp() should update the existing border, got %+v", borders.DiagonalUp)
+	}
+}
+
 func TestNewBorders(t *testing.T) {
 	left := BorderStyleThin
 	right := BorderStyleMedium
@@ -1664,3 +1694,238 @@ func TestStyle_NumFmt(t *testing.T) {
 		t.Errorf("NumFmt = %v, want #,##0.00 €", style.NumFmt)
 	}
 }
+
+func TestColumn_WithKeys(t *testing.T) {
+	col := NewColumn("displayName", "Name").WithKeys("name", "id")
+	if !reflect.DeepEqual(col.Keys, []string{"name", "id"}) {
+		t.Errorf("WithKeys() Keys = %v, want [name id]", col.Keys)
+	}
+	if got := col.LookupKeys(); !reflect.DeepEqual(got, []string{"displayName", "name", "id"}) {
+		t.Errorf("LookupKeys() = %v, want [displayName name id]", got)
+	}
+	if got := (&Column{Keys: []string{"id"}}).LookupKeys(); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Errorf("LookupKeys() without Name = %v, want [id]", got)
+	}
+}
+
+func TestData_LookupColumn(t *testing.T) {
+	column := NewColumn("displayName", "Name").WithKeys("name", "id")
+
+	tests := []struct {
+		name      string
+		data      Data
+		expected  interface{}
+		wantFound bool
+	}{
+		{name: "Primary name present", data: Data{"displayName": "Alice", "name": "alice", "id": 1}, expected: "Alice", wantFound: true},
+		{name: "First fallback", data: Data{"name": "bob", "id": 2}, expected: "bob", wantFound: true},
+		{name: "Last fallback", data: Data{"id": 3}, expected: 3, wantFound: true},
+		{name: "Present nil value wins", data: Data{"displayName": nil, "id": 4}, expected: nil, wantFound: true},
+		{name: "No key present", data: Data{"other": "x"}, expected: nil, wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err, found := tt.data.LookupColumn(column)
+			if err != nil {
+				t.Fatalf("LookupColumn() unexpected error: %v", err)
+			}
+			if found != tt.wantFound {
+				t.Errorf("LookupColumn() found = %v, want %v", found, tt.wantFound)
+			}
+			if result != tt.expected {
+				t.Errorf("LookupColumn() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumns_SelectExcludeColumns(t *testing.T) {
+	columns := Columns{
+		NewColumn("a", "A"),
+		NewColumn("id", "ID").WithPinned(true),
+		NewColumn("b", "B"),
+		NewColumn("c", "C"),
+	}
+	names := func(cols Columns) []string {
+		out := make([]string, len(cols))
+		for i, col := range cols {
+			out[i] = col.Name
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		result   Columns
+		expected []string
+	}{
+		{name: "Select keeps pinned first and follows requested order", result: columns.SelectColumns("c", "a"), expected: []string{"id", "c", "a"}},
+		{name: "Select ignores unknown names and duplicates of pinned", result: columns.SelectColumns("id", "x"), expected: []string{"id"}},
+		{name: "Exclude never drops pinned", result: columns.ExcludeColumns("id", "b"), expected: []string{"id", "a", "c"}},
+		{name: "Exclude nothing moves pinned first", result: columns.ExcludeColumns(), expected: []string{"id", "a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(tt.result); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := names(columns); !reflect.DeepEqual(got, []string{"a", "id", "b", "c"}) {
+		t.Errorf("receiver must not be modified, got %v", got)
+	}
+}
+
+func TestTable_ForFormat(t *testing.T) {
+	notes := NewColumn("notes", "Notes").WithFormats(FormatXSLX)
+	table := NewTable(DataSlice{{"id": 1, "notes": "check", "amount": 10, "cost": 4}}, Columns{
+		NewColumn("id", "ID"),
+		notes,
+		NewColumn("q1", "Q1").WithSubColumns(Columns{
+			NewColumn("amount", "Amount"),
+			NewColumn("cost", "Cost").WithFormats(FormatXSLX, FormatHTML),
+		}),
+	}, true).WithCellOptions(CellOptionsMap{
+		2: {0: CellOptions{Style: &Style{Bold: true}}},
+		3: {0: CellOptions{Style: &Style{Italic: true}}},
+	})
+
+	if table.ForFormat(FormatXSLX) != table {
+		t.Error("ForFormat() should return the table itself when no column is removed")
+	}
+
+	csvTable := table.ForFormat(FormatCSV)
+	var names []string
+	for _, column := range csvTable.Columns.GetFlattenedColumns() {
+		names = append(names, column.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"id", "amount"}) {
+		t.Errorf("CSV columns = %v, want [id amount]", names)
+	}
+	if len(csvTable.CellOptionsMap) != 1 || !csvTable.CellOptionsMap[2][0].Style.Italic {
+		t.Errorf("cell options should follow the amount column to index 2, got %v", csvTable.CellOptionsMap)
+	}
+	if len(table.Columns[2].Columns) != 2 || len(table.Columns.GetFlattenedColumns()) != 4 {
+		t.Error("ForFormat() must not modify the original columns")
+	}
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "notes", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(content), "check") || strings.Contains(string(content), "Notes") {
+		t.Errorf("XLSX-only column should not be written to CSV:\n%s", content)
+	}
+}
+
+func TestData_LookupKey(t *testing.T) {
+	row := Data{
+		"address":     Data{"city": "Paris", "geo": map[string]interface{}{"lat": 48.85}},
+		"address.zip": "75001",
+		"name":        "Alice",
+		"malformed":   "text",
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		expected  interface{}
+		wantFound bool
+		wantErr   bool
+	}{
+		{name: "Flat key", key: "name", expected: "Alice", wantFound: true},
+		{name: "Nested path", key: "address.city", expected: "Paris", wantFound: true},
+		{name: "Nested plain map", key: "address.geo.lat", expected: 48.85, wantFound: true},
+		{name: "Literal dotted key wins", key: "address.zip", expected: "75001", wantFound: true},
+		{name: "Missing nested key", key: "address.country", wantFound: false},
+		{name: "Missing parent", key: "contact.email", wantFound: false},
+		{name: "Malformed path", key: "malformed.value", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err, found := row.LookupKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if found != tt.wantFound || result != tt.expected {
+				t.Errorf("LookupKey() = %v, %v; want %v, %v", result, found, tt.expected, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestExportCSV_nestedKeys(t *testing.T) {
+	table := NewTable(DataSlice{
+		{"name": "Alice", "address": Data{"city": "Paris"}},
+		{"name": "Bob", "address": map[string]interface{}{"city": "Lyon"}},
+	}, Columns{NewColumn("name", "Name"), NewColumn("address.city", "City")}, true)
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "nested", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if want := "Name,City\nAlice,Paris\nBob,Lyon\n"; string(content) != want {
+		t.Errorf("CSV = %q, want %q", content, want)
+	}
+}
+
+func TestColumns_inheritStyles(t *testing.T) {
+	groupStyle := &Style{Bold: true}
+	groupBorders := NewBordersBoundaries(BorderStyleThin)
+	ownStyle := &Style{Italic: true}
+	innerStyle := &Style{TextColor: "#FF0000"}
+
+	inner := NewColumn("inner", "Inner").WithStyle(innerStyle).WithInheritStyle(true).WithSubColumns(Columns{NewColumn("c", "C")})
+	columns := Columns{
+		NewColumn("plain", "Plain").WithStyle(groupStyle).WithSubColumns(Columns{NewColumn("x", "X")}),
+		NewColumn("group", "Group").WithStyle(groupStyle).WithBorders(groupBorders).WithInheritStyle(true).WithSubColumns(Columns{
+			NewColumn("a", "A"),
+			NewColumn("b", "B").WithStyle(ownStyle),
+			inner,
+		}),
+	}
+
+	inherited, changed := columns.inheritStyles(nil, nil)
+	if !changed {
+		t.Fatal("inheritStyles() reported no change")
+	}
+	leaves := inherited.GetFlattenedColumns()
+	tests := []struct {
+		name    string
+		style   *Style
+		borders *Borders
+	}{
+		{"x", nil, nil},
+		{"a", groupStyle, groupBorders},
+		{"b", ownStyle, groupBorders},
+		{"c", innerStyle, groupBorders},
+	}
+	for i, tt := range tests {
+		if leaves[i].Name != tt.name || leaves[i].Style != tt.style || leaves[i].Borders != tt.borders {
+			t.Errorf("leaf %d = %s (%v, %v), want %s (%v, %v)", i, leaves[i].Name, leaves[i].Style, leaves[i].Borders, tt.name, tt.style, tt.borders)
+		}
+	}
+	if columns[1].Columns[0].Style != nil || inner.Columns[0].Style != nil {
+		t.Error("inheritStyles() modified the receiver")
+	}
+	if _, changed := (Columns{columns[0]}).inheritStyles(nil, nil); changed {
+		t.Error("inheritStyles() without inheriting groups reported a change")
+	}
+
+	table := NewTable(DataSlice{{"x": 1, "a": 2, "b": 3, "c": 4}}, columns, true)
+	if e := ExplainCellStyle(table, 2, 3); e.Resolved == nil || !e.Resolved.Bold {
+		t.Errorf("inherited cell style = %s, want the group style", e)
+	}
+}
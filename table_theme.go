@@ -0,0 +1,180 @@
+// table_theme.go - Table themes.
+//
+// This file implements themes: bundles of header, data and footer styles, borders, zebra bands and
+// number formats applied to a table in one call (see Table.WithTheme). A theme only fills what the
+// table leaves unset, so explicit header options, column styles and zebra bands always win. Themes
+// are applied by Table.Prepare, so columns added after WithTheme are themed as well.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Theme bundles the default styling of a table (see Table.WithTheme). Nil fields leave the
+// corresponding defaults unchanged. Data row backgrounds belong in Zebra: a DataStyle background
+// would hide the bands, column styles taking precedence over them.
+type Theme struct {
+	Name          string                // Name of the theme, used by configuration files (see LookupTheme)
+	HeaderStyle   *Style                // Style of the header cells
+	HeaderBorders *Borders              // Borders of the header cells
+	DataStyle     *Style                // Style of the data cells of the leaf columns setting none
+	DataBorders   *Borders              // Borders of the data cells of the leaf columns setting none
+	Zebra         *ZebraOptions         // Alternating styles of the data rows
+	FooterStyle   *Style                // Style of the footer row
+	NumFmts       map[ColumnType]string // Number formats of typed columns whose style sets none (XLSX)
+}
+
+var (
+	// ThemeMinimal is a light theme: bold headers underlined by a thin border and no grid.
+	ThemeMinimal = &Theme{
+		Name:          "minimal",
+		HeaderStyle:   &Style{Bold: true, Alignment: AlignmentLeftMiddle},
+		HeaderBorders: &Borders{Bottom: NewBorder(BorderStyleThin)},
+		FooterStyle:   &Style{Bold: true},
+	}
+
+	// ThemeCorporate is a business theme: blue headers, thin grid, light blue bands and grouped
+	// thousands.
+	ThemeCorporate = &Theme{
+		Name:          "corporate",
+		HeaderStyle:   &Style{Bold: true, TextColor: "#FFFFFF", BackgroundColor: "#1F4E78", Alignment: AlignmentCenterMiddle, WrapText: true},
+		HeaderBorders: NewBordersBoundaries(BorderStyleThin),
+		DataBorders:   NewBordersBoundaries(BorderStyleThin),
+		Zebra:         &ZebraOptions{Odd: &Style{BackgroundColor: "#DDEBF7"}},
+		FooterStyle:   &Style{Bold: true, BackgroundColor: "#BDD7EE"},
+		NumFmts: map[ColumnType]string{
+			ColumnTypeInt:      "#,##0",
+			ColumnTypeFloat:    "#,##0.00",
+			ColumnTypeCurrency: "#,##0.00",
+			ColumnTypePercent:  "0.0%",
+		},
+	}
+
+	// ThemeDark is a dark theme: light text on dark grey backgrounds.
+	ThemeDark = &Theme{
+		Name:          "dark",
+		HeaderStyle:   &Style{Bold: true, TextColor: "#F9FAFB", BackgroundColor: "#111827", Alignment: AlignmentCenterMiddle},
+		HeaderBorders: NewBordersBoundaries(BorderStyleThin),
+		DataStyle:     &Style{TextColor: "#E5E7EB"},
+		DataBorders:   NewBordersBoundaries(BorderStyleThin),
+		Zebra:         &ZebraOptions{Even: &Style{BackgroundColor: "#1F2937"}, Odd: &Style{BackgroundColor: "#374151"}},
+		FooterStyle:   &Style{Bold: true, TextColor: "#F9FAFB", BackgroundColor: "#111827"},
+	}
+)
+
+// themes lists the built-in themes by name.
+var themes = []*Theme{ThemeMinimal, ThemeCorporate, ThemeDark}
+
+// LookupTheme returns the built-in theme of the given name, ignoring case.
+func LookupTheme(name string) (*Theme, bool) {
+	for _, theme := range themes {
+		if strings.EqualFold(theme.Name, name) {
+			return theme, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalText returns the name of the theme when it names a built-in theme. Custom themes cannot
+// be marshalled.
+func (th *Theme) MarshalText() ([]byte, error) {
+	if _, ok := LookupTheme(th.Name); ok {
+		return []byte(th.Name), nil
+	}
+	return nil, fmt.Errorf("custom themes cannot be marshalled")
+}
+
+// UnmarshalText sets the theme to the built-in theme of the given name (see LookupTheme).
+func (th *Theme) UnmarshalText(text []byte) error {
+	builtin, ok := LookupTheme(strings.TrimSpace(string(text)))
+	if !ok {
+		return fmt.Errorf("unknown theme %q", text)
+	}
+	*th = *builtin
+	return nil
+}
+
+// WithTheme applies a theme to the table (see Theme). The theme fills what the table leaves
+// unset: header options, zebra bands, footer style, and the style, borders and number format of
+// the leaf columns. Styles set on the table or its columns take precedence.
+func (t *Table) WithTheme(theme *Theme) *Table {
+	t.Theme = theme
+	return t
+}
+
+// withTheme returns a shallow copy of the table with its theme applied. The receiver is not
+// modified.
+func (t *Table) withTheme(theme *Theme) *Table {
+	themed := *t
+	themed.prepared = nil
+	themed.themeStyles = make(map[*Style]bool)
+
+	if theme.HeaderStyle != nil || theme.HeaderBorders != nil {
+		header := HeaderOptions{}
+		if t.HeaderOptions != nil {
+			header = *t.HeaderOptions
+		}
+		if header.Style == nil {
+			header.Style = theme.HeaderStyle
+			themed.themeStyles[theme.HeaderStyle] = true
+		}
+		if header.Borders == nil {
+			header.Borders = theme.HeaderBorders
+		}
+		themed.HeaderOptions = &header
+	}
+	if themed.Zebra == nil && theme.Zebra != nil {
+		themed.Zebra = theme.Zebra
+		themed.themeStyles[theme.Zebra.Even] = true
+		themed.themeStyles[theme.Zebra.Odd] = true
+	}
+	if theme.FooterStyle != nil && (t.FooterOptions == nil || t.FooterOptions.Style == nil) {
+		footer := FooterOptions{}
+		if t.FooterOptions != nil {
+			footer = *t.FooterOptions
+		}
+		footer.Style = theme.FooterStyle
+		themed.FooterOptions = &footer
+		themed.themeStyles[theme.FooterStyle] = true
+	}
+
+	themed.Columns = t.Columns.withTheme(theme, themed.themeStyles)
+	delete(themed.themeStyles, nil)
+	return &themed
+}
+
+// withTheme returns the columns with the data style, borders and number formats of the theme set
+// on the leaf columns setting none, recording the styles set in themeStyles. Themed leaf columns
+// and their groups are copied; the receiver is not modified.
+func (c Columns) withTheme(theme *Theme, themeStyles map[*Style]bool) Columns {
+	themed := make(Columns, len(c))
+	for i, column := range c {
+		copied := *column
+		if len(column.Columns) > 0 {
+			copied.Columns = column.Columns.withTheme(theme, themeStyles)
+			themed[i] = &copied
+			continue
+		}
+		if copied.Style == nil {
+			copied.Style = theme.DataStyle
+			themeStyles[copied.Style] = true
+		}
+		if copied.Borders == nil {
+			copied.Borders = theme.DataBorders
+		}
+		if numFmt, ok := theme.NumFmts[copied.Type]; ok && copied.typed() && (copied.Style == nil || copied.Style.NumFmt == "") {
+			style := Style{}
+			if copied.Style != nil {
+				style = *copied.Style
+			}
+			style.NumFmt = numFmt
+			// Number formats added to a column style are reported with the column
+			themeStyles[&style] = copied.Style == nil || themeStyles[copied.Style]
+			copied.Style = &style
+		}
+		themed[i] = &copied
+	}
+	return themed
+}
@@ -0,0 +1,80 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_WithTheme(t *testing.T) {
+	custom := &Style{Italic: true}
+	table := NewTable(DataSlice{{"name": "a", "amount": 1}}, Columns{
+		NewColumn("name", "Name").WithStyle(custom),
+		NewColumn("", "Sales").WithSubColumns(Columns{
+			NewColumn("amount", "Amount").WithType(ColumnTypeCurrency),
+		}),
+	}, true).WithFooter(nil).WithTheme(ThemeCorporate)
+
+	prepared := table.Prepare()
+	if prepared.HeaderOptions.Style != ThemeCorporate.HeaderStyle || prepared.HeaderOptions.Borders != ThemeCorporate.HeaderBorders {
+		t.Errorf("header options = %+v, want the theme header", prepared.HeaderOptions)
+	}
+	if prepared.Zebra != ThemeCorporate.Zebra || prepared.FooterOptions.Style != ThemeCorporate.FooterStyle {
+		t.Errorf("zebra %+v, footer %+v, want the theme ones", prepared.Zebra, prepared.FooterOptions)
+	}
+	flatColumns := prepared.Columns.GetFlattenedColumns()
+	if flatColumns[0].Style != custom || flatColumns[0].Borders != ThemeCorporate.DataBorders {
+		t.Errorf("name column style %+v, borders %+v, want its own style and the theme borders", flatColumns[0].Style, flatColumns[0].Borders)
+	}
+	if style := flatColumns[1].Style; style == nil || style.NumFmt != "#,##0.00" {
+		t.Errorf("amount column style = %+v, want the theme currency format", style)
+	}
+	if table.HeaderOptions != nil || table.Columns[1].Columns[0].Style != nil {
+		t.Error("WithTheme modified the table")
+	}
+
+	// Explicit table options take precedence over the theme
+	own := &Style{Bold: true}
+	prepared = NewTable(nil, Columns{NewColumn("a", "A")}, true).
+		WithHeaderOptions(&HeaderOptions{Style: own}).
+		WithZebra(nil, own).
+		WithTheme(ThemeDark).
+		Prepare()
+	if prepared.HeaderOptions.Style != own || prepared.HeaderOptions.Borders != ThemeDark.HeaderBorders || prepared.Zebra.Odd != own {
+		t.Errorf("header options %+v, zebra %+v, want the table ones", prepared.HeaderOptions, prepared.Zebra)
+	}
+}
+
+func TestExportXLSX_theme(t *testing.T) {
+	table := NewTable(DataSlice{{"name": "a"}, {"name": "b"}}, Columns{NewColumn("name", "Name")}, true).WithTheme(ThemeDark)
+	var buf bytes.Buffer
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "theme", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	for cell, want := range map[string]string{"A1": "111827", "A2": "1F2937", "A3": "374151"} {
+		styleID, _ := f.GetCellStyle("Data", cell)
+		if style, _ := f.GetStyle(styleID); style == nil || len(style.Fill.Color) == 0 || style.Fill.Color[0] != want {
+			t.Errorf("%s fill = %+v, want %s", cell, style, want)
+		}
+	}
+}
+
+func TestLoadTableConfig_theme(t *testing.T) {
+	table, err := LoadTableConfig(strings.NewReader("theme: Corporate\ncolumns: [{name: a, label: A}]\n"))
+	if err != nil {
+		t.Fatalf("LoadTableConfig() error = %v", err)
+	}
+	if table.Theme == nil || table.Theme.Name != "corporate" {
+		t.Errorf("theme = %+v, want corporate", table.Theme)
+	}
+	if _, err := LoadTableConfig(strings.NewReader("theme: neon\n")); err == nil || !strings.Contains(err.Error(), `unknown theme "neon"`) {
+		t.Errorf("LoadTableConfig() error = %v, want the unknown theme", err)
+	}
+}
@@ -0,0 +1,119 @@
+// table_time.go - Time zones and serial dates.
+//
+// This file implements the time zone of a table (see Table.WithTimeLocation): time.Time values
+// are converted to the zone before being formatted by any backend, so the zone of the caller's
+// values never leaks into the output. Columns may override the zone of the table. Tables may also
+// write their time values as native Excel date cells (see Table.WithSerialDates) rather than text.
+
+package spit
+
+import "time"
+
+// DefaultSerialDateNumFmt is the number format of the date cells written by tables with
+// SerialDates when the cell style sets none.
+const DefaultSerialDateNumFmt = "yyyy-mm-dd hh:mm:ss"
+
+// WithTimeLocation sets the zone time values are converted to before formatting (nil keeps the
+// zone of each value), e.g. time.UTC or a zone loaded with time.LoadLocation.
+func (t *Table) WithTimeLocation(location *time.Location) *Table {
+	t.TimeLocation = location
+	return t
+}
+
+// WithSerialDates sets whether XLSX exports write time values as native date cells (Excel serial
+// date-times, formatted with DefaultSerialDateNumFmt unless the cell style sets a NumFmt) instead
+// of text. Column.Format does not apply to these cells.
+func (t *Table) WithSerialDates(serial bool) *Table {
+	t.SerialDates = serial
+	return t
+}
+
+// WithTimeLocation sets the zone of this column's time values, overriding Table.TimeLocation.
+func (c *Column) WithTimeLocation(location *time.Location) *Column {
+	c.TimeLocation = location
+	return c
+}
+
+// timeLocation returns the zone of the column's time values, or nil to keep their zone.
+func (t *Table) timeLocation(column *Column) *time.Location {
+	if column.TimeLocation != nil {
+		return column.TimeLocation
+	}
+	if t == nil {
+		return nil
+	}
+	return t.TimeLocation
+}
+
+// inLocation returns the value converted to the zone of the column when it is a time, or a list of
+// values holding times; other values are returned unchanged.
+func (t *Table) inLocation(column *Column, value interface{}) interface{} {
+	location := t.timeLocation(column)
+	if location == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v.In(location)
+	case *time.Time:
+		if v != nil {
+			converted := v.In(location)
+			return &converted
+		}
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = t.inLocation(column, item)
+		}
+		return converted
+	}
+	return value
+}
+
+// serialDate returns the time written as a native date cell for the value of the column, or false
+// when the value is written as usual.
+func (t *Table) serialDate(column *Column, value interface{}) (time.Time, bool) {
+	if t == nil || !t.SerialDates || column.Format == ExcelizeFormatFormula || column.Format == ExcelizeFormatHyperlink {
+		return time.Time{}, false
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// cellNumFmtStyle returns the style of a data cell with the number format of its value: the format
+// of the column type (see ColumnType.NumFmt), DefaultSerialDateNumFmt for serial dates or the
+// duration format of DurationFormatClock durations, unless the style or the column style (see
+// Column.WithColumnStyle) sets its own number format.
+func (t *Table) cellNumFmtStyle(column *Column, item Data, style *Style) *Style {
+	if style != nil && style.NumFmt != "" || column.ColumnStyle != nil && column.ColumnStyle.NumFmt != "" {
+		return style
+	}
+	numFmt := ""
+	if column.typed() {
+		numFmt = column.Type.NumFmt()
+	} else if (t.SerialDates || column.Format == DurationFormatClock) && item != nil {
+		if value, err, found := item.LookupColumn(column); err == nil && found {
+			if _, ok := t.serialDate(column, value); ok {
+				numFmt = DefaultSerialDateNumFmt
+			} else if _, ok := nativeDuration(value, column.Format); ok {
+				numFmt = ColumnTypeDuration.NumFmt()
+			}
+		}
+	}
+	if numFmt == "" {
+		return style
+	}
+	resolved := Style{}
+	if style != nil {
+		resolved = *style
+	}
+	resolved.NumFmt = numFmt
+	return &resolved
+}
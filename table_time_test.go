@@ -0,0 +1,89 @@
+package spit
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestTable_inLocation(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*3600)
+	tokyo := time.FixedZone("JST", 9*3600)
+	instant := time.Date(2024, 3, 15, 10, 0, 0, 0, paris)
+	table := NewTable(nil, nil, true).WithTimeLocation(time.UTC)
+	column := NewColumn("at", "At")
+
+	if got := table.inLocation(column, instant); got != instant.In(time.UTC) {
+		t.Errorf("inLocation() = %v, want the time in UTC", got)
+	}
+	if got := table.inLocation(column, &instant).(*time.Time); got.Location() != time.UTC || !got.Equal(instant) {
+		t.Errorf("inLocation(pointer) = %v, want the time in UTC", got)
+	}
+	list := table.inLocation(column, []interface{}{instant, "x"}).([]interface{})
+	if !reflect.DeepEqual(list, []interface{}{instant.In(time.UTC), "x"}) {
+		t.Errorf("inLocation(list) = %v, want the times converted", list)
+	}
+	if got := table.inLocation(NewColumn("at", "At").WithTimeLocation(tokyo), instant).(time.Time); got.Hour() != 17 {
+		t.Errorf("column zone gave %v, want 17:00 JST", got)
+	}
+	if got := NewTable(nil, nil, true).inLocation(column, instant); got != instant {
+		t.Errorf("inLocation() without zone = %v, want the value unchanged", got)
+	}
+}
+
+func TestExport_timeLocation(t *testing.T) {
+	at := time.Date(2024, 3, 15, 10, 30, 0, 0, time.FixedZone("CEST", 2*3600))
+	table := NewTable(DataSlice{{"at": at, "local": at}}, Columns{
+		NewColumn("at", "At").WithFormat("2006-01-02 15:04 MST"),
+		NewColumn("local", "Local").WithFormat("2006-01-02 15:04 MST").WithTimeLocation(time.FixedZone("EDT", -4*3600)),
+	}, true).WithTimeLocation(time.UTC)
+
+	var buf bytes.Buffer
+	if _, err := ExportCSV(",", table, FileWriteParams{Filename: "times", Writer: &buf}); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+	if want := "At,Local\n2024-03-15 08:30 UTC,2024-03-15 04:30 EDT\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	markup, err := RenderMarkdown(table)
+	if err != nil || !strings.Contains(markup, "| 2024-03-15 08:30 UTC | 2024-03-15 04:30 EDT |") {
+		t.Errorf("RenderMarkdown() = %q, %v; want the converted times", markup, err)
+	}
+}
+
+func TestExportXLSX_serialDates(t *testing.T) {
+	at := time.Date(2024, 3, 15, 10, 30, 0, 0, time.FixedZone("CEST", 2*3600))
+	table := NewTable(DataSlice{{"at": at, "local": at}}, Columns{
+		NewColumn("at", "At").WithFormat("2006-01-02 15:04 MST"),
+		NewColumn("local", "Local").WithFormat("2006-01-02 15:04 MST").WithTimeLocation(time.FixedZone("EDT", -4*3600)).
+			WithStyle(&Style{Bold: true}),
+	}, true).WithTimeLocation(time.UTC).WithSerialDates(true)
+
+	var buf bytes.Buffer
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{Filename: "times", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	for cell, want := range map[string]string{"A2": "2024-03-15 08:30:00", "B2": "2024-03-15 04:30:00"} {
+		if raw, _ := f.GetCellValue("Data", cell, excelize.Options{RawCellValue: true}); raw == want {
+			t.Errorf("%s holds text %q, want a serial date", cell, raw)
+		}
+		if got, _ := f.GetCellValue("Data", cell); got != want {
+			t.Errorf("%s displays %q, want %q", cell, got, want)
+		}
+	}
+	id, _ := f.GetCellStyle("Data", "B2")
+	if style, _ := f.GetStyle(id); style == nil || !style.Font.Bold || style.CustomNumFmt == nil || *style.CustomNumFmt != DefaultSerialDateNumFmt {
+		t.Errorf("B2 style = %+v, want bold with the serial date format", style)
+	}
+}
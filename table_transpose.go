@@ -0,0 +1,107 @@
+// table_transpose.go - Transposed tables (headers on the left).
+//
+// This file implements the transposed layout, where column labels run down the first sheet
+// column(s) and each data row extends to the right as a sheet column (the classic "attribute
+// sheet"). Layout calculations stay in table-relative coordinates: sheet backends swap columns
+// and rows when translating them (see Table.Offset), so styles, borders and merges defined on the
+// table are rendered rotated. HTML rotates its cell grid and CSV its records once written.
+
+package spit
+
+// WithTransposed sets whether the table is written transposed: column labels down the first
+// column and one sheet column per data row. Column, row and cell options keep their meaning
+// (a column style styles the cells of that attribute), borders and merges are rotated with the
+// cells. XLSX features tied to sheet rows or columns (auto-filters, Excel tables, grouping
+// outlines, column widths) are skipped for transposed tables.
+func (t *Table) WithTransposed(transposed bool) *Table {
+	t.Transposed = transposed
+	return t
+}
+
+// sheetCoords returns the absolute sheet coordinates of a table-relative cell, accounting for
+// the start position and orientation of the table.
+func (t *Table) sheetCoords(col, row int) (int, int) {
+	if t.Transposed {
+		col, row = row, col
+	}
+	return col + t.GetStartCol() - 1, row + t.GetStartRow() - 1
+}
+
+// sheetRange returns the absolute sheet range of a table-relative range.
+func (t *Table) sheetRange(r CellRange) CellRange {
+	startCol, startRow := t.sheetCoords(r.StartCol, r.StartRow)
+	endCol, endRow := t.sheetCoords(r.EndCol, r.EndRow)
+	return CellRange{StartCol: startCol, StartRow: startRow, EndCol: endCol, EndRow: endRow}
+}
+
+// sheetSize returns the number of sheet columns and rows the table occupies.
+func (t *Table) sheetSize() (cols, rows int) {
+	if t.Transposed {
+		return t.GetRowCount(), t.GetColumnCount()
+	}
+	return t.GetColumnCount(), t.GetRowCount()
+}
+
+// transposedSide returns the border side a table-relative side lands on in a transposed table.
+func transposedSide(side string) string {
+	switch side {
+	case "left":
+		return "top"
+	case "top":
+		return "left"
+	case "right":
+		return "bottom"
+	case "bottom":
+		return "right"
+	}
+	return side
+}
+
+// transposedBorders returns borders with their sides rotated for a transposed table.
+func transposedBorders(borders Borders) Borders {
+	// Diagonals run through the corners swapped by the transposition, so they keep their direction
+	rotated := Borders{Left: borders.Top, Top: borders.Left, Right: borders.Bottom, Bottom: borders.Right,
+		DiagonalUp: borders.DiagonalUp, DiagonalDown: borders.DiagonalDown}
+	if borders.Inner != nil {
+		inner := transposedBorders(*borders.Inner)
+		rotated.Inner = &inner
+	}
+	return rotated
+}
+
+// transpose rotates the built HTML grid: columns become rows, spans and border sides are
+// swapped accordingly.
+func (h *htmlExport) transpose() {
+	grid := make(map[int]map[int]*htmlCell, h.maxCol)
+	for row, cells := range h.grid {
+		for col, c := range cells {
+			c.colspan, c.rowspan = c.rowspan, c.colspan
+			c.borders = transposedBorders(c.borders)
+			if grid[col] == nil {
+				grid[col] = make(map[int]*htmlCell)
+			}
+			grid[col][row] = c
+		}
+	}
+	h.grid = grid
+	h.maxRow, h.maxCol = h.maxCol, h.maxRow
+}
+
+// transposeRecords rotates CSV records: the i-th field of every record forms the i-th output
+// record. Short records are padded with empty fields.
+func transposeRecords(records [][]string) [][]string {
+	width := 0
+	for _, record := range records {
+		width = max(width, len(record))
+	}
+	transposed := make([][]string, width)
+	for i := range transposed {
+		transposed[i] = make([]string, len(records))
+		for j, record := range records {
+			if i < len(record) {
+				transposed[i][j] = record[i]
+			}
+		}
+	}
+	return transposed
+}
@@ -0,0 +1,133 @@
+package spit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_transposed(t *testing.T) {
+	table := fixtureTable("name", "team", "score").WithTransposed(true).WithStartPosition(2, 3)
+	table.Columns[1].WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil))
+	table.Columns[2].WithStyle(&Style{Italic: true}).WithHeaderComment("QA", "Out of 100")
+
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", table), FileWriteParams{Filename: "transposed", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Labels run down column B from row 3, records extend to the right
+	rows := map[string]string{
+		"B3": "Name", "B4": "Team", "B5": "Score",
+		"C3": "carol", "D3": "alice", "G3": "erin",
+		"C4": "b", "D4": "a", "F4": "a",
+		"C5": "7", "F5": "", "G5": "3",
+	}
+	for cell, want := range rows {
+		if got, _ := f.GetCellValue("Sheet1", cell); got != want {
+			t.Errorf("%s = %q, want %q", cell, got, want)
+		}
+	}
+
+	// Vertical merges of the table become horizontal merges of the sheet
+	merges, err := f.GetMergeCells("Sheet1")
+	if err != nil {
+		t.Fatalf("GetMergeCells failed: %v", err)
+	}
+	if len(merges) != 1 || merges[0].GetStartAxis() != "F4" || merges[0].GetEndAxis() != "G4" {
+		t.Errorf("merges = %v, want F4:G4", merges)
+	}
+
+	// Column styles follow the attribute, header styles the labels
+	for cell, check := range map[string]func(*excelize.Style) bool{
+		"D5": func(s *excelize.Style) bool { return s.Font != nil && s.Font.Italic },
+		"B5": func(s *excelize.Style) bool { return s.Font != nil && s.Font.Bold },
+	} {
+		styleID, _ := f.GetCellStyle("Sheet1", cell)
+		style, err := f.GetStyle(styleID)
+		if err != nil || !check(style) {
+			t.Errorf("%s has an unexpected style %+v", cell, style)
+		}
+	}
+
+	comments, err := f.GetComments("Sheet1")
+	if err != nil || len(comments) != 1 || comments[0].Cell != "B5" {
+		t.Errorf("comments = %+v, want one on the Score label (B5)", comments)
+	}
+
+	sheet := res.Sheets[0]
+	if got := sheet.HeaderRange.String(); got != "B3:B5" {
+		t.Errorf("HeaderRange = %q, want B3:B5", got)
+	}
+	if got := sheet.DataRange.String(); got != "C3:G5" {
+		t.Errorf("DataRange = %q, want C3:G5", got)
+	}
+}
+
+func TestExportCSV_transposed(t *testing.T) {
+	table := fixtureTable("name", "team", "score").WithTransposed(true)
+	table.Columns[1].WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil))
+	table.Columns[2].WithStyle(&Style{Italic: true}).WithHeaderComment("QA", "Out of 100")
+
+	res, err := ExportCSV(",", table, FileWriteParams{Filename: "transposed", Filepath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	want := "Name,carol,alice,bob,dave,erin\nTeam,b,a,b,a,a\nScore,7,10,10,,3\n"
+	if string(content) != want {
+		t.Errorf("CSV = %q, want %q", content, want)
+	}
+}
+
+func TestExportHTML_transposed(t *testing.T) {
+	table := fixtureTable("name", "team", "score").WithTransposed(true)
+	table.Columns[1].WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil))
+	table.Columns[2].WithStyle(&Style{Italic: true}).WithHeaderComment("QA", "Out of 100")
+
+	grid, err := Preview(table, 0)
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	if grid.Rows != 3 || grid.Cols != 6 {
+		t.Fatalf("grid = %d x %d, want 3 x 6", grid.Rows, grid.Cols)
+	}
+	if c := grid.Cells[1][4]; c.Value != "a" || c.ColSpan != 2 || c.RowSpan != 1 || !grid.Cells[1][5].Covered {
+		t.Errorf("team cells = %+v / %+v, want a spanning two columns", c, grid.Cells[1][5])
+	}
+
+	dir := t.TempDir()
+	res, err := ExportHTML(table, HTMLOptions{FragmentOnly: true}, FileWriteParams{Filename: "transposed", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportHTML failed: %v", err)
+	}
+	content, err := os.ReadFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	markup := string(content)
+	if strings.Contains(markup, "<thead>") || strings.Count(markup, "scope=\"row\"") != 3 {
+		t.Errorf("transposed HTML should have one row header per label:\n%s", markup)
+	}
+}
+
+func TestTransposedBorders(t *testing.T) {
+	thin, thick := NewBorder(BorderStyleThin), NewBorder(BorderStyleThick)
+	got := transposedBorders(Borders{Left: thin, Bottom: thick, Inner: &Borders{Top: thin}})
+	if got.Top != thin || got.Right != thick || got.Left != nil || got.Inner == nil || got.Inner.Left != thin {
+		t.Errorf("transposedBorders = %+v", got)
+	}
+	if got := transposedBorders(Borders{DiagonalUp: thin}); got.DiagonalUp != thin || got.DiagonalDown != nil {
+		t.Errorf("transposedBorders diagonals = %+v, want the same diagonal", got)
+	}
+}
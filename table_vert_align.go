@@ -0,0 +1,126 @@
+// table_vert_align.go - Superscript and subscript text.
+//
+// This file implements the vertical alignment of text runs (see Style.VertAlign): superscript and
+// subscript text, e.g. footnote markers or units. Excel only honours the vertical alignment of a
+// font in rich text runs, not in cell styles, so the text cells of a superscript or subscript
+// style are written as a single rich text run carrying the font of the style. Numbers, dates and
+// formulas keep their values and stay on the baseline.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// VertAlign defines the vertical alignment of text relative to the baseline.
+type VertAlign int
+
+const (
+	// VertAlignBaseline writes text on the baseline (default).
+	VertAlignBaseline VertAlign = iota
+
+	// VertAlignSuperscript raises the text above the baseline in a smaller font.
+	VertAlignSuperscript
+
+	// VertAlignSubscript lowers the text below the baseline in a smaller font.
+	VertAlignSubscript
+)
+
+// excelVertAlign returns the Excel vertical alignment of text runs, or "" for the baseline.
+func (v VertAlign) excelVertAlign() string {
+	switch v {
+	case VertAlignSuperscript:
+		return "superscript"
+	case VertAlignSubscript:
+		return "subscript"
+	}
+	return ""
+}
+
+// htmlTag returns the HTML element raising or lowering text, or "" for the baseline.
+func (v VertAlign) htmlTag() string {
+	switch v {
+	case VertAlignSuperscript:
+		return "sup"
+	case VertAlignSubscript:
+		return "sub"
+	}
+	return ""
+}
+
+// applyVertAlign writes the text of a cell as a rich text run in the given font when the font
+// raises or lowers text. Cells holding no text are left unchanged.
+func (e *TableExcelize) applyVertAlign(cellRef string, font *excelize.Font) error {
+	if font == nil || font.VertAlign == "" {
+		return nil
+	}
+	cellType, err := e.File.GetCellType(e.SheetName, cellRef)
+	if err != nil || (cellType != excelize.CellTypeSharedString && cellType != excelize.CellTypeInlineString) {
+		return err
+	}
+	text, err := e.File.GetCellValue(e.SheetName, cellRef, excelize.Options{RawCellValue: true})
+	if err != nil || text == "" {
+		return err
+	}
+	run := excelize.RichTextRun{Text: text, Font: font}
+	return e.File.SetCellRichText(e.SheetName, cellRef, []excelize.RichTextRun{run})
+}
+
+// applyVertAlignToRange writes the text cells of a range as rich text runs in the given font
+// when the font raises or lowers text (see applyVertAlign).
+func (e *TableExcelize) applyVertAlignToRange(startCol, startRow, endCol, endRow int, font *excelize.Font) error {
+	if font == nil || font.VertAlign == "" {
+		return nil
+	}
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cellRef, err := e.cellName(col, row)
+			if err != nil {
+				return err
+			}
+			if err := e.applyVertAlign(cellRef, font); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// vertAlignValue returns the value of a streamed cell written as a rich text run in the given
+// font when the font raises or lowers text and the value is text, otherwise the value unchanged.
+func vertAlignValue(value interface{}, font *excelize.Font) interface{} {
+	if font == nil || font.VertAlign == "" {
+		return value
+	}
+	text := ""
+	switch v := value.(type) {
+	case string:
+		text = v
+	case []excelize.RichTextRun:
+		text = richText(v)
+	}
+	if text == "" {
+		return value
+	}
+	return []excelize.RichTextRun{{Text: text, Font: font}}
+}
+
+// richText returns the text of rich text runs.
+func richText(runs []excelize.RichTextRun) string {
+	var b strings.Builder
+	for _, run := range runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// cellText returns the text of a streamed cell value, rich text runs included.
+func cellText(value interface{}) string {
+	if runs, ok := value.([]excelize.RichTextRun); ok {
+		return richText(runs)
+	}
+	return fmt.Sprint(value)
+}
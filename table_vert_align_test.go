@@ -0,0 +1,56 @@
+package spit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_strikethroughAndVertAlign(t *testing.T) {
+	for name, streaming := range map[string]StreamingMode{"cell by cell": StreamingNever, "streamed": StreamingAlways} {
+		t.Run(name, func(t *testing.T) {
+			note := &Style{VertAlign: VertAlignSuperscript, Strikethrough: true, Bold: true}
+			table := NewTable(DataSlice{{"note": "a", "rank": 1}}, Columns{
+				NewColumn("note", "Note").WithStyle(note),
+				NewColumn("rank", "Rank").WithType(ColumnTypeInt).WithStyle(&Style{VertAlign: VertAlignSubscript}),
+			}, true)
+			var buf bytes.Buffer
+			params := FileWriteParams{Filename: "fonts", Writer: &buf, Streaming: streaming}
+			if _, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), params); err != nil {
+				t.Fatalf("ExportXLSX() error = %v", err)
+			}
+			f, err := excelize.OpenReader(&buf)
+			if err != nil {
+				t.Fatalf("OpenReader failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			styleID, _ := f.GetCellStyle("Data", "A2")
+			if style, _ := f.GetStyle(styleID); style == nil || style.Font == nil || !style.Font.Strike || !style.Font.Bold {
+				t.Errorf("A2 style = %+v, want a bold strikethrough font", style)
+			}
+			// Text cells are written as a raised rich text run keeping the font of the style
+			runs, err := f.GetCellRichText("Data", "A2")
+			if err != nil || len(runs) != 1 || runs[0].Text != "a" || runs[0].Font == nil ||
+				runs[0].Font.VertAlign != "superscript" || !runs[0].Font.Strike || !runs[0].Font.Bold {
+				t.Errorf("A2 runs = %+v (%v), want a single bold strikethrough superscript run", runs, err)
+			}
+			// Numbers keep their values on the baseline
+			if cellType, _ := f.GetCellType("Data", "B2"); cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+				t.Errorf("B2 type = %v, want a number", cellType)
+			}
+		})
+	}
+}
+
+func TestHTMLVertAlign(t *testing.T) {
+	table := NewTable(DataSlice{{"note": "1"}}, Columns{
+		NewColumn("note", "Note").WithStyle(&Style{VertAlign: VertAlignSuperscript}),
+	}, true)
+	out := buildHTML(t, table, HTMLOptions{})
+	if !strings.Contains(out, "<sup>1</sup>") {
+		t.Errorf("expected a superscript note, got:\n%s", out)
+	}
+}
@@ -0,0 +1,79 @@
+// table_zebra.go - Zebra striping of data rows.
+//
+// This file implements banded rows: data rows alternate between an even and an odd style (see
+// Table.WithZebra). Rows covered by a vertically merged cell stay in the band of the row starting
+// the merge, so stripes never cut through merged cells.
+
+package spit
+
+// ZebraOptions configures the alternating styles of data rows (see Table.WithZebra).
+type ZebraOptions struct {
+	Even *Style `json:"even,omitempty" yaml:"even,omitempty"` // Style of even bands, starting with the first one (band 0) (nil = unstyled)
+	Odd  *Style `json:"odd,omitempty" yaml:"odd,omitempty"`   // Style of odd bands (nil = unstyled)
+}
+
+// WithZebra alternates the styles of data rows. Bands are numbered from 0, so the first band uses
+// even. A band is a single row, or the rows spanned by a vertically merged cell. The band style is
+// the base style of the row: a more specific style (cell, style rule, row or column) takes
+// precedence, keeping the band background when it sets none. Group header and subtotal rows are
+// not banded.
+func (t *Table) WithZebra(even, odd *Style) *Table {
+	t.Zebra = &ZebraOptions{Even: even, Odd: odd}
+	return t
+}
+
+// zebraBands returns the band of each data row, or -1 for rows inserted by grouping. A row
+// continuing a vertical merge range of any column stays in the band of the previous row.
+func (t *Table) zebraBands(ops TableOperations) []int {
+	continued := make([]bool, len(t.Data))
+	for colIndex, column := range t.Columns.GetFlattenedColumns() {
+		if column.Merge == nil || len(column.Merge.Vertical) == 0 {
+			continue
+		}
+		for _, mr := range t.findVerticalMergeRanges(colIndex+1, column, column.Merge.Vertical, ops) {
+			for _, rowIndex := range mr[1:] {
+				continued[rowIndex] = true
+			}
+		}
+	}
+
+	bands := make([]int, len(t.Data))
+	band := -1
+	for rowIndex := range t.Data {
+		if t.SourceRowIndex(rowIndex) < 0 {
+			bands[rowIndex] = -1
+			continue
+		}
+		if !continued[rowIndex] || band < 0 {
+			band++
+		}
+		bands[rowIndex] = band
+	}
+	return bands
+}
+
+// bandStyle returns the style of a band, or nil for rows outside bands.
+func (z *ZebraOptions) bandStyle(band int) *Style {
+	switch {
+	case z == nil || band < 0:
+		return nil
+	case band%2 == 0:
+		return z.Even
+	default:
+		return z.Odd
+	}
+}
+
+// withBand returns the style of a cell on top of its band style: the band style when the cell has
+// none, the cell style with the band background when it sets no background, or the cell style.
+func withBand(style, band *Style) *Style {
+	if style == nil {
+		return band
+	}
+	if band == nil || style.BackgroundColor != "" || band.BackgroundColor == "" {
+		return style
+	}
+	banded := *style
+	banded.BackgroundColor = band.BackgroundColor
+	return &banded
+}
@@ -0,0 +1,80 @@
+package spit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_zebraBands(t *testing.T) {
+	data := DataSlice{
+		{"region": "North", "city": "Oslo"},
+		{"region": "North", "city": "Bergen"},
+		{"region": "South", "city": "Rome"},
+		{"region": "East", "city": "Riga"},
+		{"region": "East", "city": "Tartu"},
+	}
+	columns := Columns{
+		NewColumn("region", "Region").WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil)),
+		NewColumn("city", "City"),
+	}
+
+	table := NewTable(data, columns, true).WithZebra(nil, &Style{BackgroundColor: "#EEEEEE"})
+	if got, want := table.zebraBands(&htmlExport{table: table}), []int{0, 0, 1, 2, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bands with merges = %v, want %v", got, want)
+	}
+
+	columns[0].Merge = nil
+	if got, want := table.zebraBands(&htmlExport{table: table}), []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bands without merges = %v, want %v", got, want)
+	}
+
+	grouped := NewTable(data, columns, true).WithGroupBy("region").WithZebra(nil, nil).Prepare()
+	bands := grouped.zebraBands(&htmlExport{table: grouped})
+	for i, band := range bands {
+		if (grouped.SourceRowIndex(i) < 0) != (band < 0) {
+			t.Errorf("row %d band = %d, want -1 only for group header rows", i, band)
+		}
+	}
+}
+
+func TestWithBand(t *testing.T) {
+	band := &Style{BackgroundColor: "#EEEEEE", Italic: true}
+	tests := []struct {
+		name  string
+		style *Style
+		band  *Style
+		want  *Style
+	}{
+		{"no styles", nil, nil, nil},
+		{"band only", nil, band, band},
+		{"style only", &Style{Bold: true}, nil, &Style{Bold: true}},
+		{"style keeps band background", &Style{Bold: true}, band, &Style{Bold: true, BackgroundColor: "#EEEEEE"}},
+		{"style background wins", &Style{BackgroundColor: "#FF0000"}, band, &Style{BackgroundColor: "#FF0000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withBand(tt.style, tt.band); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withBand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainCellStyle_zebra(t *testing.T) {
+	odd := &Style{BackgroundColor: "#EEEEEE", TextColor: "#000000"}
+	table := NewTable(DataSlice{{"a": 1, "b": 2}, {"a": 3, "b": 4}}, Columns{
+		NewColumn("a", "A").WithStyle(&Style{Bold: true}),
+		NewColumn("b", "B"),
+	}, true).WithZebra(nil, odd)
+
+	if e := ExplainCellStyle(table, 2, 2); e.Resolved != nil {
+		t.Errorf("even band cell resolved = %v, want unstyled", e.Resolved)
+	}
+	if e := ExplainCellStyle(table, 2, 3); e.Resolved == nil || *e.Resolved != *odd || !e.Sources[0].Applied {
+		t.Errorf("odd band cell = %s, want the band style", e)
+	}
+	want := Style{Bold: true, BackgroundColor: "#EEEEEE", TextColor: ContrastTextDark}
+	if e := ExplainCellStyle(table, 1, 3); e.Resolved == nil || *e.Resolved != want {
+		t.Errorf("styled odd band cell = %s, want %+v", e, want)
+	}
+}
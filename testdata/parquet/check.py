@@ -0,0 +1,73 @@
+"""Reads types.parquet with pyarrow (or DuckDB) and compares it with types.json.
+
+The fixture is written by TestExportParquet_fixture; run this script whenever the fixture changes
+to confirm that real Parquet readers accept the files written by ExportParquet.
+"""
+
+import datetime
+import json
+import pathlib
+import sys
+
+HERE = pathlib.Path(__file__).parent
+
+
+def normalize(value):
+    if isinstance(value, datetime.datetime):
+        if value.tzinfo is not None:
+            value = value.astimezone(datetime.timezone.utc).replace(tzinfo=None)
+        return value.isoformat()
+    if isinstance(value, datetime.date):
+        return value.isoformat()
+    return value
+
+
+def read_pyarrow(path):
+    import pyarrow.parquet as pq
+    import pyarrow.types as pat
+
+    def type_name(typ):
+        if pat.is_timestamp(typ):
+            return f"timestamp[{typ.unit}]"
+        if pat.is_date32(typ):
+            return "date32"
+        return str(typ)
+
+    table = pq.read_table(path)
+    schema = {field.name: type_name(field.type) for field in table.schema}
+    return schema, table.to_pylist()
+
+
+def read_duckdb(path):
+    import duckdb
+
+    relation = duckdb.sql(f"SELECT * FROM read_parquet('{path}')")
+    types = {"BIGINT": "int64", "VARCHAR": "string", "DOUBLE": "double", "BOOLEAN": "bool",
+             "TIMESTAMP": "timestamp[ms]", "TIMESTAMP WITH TIME ZONE": "timestamp[ms]", "DATE": "date32"}
+    schema = {name: types.get(str(typ), str(typ)) for name, typ in zip(relation.columns, relation.types)}
+    return schema, [dict(zip(relation.columns, row)) for row in relation.fetchall()]
+
+
+def main():
+    expected = json.loads((HERE / "types.json").read_text(encoding="utf-8"))
+    path = HERE / "types.parquet"
+    try:
+        schema, rows = read_pyarrow(path)
+    except ImportError:
+        schema, rows = read_duckdb(path)
+
+    rows = [{name: normalize(value) for name, value in row.items()} for row in rows]
+    errors = []
+    if schema != expected["schema"]:
+        errors.append(f"schema {schema}, want {expected['schema']}")
+    if rows != expected["rows"]:
+        errors.append(f"rows {rows}, want {expected['rows']}")
+    for error in errors:
+        print(error, file=sys.stderr)
+    if errors:
+        sys.exit(1)
+    print(f"{path.name}: {len(rows)} rows match types.json")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,95 @@
+// timings.go - Per-phase export timings.
+//
+// This file implements the timings of an export run (FileWriteParams.CollectTimings), so callers
+// can quantify what merging and styling cost on their data and catch regressions. Phases are
+// measured where they run: table preparation by each exporter, merging and styling by the shared
+// pipelines (see Table.ProcessMerging and Table.RenderStyles), saving by the sheet formats; the
+// remaining time of the run is spent writing. Nested exports (the sheets of a workbook, the
+// formats of ExportMulti, the entries of ExportArchive) add up to the timings of their run.
+
+package spit
+
+import (
+	"sync"
+	"time"
+)
+
+// Timings is the time spent per phase by an export run.
+type Timings struct {
+	Prepare time.Duration // Preparing tables: filters, sorting, grouping and cached values
+	Write   time.Duration // Writing headers, data, footers and formulas, and the remaining steps of the run
+	Merge   time.Duration // Merging cells (XLSX and HTML)
+	Style   time.Duration // Styling cells (XLSX and HTML)
+	Save    time.Duration // Serializing the document to its destination (XLSX and HTML; other formats write as they go)
+	Total   time.Duration // Time elapsed since the start of the run
+}
+
+// timingPhase identifies a measured phase of Timings.
+type timingPhase int
+
+const (
+	timingPrepare timingPhase = iota
+	timingMerge
+	timingStyle
+	timingSave
+	timingPhases // Number of measured phases
+)
+
+// runTimings accumulates the time spent per phase by an export run. Methods accept nil timings
+// (runs without CollectTimings), which measure nothing.
+type runTimings struct {
+	mu     sync.Mutex
+	start  time.Time
+	phases [timingPhases]time.Duration
+}
+
+// resolveTimings returns the timings of the run: the ones shared by an enclosing export, new ones
+// when CollectTimings is set, or nil.
+func (fwo FileWriteParams) resolveTimings() *runTimings {
+	if fwo.timings != nil {
+		return fwo.timings
+	}
+	if !fwo.CollectTimings {
+		return nil
+	}
+	return &runTimings{start: time.Now()}
+}
+
+// track lets the shared pipelines of a prepared table measure their phases.
+func (r *runTimings) track(t *Table) {
+	if r == nil {
+		return
+	}
+	t.timings = r
+}
+
+// measure starts measuring a phase and returns the function ending the measure.
+func (r *runTimings) measure(phase timingPhase) func() {
+	if r == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.phases[phase] += time.Since(start)
+	}
+}
+
+// snapshot returns the timings of the run so far, or nil when timings are not collected.
+func (r *runTimings) snapshot() *Timings {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	timings := &Timings{
+		Prepare: r.phases[timingPrepare],
+		Merge:   r.phases[timingMerge],
+		Style:   r.phases[timingStyle],
+		Save:    r.phases[timingSave],
+		Total:   time.Since(r.start),
+	}
+	timings.Write = max(timings.Total-timings.Prepare-timings.Merge-timings.Style-timings.Save, 0)
+	return timings
+}
@@ -0,0 +1,51 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExportTimings(t *testing.T) {
+	table := func() *Table {
+		table := fixtureTable("region", "country", "sales").WithSort(SortKey{Field: "region"})
+		table.Columns[0].WithMerge(NewMergeRules(MergeConditions{MergeConditionIdentical}, nil))
+		table.Columns[1].WithStyle(&Style{Bold: true})
+		return table
+	}
+
+	t.Run("xlsx", func(t *testing.T) {
+		res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table()), FileWriteParams{Filename: "timings", Writer: &bytes.Buffer{}, CollectTimings: true})
+		if err != nil {
+			t.Fatalf("ExportXLSX failed: %v", err)
+		}
+		tm := res.Timings
+		if tm == nil || tm.Prepare <= 0 || tm.Merge <= 0 || tm.Style <= 0 || tm.Save <= 0 || tm.Write < 0 {
+			t.Fatalf("Timings = %+v, want every phase measured", tm)
+		}
+		if sum := tm.Prepare + tm.Write + tm.Merge + tm.Style + tm.Save; sum != tm.Total {
+			t.Errorf("phases sum to %v, want the total %v", sum, tm.Total)
+		}
+	})
+
+	t.Run("not collected", func(t *testing.T) {
+		res, err := ExportCSV(",", table(), FileWriteParams{Filename: "timings", Writer: &bytes.Buffer{}})
+		if err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+		if res.Timings != nil {
+			t.Errorf("Timings = %+v, want nil", res.Timings)
+		}
+	})
+
+	t.Run("multi", func(t *testing.T) {
+		params := MultiExportParams{FileWriteParams: FileWriteParams{Filename: "timings", Filepath: t.TempDir(), CollectTimings: true}}
+		res, err := ExportMulti(table(), []Format{FormatCSV, FormatHTML}, params)
+		if err != nil {
+			t.Fatalf("ExportMulti failed: %v", err)
+		}
+		csv, html := res[FormatCSV].Timings, res[FormatHTML].Timings
+		if csv == nil || html == nil || csv.Merge != 0 || html.Merge <= 0 || html.Total < csv.Total {
+			t.Errorf("Timings = %+v and %+v, want the HTML export to add up to the run", csv, html)
+		}
+	})
+}
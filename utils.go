@@ -5,7 +5,14 @@
 package spit
 
 import (
+	"cmp"
+	"database/sql/driver"
+	"encoding"
 	"fmt"
+	"maps"
+	"math/big"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +23,11 @@ import (
 func ConvertSliceToString(slice []interface{}, format string, separator string) (string, error) {
 	var strValues []string
 	for _, elem := range slice {
+		if text, ok := FormatDuration(elem, format); ok {
+			strValues = append(strValues, text)
+			continue
+		}
+		elem = NormalizeValue(elem)
 		if format != "" {
 			var err error
 			elem, err = FormatValue(elem, format)
@@ -28,9 +40,112 @@ func ConvertSliceToString(slice []interface{}, format string, separator string)
 	return strings.Join(strValues, separator), nil
 }
 
+// uniqueName returns name, or name with the first numeric suffix ("id_2", "id_3"...) not in used,
+// and marks the returned name as used.
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", name, n)
+	}
+	used[unique] = true
+	return unique
+}
+
+// Decimal is implemented by arbitrary-precision decimal types such as shopspring/decimal.Decimal.
+// Such values are exported as float64 numbers, or as their text (fmt.Stringer) when the float64
+// would lose digits.
+type Decimal interface {
+	Float64() (f float64, exact bool)
+}
+
+// NormalizeValue converts rich value types to the basic types understood by every backend.
+// Applied in order:
+//   - time values, images and basic types are returned unchanged
+//   - nil pointers of any other type become nil
+//   - driver.Valuer (e.g. sql.NullString) is replaced by its driver value; SQL NULL becomes nil
+//   - *big.Int becomes an int64 when it fits, otherwise its decimal string
+//   - *big.Float and *big.Rat become a float64 when exactly representable, otherwise a decimal string
+//   - Decimal becomes a float64 unless it loses digits, then its text representation
+//   - []byte becomes a string
+//   - encoding.TextMarshaler and fmt.Stringer become their text representation
+//
+// Any other value is returned unchanged.
+func NormalizeValue(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, time.Time, *time.Time, []interface{}, Image, *Image:
+		return value
+	}
+
+	// Nil pointers would panic in their methods (e.g. (*url.URL).String)
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case driver.Valuer:
+		dv, err := v.Value()
+		if err != nil {
+			return value
+		}
+		if dv == nil {
+			return nil
+		}
+		return NormalizeValue(dv)
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64()
+		}
+		return v.String()
+	case *big.Float:
+		if f, accuracy := v.Float64(); accuracy == big.Exact {
+			return f
+		}
+		return v.Text('f', -1)
+	case *big.Rat:
+		if f, exact := v.Float64(); exact {
+			return f
+		}
+		return v.FloatString(10)
+	case Decimal:
+		// shopspring reports most values (e.g. 19.99) as inexact, as their binary value differs
+		// from the decimal one; only keep the text when the float64 loses digits
+		f, exact := v.Float64()
+		if stringer, ok := v.(fmt.Stringer); ok && !exact {
+			if text := stringer.String(); strconv.FormatFloat(f, 'f', -1, 64) != text {
+				return text
+			}
+		}
+		return f
+	case []byte:
+		return string(v)
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return value
+		}
+		return string(text)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
+
 // FormatValue applies the specified format to a given value.
-// Supports time.Time and string values that can be parsed as dates.
+// A format naming a registered formatter (see RegisterFormatter) delegates to it; otherwise
+// the format is a duration format applied to time.Duration values (see FormatDuration) or a time
+// layout applied to time.Time values.
 func FormatValue(value interface{}, format string) (interface{}, error) {
+	if formatter, ok := LookupFormatter(format); ok {
+		formatted, err := formatter(value)
+		if err != nil {
+			return nil, fmt.Errorf("formatter %q failed: %w", format, err)
+		}
+		return formatted, nil
+	}
+	if text, ok := FormatDuration(value, format); ok {
+		return text, nil
+	}
 	switch v := value.(type) {
 	case time.Time:
 		if format != "" {
@@ -107,3 +222,8 @@ func parseAsBool(s string) (bool, error) {
 		return false, fmt.Errorf("cannot parse '%s' as boolean", s)
 	}
 }
+
+// sortedKeys returns the keys of a map in ascending order, for deterministic iteration.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
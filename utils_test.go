@@ -1,10 +1,37 @@
 package spit
 
 import (
+	"database/sql"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
 	"testing"
 	"time"
 )
 
+// testDecimal mimics arbitrary-precision decimal types such as shopspring/decimal.Decimal.
+type testDecimal struct{ f float64 }
+
+func (d testDecimal) Float64() (float64, bool) { return d.f, false }
+
+// testPreciseDecimal mimics shopspring/decimal.Decimal: a fmt.Stringer reporting every value whose
+// binary form differs from its decimal one as inexact.
+type testPreciseDecimal string
+
+func (d testPreciseDecimal) Float64() (float64, bool) {
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f, false
+}
+
+func (d testPreciseDecimal) String() string { return string(d) }
+
+// testStatus is a named type rendered through fmt.Stringer.
+type testStatus int
+
+func (s testStatus) String() string { return [...]string{"open", "closed"}[s] }
+
 func TestConvertSliceToString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -197,3 +224,41 @@ func TestParseDate(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeValue(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{name: "Basic type unchanged", value: 42, expected: 42},
+		{name: "Time unchanged", value: now, expected: now},
+		{name: "Valid sql.NullString", value: sql.NullString{String: "x", Valid: true}, expected: "x"},
+		{name: "SQL NULL", value: sql.NullInt64{}, expected: nil},
+		{name: "sql.NullTime keeps time", value: sql.NullTime{Time: now, Valid: true}, expected: now},
+		{name: "Small big.Int", value: big.NewInt(7), expected: int64(7)},
+		{name: "Huge big.Int", value: huge, expected: "123456789012345678901234567890"},
+		{name: "Exact big.Float", value: big.NewFloat(1.5), expected: 1.5},
+		{name: "Exact big.Rat", value: big.NewRat(1, 4), expected: 0.25},
+		{name: "Inexact big.Rat", value: big.NewRat(1, 3), expected: "0.3333333333"},
+		{name: "Decimal", value: testDecimal{19.99}, expected: 19.99},
+		{name: "Decimal Stringer", value: testPreciseDecimal("19.99"), expected: 19.99},
+		{name: "Inexact Decimal", value: testPreciseDecimal("0.1000000000000000000001"), expected: "0.1000000000000000000001"},
+		{name: "Nil Stringer pointer", value: (*url.URL)(nil), expected: nil},
+		{name: "Nil big.Int", value: (*big.Int)(nil), expected: nil},
+		{name: "Bytes", value: []byte("raw"), expected: "raw"},
+		{name: "TextMarshaler", value: net.IPv4(10, 0, 0, 1), expected: "10.0.0.1"},
+		{name: "Stringer", value: testStatus(1), expected: "closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeValue(tt.value); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("NormalizeValue(%#v) = %#v, want %#v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
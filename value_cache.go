@@ -0,0 +1,49 @@
+// value_cache.go - Processed-value cache.
+//
+// This file implements a cache of processed cell values for a single export run. Writing a cell and
+// comparing it for vertical and horizontal merging all need the value returned by ProcessValue;
+// caching it by data row and column means each cell is processed once per run.
+
+package spit
+
+import "reflect"
+
+// valueKey identifies a data cell: the row (by identity) and the leaf column.
+type valueKey struct {
+	row    uintptr
+	column *Column
+}
+
+// processedValue is a cached ProcessValue result.
+type processedValue struct {
+	value interface{}
+	err   error
+}
+
+// valueCache caches processed values by data cell.
+type valueCache map[valueKey]processedValue
+
+// CacheValues returns a shallow copy of the table that caches processed values (see
+// ProcessCellValue) for a single export run. Backends call it once per run on the prepared table;
+// the cache is never shared with the original table or with other runs.
+func (t *Table) CacheValues() *Table {
+	c := *t
+	c.values = make(valueCache)
+	return &c
+}
+
+// ProcessCellValue returns ops.ProcessValue(value, column.Format) for the given data row and
+// column, reusing the previous result when the table caches values (see CacheValues).
+// A nil table processes the value without caching.
+func (t *Table) ProcessCellValue(ops TableOperations, item Data, column *Column, value interface{}) (interface{}, error) {
+	if t == nil || t.values == nil || item == nil {
+		return t.processCellValue(ops, column, value)
+	}
+	key := valueKey{row: reflect.ValueOf(item).Pointer(), column: column}
+	if cached, ok := t.values[key]; ok {
+		return cached.value, cached.err
+	}
+	processed, err := t.processCellValue(ops, column, value)
+	t.values[key] = processedValue{processed, err}
+	return processed, err
+}
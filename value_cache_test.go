@@ -0,0 +1,34 @@
+package spit
+
+import "testing"
+
+func TestTable_CacheValues(t *testing.T) {
+	calls := 0
+	if err := RegisterFormatter("counted", func(v interface{}) (interface{}, error) {
+		calls++
+		return v, nil
+	}); err != nil {
+		t.Fatalf("RegisterFormatter failed: %v", err)
+	}
+	defer UnregisterFormatter("counted")
+
+	merge := NewMergeRules(MergeConditions{MergeConditionIdentical}, MergeConditions{MergeConditionIdentical})
+	table := NewTable(DataSlice{
+		{"a": "x", "b": "x"},
+		{"a": "x", "b": "y"},
+		{"a": "z", "b": "y"},
+	}, Columns{
+		NewColumn("a", "A").WithFormat("counted").WithMerge(merge),
+		NewColumn("b", "B").WithFormat("counted").WithMerge(merge),
+	}, true)
+
+	if _, err := ExportHTML(table, HTMLOptions{}, FileWriteParams{Filename: "cached", Filepath: t.TempDir()}); err != nil {
+		t.Fatalf("ExportHTML failed: %v", err)
+	}
+	if calls != 6 {
+		t.Errorf("expected each of the 6 cells to be processed once, got %d calls", calls)
+	}
+	if table.values != nil {
+		t.Error("CacheValues should not attach a cache to the original table")
+	}
+}
@@ -0,0 +1,153 @@
+// warnings.go - Structured export warnings.
+//
+// This file implements the non-fatal issues reported during an export (a merge that could not be
+// applied, a style rejected by the backend, an unsupported sheet feature): besides being logged,
+// each one is recorded as an ExportWarning returned in FileWriteResult.Warnings and passed to the
+// optional FileWriteParams.OnWarning hook, so callers can handle them programmatically. Tables in
+// strict mode (see Table.ErrorMode) fail the export with their first warning instead.
+
+package spit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WarningPhase identifies the export step that reported a warning.
+type WarningPhase string
+
+const (
+	WarningPhaseHeader WarningPhase = "header" // Writing header rows
+	WarningPhaseData   WarningPhase = "data"   // Writing data rows
+	WarningPhaseMerge  WarningPhase = "merge"  // Merging cells
+	WarningPhaseStyle  WarningPhase = "style"  // Applying styles and borders
+	WarningPhaseSheet  WarningPhase = "sheet"  // Sheet features (column widths, outlines, filters, tables, names)
+	// WarningPhaseValidation reports data cells failing a column validation (see Column.WithValidation)
+	WarningPhaseValidation WarningPhase = "validation"
+	// WarningPhaseCapability reports features degraded for a backend lacking them (see Capabilities)
+	WarningPhaseCapability WarningPhase = "capability"
+)
+
+// ErrorMode defines how an export handles the issues it reports as warnings.
+type ErrorMode int
+
+const (
+	// ErrorLenient collects warnings and completes the export (default).
+	ErrorLenient ErrorMode = iota
+
+	// ErrorStrict fails the export with its first warning, e.g. for CI pipelines.
+	ErrorStrict
+)
+
+// ExportWarning is a non-fatal issue reported during an export.
+type ExportWarning struct {
+	Phase   WarningPhase // Export step that reported the warning
+	Sheet   string       // Sheet name (XLSX only)
+	Cell    string       // Sheet cell reference (e.g. "B3"), empty when not tied to a cell
+	Message string       // Human-readable description
+	Err     error        // Underlying error, if any
+}
+
+// Error returns the warning as a single line, e.g. "style Sheet1!B3: Failed to apply cell style: ...".
+func (w ExportWarning) Error() string {
+	location := w.Cell
+	if w.Sheet != "" && w.Cell != "" {
+		location = quoteSheetName(w.Sheet) + "!" + w.Cell
+	} else if w.Sheet != "" {
+		location = quoteSheetName(w.Sheet)
+	}
+	message := w.Message
+	if w.Err != nil {
+		message = fmt.Sprintf("%s: %v", message, w.Err)
+	}
+	if location == "" {
+		return fmt.Sprintf("%s: %s", w.Phase, message)
+	}
+	return fmt.Sprintf("%s %s: %s", w.Phase, location, message)
+}
+
+// Unwrap returns the underlying error, so errors.Is and errors.As see through warnings.
+func (w ExportWarning) Unwrap() error {
+	return w.Err
+}
+
+// warningLog collects the warnings of an export run. Shared by the copies of a prepared table.
+type warningLog struct {
+	mu       sync.Mutex
+	sheet    string
+	hook     func(ExportWarning)
+	warnings []ExportWarning
+	strict   bool   // Whether the first warning fails the export (see ErrorStrict)
+	logger   Logger // Logger of the run (see FileWriteParams.Logger), nil for the global logger
+}
+
+// withWarnings returns a shallow copy of t recording its warnings for the given sheet (empty for
+// formats without sheets), calling hook (optional) for each of them and logging them to logger
+// (nil for the global logger). The data cells failing a column validation are reported right
+// away, so every export surfaces them.
+func (t *Table) withWarnings(sheet string, hook func(ExportWarning), logger Logger) *Table {
+	c := *t
+	c.warnings = &warningLog{sheet: sheet, hook: hook, strict: t.ErrorMode == ErrorStrict, logger: logger}
+	c.reportValidations()
+	return &c
+}
+
+// exportWarnings returns the warnings recorded during the current export run.
+func (t *Table) exportWarnings() []ExportWarning {
+	if t == nil || t.warnings == nil {
+		return nil
+	}
+	t.warnings.mu.Lock()
+	defer t.warnings.mu.Unlock()
+	return append([]ExportWarning(nil), t.warnings.warnings...)
+}
+
+// warn logs a warning and records it for the current export run. cell is a sheet cell reference
+// (see cellRef) or empty; err is appended to the log fields when set.
+func (t *Table) warn(phase WarningPhase, cell, message string, err error, fields ...Field) {
+	if err != nil {
+		fields = append(fields, Error(err))
+	}
+	if t == nil || t.warnings == nil {
+		L().Warn(message, fields...)
+		return
+	}
+	if t.warnings.logger != nil {
+		t.warnings.logger.Warn(message, fields...)
+	} else {
+		L().Warn(message, fields...)
+	}
+
+	w := ExportWarning{Phase: phase, Sheet: t.warnings.sheet, Cell: cell, Message: message, Err: err}
+	t.warnings.mu.Lock()
+	t.warnings.warnings = append(t.warnings.warnings, w)
+	t.warnings.mu.Unlock()
+	if t.warnings.hook != nil {
+		t.warnings.hook(w)
+	}
+}
+
+// strictErr returns the first warning of the current export run as an error when the table is in
+// strict mode, nil otherwise. Export steps check it to stop at the first failure.
+func (t *Table) strictErr() error {
+	if t == nil || t.warnings == nil || !t.warnings.strict {
+		return nil
+	}
+	t.warnings.mu.Lock()
+	defer t.warnings.mu.Unlock()
+	if len(t.warnings.warnings) == 0 {
+		return nil
+	}
+	return t.warnings.warnings[0]
+}
+
+// cellRef returns the sheet reference (e.g. "B3") of a 1-based, table-relative cell.
+func (t *Table) cellRef(col, row int) string {
+	ref, err := excelize.CoordinatesToCellName(t.sheetCoords(col, row))
+	if err != nil {
+		return ""
+	}
+	return ref
+}
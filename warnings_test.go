@@ -0,0 +1,96 @@
+package spit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExportXLSX_warnings(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1}}, Columns{
+		NewColumn("g", "G").WithSubColumns(Columns{NewColumn("a", "A")}),
+	}, true).WithExcelTable(nil)
+
+	var hooked []ExportWarning
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{
+		Filename:  "warnings",
+		Filepath:  t.TempDir(),
+		OnWarning: func(w ExportWarning) { hooked = append(hooked, w) },
+	})
+	if err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+
+	if len(res.Warnings) != 1 || len(hooked) != 1 {
+		t.Fatalf("Warnings = %v, hooked = %v, want one warning", res.Warnings, hooked)
+	}
+	w := res.Warnings[0]
+	if w.Phase != WarningPhaseSheet || w.Sheet != "Data" || w.Message != "Excel tables require a single header row, table skipped" {
+		t.Errorf("warning = %+v", w)
+	}
+	if got, want := w.Error(), "sheet Data: Excel tables require a single header row, table skipped"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestTable_warn(t *testing.T) {
+	cause := errors.New("boom")
+	table := NewTable(nil, nil, true).WithStartPosition(2, 3).withWarnings("Q1 Sales", nil, nil)
+	table.warn(WarningPhaseStyle, table.cellRef(1, 1), "Failed to apply cell style", cause)
+
+	warnings := table.exportWarnings()
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1", len(warnings))
+	}
+	if got, want := warnings[0].Error(), "style 'Q1 Sales'!B3: Failed to apply cell style: boom"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(warnings[0], cause) {
+		t.Error("warnings should unwrap to their cause")
+	}
+
+	// Tables outside an export run only log
+	NewTable(nil, nil, true).warn(WarningPhaseData, "", "ignored", nil)
+}
+
+func TestErrorMode(t *testing.T) {
+	table := func(mode ErrorMode) *Table {
+		return NewTable(DataSlice{{"a": 1}, {"a": -2}}, Columns{
+			NewColumn("g", "G").WithSubColumns(Columns{NewColumn("a", "A").WithValidation("value >= 0", "")}),
+		}, true).WithExcelTable(nil).WithErrorMode(mode)
+	}
+	exports := []struct {
+		name   string
+		export func(t *Table, params FileWriteParams) (*FileWriteResult, error)
+	}{
+		{"xlsx", func(t *Table, params FileWriteParams) (*FileWriteResult, error) {
+			return ExportXLSX(NewSpreadsheetExcelize("Data", t), params)
+		}},
+		{"csv", func(t *Table, params FileWriteParams) (*FileWriteResult, error) { return ExportCSV(",", t, params) }},
+		{"html", func(t *Table, params FileWriteParams) (*FileWriteResult, error) {
+			return ExportHTML(t, HTMLOptions{}, params)
+		}},
+		{"markdown", ExportMarkdown},
+	}
+	for _, tt := range exports {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.export(table(ErrorLenient), FileWriteParams{Filename: "lenient", Filepath: t.TempDir()})
+			if err != nil || len(res.Warnings) == 0 {
+				t.Fatalf("lenient export = %v, %v; want warnings and no error", res, err)
+			}
+
+			var hooked []ExportWarning
+			_, err = tt.export(table(ErrorStrict), FileWriteParams{
+				Filename:  "strict",
+				Filepath:  t.TempDir(),
+				OnWarning: func(w ExportWarning) { hooked = append(hooked, w) },
+			})
+			var w ExportWarning
+			if !errors.As(err, &w) || w.Phase != WarningPhaseValidation {
+				t.Fatalf("strict export error = %v, want the validation warning", err)
+			}
+			if len(hooked) != 1 {
+				t.Errorf("hooked %d warnings, want the failing one only", len(hooked))
+			}
+		})
+	}
+}
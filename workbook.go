@@ -0,0 +1,174 @@
+// workbook.go - Incremental workbook updates.
+//
+// This file implements Workbook, a handle on an existing XLSX file for small scheduled updates of
+// a big report: patch a few cells or a block of values, append a table below the existing content
+// and save, keeping everything else (styles, charts, formulas, other sheets) untouched instead of
+// regenerating the whole workbook. Writes go through SpreadsheetExcelize, so patched cells and
+// appended tables behave exactly like exported ones.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Workbook is an open XLSX file being updated in place. Close it once done.
+type Workbook struct {
+	File      *excelize.File      // Underlying Excelize file
+	Path      string              // Path the workbook was opened from, written back by Save
+	OnWarning func(ExportWarning) // Optional: called for each non-fatal issue of appended tables
+
+	sheets   []SheetResult   // Tables appended since the workbook was opened
+	warnings []ExportWarning // Warnings of the appended tables
+	sparse   []SparseColumn  // Sparse columns compacted in the appended tables
+}
+
+// OpenWorkbook opens an existing XLSX file for incremental updates.
+func OpenWorkbook(path string) (*Workbook, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workbook %s: %w", path, err)
+	}
+	return &Workbook{File: f, Path: path}, nil
+}
+
+// sheet returns the spreadsheet writing cells of the named sheet, failing when it does not exist.
+func (w *Workbook) sheet(name string) (*SpreadsheetExcelize, error) {
+	if index, err := w.File.GetSheetIndex(name); err != nil || index == -1 {
+		return nil, fmt.Errorf("sheet %q does not exist", name)
+	}
+	return NewSpreadsheetExcelize(name, nil).WithFile(w.File), nil
+}
+
+// patchValue writes a single value, keeping the existing cell style. Values are normalized (see
+// NormalizeValue) and images inserted as pictures.
+func patchValue(s *SpreadsheetExcelize, col, row int, value interface{}) error {
+	if img, ok := asImage(value); ok {
+		return s.SetCellImage(col, row, img)
+	}
+	return s.SetCellValue(col, row, NormalizeValue(value))
+}
+
+// PatchCell sets the value of a 1-based cell of an existing sheet. The cell keeps its style.
+func (w *Workbook) PatchCell(sheet string, col, row int, value interface{}) error {
+	s, err := w.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	if err := patchValue(s, col, row, value); err != nil {
+		return fmt.Errorf("failed to patch cell (%d, %d) of sheet %q: %w", col, row, sheet, err)
+	}
+	return nil
+}
+
+// PatchRange sets a block of values of an existing sheet, row by row, starting at the 1-based
+// top-left cell (col, row). Cells keep their style; nil values clear the cell.
+func (w *Workbook) PatchRange(sheet string, col, row int, values [][]interface{}) error {
+	s, err := w.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	for i, rowValues := range values {
+		for j, value := range rowValues {
+			if err := patchValue(s, col+j, row+i, value); err != nil {
+				return fmt.Errorf("failed to patch cell (%d, %d) of sheet %q: %w", col+j, row+i, sheet, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AppendTable writes the table below the last non-empty row of the sheet, leaving gap empty rows
+// in between, and returns where it landed. The sheet is created when missing (the table then
+// starts on row 1) and the table keeps its start column. The caller's table is not modified, and
+// the active sheet of the workbook is preserved.
+func (w *Workbook) AppendTable(sheet string, t *Table, gap int) (SheetResult, error) {
+	if t == nil {
+		return SheetResult{}, fmt.Errorf("no table provided")
+	}
+
+	startRow := 1
+	if index, err := w.File.GetSheetIndex(sheet); err == nil && index != -1 {
+		rows, err := w.File.GetRows(sheet)
+		if err != nil {
+			return SheetResult{}, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+		}
+		if len(rows) > 0 {
+			startRow = len(rows) + max(gap, 0) + 1
+		}
+	}
+
+	appended := *t
+	appended.WithStartPosition(max(t.StartCol, 1), startRow)
+
+	active := w.File.GetActiveSheetIndex()
+	defer w.File.SetActiveSheet(active)
+
+	params := FileWriteParams{OnWarning: w.OnWarning}
+	params.Seed = params.resolveSeed()
+	x := &xlsx{
+		spreadsheet: NewSpreadsheetExcelize(sheet, &appended).WithFile(w.File),
+		params:      params,
+	}
+	if err := x.writeData(); err != nil {
+		return SheetResult{}, fmt.Errorf("failed to append table to sheet %q: %w", sheet, err)
+	}
+	w.sheets = append(w.sheets, x.result)
+	w.warnings = append(w.warnings, x.table.exportWarnings()...)
+	w.sparse = append(w.sparse, x.table.sparseColumns(x.result.Name)...)
+	return x.result, nil
+}
+
+// RecalcHint asks spreadsheet applications to recalculate every formula when the workbook is next
+// opened, so formulas depending on patched cells never show stale cached results.
+func (w *Workbook) RecalcHint() error {
+	fullCalcOnLoad := true
+	if err := w.File.SetCalcProps(&excelize.CalcPropsOptions{FullCalcOnLoad: &fullCalcOnLoad}); err != nil {
+		return fmt.Errorf("failed to set calculation properties: %w", err)
+	}
+	return nil
+}
+
+// Save writes the workbook back to the file it was opened from. The result lists the appended
+// tables and their warnings.
+func (w *Workbook) Save() (*FileWriteResult, error) {
+	if err := w.File.SaveAs(w.Path); err != nil {
+		return nil, fmt.Errorf("failed to save workbook %s: %w", w.Path, err)
+	}
+	L().Info("Workbook saved", String("filePath", w.Path))
+	return &FileWriteResult{
+		Filepath:      w.Path,
+		Filename:      filepath.Base(w.Path),
+		Sheets:        w.sheets,
+		Warnings:      w.warnings,
+		SparseColumns: w.sparse,
+	}, nil
+}
+
+// SaveAs writes the workbook as a new file (or sink) with the given parameters, leaving the
+// original file untouched. The ".xlsx" extension is added when params.Extension is empty.
+func (w *Workbook) SaveAs(params FileWriteParams) (*FileWriteResult, error) {
+	if params.Extension == "" {
+		params.Extension = FormatXSLX.String()
+	}
+	result, err := params.WriteToFile(func(writer io.Writer) error {
+		_, err := w.File.WriteTo(writer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Sheets = w.sheets
+	result.Warnings = w.warnings
+	result.SparseColumns = w.sparse
+	return result, nil
+}
+
+// Close releases the resources of the workbook without saving it.
+func (w *Workbook) Close() error {
+	return w.File.Close()
+}
@@ -0,0 +1,108 @@
+package spit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWorkbook_patchAndAppend(t *testing.T) {
+	dir := t.TempDir()
+	report := NewTable(DataSlice{
+		{"region": "North", "amount": 100},
+		{"region": "South", "amount": 250},
+	}, Columns{NewColumn("region", "Region"), NewColumn("amount", "Amount")}, true)
+	res, err := ExportXLSXSheets([]Spreadsheet{
+		NewSpreadsheetExcelize("Report", report),
+		NewSpreadsheetExcelize("Notes", NewTable(DataSlice{{"note": "n/a"}}, Columns{NewColumn("note", "Note")}, true)),
+	}, FileWriteParams{Filename: "report", Filepath: dir})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets failed: %v", err)
+	}
+
+	wb, err := OpenWorkbook(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenWorkbook failed: %v", err)
+	}
+	defer func() { _ = wb.Close() }()
+	active := wb.File.GetActiveSheetIndex()
+
+	if err := wb.PatchCell("Report", 2, 2, 120); err != nil {
+		t.Fatalf("PatchCell failed: %v", err)
+	}
+	if err := wb.PatchRange("Report", 1, 3, [][]interface{}{{"West", 300}}); err != nil {
+		t.Fatalf("PatchRange failed: %v", err)
+	}
+	if err := wb.PatchCell("Missing", 1, 1, "x"); err == nil {
+		t.Error("expected an error when patching a missing sheet")
+	}
+
+	appended := NewTable(DataSlice{{"region": "East", "amount": 75}}, Columns{
+		NewColumn("region", "Region"),
+		NewColumn("amount", "Amount"),
+	}, true)
+	result, err := wb.AppendTable("Report", appended, 1)
+	if err != nil {
+		t.Fatalf("AppendTable failed: %v", err)
+	}
+	if got := result.TableRange.String(); got != "A5:B6" {
+		t.Errorf("appended table range = %q, want A5:B6", got)
+	}
+	if appended.StartRow != 0 {
+		t.Errorf("caller table start row modified: %d", appended.StartRow)
+	}
+	if _, err := wb.AppendTable("Extra", appended, 1); err != nil {
+		t.Fatalf("AppendTable to a new sheet failed: %v", err)
+	}
+	if got := wb.File.GetActiveSheetIndex(); got != active {
+		t.Errorf("active sheet changed from %d to %d", active, got)
+	}
+	if err := wb.RecalcHint(); err != nil {
+		t.Fatalf("RecalcHint failed: %v", err)
+	}
+
+	saved, err := wb.Save()
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if len(saved.Sheets) != 2 || saved.Filepath != res.Filepath {
+		t.Errorf("unexpected save result: %+v", saved)
+	}
+	copied, err := wb.SaveAs(FileWriteParams{Filename: "copy", Filepath: dir})
+	if err != nil {
+		t.Fatalf("SaveAs failed: %v", err)
+	}
+	if copied.Filepath != filepath.Join(dir, "copy.xlsx") {
+		t.Errorf("copy path = %q", copied.Filepath)
+	}
+
+	f, err := excelize.OpenFile(res.Filepath)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	rows, err := f.GetRows("Report")
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	want := [][]string{{"Region", "Amount"}, {"North", "120"}, {"West", "300"}, nil, {"Region", "Amount"}, {"East", "75"}}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %v, want %v", rows, want)
+	}
+	for i := range want {
+		if len(rows[i]) != len(want[i]) || len(want[i]) > 0 && (rows[i][0] != want[i][0] || rows[i][1] != want[i][1]) {
+			t.Errorf("row %d = %v, want %v", i+1, rows[i], want[i])
+		}
+	}
+	if notes, _ := f.GetCellValue("Notes", "A2"); notes != "n/a" {
+		t.Errorf("other sheets should be preserved, got %q", notes)
+	}
+	if extra, _ := f.GetCellValue("Extra", "A2"); extra != "East" {
+		t.Errorf("Extra!A2 = %q, want East", extra)
+	}
+	props, err := f.GetCalcProps()
+	if err != nil || props.FullCalcOnLoad == nil || !*props.FullCalcOnLoad {
+		t.Errorf("expected full calculation on load, got %+v (%v)", props, err)
+	}
+}
@@ -29,20 +29,38 @@ func ExportXLSXSheets(sheets []Spreadsheet, params FileWriteParams) (*FileWriteR
 		params.Extension = FormatXSLX.String()
 	}
 
+	// Chunked tables are written one part per sheet, or one part per file
+	sheets, parts, err := chunkSheets(sheets)
+	if err != nil {
+		return nil, err
+	}
+	if parts != nil {
+		e := sheets[0].(*SpreadsheetExcelize)
+		return exportParts(parts, params, func(part *Table, params FileWriteParams) (*FileWriteResult, error) {
+			return ExportXLSX(e.forPart(e.SheetName, part), params)
+		})
+	}
+
+	// Every sheet shares the seed of the run, so the whole workbook can be reproduced
+	params.Seed = params.resolveSeed()
+	params.quota = params.resolveQuota()
+	params.progress = params.resolveProgress()
+	params.timings = params.resolveTimings()
+
 	firstSheet := sheets[0]
 
 	// Ensure the spreadsheet file is initialized
 	f := firstSheet.GetFile()
 	if f == nil || reflect.ValueOf(f).IsNil() {
-		L().Debug("No existing spreadsheet file found, creating new one")
+		params.logger().Debug("No existing spreadsheet file found, creating new one")
 		if err := firstSheet.CreateNewFile(); err != nil {
-			L().Error("Failed to create new XLSX file", Error(err))
+			params.logger().Error("Failed to create new XLSX file", Error(err))
 			return nil, fmt.Errorf("failed to create new XLSX file: %w", err)
 		}
 
 		defer func() {
 			if err := firstSheet.Close(); err != nil {
-				L().Warn("Error closing spreadsheet", Error(err))
+				params.logger().Warn("Error closing spreadsheet", Error(err))
 			}
 		}()
 	}
@@ -55,30 +73,61 @@ func ExportXLSXSheets(sheets []Spreadsheet, params FileWriteParams) (*FileWriteR
 			sheetF := sheet.GetFile()
 			if sheetF == nil || reflect.ValueOf(sheetF).IsNil() {
 				if err := sheet.InitWithFile(f); err != nil {
-					L().Error("Failed to initialize sheet with existing file", Error(err))
+					params.logger().Error("Failed to initialize sheet with existing file", Error(err))
 					return nil, fmt.Errorf("failed to initialize sheet with existing file: %w", err)
 				}
 			}
 		}
 	}
 
-	L().Info("Starting XLSX export to file", String("filename", params.Filename))
+	params.logger().Info("Starting XLSX export to file", String("filename", params.Filename))
 
 	// Create a write function that handles the XLSX file creation and writing
+	var results []SheetResult
+	var warnings []ExportWarning
+	var sparse []SparseColumn
+	written := make(map[string]bool)
+	names := newSheetNamer()
+	shared := sharedSheets(sheets)
+	var restores []func()
+	defer func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}()
 	writeFunc := func(writer io.Writer) error {
+		streams := &streamedSheets{}
 		for _, sheet := range sheets {
 			xlsxConfig := &xlsx{
 				spreadsheet: sheet,
 				params:      params,
+				written:     written,
+				streams:     streams,
+				names:       names,
+				exclusive:   !shared[sheet],
 			}
+			restores = append(restores, func() {
+				if xlsxConfig.restoreName != "" {
+					sheet.SetSheetName(xlsxConfig.restoreName)
+				}
+			})
 
-			L().Debug("Writing data to sheet")
+			params.logger().Debug("Writing data to sheet")
 			if err := xlsxConfig.writeData(); err != nil {
 				return fmt.Errorf("failed to write data to XLSX file: %w", err)
 			}
+			results = append(results, xlsxConfig.result)
+			warnings = append(warnings, xlsxConfig.table.exportWarnings()...)
+			sparse = append(sparse, xlsxConfig.table.sparseColumns(xlsxConfig.result.Name)...)
 		}
 
-		L().Debug("Saving Excel file to writer")
+		// Streamed sheets are written once every table of the export is in place
+		defer params.timings.measure(timingSave)()
+		if err := streams.flush(); err != nil {
+			return err
+		}
+
+		params.logger().Debug("Saving Excel file to writer")
 		if err := firstSheet.SaveToWriter(writer); err != nil {
 			return fmt.Errorf("failed to write XLSX to writer: %w", err)
 		}
@@ -89,11 +138,14 @@ func ExportXLSXSheets(sheets []Spreadsheet, params FileWriteParams) (*FileWriteR
 	// Use the generic file writer to handle the actual file writing
 	result, err := params.WriteToFile(writeFunc)
 	if err != nil {
-		L().Error("Failed to write XLSX to file", Error(err))
+		params.logger().Error("Failed to write XLSX to file", Error(err))
 		return nil, err
 	}
+	result.Sheets = results
+	result.Warnings = warnings
+	result.SparseColumns = sparse
 
-	L().Info("XLSX export completed", String("filename", params.Filename))
+	params.logger().Info("XLSX export completed", String("filename", params.Filename))
 	return result, nil
 }
 
@@ -101,16 +153,68 @@ func ExportXLSXSheets(sheets []Spreadsheet, params FileWriteParams) (*FileWriteR
 type xlsx struct {
 	spreadsheet Spreadsheet
 	params      FileWriteParams
+	table       *Table          // Prepared table for the current write (see Table.Prepare); resolved in writeData
+	ops         TableOperations // Spreadsheet operations degraded to its capabilities; resolved in writeData
+	result      SheetResult     // Location of the written table, filled by writeData
+	written     map[string]bool // Sheets already written by the export, exempt from conflict policies (may be nil)
+	streams     *streamedSheets // Sheets streamed by the export (see FileWriteParams.Streaming); nil writes cell by cell
+	names       *sheetNamer     // Sheet names assigned by the export (may be nil)
+	exclusive   bool            // No other table of the export requests the sheet, so streamed rows are written as they are complete
+	restoreName string          // Sheet name of the caller's spreadsheet, restored after the export when writeData renamed it for a preview
+}
+
+// getTable returns the prepared table for the current write, falling back to the spreadsheet's table.
+func (xlsx *xlsx) getTable() *Table {
+	if xlsx.table != nil {
+		return xlsx.table
+	}
+	return xlsx.spreadsheet.GetTable()
+}
+
+// cells returns the operations used to write cells, degraded to the spreadsheet capabilities and
+// translated by the table start position (see Table.Offset) once the table for the current write
+// has been resolved.
+func (xlsx *xlsx) cells() TableOperations {
+	if xlsx.table != nil {
+		return xlsx.table.Offset(xlsx.ops)
+	}
+	return xlsx.spreadsheet
 }
 
 // writeData writes the provided table data to the XLSX file.
 // Handles sheet creation, header writing, data rows, merging, styling, and auto-fitting columns.
 func (xlsx *xlsx) writeData() error {
-	if xlsx.spreadsheet.GetSheetName() == "" {
-		xlsx.spreadsheet.SetSheetName("Sheet1")
+	source := xlsx.spreadsheet.GetTable()
+	if source == nil {
+		return fmt.Errorf("no table data provided")
+	}
+	sheetName := xlsx.spreadsheet.GetSheetName()
+	if sheetName == "" {
+		sheetName = "Sheet1"
+		xlsx.spreadsheet.SetSheetName(sheetName)
+	}
+
+	// Preview exports are titled accordingly so samples are never mistaken for full data; the
+	// caller's sheet name is restored once the export is saved (see restoreName)
+	current, requested := sheetName, sheetName
+	if source.Preview != nil {
+		requested = source.Preview.sheetTitleFor(sheetName)
+		xlsx.restoreName = sheetName
 	}
 
-	L().Debug("Creating sheet")
+	// Invalid names are sanitized, and distinct names sanitized alike made unique
+	if xlsx.names != nil && source.Preview != nil {
+		sheetName = xlsx.names.nameAs(previewSheetKey+current, requested)
+	} else if xlsx.names != nil {
+		sheetName = xlsx.names.name(requested)
+	} else {
+		sheetName = SanitizeSheetName(requested)
+	}
+	if sheetName != current {
+		xlsx.spreadsheet.SetSheetName(sheetName)
+	}
+
+	xlsx.params.logger().Debug("Creating sheet")
 	if err := xlsx.spreadsheet.CreateSheet(); err != nil {
 		return fmt.Errorf("failed to create sheet: %w", err)
 	}
@@ -119,14 +223,61 @@ func (xlsx *xlsx) writeData() error {
 		return fmt.Errorf("failed to set active sheet: %w", err)
 	}
 
-	t := xlsx.spreadsheet.GetTable()
-	if t == nil {
-		return fmt.Errorf("no table data provided")
+	// Content found in the sheet before the export is handled by the conflict policy; the following
+	// tables written into the same sheet by this export are placed as usual
+	skipRows := 0
+	if resolver, ok := xlsx.spreadsheet.(sheetConflictResolver); ok {
+		target := xlsx.spreadsheet.GetSheetName()
+		if !xlsx.written[target] {
+			rows, err := resolver.resolveSheetConflict()
+			if err != nil {
+				return err
+			}
+			skipRows = rows
+		}
+		if xlsx.written != nil {
+			xlsx.written[target] = true
+		}
 	}
 
+	// Prepared once the sheet exists, as creating it may resolve the table start position (e.g. a template start cell)
+	prepared := xlsx.params.timings.measure(timingPrepare)
+	t := source.withSeed(xlsx.params.Seed).Prepare().ForFormat(FormatXSLX).CacheValues().withWarnings(sheetName, xlsx.params.OnWarning, xlsx.params.Logger)
+	prepared()
+	if sheetName != requested {
+		t.warn(WarningPhaseSheet, "", "Sheet renamed", nil, String("requested", requested), String("sheet", sheetName))
+	}
+	t.HeaderOptions = t.excelTableHeaderOptions()
+	if positioner, ok := xlsx.spreadsheet.(sheetStartPositioner); ok {
+		if col, row, ok := positioner.startPosition(); ok {
+			t.StartCol, t.StartRow = col, row
+		}
+	}
+	if skipRows > 0 {
+		t.StartRow = skipRows + max(t.StartRow, 1)
+	}
+	// Features the spreadsheet implementation lacks are degraded up front rather than failing mid-export
+	caps := capabilitiesOf(xlsx.spreadsheet, AllCapabilities())
+	t = t.degrade(caps)
+	xlsx.table, xlsx.ops = t, t.degraded(xlsx.spreadsheet, caps)
+	if sheet := xlsx.streamSheet(t); sheet != nil {
+		xlsx.spreadsheet, xlsx.ops = sheet, t.degraded(sheet, caps)
+	}
+	if err := t.checkSheetBounds(); err != nil {
+		return err
+	}
+	if err := xlsx.params.quota.addTable(t); err != nil {
+		return err
+	}
+	xlsx.params.progress.track(t, progressPhasesSheet)
+	xlsx.params.timings.track(t)
+
+	xlsx.writeColumnStyles()
+
 	currentRow := 1
+	headerRow, headerRows := 0, 0
 	if len(t.Preamble) > 0 {
-		L().Debug("Writing preamble rows")
+		xlsx.params.logger().Debug("Writing preamble rows")
 		preambleRows, err := xlsx.writePreamble(currentRow)
 		if err != nil {
 			return fmt.Errorf("failed to write preamble: %w", err)
@@ -135,63 +286,154 @@ func (xlsx *xlsx) writeData() error {
 	}
 
 	if len(t.Columns) > 0 {
-		L().Debug("Writing headers")
-		headerRows, err := xlsx.writeHeaders(currentRow)
+		xlsx.params.logger().Debug("Writing headers")
+		rows, err := xlsx.writeHeaders(currentRow)
 		if err != nil {
 			return fmt.Errorf("failed to write headers: %w", err)
 		}
-		currentRow += headerRows
+		headerRow, headerRows = currentRow, rows
+		currentRow += rows
+	}
+	dataRow := currentRow
+
+	// Sheet settings come first, as streamed sheets may write their rows along with the data
+	xlsx.result = xlsx.sheetResult(sheetName, headerRow, headerRows, dataRow)
+	xlsx.autoFitColumns()
+	xlsx.writeHiddenColumns()
+	xlsx.writeDirection()
+	xlsx.writePrintSetup(xlsx.result)
+	outline := xlsx.startOutline()
+	if err := t.strictErr(); err != nil {
+		return err
+	}
+	window, err := xlsx.streamWindow(outline, caps.Formulas)
+	if err != nil {
+		return err
 	}
 
-	L().Debug("Writing data rows")
+	xlsx.params.logger().Debug("Writing data rows")
 	flatColumns := t.Columns.GetFlattenedColumns()
-	for _, item := range t.Data {
+	for rowIndex, item := range t.Data {
 		colIndex := 1
 		for _, column := range flatColumns {
 			if err := xlsx.writeCell(item, column, colIndex, currentRow); err != nil {
-				return fmt.Errorf("failed to write cell: %w", err)
+				return t.cellError(colIndex, currentRow, column, err)
 			}
 			colIndex++
 		}
+		if source := t.SourceRowIndex(rowIndex); t.AfterRowWrite != nil && source >= 0 {
+			t.AfterRowWrite(source, xlsx.rowRange(currentRow))
+		}
 		currentRow++
+		if err := t.advance(1); err != nil {
+			return err
+		}
+		if window != nil {
+			if err := window.written(rowIndex + 1); err != nil {
+				return err
+			}
+		}
+	}
+	if window != nil {
+		if err := window.finish(); err != nil {
+			return err
+		}
 	}
 
-	xlsx.autoFitColumns()
+	if err := t.RenderFooter(xlsx.ops, caps.Formulas); err != nil {
+		return fmt.Errorf("failed to write footer: %w", err)
+	}
+
+	if err := t.RenderTruncationNotice(xlsx.ops); err != nil {
+		return err
+	}
+
+	if window == nil {
+		if err := xlsx.completeRows(outline, caps.Formulas); err != nil {
+			return err
+		}
+	} else {
+		styled := t.timings.measure(timingStyle)
+		err := t.styleTrailer(t.Offset(xlsx.ops))
+		styled()
+		if err != nil {
+			return fmt.Errorf("failed to render styles: %w", err)
+		}
+	}
+	if err := t.strictErr(); err != nil {
+		return err
+	}
+
+	xlsx.writeMeta(sheetName)
+	xlsx.writeComments()
+	xlsx.writeNamedRanges(xlsx.result)
+	xlsx.writeDataName(xlsx.result)
+	xlsx.writeAutoFilter(xlsx.result)
+	xlsx.writeExcelTable(xlsx.result)
+	xlsx.writeExcelPivot(xlsx.result)
+	xlsx.writeCharts(xlsx.result)
+	xlsx.writeProtection()
+	if err := t.strictErr(); err != nil {
+		return err
+	}
+	t.progress.finish()
+
+	xlsx.params.logger().Debug("XLSX data writing complete.")
+	return nil
+}
+
+// completeRows writes the subtotal and cell formulas, merges, styles and outline levels of the
+// table once its rows are written.
+func (xlsx *xlsx) completeRows(outline outlineSetter, allowFormulas bool) error {
+	t := xlsx.table
+	if err := t.RenderSubtotals(xlsx.ops, allowFormulas); err != nil {
+		return fmt.Errorf("failed to write subtotals: %w", err)
+	}
 
-	if err := t.ProcessMerging(xlsx.spreadsheet); err != nil {
+	if err := t.RenderCellFormulas(xlsx.ops); err != nil {
+		return fmt.Errorf("failed to write cell formulas: %w", err)
+	}
+
+	if err := t.strictErr(); err != nil {
+		return err
+	}
+
+	if err := t.ProcessMerging(xlsx.ops); err != nil {
 		return fmt.Errorf("failed to process merging: %w", err)
 	}
 
-	if err := t.RenderStyles(xlsx.spreadsheet); err != nil {
+	if err := t.RenderStyles(xlsx.ops); err != nil {
 		return fmt.Errorf("failed to render styles: %w", err)
 	}
 
-	L().Debug("XLSX data writing complete.")
+	if outline != nil {
+		xlsx.writeOutlineRows(outline, 0, len(t.outlineLevels))
+	}
 	return nil
 }
 
 // writeHeaders writes multi-level headers to the Excel sheet starting at the given row.
 // Returns the number of header rows written and error if any header cell fails to write.
 func (xlsx *xlsx) writeHeaders(startRow int) (int, error) {
-	t := xlsx.spreadsheet.GetTable()
+	t := xlsx.getTable()
 	nbColumns := len(t.Columns)
 
 	if nbColumns == 0 {
-		L().Warn("No columns defined for headers")
+		t.warn(WarningPhaseHeader, "", "No columns defined for headers", nil)
 		return 0, nil
 	}
 
 	maxDepth := t.Columns.GetMaxDepth()
 	if maxDepth == 1 {
 		for i, column := range t.Columns {
-			if err := xlsx.spreadsheet.SetCellValue(i+1, startRow, column.Label); err != nil {
+			if err := xlsx.cells().SetCellValue(i+1, startRow, column.Label); err != nil {
 				return 0, fmt.Errorf("failed to set header cell value for column %s: %w", column.Name, err)
 			}
 		}
 		return 1, nil
 	}
 
-	L().Debug("Writing multi-level headers", Int("maxDepth", maxDepth))
+	xlsx.params.logger().Debug("Writing multi-level headers", Int("maxDepth", maxDepth))
 	maxRow := startRow + maxDepth - 1
 	if err := xlsx.writeHeaderRow(t.Columns, startRow, maxRow, 1); err != nil {
 		return 0, err
@@ -205,7 +447,7 @@ func (xlsx *xlsx) writeHeaderRow(columns Columns, currentRow, maxRow, startCol i
 	currentCol := startCol
 
 	for _, column := range columns {
-		if err := xlsx.spreadsheet.SetCellValue(currentCol, currentRow, column.Label); err != nil {
+		if err := xlsx.cells().SetCellValue(currentCol, currentRow, column.Label); err != nil {
 			return fmt.Errorf("failed to set header cell value for column %s at (%d, %d): %w", column.Name, currentCol, currentRow, err)
 		}
 
@@ -230,10 +472,10 @@ func (xlsx *xlsx) writeHeaderRow(columns Columns, currentRow, maxRow, startCol i
 // writePreamble writes free-form preamble rows to the sheet starting at startRow.
 // Returns the number of rows written.
 func (xlsx *xlsx) writePreamble(startRow int) (int, error) {
-	t := xlsx.spreadsheet.GetTable()
+	t := xlsx.getTable()
 	for i, row := range t.Preamble {
 		for j, val := range row.Values {
-			if err := xlsx.spreadsheet.SetCellValue(j+1, startRow+i, val); err != nil {
+			if err := xlsx.cells().SetCellValue(j+1, startRow+i, val); err != nil {
 				return 0, fmt.Errorf("failed to write preamble cell at (%d, %d): %w", j+1, startRow+i, err)
 			}
 		}
@@ -245,43 +487,54 @@ func (xlsx *xlsx) writePreamble(startRow int) (int, error) {
 // Looks up the value, processes formatting, and sets the cell value.
 // Special formats (formula, hyperlink, default) trigger dedicated Excelize operations.
 func (xlsx *xlsx) writeCell(item Data, column *Column, colIndex, rowIndex int) error {
-	value, err, found := item.Lookup(column.Name)
-	if err == nil && !found {
-		return nil
-	}
+	value, err, found := item.LookupColumn(column)
 	if err != nil {
 		return fmt.Errorf("error looking up value for column %s: %w", column.Name, err)
 	}
+	if text, ok := xlsx.table.CellPlaceholder(column, value, found); ok {
+		if err = xlsx.cells().SetCellValue(colIndex, rowIndex, text); err != nil {
+			return fmt.Errorf("error setting cell value for column %s at (%d, %d): %w", column.Name, colIndex, rowIndex, err)
+		}
+		return nil
+	}
+	if !found {
+		return nil
+	}
 
 	// Image values are inserted as cell-anchored pictures rather than text.
 	if img, ok := asImage(value); ok {
-		if err = xlsx.spreadsheet.SetCellImage(colIndex, rowIndex, img); err != nil {
+		if err = xlsx.cells().SetCellImage(colIndex, rowIndex, img); err != nil {
 			return fmt.Errorf("error setting image for column %s at (%d, %d): %w", column.Name, colIndex, rowIndex, err)
 		}
 		return nil
 	}
 
-	processedValue, err := xlsx.spreadsheet.ProcessValue(value, column.Format)
+	processedValue, err := xlsx.table.ProcessCellValue(xlsx.spreadsheet, item, column, value)
 	if err != nil {
 		return fmt.Errorf("error processing value %s for column %s: %w", value, column.Name, err)
 	}
+	if text, ok := processedValue.(string); ok {
+		if processedValue, err = xlsx.table.fitCellText(column, colIndex, rowIndex, text); err != nil {
+			return err
+		}
+	}
 
 	switch column.Format {
 	case ExcelizeFormatFormula:
 		formula := fmt.Sprintf("%v", processedValue)
-		if err = xlsx.spreadsheet.SetCellFormula(colIndex, rowIndex, formula); err != nil {
+		if err = xlsx.cells().SetCellFormula(colIndex, rowIndex, formula); err != nil {
 			return fmt.Errorf("error setting formula for column %s at (%d, %d): %w", column.Name, colIndex, rowIndex, err)
 		}
 	case ExcelizeFormatHyperlink:
 		link := fmt.Sprintf("%v", processedValue)
-		if err = xlsx.spreadsheet.SetCellValue(colIndex, rowIndex, link); err != nil {
+		if err = xlsx.cells().SetCellValue(colIndex, rowIndex, link); err != nil {
 			return fmt.Errorf("error setting cell value for column %s at (%d, %d): %w", column.Name, colIndex, rowIndex, err)
 		}
-		if err = xlsx.spreadsheet.SetCellHyperLink(colIndex, rowIndex, link); err != nil {
+		if err = xlsx.cells().SetCellHyperLink(colIndex, rowIndex, link); err != nil {
 			return fmt.Errorf("error setting hyperlink for column %s at (%d, %d): %w", column.Name, colIndex, rowIndex, err)
 		}
 	default:
-		if err = xlsx.spreadsheet.SetCellValue(colIndex, rowIndex, processedValue); err != nil {
+		if err = xlsx.cells().SetCellValue(colIndex, rowIndex, processedValue); err != nil {
 			return fmt.Errorf("error setting cell value for column %s at (%d, %d): %w", column.Name, colIndex, rowIndex, err)
 		}
 	}
@@ -293,15 +546,35 @@ func (xlsx *xlsx) writeCell(item Data, column *Column, colIndex, rowIndex int) e
 // Uses the column-specific width when set, otherwise falls back to a default width of 15.
 func (xlsx *xlsx) autoFitColumns() {
 	const defaultWidth = 15
-	flatColumns := xlsx.spreadsheet.GetTable().Columns.GetFlattenedColumns()
+	if xlsx.getTable().Transposed {
+		// Sheet columns hold labels and records rather than table columns: keep the sheet defaults
+		return
+	}
+	flatColumns := xlsx.getTable().Columns.GetFlattenedColumns()
 	for i, column := range flatColumns {
-		colLetter := xlsx.spreadsheet.GetColumnLetter(i + 1)
+		colLetter := xlsx.cells().GetColumnLetter(i + 1)
 		width := column.Width
 		if width <= 0 {
 			width = defaultWidth
 		}
 		if err := xlsx.spreadsheet.SetColumnWidth(colLetter, width); err != nil {
-			L().Warn("Failed to set column width", String("column", colLetter), Error(err))
+			xlsx.table.warn(WarningPhaseSheet, "", "Failed to set column width", err, String("column", colLetter))
 		}
 	}
 }
+
+// maxSheetNameLength is the maximum number of characters of an Excel sheet name.
+const maxSheetNameLength = 31
+
+// previewSheetKey prefixes the sheet namer keys of preview sheets, so a preview title never shares
+// the sheet of a table requesting that title.
+const previewSheetKey = "\x00preview:"
+
+// truncateSheetName shortens a sheet name to Excel's 31-character limit.
+func truncateSheetName(name string) string {
+	runes := []rune(name)
+	if len(runes) > maxSheetNameLength {
+		return string(runes[:maxSheetNameLength])
+	}
+	return name
+}
@@ -0,0 +1,347 @@
+// xlsx_import.go - XLSX import.
+//
+// This file implements ImportXLSX, which reads a sheet of an existing workbook into a Table: the
+// columns come from the header rows, nested headers being rebuilt from merged header cells, and the
+// data rows from the rows below them. Together with the exporters, it turns spit into a full
+// read/transform/write pipeline, e.g. to restyle or reshape a report produced elsewhere.
+
+package spit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// XLSXImportOptions contains the parameters of ImportXLSX.
+type XLSXImportOptions struct {
+	// HeaderRow is the 1-based row of the first header row (default: the first non-empty row).
+	// Rows above it, such as titles, are ignored.
+	HeaderRow int
+	// HeaderRows is the number of header rows (default: detected from the merged cells starting
+	// in the header, one row when there are none).
+	HeaderRows int
+	// InferTypes converts the values of each column to int64, float64, bool or time.Time like
+	// CSVImportOptions.InferTypes. The displayed values are tried first, then the raw cell values,
+	// so formatted numbers (e.g. "1,234.50" or "50%") are read as numbers.
+	InferTypes bool
+	// FillMergedCells repeats the value of merged data cells in every row and column they cover;
+	// otherwise only the top-left cell holds the value.
+	FillMergedCells bool
+}
+
+// ImportXLSX reads a sheet of an XLSX workbook into a table whose header is written on export. An
+// empty sheet name reads the active sheet. See ImportXLSXFile.
+func ImportXLSX(r io.Reader, sheet string, opts XLSXImportOptions) (*Table, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XLSX: %w", err)
+	}
+	defer f.Close()
+	return importXLSX(f, sheet, opts)
+}
+
+// ImportXLSXFile reads a sheet of an XLSX file into a table whose header is written on export. An
+// empty sheet name reads the active sheet.
+//
+// Each header cell becomes a column labelled after its value; a header cell merged over several
+// columns becomes a column group holding the columns below it. Leaf columns are named after the
+// labels of their groups and their own label, joined by KeyPathSeparator (e.g. "Q1.Sales"), with
+// column_<position> for blank labels and the position appended to duplicate names. Empty rows are
+// skipped and leading empty columns ignored.
+func ImportXLSXFile(path string, sheet string, opts XLSXImportOptions) (*Table, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workbook %s: %w", path, err)
+	}
+	defer f.Close()
+	return importXLSX(f, sheet, opts)
+}
+
+// xlsxRange is a merged range of an imported sheet, in 1-based coordinates.
+type xlsxRange struct {
+	col1, row1, col2, row2 int
+}
+
+// xlsxGrid holds the cells of an imported sheet, displayed and raw, in 1-based coordinates.
+type xlsxGrid struct {
+	values, raw [][]string
+	merges      map[[2]int]xlsxRange // Merged ranges by top-left cell
+}
+
+// value returns the displayed value of a cell, or "" when it is empty.
+func (g *xlsxGrid) value(col, row int) string {
+	return gridCell(g.values, col, row)
+}
+
+// gridCell returns a cell of a grid, or "" when it is out of range.
+func gridCell(grid [][]string, col, row int) string {
+	if row < 1 || row > len(grid) || col < 1 || col > len(grid[row-1]) {
+		return ""
+	}
+	return grid[row-1][col-1]
+}
+
+// importXLSX reads a sheet of an open workbook into a table.
+func importXLSX(f *excelize.File, sheet string, opts XLSXImportOptions) (*Table, error) {
+	if sheet == "" {
+		sheet = f.GetSheetName(f.GetActiveSheetIndex())
+	}
+	if index, err := f.GetSheetIndex(sheet); err != nil || index == -1 {
+		return nil, fmt.Errorf("sheet %q does not exist", sheet)
+	}
+
+	grid := &xlsxGrid{merges: map[[2]int]xlsxRange{}}
+	var err error
+	if grid.values, err = f.GetRows(sheet); err != nil {
+		return nil, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+	}
+	if grid.raw, err = f.GetRows(sheet, excelize.Options{RawCellValue: true}); err != nil {
+		return nil, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+	}
+	merged, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read merged cells of sheet %q: %w", sheet, err)
+	}
+	for _, m := range merged {
+		col1, row1, err1 := excelize.CellNameToCoordinates(m.GetStartAxis())
+		col2, row2, err2 := excelize.CellNameToCoordinates(m.GetEndAxis())
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		grid.merges[[2]int{col1, row1}] = xlsxRange{col1, row1, col2, row2}
+	}
+
+	headerRow := opts.HeaderRow
+	if headerRow <= 0 {
+		headerRow = 1
+		for headerRow <= len(grid.values) && emptyRecord(grid.values[headerRow-1]) {
+			headerRow++
+		}
+	}
+	if headerRow > len(grid.values) {
+		L().Debug("Imported XLSX", String("sheet", sheet), Int("rows", 0), Int("columns", 0))
+		return NewTable(DataSlice{}, Columns{}, true), nil
+	}
+
+	firstCol, lastCol := 0, 0
+	for _, record := range grid.values[headerRow-1:] {
+		for j, value := range record {
+			if value == "" {
+				continue
+			}
+			if firstCol == 0 || j+1 < firstCol {
+				firstCol = j + 1
+			}
+			lastCol = max(lastCol, j+1)
+		}
+	}
+
+	depth := opts.HeaderRows
+	if depth <= 0 {
+		depth = grid.headerDepth(headerRow)
+	}
+	depth = min(depth, len(grid.values)-headerRow+1)
+
+	if opts.FillMergedCells {
+		grid.fillMerged(headerRow + depth)
+	}
+
+	builder := &xlsxHeader{grid: grid, headerRow: headerRow, depth: depth, firstCol: firstCol, used: map[string]bool{}}
+	columns := builder.build(0, firstCol, lastCol, "")
+
+	var records [][]string
+	var raws [][]string
+	for row := headerRow + depth; row <= len(grid.values); row++ {
+		if emptyRecord(grid.values[row-1]) {
+			continue
+		}
+		record := make([]string, len(builder.leaves))
+		raw := make([]string, len(builder.leaves))
+		for j, col := range builder.cols {
+			record[j] = grid.value(col, row)
+			raw[j] = gridCell(grid.raw, col, row)
+		}
+		records = append(records, record)
+		raws = append(raws, raw)
+	}
+
+	data := make(DataSlice, len(records))
+	for i, record := range records {
+		row := make(Data, len(record))
+		for j, value := range record {
+			if value != "" {
+				row[builder.leaves[j].Name] = value
+			}
+		}
+		data[i] = row
+	}
+
+	if opts.InferTypes {
+		for j, column := range builder.leaves {
+			typed := records
+			values := columnValues(records, j)
+			typ, ok := inferCSVType(values)
+			if !ok {
+				typed = raws
+				if typ, ok = inferCSVType(columnValues(raws, j)); !ok {
+					continue
+				}
+			}
+			column.Format = typ.format
+			for i, record := range typed {
+				if record[j] != "" {
+					data[i][column.Name] = typ.convert(record[j])
+				}
+			}
+		}
+	}
+
+	L().Debug("Imported XLSX", String("sheet", sheet), Int("rows", len(data)), Int("columns", len(builder.leaves)))
+	return NewTable(data, columns, true), nil
+}
+
+// emptyRecord reports whether every value of a record is blank.
+func emptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// columnValues returns the j-th value of each record.
+func columnValues(records [][]string, j int) []string {
+	values := make([]string, len(records))
+	for i, record := range records {
+		values[i] = record[j]
+	}
+	return values
+}
+
+// headerDepth returns the number of header rows starting at headerRow: a header cell merged over
+// several columns is a group whose columns are on the row below it, and a header cell merged over
+// several rows extends the header down to its last row.
+func (g *xlsxGrid) headerDepth(headerRow int) int {
+	depth := 1
+	for changed := true; changed; {
+		changed = false
+		for _, m := range g.merges {
+			if m.row1 < headerRow || m.row1 >= headerRow+depth {
+				continue
+			}
+			need := m.row2 - headerRow + 1
+			if m.col2 > m.col1 {
+				need++
+			}
+			if need > depth {
+				depth, changed = need, true
+			}
+		}
+	}
+	return depth
+}
+
+// fillMerged repeats the value of the merged cells starting at or below firstRow in every cell
+// they cover.
+func (g *xlsxGrid) fillMerged(firstRow int) {
+	for _, m := range g.merges {
+		if m.row1 < firstRow {
+			continue
+		}
+		value, raw := g.value(m.col1, m.row1), gridCell(g.raw, m.col1, m.row1)
+		for row := m.row1; row <= m.row2; row++ {
+			for col := m.col1; col <= m.col2; col++ {
+				g.values = setGridCell(g.values, col, row, value)
+				g.raw = setGridCell(g.raw, col, row, raw)
+			}
+		}
+	}
+}
+
+// setGridCell sets a cell of a grid, growing it as needed.
+func setGridCell(grid [][]string, col, row int, value string) [][]string {
+	for len(grid) < row {
+		grid = append(grid, nil)
+	}
+	for len(grid[row-1]) < col {
+		grid[row-1] = append(grid[row-1], "")
+	}
+	grid[row-1][col-1] = value
+	return grid
+}
+
+// xlsxHeader rebuilds the columns of an imported sheet from its header rows.
+type xlsxHeader struct {
+	grid      *xlsxGrid
+	headerRow int
+	depth     int
+	firstCol  int
+	used      map[string]bool
+	leaves    Columns // Leaf columns, left to right
+	cols      []int   // Sheet column of each leaf column
+}
+
+// build returns the columns of the header cells of a header level (0-based) between two sheet
+// columns, prefixing names with the names of their groups.
+func (h *xlsxHeader) build(level, from, to int, prefix string) Columns {
+	var columns Columns
+	row := h.headerRow + level
+	for col := from; col <= to; {
+		end, bottom := col, row
+		if m, ok := h.grid.merges[[2]int{col, row}]; ok {
+			end, bottom = min(max(m.col2, col), to), m.row2
+		}
+		next := bottom - h.headerRow + 1
+		label := strings.TrimSpace(h.grid.value(col, row))
+		if next < h.depth && (end > col || h.labelsBelow(next, col, end)) {
+			if label == "" && end == col {
+				columns = append(columns, h.build(next, col, end, prefix)...)
+			} else {
+				group := h.column(label, col, prefix)
+				group.Columns = h.build(next, col, end, group.Name+KeyPathSeparator)
+				columns = append(columns, group)
+			}
+		} else {
+			for c := col; c <= end; c++ {
+				leafLabel := label
+				if c > col {
+					leafLabel = ""
+				}
+				leaf := h.column(leafLabel, c, prefix)
+				h.leaves = append(h.leaves, leaf)
+				h.cols = append(h.cols, c)
+				columns = append(columns, leaf)
+			}
+		}
+		col = end + 1
+	}
+	return columns
+}
+
+// labelsBelow reports whether a header cell below a header level holds a label between two sheet
+// columns.
+func (h *xlsxHeader) labelsBelow(level, from, to int) bool {
+	for row := h.headerRow + level; row < h.headerRow+h.depth; row++ {
+		for col := from; col <= to; col++ {
+			if strings.TrimSpace(h.grid.value(col, row)) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// column returns a column for a header label at a sheet column, named after its prefix and label.
+func (h *xlsxHeader) column(label string, col int, prefix string) *Column {
+	position := col - h.firstCol + 1
+	if label == "" {
+		label = fmt.Sprintf("column_%d", position)
+	}
+	column := newImportedColumn(h.used, prefix+label, position)
+	column.Label = label
+	return column
+}
@@ -0,0 +1,152 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// importFixture builds a workbook with a title row and nested headers:
+//
+//	Report
+//	Region | Q1            | Q2
+//	       | Sales | Units | Sales | Units
+func importFixture(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	rows := [][]interface{}{
+		{"Report"},
+		{"Region", "Q1", nil, "Q2"},
+		{nil, "Sales", "Units", "Sales", "Units"},
+		{"North", 1200.5, 3, 80, 1},
+		{},
+		{"South", 10, 2, 20, 4},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatalf("SetSheetRow failed: %v", err)
+		}
+	}
+	style, _ := f.NewStyle(&excelize.Style{NumFmt: 4}) // #,##0.00
+	_ = f.SetCellStyle("Sheet1", "B4", "B6", style)
+	for _, r := range [][2]string{{"A2", "A3"}, {"B2", "C2"}, {"D2", "E2"}} {
+		if err := f.MergeCell("Sheet1", r[0], r[1]); err != nil {
+			t.Fatalf("MergeCell failed: %v", err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("WriteToBuffer failed: %v", err)
+	}
+	return buf
+}
+
+func TestImportXLSX_nestedHeaders(t *testing.T) {
+	table, err := ImportXLSX(importFixture(t), "", XLSXImportOptions{HeaderRow: 2, InferTypes: true})
+	if err != nil {
+		t.Fatalf("ImportXLSX failed: %v", err)
+	}
+
+	if len(table.Columns) != 3 {
+		t.Fatalf("top-level columns = %d, want 3", len(table.Columns))
+	}
+	if c := table.Columns[0]; c.Label != "Region" || len(c.Columns) != 0 {
+		t.Errorf("column 0 = %q with %d sub-columns, want a Region leaf", c.Label, len(c.Columns))
+	}
+	for i, want := range []string{"Q1", "Q2"} {
+		group := table.Columns[i+1]
+		if group.Label != want || len(group.Columns) != 2 {
+			t.Fatalf("column %d = %q with %d sub-columns, want group %q of 2", i+1, group.Label, len(group.Columns), want)
+		}
+		if group.Columns[0].Label != "Sales" || group.Columns[0].Name != want+".Sales" {
+			t.Errorf("%s first sub-column = %q (%s), want Sales (%s.Sales)", want, group.Columns[0].Label, group.Columns[0].Name, want)
+		}
+	}
+
+	want := DataSlice{
+		{"Region": "North", "Q1.Sales": 1200.5, "Q1.Units": int64(3), "Q2.Sales": int64(80), "Q2.Units": int64(1)},
+		{"Region": "South", "Q1.Sales": 10.0, "Q1.Units": int64(2), "Q2.Sales": int64(20), "Q2.Units": int64(4)},
+	}
+	if len(table.Data) != len(want) {
+		t.Fatalf("rows = %d, want %d", len(table.Data), len(want))
+	}
+	for i, row := range want {
+		for k, v := range row {
+			if got := table.Data[i][k]; got != v {
+				t.Errorf("row %d %s = %#v, want %#v", i, k, got, v)
+			}
+		}
+	}
+}
+
+func TestImportXLSX_detectedHeader(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	_ = f.SetSheetName("Sheet1", "Data")
+	_ = f.SetSheetRow("Data", "B3", &[]interface{}{"name", "name", nil})
+	_ = f.SetSheetRow("Data", "B4", &[]interface{}{"Ada", "Lovelace", "x"})
+	_ = f.SetSheetRow("Data", "B5", &[]interface{}{"Alan"})
+	_ = f.SetSheetRow("Data", "B6", &[]interface{}{"", "Turing"})
+	_ = f.MergeCell("Data", "B5", "B6")
+	buf, _ := f.WriteToBuffer()
+	data := buf.Bytes()
+
+	table, err := ImportXLSX(bytes.NewReader(data), "Data", XLSXImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportXLSX failed: %v", err)
+	}
+	var names []string
+	for _, c := range table.Columns {
+		names = append(names, c.Name)
+	}
+	if len(names) != 3 || names[0] != "name" || names[1] != "name_2" || names[2] != "column_3" {
+		t.Errorf("column names = %v, want [name name_2 column_3]", names)
+	}
+	if len(table.Data) != 3 || table.Data[2]["name"] != nil {
+		t.Errorf("data = %v, want 3 rows with an empty merged cell", table.Data)
+	}
+
+	table, err = ImportXLSX(bytes.NewReader(data), "Data", XLSXImportOptions{FillMergedCells: true})
+	if err != nil {
+		t.Fatalf("ImportXLSX failed: %v", err)
+	}
+	if got := table.Data[2]["name"]; got != "Alan" {
+		t.Errorf("filled merged cell = %v, want Alan", got)
+	}
+
+	if _, err := ImportXLSX(bytes.NewReader(data), "Missing", XLSXImportOptions{}); err == nil {
+		t.Error("ImportXLSX of a missing sheet succeeded, want an error")
+	}
+}
+
+func TestImportXLSX_roundTrip(t *testing.T) {
+	columns := Columns{
+		NewColumn("region", "Region"),
+		{Name: "totals", Label: "Totals", Columns: Columns{
+			NewColumn("sales", "Sales"),
+			NewColumn("units", "Units"),
+		}},
+	}
+	data := DataSlice{
+		{"region": "North", "sales": 10, "units": 1},
+		{"region": "South", "sales": 20, "units": 2},
+	}
+	var buf bytes.Buffer
+	if _, err := ExportXLSX(NewSpreadsheetExcelize("Sheet1", NewTable(data, columns, true)), FileWriteParams{Filename: "report", Writer: &buf}); err != nil {
+		t.Fatalf("ExportXLSX failed: %v", err)
+	}
+
+	table, err := ImportXLSX(&buf, "Sheet1", XLSXImportOptions{InferTypes: true})
+	if err != nil {
+		t.Fatalf("ImportXLSX failed: %v", err)
+	}
+	if len(table.Columns) != 2 || len(table.Columns[1].Columns) != 2 || table.Columns[1].Label != "Totals" {
+		t.Fatalf("columns = %+v, want Region and a Totals group of 2", table.Columns)
+	}
+	if len(table.Data) != 2 || table.Data[1]["Totals.Sales"] != int64(20) {
+		t.Errorf("data = %v, want the exported rows", table.Data)
+	}
+}
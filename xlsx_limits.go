@@ -0,0 +1,111 @@
+// xlsx_limits.go - Cell text and sheet name limits.
+//
+// This file implements the checks of the limits Excel puts on cell text and sheet names. Text
+// longer than a cell holds is truncated with a warning or fails the export (see
+// Table.WithCellOverflow), rather than being cut short silently. Sheet names are sanitized before
+// sheets are created (see SanitizeSheetName): invalid characters are replaced and long names
+// truncated, and distinct names of an export sanitized to the same name are made unique.
+
+package spit
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellOverflowMode defines how XLSX exports write text longer than a cell holds
+// (excelize.TotalCellChars, 32,767 characters).
+type CellOverflowMode int
+
+const (
+	// CellOverflowTruncate writes the first characters of the text and reports a warning (default).
+	CellOverflowTruncate CellOverflowMode = iota
+
+	// CellOverflowError fails the export with ErrCellTextTooLong.
+	CellOverflowError
+)
+
+// ErrCellTextTooLong is the cause of the CellWriteError returned by exports in CellOverflowError
+// mode writing text longer than a cell holds.
+var ErrCellTextTooLong = fmt.Errorf("cell text exceeds %d characters", excelize.TotalCellChars)
+
+// invalidSheetNameChars lists the characters Excel does not allow in sheet names.
+const invalidSheetNameChars = ":\\/?*[]"
+
+// WithCellOverflow sets how XLSX exports write text longer than a cell holds.
+func (t *Table) WithCellOverflow(mode CellOverflowMode) *Table {
+	t.CellOverflow = mode
+	return t
+}
+
+// fitCellText returns the text of a data cell of the column fitting in a cell, truncated with a
+// warning in CellOverflowTruncate mode. The cell is 1-based and table-relative.
+func (t *Table) fitCellText(column *Column, col, row int, text string) (string, error) {
+	if utf8.RuneCountInString(text) <= excelize.TotalCellChars {
+		return text, nil
+	}
+	if t.CellOverflow == CellOverflowError {
+		return "", ErrCellTextTooLong
+	}
+	t.warn(WarningPhaseData, t.cellRef(col, row), "Cell text truncated", ErrCellTextTooLong,
+		String("column", column.Name),
+		Int("length", utf8.RuneCountInString(text)))
+	return string([]rune(text)[:excelize.TotalCellChars]), nil
+}
+
+// SanitizeSheetName returns a valid sheet name for name: the characters Excel does not allow
+// (: \ / ? * [ ]) are replaced with "_", leading and trailing quotes are removed and the name is
+// truncated to 31 characters. Empty names become "Sheet1".
+func SanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidSheetNameChars, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = truncateSheetName(strings.Trim(name, "'"))
+	// Truncation may leave a trailing quote
+	if name = strings.TrimRight(name, "'"); name == "" {
+		return "Sheet1"
+	}
+	return name
+}
+
+// sheetNamer assigns the sheet names of an export: each name is sanitized, and distinct names
+// sanitized to the same sheet name, compared case-insensitively like Excel does, get a " (<n>)"
+// suffix. Tables sharing a name (e.g. a SheetLayout) keep sharing the sheet.
+type sheetNamer struct {
+	assigned map[string]string // Sheet names by key (the requested name, see nameAs)
+	owners   map[string]string // Keys by lower-cased sheet name
+}
+
+// newSheetNamer creates a sheetNamer with no assigned names.
+func newSheetNamer() *sheetNamer {
+	return &sheetNamer{assigned: map[string]string{}, owners: map[string]string{}}
+}
+
+// name returns the sheet name assigned to a requested name.
+func (n *sheetNamer) name(requested string) string {
+	return n.nameAs(requested, requested)
+}
+
+// nameAs returns the sheet name assigned to key, derived from title when key is new. Keys keep
+// titles requested for different purposes (e.g. preview titles) from sharing a sheet.
+func (n *sheetNamer) nameAs(key, title string) string {
+	if name, ok := n.assigned[key]; ok {
+		return name
+	}
+	base := SanitizeSheetName(title)
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := n.owners[strings.ToLower(name)]; !taken {
+			break
+		}
+		name = partSheetName(base, i)
+	}
+	n.assigned[key], n.owners[strings.ToLower(name)] = name, key
+	return name
+}
@@ -0,0 +1,98 @@
+package spit
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSanitizeSheetName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Orders", "Orders"},
+		{"Q1/Q2: [draft]?", "Q1_Q2_ _draft__"},
+		{"'quoted'", "quoted"},
+		{strings.Repeat("x", 30) + "'y", strings.Repeat("x", 30)},
+		{strings.Repeat("é", 40), strings.Repeat("é", 31)},
+		{"", "Sheet1"},
+		{"''", "Sheet1"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeSheetName(tt.name); got != tt.want {
+			t.Errorf("SanitizeSheetName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_sheetNamer(t *testing.T) {
+	names := newSheetNamer()
+	got := []string{names.name("Q1/Q2"), names.name("Q1:Q2"), names.name("Q1/Q2"), names.name("Q1?Q2")}
+	if want := []string{"Q1_Q2", "Q1_Q2 (2)", "Q1_Q2", "Q1_Q2 (3)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+
+	// Excel compares sheet names case-insensitively
+	got = []string{names.name("Sales"), names.name("SALES"), names.name("sales (2)"), names.name("Sales")}
+	if want := []string{"Sales", "SALES (2)", "sales (2) (2)", "Sales"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+}
+
+func TestExportXLSX_sheetNames(t *testing.T) {
+	var buf bytes.Buffer
+	sheets := []Spreadsheet{
+		NewSpreadsheetExcelize("Sales/Costs", fixtureTable("name", "team", "score")),
+		NewSpreadsheetExcelize("Sales:Costs", fixtureTable("name", "team", "score")),
+	}
+	res, err := ExportXLSXSheets(sheets, FileWriteParams{Filename: "names", Writer: &buf})
+	if err != nil {
+		t.Fatalf("ExportXLSXSheets() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if got, want := f.GetSheetList(), []string{"Sales_Costs", "Sales_Costs (2)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sheets = %v, want %v", got, want)
+	}
+	if warnings := res.Report().Phase(WarningPhaseSheet); len(warnings) != 2 || warnings[1].Sheet != "Sales_Costs (2)" {
+		t.Errorf("sheet warnings = %+v, want one per renamed sheet", warnings)
+	}
+}
+
+func TestExportXLSX_cellOverflow(t *testing.T) {
+	long := strings.Repeat("a", excelize.TotalCellChars+10)
+	table := func(mode CellOverflowMode) *Table {
+		return NewTable(DataSlice{{"text": long}}, Columns{NewColumn("text", "Text")}, true).WithCellOverflow(mode)
+	}
+
+	var buf bytes.Buffer
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table(CellOverflowTruncate)), FileWriteParams{Filename: "long", Writer: &buf})
+	if err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	if warnings := res.Report().Phase(WarningPhaseData); len(warnings) != 1 || warnings[0].Cell != "A2" || !errors.Is(warnings[0].Err, ErrCellTextTooLong) {
+		t.Errorf("data warnings = %+v, want the truncation of A2", warnings)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if got, _ := f.GetCellValue("Data", "A2"); len(got) != excelize.TotalCellChars {
+		t.Errorf("A2 holds %d characters, want %d", len(got), excelize.TotalCellChars)
+	}
+
+	_, err = ExportXLSX(NewSpreadsheetExcelize("Data", table(CellOverflowError)), FileWriteParams{Filename: "long", Writer: &bytes.Buffer{}})
+	var cellErr *CellWriteError
+	if !errors.Is(err, ErrCellTextTooLong) || !errors.As(err, &cellErr) || cellErr.Cell() != "A2" {
+		t.Errorf("ExportXLSX() error = %v, want ErrCellTextTooLong at A2", err)
+	}
+}
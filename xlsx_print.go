@@ -0,0 +1,136 @@
+// xlsx_print.go - XLSX print setup.
+//
+// This file implements the page setup of the sheets written by XLSX exports (see
+// FileWriteParams.Print): orientation, paper size, margins, scaling to the page width and header
+// rows repeated at the top of every printed page. Reports then print correctly without manual
+// adjustment in the spreadsheet application.
+
+package spit
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// PrintOrientation defines the orientation of printed pages.
+type PrintOrientation int
+
+const (
+	// PrintPortrait prints pages upright (default).
+	PrintPortrait PrintOrientation = iota
+
+	// PrintLandscape prints pages sideways, fitting wider tables.
+	PrintLandscape
+)
+
+// PaperSize is the paper size of printed pages, as an Excel paper size code.
+type PaperSize int
+
+const (
+	PaperDefault PaperSize = 0  // Paper size of the printer settings
+	PaperLetter  PaperSize = 1  // Letter (8.5 x 11 in)
+	PaperLegal   PaperSize = 5  // Legal (8.5 x 14 in)
+	PaperA3      PaperSize = 8  // A3 (297 x 420 mm)
+	PaperA4      PaperSize = 9  // A4 (210 x 297 mm)
+	PaperA5      PaperSize = 11 // A5 (148 x 210 mm)
+)
+
+// PrintMargins are the margins of printed pages, in inches.
+type PrintMargins struct {
+	Top    float64
+	Bottom float64
+	Left   float64
+	Right  float64
+	Header float64 // Distance between the top edge and the page header
+	Footer float64 // Distance between the bottom edge and the page footer
+}
+
+// PrintOptions configures how the sheets of XLSX exports print (see FileWriteParams.Print).
+type PrintOptions struct {
+	Orientation      PrintOrientation // Page orientation (default: PrintPortrait)
+	PaperSize        PaperSize        // Paper size (default: PaperDefault)
+	FitToWidth       bool             // Scale the sheet so every column fits the page width, rows flowing over as many pages as needed
+	RepeatHeaderRows bool             // Repeat the header rows of the table at the top of every printed page
+	Margins          *PrintMargins    // Optional page margins (nil = application defaults)
+}
+
+// printTitlesName is the defined name holding the rows repeated on every printed page.
+const printTitlesName = "_xlnm.Print_Titles"
+
+// printSetter is implemented by spreadsheets supporting print setup.
+type printSetter interface {
+	SetPrintOptions(options PrintOptions, titleRows CellRange) error
+}
+
+// SetPrintOptions sets the page setup of the sheet. Non-empty titleRows are repeated at the top of
+// every printed page, unless the sheet already repeats rows (e.g. those of a previous table written
+// to the sheet, or of a template).
+func (e *SpreadsheetExcelize) SetPrintOptions(options PrintOptions, titleRows CellRange) error {
+	orientation := "portrait"
+	if options.Orientation == PrintLandscape {
+		orientation = "landscape"
+	}
+	layout := &excelize.PageLayoutOptions{Orientation: &orientation}
+	if options.PaperSize != PaperDefault {
+		size := int(options.PaperSize)
+		layout.Size = &size
+	}
+	if options.FitToWidth {
+		fit, width, height := true, 1, 0
+		if err := e.File.SetSheetProps(e.SheetName, &excelize.SheetPropsOptions{FitToPage: &fit}); err != nil {
+			return err
+		}
+		layout.FitToWidth, layout.FitToHeight = &width, &height
+	}
+	if err := e.File.SetPageLayout(e.SheetName, layout); err != nil {
+		return err
+	}
+
+	if m := options.Margins; m != nil {
+		margins := &excelize.PageLayoutMarginsOptions{
+			Top: &m.Top, Bottom: &m.Bottom, Left: &m.Left, Right: &m.Right, Header: &m.Header, Footer: &m.Footer,
+		}
+		if err := e.File.SetPageMargins(e.SheetName, margins); err != nil {
+			return err
+		}
+	}
+
+	if titleRows.IsEmpty() {
+		return nil
+	}
+	for _, name := range e.File.GetDefinedName() {
+		if name.Name == printTitlesName && name.Scope == e.SheetName {
+			return nil
+		}
+	}
+	refersTo := fmt.Sprintf("%s!$%d:$%d", quoteSheetName(e.SheetName), titleRows.StartRow, titleRows.EndRow)
+	return e.SetDefinedName(printTitlesName, refersTo, "")
+}
+
+// writePrintSetup sets the page setup of the written sheet (see FileWriteParams.Print). A setup
+// the spreadsheet rejects is reported as one warning for the sheet (see ErrorMode).
+func (xlsx *xlsx) writePrintSetup(result SheetResult) {
+	options := xlsx.params.Print
+	if options == nil {
+		return
+	}
+	t := xlsx.table
+	setter, ok := xlsx.spreadsheet.(printSetter)
+	if !ok {
+		t.warn(WarningPhaseSheet, "", "Spreadsheet does not support print setup, skipped", nil, String("sheet", result.Name))
+		return
+	}
+
+	var titleRows CellRange
+	if options.RepeatHeaderRows {
+		if t.Transposed {
+			t.warn(WarningPhaseSheet, "", "Repeated header rows are not supported by transposed tables, skipped", nil)
+		} else {
+			titleRows = result.HeaderRange
+		}
+	}
+	if err := setter.SetPrintOptions(*options, titleRows); err != nil {
+		t.warn(WarningPhaseSheet, "", "Failed to set print setup", err, String("sheet", result.Name))
+	}
+}
@@ -0,0 +1,89 @@
+package spit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_print(t *testing.T) {
+	for name, streaming := range map[string]StreamingMode{"cell by cell": StreamingNever, "streamed": StreamingAlways} {
+		t.Run(name, func(t *testing.T) {
+			table := NewTable(DataSlice{{"a": 1, "b": 2}}, Columns{
+				NewColumn("a", "A"),
+				NewColumn("", "Group").WithSubColumns(Columns{NewColumn("b", "B")}),
+			}, true).WithPreamble(PreambleRows{NewPreambleRow("Report")})
+			second := NewTable(DataSlice{{"c": 3}}, Columns{NewColumn("c", "C")}, true)
+
+			var buf bytes.Buffer
+			res, err := ExportXLSXSheets([]Spreadsheet{
+				NewSpreadsheetExcelize("Q1 Sales", table),
+				NewSpreadsheetExcelize("Q1 Sales", second).WithConflictPolicy(ConflictAppendBelow),
+			}, FileWriteParams{
+				Filename:  "print",
+				Writer:    &buf,
+				Streaming: streaming,
+				Print: &PrintOptions{
+					Orientation:      PrintLandscape,
+					PaperSize:        PaperA4,
+					FitToWidth:       true,
+					RepeatHeaderRows: true,
+					Margins:          &PrintMargins{Top: 1, Bottom: 1, Left: 0.5, Right: 0.5, Header: 0.3, Footer: 0.3},
+				},
+			})
+			if err != nil {
+				t.Fatalf("ExportXLSXSheets() error = %v", err)
+			}
+			if len(res.Warnings) != 0 {
+				t.Errorf("warnings = %+v", res.Warnings)
+			}
+			f, err := excelize.OpenReader(&buf)
+			if err != nil {
+				t.Fatalf("OpenReader failed: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+
+			layout, err := f.GetPageLayout("Q1 Sales")
+			if err != nil {
+				t.Fatalf("GetPageLayout failed: %v", err)
+			}
+			if *layout.Orientation != "landscape" || *layout.Size != 9 || *layout.FitToWidth != 1 || *layout.FitToHeight != 0 {
+				t.Errorf("page layout = orientation %s, size %d, fit %dx%d", *layout.Orientation, *layout.Size, *layout.FitToWidth, *layout.FitToHeight)
+			}
+			if props, _ := f.GetSheetProps("Q1 Sales"); props.FitToPage == nil || !*props.FitToPage {
+				t.Error("FitToPage is not set")
+			}
+			if margins, _ := f.GetPageMargins("Q1 Sales"); *margins.Top != 1 || *margins.Left != 0.5 || *margins.Footer != 0.3 {
+				t.Errorf("margins = top %v, left %v, footer %v", *margins.Top, *margins.Left, *margins.Footer)
+			}
+
+			// The header rows of the first table repeat, below the preamble row
+			var titles []string
+			for _, name := range f.GetDefinedName() {
+				if name.Name == printTitlesName {
+					titles = append(titles, name.Scope+" "+name.RefersTo)
+				}
+			}
+			if len(titles) != 1 || titles[0] != "Q1 Sales 'Q1 Sales'!$2:$3" {
+				t.Errorf("print titles = %q, want the header rows of the first table", titles)
+			}
+		})
+	}
+}
+
+func TestExportXLSX_printTransposed(t *testing.T) {
+	table := NewTable(DataSlice{{"a": 1}}, Columns{NewColumn("a", "A")}, true)
+	table.Transposed = true
+	res, err := ExportXLSX(NewSpreadsheetExcelize("Data", table), FileWriteParams{
+		Filename: "print",
+		Writer:   &bytes.Buffer{},
+		Print:    &PrintOptions{RepeatHeaderRows: true},
+	})
+	if err != nil {
+		t.Fatalf("ExportXLSX() error = %v", err)
+	}
+	if len(res.Warnings) != 1 || res.Warnings[0].Message != "Repeated header rows are not supported by transposed tables, skipped" {
+		t.Errorf("warnings = %+v, want the transposed table", res.Warnings)
+	}
+}
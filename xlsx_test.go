@@ -139,6 +139,7 @@ func TestExportXLSX(t *testing.T) {
 			name: "error_writing_data",
 			setupMock: func(mock *MockSpreadsheet) {
 				mock.EXPECT().GetFile().Return(&struct{ name string }{name: "existing_file"})
+				mock.EXPECT().GetTable().Return(&Table{}).AnyTimes()
 				mock.EXPECT().GetSheetName().Return("Sheet1")
 				mock.EXPECT().CreateSheet().Return(errors.New("create sheet error"))
 			},
@@ -269,10 +270,6 @@ func TestXlsx_writeData(t *testing.T) {
 				params: FileWriteParams{},
 			},
 			setupMock: func(mock *MockSpreadsheet) {
-				mock.EXPECT().GetSheetName().Return("")
-				mock.EXPECT().SetSheetName("Sheet1")
-				mock.EXPECT().CreateSheet().Return(nil)
-				mock.EXPECT().SetActiveSheet().Return(nil)
 				mock.EXPECT().GetTable().Return(nil)
 			},
 			expectError: true,
@@ -284,6 +281,7 @@ func TestXlsx_writeData(t *testing.T) {
 				params: FileWriteParams{},
 			},
 			setupMock: func(mock *MockSpreadsheet) {
+				mock.EXPECT().GetTable().Return(&Table{}).AnyTimes()
 				mock.EXPECT().GetSheetName().Return("")
 				mock.EXPECT().SetSheetName("Sheet1")
 				mock.EXPECT().CreateSheet().Return(errors.New("sheet creation error"))
@@ -297,6 +295,7 @@ func TestXlsx_writeData(t *testing.T) {
 				params: FileWriteParams{},
 			},
 			setupMock: func(mock *MockSpreadsheet) {
+				mock.EXPECT().GetTable().Return(&Table{}).AnyTimes()
 				mock.EXPECT().GetSheetName().Return("")
 				mock.EXPECT().SetSheetName("Sheet1")
 				mock.EXPECT().CreateSheet().Return(nil)
@@ -1254,6 +1253,7 @@ func TestExportXLSXSheets(t *testing.T) {
 			name: "error_writing_data",
 			setupMocks: func(mocks []*MockSpreadsheet) {
 				mocks[0].EXPECT().GetFile().Return(&struct{ name string }{name: "existing_file"})
+				mocks[0].EXPECT().GetTable().Return(&Table{}).AnyTimes()
 				mocks[0].EXPECT().GetSheetName().Return("Sheet1")
 				mocks[0].EXPECT().CreateSheet().Return(errors.New("create sheet error"))
 			},